@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"flicknfit_backend/container"
+	"flicknfit_backend/dtos"
+)
+
+// commandUsage lists the CLI subcommands supported by the binary.
+const commandUsage = `Usage: flicknfit-api [command] [flags]
+
+Commands:
+  (none)            Start the HTTP server
+  migrate           Run database migrations and exit
+  import-products   Import products from a CSV or JSON file
+                      -file path     file to import (required)
+                      -format        csv or json (defaults to the file extension)
+                      -dry-run       validate only, do not save
+  export-products   Export all products to a CSV or JSON file
+                      -file path     output file (default stdout)
+                      -format        csv or json (default csv)
+  recalculate-ratings
+                    Recompute product and brand rating aggregates from reviews and report drift
+                      -dry-run       report drift only, do not correct it
+  reconcile-counters
+                    Recompute sold counters from conversions and brand product totals
+  check-links       Check all stored product and brand links and report how many are broken
+`
+
+// runCommand executes a CLI subcommand using the initialized container instead of starting the HTTP server.
+func runCommand(appContainer *container.Container, args []string) error {
+	switch args[0] {
+	case "import-products":
+		return runImportProducts(appContainer, args[1:])
+	case "export-products":
+		return runExportProducts(appContainer, args[1:])
+	case "recalculate-ratings":
+		return runRecalculateRatings(appContainer, args[1:])
+	case "reconcile-counters":
+		return runReconcileCounters(appContainer)
+	case "check-links":
+		return runCheckLinks(appContainer)
+	case "help", "-h", "--help":
+		fmt.Print(commandUsage)
+		return nil
+	default:
+		fmt.Print(commandUsage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// runImportProducts imports products from a file and prints the row-level report as JSON.
+func runImportProducts(appContainer *container.Container, args []string) error {
+	fs := flag.NewFlagSet("import-products", flag.ContinueOnError)
+	file := fs.String("file", "", "file to import")
+	format := fs.String("format", "", "csv or json")
+	dryRun := fs.Bool("dry-run", false, "validate only, do not save")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("-file is required")
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*file)), ".")
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	result, err := appContainer.Services.ProductImport.ImportProducts(f, *format, *dryRun, nil)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return err
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("%d row(s) failed validation, no products were saved", len(result.Errors))
+	}
+	return nil
+}
+
+// runExportProducts writes all products to a file or stdout.
+func runExportProducts(appContainer *container.Container, args []string) error {
+	fs := flag.NewFlagSet("export-products", flag.ContinueOnError)
+	file := fs.String("file", "", "output file (default stdout)")
+	format := fs.String("format", dtos.ProductImportFormatCSV, "csv or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if *file != "" {
+		f, err := os.Create(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	return appContainer.Services.ProductImport.ExportProducts(out, *format)
+}
+
+// runRecalculateRatings recomputes all rating aggregates and prints the drift report as JSON.
+func runRecalculateRatings(appContainer *container.Container, args []string) error {
+	fs := flag.NewFlagSet("recalculate-ratings", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "report drift only, do not correct it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	result, err := appContainer.Services.Review.RecalculateRatings(*dryRun)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// runReconcileCounters reconciles the sold and total-product counters and prints how many were corrected as JSON.
+func runReconcileCounters(appContainer *container.Container) error {
+	result, err := appContainer.Services.Conversion.ReconcileCounters()
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// runCheckLinks checks all stored links and prints the summary as JSON.
+func runCheckLinks(appContainer *container.Container) error {
+	summary, err := appContainer.Services.LinkHealth.CheckAllLinks()
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(summary)
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"errors"
+	"flicknfit_backend/constants"
 	"os"
+	"strconv"
 )
 
 // Config holds all the application-wide configuration read from environment variables.
@@ -25,6 +27,7 @@ type Config struct {
 	SmtpPassword           string
 	SmtpPort               string
 	AIApiURL               string
+	AIHealthPath           string // Health check of the model API, relative to AI_API_URL
 	FirebaseProjectID      string
 	FirebasePrivateKeyPath string
 	GroqAPIKey             string
@@ -38,6 +41,16 @@ type Config struct {
 	SupabaseKey            string
 	SupabaseBucket         string
 	EncryptionKey          string
+	UTMSource              string // Defaults for the UTM tags added to outbound links; brands may override them
+	UTMMedium              string
+	UTMCampaign            string
+	TokopediaAffiliateID   string
+	ShopeeAffiliateID      string
+	ClickIDSecret          string // Signs click IDs handed to brands; defaults to JWT_SECRET_KEY
+	ClickIPMode            string // full, truncate or hash
+	ClickIPHashKey         string // Keys the hash of IP addresses in hash mode; defaults to JWT_SECRET_KEY
+	ClickRetentionDays     int    // Clicks older than this are anonymised or deleted; 0 keeps them forever
+	ClickRetentionAction   string // anonymize or delete
 }
 
 // LoadConfig reads the configuration from environment variables.
@@ -62,6 +75,7 @@ func LoadConfig() (*Config, error) {
 		SmtpPassword:           os.Getenv("SMTP_PASSWORD"),
 		SmtpPort:               os.Getenv("SMTP_PORT"),
 		AIApiURL:               os.Getenv("AI_API_URL"),
+		AIHealthPath:           getEnvOrDefault("AI_HEALTH_PATH", constants.DefaultAIHealthPath),
 		FirebaseProjectID:      os.Getenv("FIREBASE_PROJECT_ID"),
 		FirebasePrivateKeyPath: os.Getenv("FIREBASE_PRIVATE_KEY_PATH"),
 		GroqAPIKey:             os.Getenv("GROQ_API_KEY"),
@@ -75,6 +89,15 @@ func LoadConfig() (*Config, error) {
 		SupabaseKey:            os.Getenv("SUPABASE_KEY"),
 		SupabaseBucket:         os.Getenv("SUPABASE_BUCKET"),
 		EncryptionKey:          os.Getenv("ENCRYPTION_KEY"),
+		UTMSource:              getEnvOrDefault("UTM_SOURCE", constants.DefaultUTMSource),
+		UTMMedium:              getEnvOrDefault("UTM_MEDIUM", constants.DefaultUTMMedium),
+		UTMCampaign:            getEnvOrDefault("UTM_CAMPAIGN", constants.DefaultUTMCampaign),
+		TokopediaAffiliateID:   os.Getenv("TOKOPEDIA_AFFILIATE_ID"),
+		ShopeeAffiliateID:      os.Getenv("SHOPEE_AFFILIATE_ID"),
+		ClickIDSecret:          os.Getenv("CLICK_ID_SECRET"),
+		ClickIPMode:            getEnvOrDefault("CLICK_IP_MODE", constants.ClickIPModeFull),
+		ClickIPHashKey:         os.Getenv("CLICK_IP_HASH_KEY"),
+		ClickRetentionAction:   getEnvOrDefault("CLICK_RETENTION_ACTION", constants.ClickRetentionAnonymize),
 	}
 
 	// Simple validation to ensure critical variables are set.
@@ -93,6 +116,35 @@ func LoadConfig() (*Config, error) {
 	if cfg.AIApiURL == "" {
 		return nil, errors.New("AI_API_URL environment variable is not set")
 	}
+	if cfg.ClickIDSecret == "" {
+		cfg.ClickIDSecret = cfg.JwtSecretKey
+	}
+	if cfg.ClickIPHashKey == "" {
+		cfg.ClickIPHashKey = cfg.JwtSecretKey
+	}
+	switch cfg.ClickIPMode {
+	case constants.ClickIPModeFull, constants.ClickIPModeTruncate, constants.ClickIPModeHash:
+	default:
+		return nil, errors.New("CLICK_IP_MODE must be full, truncate or hash")
+	}
+	if days := os.Getenv("CLICK_RETENTION_DAYS"); days != "" {
+		retention, err := strconv.Atoi(days)
+		if err != nil || retention < 0 {
+			return nil, errors.New("CLICK_RETENTION_DAYS must be a non-negative number of days")
+		}
+		cfg.ClickRetentionDays = retention
+	}
+	if cfg.ClickRetentionAction != constants.ClickRetentionAnonymize && cfg.ClickRetentionAction != constants.ClickRetentionDelete {
+		return nil, errors.New("CLICK_RETENTION_ACTION must be anonymize or delete")
+	}
 
 	return cfg, nil
 }
+
+// getEnvOrDefault returns the value of an environment variable, or fallback when it is not set.
+func getEnvOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -34,6 +34,19 @@ const (
 	AllowedImageExts = "jpg,jpeg,png,webp"
 )
 
+// Product Media Constants
+const (
+	ProductMediaTypeImage = "image"
+	ProductMediaTypeVideo = "video"
+
+	MaxProductVideoSize   = 50 << 20 // 50MB
+	AllowedVideoExts      = "mp4,webm,mov"
+	ProductThumbnailWidth = 400      // Maximum width of generated image thumbnails, in pixels
+	MaxImagePixels        = 40000000 // Largest image (width x height) decoded for a thumbnail
+
+	MaxRequestBodySize = MaxProductVideoSize + 1<<20 // Largest upload plus multipart overhead
+)
+
 // Cache Constants
 const (
 	CacheKeyPrefix     = "flicknfit:"
@@ -57,9 +70,317 @@ const (
 
 // Product Constants
 const (
-	ProductStatusActive   = "active"
-	ProductStatusInactive = "inactive"
-	ProductStatusDraft    = "draft"
+	ProductStatusActive    = "active"
+	ProductStatusInactive  = "inactive"
+	ProductStatusDraft     = "draft"
+	ProductStatusScheduled = "scheduled"
+
+	// ProductPublishCheckInterval is how often scheduled products are checked for publishing.
+	ProductPublishCheckInterval = time.Minute
+)
+
+// Campaign Constants
+const (
+	CampaignDiscountPercentage = "percentage"
+	CampaignDiscountFixed      = "fixed"
+
+	CampaignTargetBrand    = "brand"
+	CampaignTargetCategory = "category"
+	CampaignTargetProduct  = "product"
+
+	// PriceChartDefaultDays and PriceChartMaxDays bound the window of the product price chart.
+	PriceChartDefaultDays = 90
+	PriceChartMaxDays     = 365
+)
+
+// Stock Constants
+const (
+	StockReasonRestock     = "restock"
+	StockReasonSale        = "sale"
+	StockReasonReturn      = "return"
+	StockReasonDamaged     = "damaged"
+	StockReasonCorrection  = "correction"
+	StockReasonProductEdit = "product_edit" // Recorded when stock is changed through the product editor or import
+
+	// DefaultLowStockThreshold applies to items without their own low-stock threshold.
+	DefaultLowStockThreshold = 5
+	StockMovementsPageSize   = 50
+)
+
+// Conversion Constants
+const (
+	ConversionSourceAPI     = "api"
+	ConversionSourceWebhook = "webhook"
+
+	ConversionsPageSize = 50
+
+	// PostbackMaxClockSkew is how far a postback timestamp may be from the server clock, limiting replays.
+	PostbackMaxClockSkew = 5 * time.Minute
+	MaxPostbackItems     = 100
+
+	// CounterReconcileInterval is how often sold and total-product counters are reconciled with their sources.
+	CounterReconcileInterval = time.Hour
+)
+
+// Redirect Channels
+const (
+	RedirectChannelWhatsApp  = "whatsapp"
+	RedirectChannelTokopedia = "tokopedia"
+	RedirectChannelShopee    = "shopee"
+	RedirectChannelInstagram = "instagram"
+	RedirectChannelWebsite   = "website"
+	RedirectChannelFallback  = "flicknfit" // No usable brand link; the click goes to the FlickNFit homepage
+	RedirectChannelUnknown   = "unknown"   // Clicks and conversions recorded before channels were tracked
+
+	ChannelReportDefaultDays = 30
+	ChannelReportMaxDays     = 365
+)
+
+// Outbound Link Parameters
+const (
+	DefaultUTMSource   = "flicknfit"
+	DefaultUTMMedium   = "referral"
+	DefaultUTMCampaign = "product_click"
+
+	// Query parameters carrying the affiliate ID on marketplace links and the signed click ID on every link
+	TokopediaAffiliateParam = "aff_unique_id"
+	ShopeeAffiliateParam    = "af_id"
+	ClickIDParam            = "fnf_click_id"
+)
+
+// Link Health Constants
+const (
+	LinkEntityProduct = "product"
+	LinkEntityBrand   = "brand"
+
+	// LinkCheckInterval is how often stored product and brand links are checked.
+	LinkCheckInterval    = 6 * time.Hour
+	LinkCheckTimeout     = 10 * time.Second
+	LinkCheckConcurrency = 5
+	LinkCheckUserAgent   = "FlickNFit-LinkChecker/1.0"
+
+	// LinkBrokenAfterFailures is the number of failed checks in a row before a link is skipped by redirects,
+	// so a single timeout does not hide a working store.
+	LinkBrokenAfterFailures = 2
+)
+
+// AI Request Log Constants
+const (
+	AIRequestSkinColorTone = "skin_color_tone"
+	AIRequestWomanBodyScan = "woman_body_scan"
+	AIRequestMenBodyScan   = "men_body_scan"
+)
+
+// AI Model Client Constants
+const (
+	// AIModelAttemptTimeout bounds one request to the model API, AIModelCallTimeout all attempts of a call
+	// that did not bring its own deadline.
+	AIModelAttemptTimeout = 30 * time.Second
+	AIModelCallTimeout    = 60 * time.Second
+	AIModelMaxAttempts    = 3
+	AIModelRetryBaseDelay = 250 * time.Millisecond
+	AIModelRetryMaxDelay  = 2 * time.Second
+
+	// AIPredictionRequestTimeout bounds a synchronous prediction request: the model call and the LLM
+	// recommendations that follow it.
+	AIPredictionRequestTimeout = 2 * time.Minute
+
+	// AIModelFailureThreshold failures in a row open the circuit breaker; after AIModelOpenDuration one
+	// request is let through to probe the model API.
+	AIModelFailureThreshold = 5
+	AIModelOpenDuration     = 30 * time.Second
+
+	AIModelHealthCheckInterval = time.Minute
+	AIModelHealthCheckTimeout  = 5 * time.Second
+	DefaultAIHealthPath        = "/health"
+
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+// AI Job Constants
+const (
+	AIJobStatusQueued    = "queued"
+	AIJobStatusRunning   = "running"
+	AIJobStatusSucceeded = "succeeded"
+	AIJobStatusFailed    = "failed"
+
+	// AIJobWorkers is the number of predictions run at once; each can take minutes when the LLM providers
+	// time out one after another.
+	AIJobWorkers   = 4
+	AIJobQueueSize = 100
+	// AIJobRetention is how long a finished job and its result can still be fetched.
+	AIJobRetention = 30 * time.Minute
+	// AIJobTimeout is how long a prediction job may run, including LLM fallbacks, before it fails.
+	AIJobTimeout = 5 * time.Minute
+	// AIJobKeepAliveInterval is how often an idle job event stream sends a comment so proxies keep it open.
+	AIJobKeepAliveInterval = 15 * time.Second
+)
+
+// Analytics Constants
+const (
+	AnalyticsMetricGMV         = "gmv"         // Revenue of confirmed conversions, in rupiah
+	AnalyticsMetricClickValue  = "click_value" // Outbound clicks times the product's lowest item price, in rupiah
+	AnalyticsMetricConversions = "conversions"
+	AnalyticsMetricUnitsSold   = "units_sold"
+	AnalyticsMetricClicks      = "clicks"
+	AnalyticsMetricAIRequests  = "ai_requests"
+	AnalyticsMetricNewUsers    = "new_users"
+
+	AnalyticsGranularityDay   = "day"
+	AnalyticsGranularityWeek  = "week"
+	AnalyticsGranularityMonth = "month"
+
+	AnalyticsDefaultDays = 30
+	AnalyticsMaxDays     = 366
+	AnalyticsDateLayout  = "2006-01-02"
+
+	// RevenueTrendMonths is the number of months in the revenue analytics trend, including the current one.
+	RevenueTrendMonths = 6
+)
+
+// Click Analytics Constants
+const (
+	DeviceClassMobile  = "mobile"
+	DeviceClassTablet  = "tablet"
+	DeviceClassDesktop = "desktop"
+	DeviceClassBot     = "bot"
+	DeviceClassUnknown = "unknown"
+
+	FunnelStageView     = "view"
+	FunnelStageFavorite = "favorite"
+	FunnelStageClick    = "click"
+
+	ClickRegionUnknown        = "unknown" // Clicks recorded without a region
+	ClickRegionMaxLength      = 64
+	ClickAnalyticsTopProducts = 20
+)
+
+// Click Filtering Constants
+const (
+	// Reasons a click is flagged as suspicious; flagged clicks are stored but left out of analytics
+	ClickFlagBot       = "bot"       // Known crawler, scripted client or missing user agent
+	ClickFlagDuplicate = "duplicate" // Same user, or same IP address and user agent, clicked the product within ClickDedupWindow
+	ClickFlagRate      = "rate"      // The IP address made more than ClickRateLimit clicks within ClickRateWindow
+
+	ClickDedupWindow = 10 * time.Minute
+	ClickRateWindow  = time.Minute
+	ClickRateLimit   = 30
+)
+
+// Brand Report Constants
+const (
+	ReportFormatCSV  = "csv"
+	ReportFormatXLSX = "xlsx"
+	ReportFormatPDF  = "pdf"
+
+	// ReportTopProducts is the number of most clicked products listed in a report.
+	ReportTopProducts = 10
+	// ReportPeriodLayout formats the month a monthly report covers.
+	ReportPeriodLayout = "2006-01"
+	// BrandReportCheckInterval is how often the monthly report job looks for reports still to be emailed.
+	BrandReportCheckInterval = time.Hour
+)
+
+// Event Bus Constants
+const (
+	// EventTopicProductClick carries outbound product clicks to be stored.
+	EventTopicProductClick = "product.click"
+	// EventTopicProductView carries product detail views to be stored.
+	EventTopicProductView = "product.view"
+
+	EventQueueSize      = 10000
+	EventWorkers        = 4
+	EventBatchSize      = 200
+	EventFlushInterval  = 2 * time.Second
+	EventPublishTimeout = 50 * time.Millisecond
+)
+
+// Click Privacy Constants
+const (
+	// Modes for storing the IP address of a click, set with CLICK_IP_MODE
+	ClickIPModeFull     = "full"     // The address as received (default)
+	ClickIPModeTruncate = "truncate" // The network only: the last octet of IPv4, all but the first 48 bits of IPv6 are zeroed
+	ClickIPModeHash     = "hash"     // A keyed hash, so repeat clicks can still be matched without storing the address
+
+	// ClickIPHashLength is the number of hex characters kept of a hashed IP address.
+	ClickIPHashLength = 40
+
+	// What happens to clicks older than CLICK_RETENTION_DAYS, set with CLICK_RETENTION_ACTION
+	ClickRetentionAnonymize = "anonymize" // Clear the IP address and user (default); the counts are kept
+	ClickRetentionDelete    = "delete"    // Delete the clicks
+
+	// ClickRetentionInterval is how often the retention policy is applied.
+	ClickRetentionInterval = 24 * time.Hour
+	// ClickRetentionBatchSize is the number of clicks anonymised or deleted per statement.
+	ClickRetentionBatchSize = 1000
+)
+
+// Product View Constants
+const (
+	// ViewDebounceWindow is how long repeated views of a product by the same user or session count once.
+	ViewDebounceWindow = 30 * time.Minute
+
+	RecentlyViewedDefaultLimit = 20
+	RecentlyViewedMaxLimit     = 50
+
+	// PopularitySoldWeight is how many views one unit sold weighs in the popularity score.
+	PopularitySoldWeight = 20
+)
+
+// Product listing sort orders
+const (
+	ProductSortNewest      = "newest"
+	ProductSortBestSelling = "best_selling"
+	ProductSortRating      = "rating"
+	ProductSortPopular     = "popular"
+)
+
+// Brand directory sort orders
+const (
+	BrandSortName      = "name"
+	BrandSortRating    = "rating"
+	BrandSortFollowers = "followers"
+	BrandSortProducts  = "products"
+	BrandSortNewest    = "newest"
+
+	// BrandTopProductsLimit is the number of best-selling products shown on a brand's detail page.
+	BrandTopProductsLimit = 8
+)
+
+// Brand Member Roles
+const (
+	BrandMemberRoleOwner = "owner"
+	BrandMemberRoleStaff = "staff"
+)
+
+// Brand Application Constants
+const (
+	BrandApplicationStatusPending  = "pending"
+	BrandApplicationStatusApproved = "approved"
+	BrandApplicationStatusRejected = "rejected"
+
+	MaxBrandLogoSize = 2 << 20 // 2MB
+
+	// Domains accepted for marketplace links; subdomains such as www. are allowed
+	InstagramDomains = "instagram.com"
+	TokopediaDomains = "tokopedia.com,tokopedia.link"
+	ShopeeDomains    = "shopee.co.id,shopee.com,shp.ee"
+)
+
+// Notification Types
+const (
+	NotificationTypeLowStock    = "low_stock"
+	NotificationTypeOutOfStock  = "out_of_stock"
+	NotificationTypeBackInStock = "back_in_stock"
+	NotificationTypePriceDrop   = "price_drop"
+
+	NotificationTypeBrandApproved = "brand_application_approved"
+	NotificationTypeBrandRejected = "brand_application_rejected"
+
+	// WatchNotificationCooldown is the minimum time between two identical watch alerts to the same user.
+	WatchNotificationCooldown = 24 * time.Hour
 )
 
 // Shopping Cart Constants
@@ -76,6 +397,14 @@ const (
 	HeaderUserAgent     = "User-Agent"
 	HeaderXRealIP       = "X-Real-IP"
 	HeaderXForwardedFor = "X-Forwarded-For"
+
+	HeaderPostbackSignature = "X-FlickNFit-Signature"
+	HeaderPostbackTimestamp = "X-FlickNFit-Timestamp"
+	HeaderIdempotencyKey    = "Idempotency-Key"
+
+	HeaderClientRegion = "X-Client-Region" // Region reported by the app, e.g. the user's province
+	HeaderCDNCountry   = "CF-IPCountry"    // Country code added by the CDN in front of the API
+	HeaderSessionID    = "X-Session-ID"    // Random ID the app keeps per install, for anonymous users
 )
 
 // Response Messages
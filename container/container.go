@@ -2,6 +2,7 @@ package container
 
 import (
 	"flicknfit_backend/config"
+	"flicknfit_backend/constants"
 	"flicknfit_backend/controllers"
 	"flicknfit_backend/repositories"
 	"flicknfit_backend/services"
@@ -23,48 +24,97 @@ type Container struct {
 
 // Repositories holds all repository instances
 type Repositories struct {
-	User            repositories.UserRepository
-	Brand           repositories.BrandRepository
-	Product         repositories.ProductRepository
-	SavedItems      repositories.SavedItemsRepository
-	Favorite        repositories.FavoriteRepository
-	Review          repositories.ReviewRepository
-	Wardrobe        repositories.WardrobeRepository
-	FaceScanHistory repositories.FaceScanHistoryRepository
-	BodyScanHistory repositories.BodyScanHistoryRepository
-	ProductClick    repositories.ProductClickRepository
+	User             repositories.UserRepository
+	Brand            repositories.BrandRepository
+	Product          repositories.ProductRepository
+	SavedItems       repositories.SavedItemsRepository
+	Favorite         repositories.FavoriteRepository
+	Review           repositories.ReviewRepository
+	Wardrobe         repositories.WardrobeRepository
+	FaceScanHistory  repositories.FaceScanHistoryRepository
+	BodyScanHistory  repositories.BodyScanHistoryRepository
+	ProductClick     repositories.ProductClickRepository
+	Variation        repositories.VariationRepository
+	Campaign         repositories.CampaignRepository
+	Stock            repositories.StockRepository
+	BrandMember      repositories.BrandMemberRepository
+	Notification     repositories.NotificationRepository
+	Watch            repositories.WatchRepository
+	ProductMedia     repositories.ProductMediaRepository
+	Conversion       repositories.ConversionRepository
+	BrandApplication repositories.BrandApplicationRepository
+	LinkCheck        repositories.LinkCheckRepository
+	RedirectChannel  repositories.RedirectChannelRepository
+	Analytics        repositories.AnalyticsRepository
+	ClickAnalytics   repositories.ClickAnalyticsRepository
+	ProductView      repositories.ProductViewRepository
+	BrandReport      repositories.BrandReportRepository
 }
 
 // Services holds all service instances
 type Services struct {
-	User            services.UserService
-	Brand           services.BrandService
-	Product         services.ProductService
-	SavedItems      services.SavedItemsService
-	Favorite        services.FavoriteService
-	Review          services.ReviewService
-	Wardrobe        services.WardrobeService
-	AI              services.AIService
-	Firebase        *services.FirebaseService
-	ScanHistory     services.ScanHistoryService
-	SupabaseStorage services.SupabaseStorageService
-	Tracking        services.TrackingService
+	User             services.UserService
+	Brand            services.BrandService
+	Product          services.ProductService
+	SavedItems       services.SavedItemsService
+	Favorite         services.FavoriteService
+	Review           services.ReviewService
+	Wardrobe         services.WardrobeService
+	AI               services.AIService
+	Firebase         *services.FirebaseService
+	ScanHistory      services.ScanHistoryService
+	SupabaseStorage  services.SupabaseStorageService
+	Tracking         services.TrackingService
+	Variation        services.VariationService
+	ProductImport    services.ProductImportService
+	Campaign         services.CampaignService
+	Notification     services.NotificationService
+	BrandMember      services.BrandMemberService
+	Stock            services.StockService
+	Watch            services.WatchService
+	ProductMedia     services.ProductMediaService
+	Conversion       services.ConversionService
+	BrandApplication services.BrandApplicationService
+	LinkHealth       services.LinkHealthService
+	RedirectChannel  services.RedirectChannelService
+	Analytics        services.AnalyticsService
+	ClickAnalytics   services.ClickAnalyticsService
+	ProductView      services.ProductViewService
+	Events           services.EventBus
+	AIJobs           services.AIJobService
+	AIModel          services.AIModelClient
+	BrandReport      services.BrandReportService
 }
 
 // Controllers holds all controller instances
 type Controllers struct {
-	User        controllers.UserController
-	Brand       controllers.BrandController
-	Product     controllers.ProductController
-	SavedItems  controllers.SavedItemsController
-	Favorite    controllers.FavoriteController
-	Review      controllers.ReviewController
-	Wardrobe    controllers.WardrobeController
-	AI          controllers.AIController
-	Dashboard   controllers.DashboardController
-	OAuth       *controllers.OAuthController
-	ScanHistory controllers.ScanHistoryController
-	Tracking    controllers.TrackingController
+	User             controllers.UserController
+	Brand            controllers.BrandController
+	Product          controllers.ProductController
+	SavedItems       controllers.SavedItemsController
+	Favorite         controllers.FavoriteController
+	Review           controllers.ReviewController
+	Wardrobe         controllers.WardrobeController
+	AI               controllers.AIController
+	Dashboard        controllers.DashboardController
+	OAuth            *controllers.OAuthController
+	ScanHistory      controllers.ScanHistoryController
+	Tracking         controllers.TrackingController
+	Variation        controllers.VariationController
+	ProductImport    controllers.ProductImportController
+	Campaign         controllers.CampaignController
+	Stock            controllers.StockController
+	BrandMember      controllers.BrandMemberController
+	Notification     controllers.NotificationController
+	Watch            controllers.WatchController
+	ProductMedia     controllers.ProductMediaController
+	Conversion       controllers.ConversionController
+	BrandApplication controllers.BrandApplicationController
+	LinkHealth       controllers.LinkHealthController
+	RedirectChannel  controllers.RedirectChannelController
+	ClickAnalytics   controllers.ClickAnalyticsController
+	Health           controllers.HealthController
+	BrandReport      controllers.BrandReportController
 }
 
 // NewContainer creates and initializes a new container with all dependencies
@@ -91,16 +141,31 @@ func NewContainer(db *gorm.DB, cfg *config.Config) (*Container, error) {
 // initRepositories initializes all repository instances
 func (c *Container) initRepositories() {
 	c.Repositories = &Repositories{
-		User:            repositories.NewUserRepository(c.DB),
-		Brand:           repositories.NewBrandRepository(c.DB),
-		Product:         repositories.NewProductRepository(c.DB),
-		SavedItems:      repositories.NewSavedItemsRepository(c.DB),
-		Favorite:        repositories.NewFavoriteRepository(c.DB),
-		Review:          repositories.NewReviewRepository(c.DB),
-		Wardrobe:        repositories.NewWardrobeRepository(c.DB),
-		FaceScanHistory: repositories.NewFaceScanHistoryRepository(c.DB),
-		BodyScanHistory: repositories.NewBodyScanHistoryRepository(c.DB),
-		ProductClick:    repositories.NewProductClickRepository(c.DB),
+		User:             repositories.NewUserRepository(c.DB),
+		Brand:            repositories.NewBrandRepository(c.DB),
+		Product:          repositories.NewProductRepository(c.DB),
+		SavedItems:       repositories.NewSavedItemsRepository(c.DB),
+		Favorite:         repositories.NewFavoriteRepository(c.DB),
+		Review:           repositories.NewReviewRepository(c.DB),
+		Wardrobe:         repositories.NewWardrobeRepository(c.DB),
+		FaceScanHistory:  repositories.NewFaceScanHistoryRepository(c.DB),
+		BodyScanHistory:  repositories.NewBodyScanHistoryRepository(c.DB),
+		ProductClick:     repositories.NewProductClickRepository(c.DB),
+		Variation:        repositories.NewVariationRepository(c.DB),
+		Campaign:         repositories.NewCampaignRepository(c.DB),
+		Stock:            repositories.NewStockRepository(c.DB),
+		BrandMember:      repositories.NewBrandMemberRepository(c.DB),
+		Notification:     repositories.NewNotificationRepository(c.DB),
+		Watch:            repositories.NewWatchRepository(c.DB),
+		ProductMedia:     repositories.NewProductMediaRepository(c.DB),
+		Conversion:       repositories.NewConversionRepository(c.DB),
+		BrandApplication: repositories.NewBrandApplicationRepository(c.DB),
+		LinkCheck:        repositories.NewLinkCheckRepository(c.DB),
+		RedirectChannel:  repositories.NewRedirectChannelRepository(c.DB),
+		Analytics:        repositories.NewAnalyticsRepository(c.DB),
+		ClickAnalytics:   repositories.NewClickAnalyticsRepository(c.DB),
+		ProductView:      repositories.NewProductViewRepository(c.DB),
+		BrandReport:      repositories.NewBrandReportRepository(c.DB),
 	}
 }
 
@@ -122,36 +187,103 @@ func (c *Container) initServices() {
 		utils.GetLogger().Warn("Supabase storage service not initialized - credentials missing")
 	}
 
+	notificationService := services.NewNotificationService(c.Repositories.Notification)
+	mailer := services.NewSMTPMailer(c.Config)
+	watchService := services.NewWatchService(c.Repositories.Watch, c.Repositories.Campaign, notificationService, mailer)
+	stockService := services.NewStockService(c.Repositories.Stock, c.Repositories.Product, c.Repositories.BrandMember, notificationService, watchService)
+	productService := services.NewProductService(c.Repositories.Product, c.Repositories.Campaign, watchService, stockService)
+	eventBus := services.NewEventBus(services.EventBusOptions{
+		QueueSize:      constants.EventQueueSize,
+		Workers:        constants.EventWorkers,
+		BatchSize:      constants.EventBatchSize,
+		FlushInterval:  constants.EventFlushInterval,
+		PublishTimeout: constants.EventPublishTimeout,
+	})
+	aiModelClient := services.NewAIModelClient(c.Config.AIApiURL, services.AIModelClientOptions{
+		HealthPath:         c.Config.AIHealthPath,
+		AttemptTimeout:     constants.AIModelAttemptTimeout,
+		CallTimeout:        constants.AIModelCallTimeout,
+		HealthCheckTimeout: constants.AIModelHealthCheckTimeout,
+		MaxAttempts:        constants.AIModelMaxAttempts,
+		RetryBaseDelay:     constants.AIModelRetryBaseDelay,
+		RetryMaxDelay:      constants.AIModelRetryMaxDelay,
+		FailureThreshold:   constants.AIModelFailureThreshold,
+		OpenDuration:       constants.AIModelOpenDuration,
+	})
+	aiService := services.NewAIService(c.Config, aiModelClient, c.Repositories.Analytics)
+	scanHistoryService := services.NewScanHistoryService(c.Repositories.FaceScanHistory, c.Repositories.BodyScanHistory, supabaseStorageService)
+	aiJobService := services.NewAIJobService(aiService, scanHistoryService, services.AIJobOptions{
+		Workers:   constants.AIJobWorkers,
+		QueueSize: constants.AIJobQueueSize,
+		Retention: constants.AIJobRetention,
+		Timeout:   constants.AIJobTimeout,
+	})
+	clickAnalyticsService := services.NewClickAnalyticsService(c.Repositories.ClickAnalytics, c.Repositories.BrandMember)
+
 	c.Services = &Services{
-		User:            services.NewUserService(c.Repositories.User, c.Config),
-		Brand:           services.NewBrandService(c.Repositories.Brand),
-		Product:         services.NewProductService(c.Repositories.Product),
-		SavedItems:      services.NewSavedItemsService(c.Repositories.SavedItems, c.Repositories.Product),
-		Favorite:        services.NewFavoriteService(c.Repositories.Favorite, c.Repositories.Product),
-		Review:          services.NewReviewService(c.Repositories.Review, c.Repositories.Product),
-		Wardrobe:        services.NewWardrobeService(c.Repositories.Wardrobe),
-		AI:              services.NewAIService(c.Config),
-		Firebase:        firebaseService,
-		SupabaseStorage: supabaseStorageService,
-		ScanHistory:     services.NewScanHistoryService(c.Repositories.FaceScanHistory, c.Repositories.BodyScanHistory, supabaseStorageService),
-		Tracking:        services.NewTrackingService(c.Repositories.ProductClick, c.Repositories.Product, c.Repositories.Brand),
+		User:             services.NewUserService(c.Repositories.User, c.Config),
+		Brand:            services.NewBrandService(c.Repositories.Brand, productService),
+		Product:          productService,
+		SavedItems:       services.NewSavedItemsService(c.Repositories.SavedItems, c.Repositories.Product),
+		Favorite:         services.NewFavoriteService(c.Repositories.Favorite, c.Repositories.Product),
+		Review:           services.NewReviewService(c.Repositories.Review, c.Repositories.Product),
+		Wardrobe:         services.NewWardrobeService(c.Repositories.Wardrobe),
+		AI:               aiService,
+		Firebase:         firebaseService,
+		SupabaseStorage:  supabaseStorageService,
+		ScanHistory:      scanHistoryService,
+		Tracking:         services.NewTrackingService(c.Repositories.ProductClick, c.Repositories.Product, c.Repositories.Brand, c.Repositories.User, c.Repositories.LinkCheck, c.Repositories.RedirectChannel, eventBus, c.Config),
+		Variation:        services.NewVariationService(c.Repositories.Variation),
+		ProductImport:    services.NewProductImportService(c.Repositories.Product, c.Repositories.Brand, c.Repositories.Variation, watchService, stockService),
+		Campaign:         services.NewCampaignService(c.Repositories.Campaign),
+		Notification:     notificationService,
+		BrandMember:      services.NewBrandMemberService(c.Repositories.BrandMember, c.Repositories.Brand, c.Repositories.User),
+		Stock:            stockService,
+		Watch:            watchService,
+		ProductMedia:     services.NewProductMediaService(c.Repositories.ProductMedia, c.Repositories.Product, supabaseStorageService),
+		Conversion:       services.NewConversionService(c.Repositories.Conversion, c.Repositories.Product, c.Repositories.BrandMember, c.Repositories.ProductClick, c.Config),
+		BrandApplication: services.NewBrandApplicationService(c.Repositories.BrandApplication, c.Repositories.Brand, c.Repositories.BrandMember, notificationService, supabaseStorageService),
+		LinkHealth:       services.NewLinkHealthService(c.Repositories.LinkCheck, utils.NewPublicHTTPClient(constants.LinkCheckTimeout)),
+		RedirectChannel:  services.NewRedirectChannelService(c.Repositories.RedirectChannel, c.Repositories.BrandMember),
+		Analytics:        services.NewAnalyticsService(c.Repositories.Analytics),
+		ClickAnalytics:   clickAnalyticsService,
+		ProductView:      services.NewProductViewService(c.Repositories.ProductView, productService, eventBus, c.Config),
+		Events:           eventBus,
+		AIJobs:           aiJobService,
+		AIModel:          aiModelClient,
+		BrandReport:      services.NewBrandReportService(clickAnalyticsService, c.Repositories.BrandReport, c.Repositories.Brand, c.Repositories.BrandMember, mailer),
 	}
 }
 
 // initControllers initializes all controller instances
 func (c *Container) initControllers() {
 	c.Controllers = &Controllers{
-		User:        controllers.NewUserController(c.Services.User, c.Validator),
-		Brand:       controllers.NewBrandController(c.Services.Brand, c.Validator),
-		Product:     controllers.NewProductController(c.Services.Product, c.Validator),
-		SavedItems:  controllers.NewSavedItemsController(c.Services.SavedItems, c.Validator),
-		Favorite:    controllers.NewFavoriteController(c.Services.Favorite, c.Validator),
-		Review:      controllers.NewReviewController(c.Services.Review, c.Validator),
-		Wardrobe:    controllers.NewWardrobeController(c.Services.Wardrobe, c.Validator),
-		AI:          controllers.NewAIController(c.Services.AI, c.Services.ScanHistory),
-		Dashboard:   controllers.NewDashboardController(c.DB, c.Services.User, c.Services.Brand),
-		OAuth:       controllers.NewOAuthController(c.Services.User, c.Services.Firebase),
-		ScanHistory: controllers.NewScanHistoryController(c.Services.ScanHistory, c.Services.SupabaseStorage),
-		Tracking:    controllers.NewTrackingController(c.Services.Tracking, c.Services.Product, c.Repositories.Brand),
+		User:             controllers.NewUserController(c.Services.User, c.Validator),
+		Brand:            controllers.NewBrandController(c.Services.Brand, c.Validator),
+		Product:          controllers.NewProductController(c.Services.Product, c.Services.ProductView, c.Validator),
+		SavedItems:       controllers.NewSavedItemsController(c.Services.SavedItems, c.Validator),
+		Favorite:         controllers.NewFavoriteController(c.Services.Favorite, c.Validator),
+		Review:           controllers.NewReviewController(c.Services.Review, c.Validator),
+		Wardrobe:         controllers.NewWardrobeController(c.Services.Wardrobe, c.Validator),
+		AI:               controllers.NewAIController(c.Services.AI, c.Services.AIJobs, c.Services.ScanHistory),
+		Dashboard:        controllers.NewDashboardController(c.DB, c.Services.User, c.Services.Brand, c.Services.Analytics),
+		OAuth:            controllers.NewOAuthController(c.Services.User, c.Services.Firebase),
+		ScanHistory:      controllers.NewScanHistoryController(c.Services.ScanHistory, c.Services.SupabaseStorage),
+		Tracking:         controllers.NewTrackingController(c.Services.Tracking, c.Services.Product, c.Repositories.Brand),
+		Variation:        controllers.NewVariationController(c.Services.Variation, c.Validator),
+		ProductImport:    controllers.NewProductImportController(c.Services.ProductImport),
+		Campaign:         controllers.NewCampaignController(c.Services.Campaign, c.Validator),
+		Stock:            controllers.NewStockController(c.Services.Stock, c.Validator),
+		BrandMember:      controllers.NewBrandMemberController(c.Services.BrandMember, c.Validator),
+		Notification:     controllers.NewNotificationController(c.Services.Notification),
+		Watch:            controllers.NewWatchController(c.Services.Watch, c.Validator),
+		ProductMedia:     controllers.NewProductMediaController(c.Services.ProductMedia, c.Validator),
+		Conversion:       controllers.NewConversionController(c.Services.Conversion, c.Validator),
+		BrandApplication: controllers.NewBrandApplicationController(c.Services.BrandApplication, c.Validator),
+		LinkHealth:       controllers.NewLinkHealthController(c.Services.LinkHealth),
+		RedirectChannel:  controllers.NewRedirectChannelController(c.Services.RedirectChannel, c.Validator),
+		ClickAnalytics:   controllers.NewClickAnalyticsController(c.Services.ClickAnalytics),
+		Health:           controllers.NewHealthController(c.Services.AIModel),
+		BrandReport:      controllers.NewBrandReportController(c.Services.BrandReport),
 	}
 }
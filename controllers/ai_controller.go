@@ -1,10 +1,17 @@
 package controllers
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flicknfit_backend/constants"
 	"flicknfit_backend/services"
 	"flicknfit_backend/utils"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -14,21 +21,28 @@ type AIController interface {
 	PredictSkinColorTone(c *fiber.Ctx) error
 	PredictWomanBodyScan(c *fiber.Ctx) error
 	PredictMenBodyScan(c *fiber.Ctx) error
+	SubmitSkinColorToneJob(c *fiber.Ctx) error
+	SubmitWomanBodyScanJob(c *fiber.Ctx) error
+	SubmitMenBodyScanJob(c *fiber.Ctx) error
+	GetJob(c *fiber.Ctx) error
+	StreamJob(c *fiber.Ctx) error
 }
 
 // aiController is the implementation of AIController.
 type aiController struct {
 	aiService          services.AIService
+	aiJobService       services.AIJobService
 	scanHistoryService services.ScanHistoryService
 }
 
 // NewAIController creates and returns a new instance of AIController.
-func NewAIController(aiService services.AIService, scanHistoryService services.ScanHistoryService) AIController {
+func NewAIController(aiService services.AIService, aiJobService services.AIJobService, scanHistoryService services.ScanHistoryService) AIController {
 	if aiService == nil {
 		panic("aiService cannot be nil")
 	}
 	return &aiController{
 		aiService:          aiService,
+		aiJobService:       aiJobService,
 		scanHistoryService: scanHistoryService,
 	}
 }
@@ -45,6 +59,7 @@ func NewAIController(aiService services.AIService, scanHistoryService services.S
 // @Failure 400 {object} utils.Response "Invalid file or request"
 // @Failure 401 {object} utils.Response "Unauthorized"
 // @Failure 500 {object} utils.Response "Internal server error or AI API error"
+// @Failure 503 {object} utils.Response "AI model temporarily unavailable"
 // @Router /ai/predict/skin-color-tone [post]
 func (ctrl *aiController) PredictSkinColorTone(c *fiber.Ctx) error {
 	// Debug logging
@@ -111,10 +126,10 @@ func (ctrl *aiController) PredictSkinColorTone(c *fiber.Ctx) error {
 	log.Printf("DEBUG: Calling AI service...")
 
 	// Call AI service with error recovery
-	result, err := ctrl.aiService.PredictSkinColorTone(src, file.Filename)
+	result, err := ctrl.aiService.PredictSkinColorTone(c.UserContext(), src, file.Filename)
 	if err != nil {
 		log.Printf("ERROR: AI service error: %v", err)
-		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to predict skin color tone: "+err.Error(), nil)
+		return sendPredictionError(c, "Failed to predict skin color tone", err)
 	}
 
 	if result == nil {
@@ -156,6 +171,7 @@ func (ctrl *aiController) PredictSkinColorTone(c *fiber.Ctx) error {
 // @Failure 400 {object} utils.Response "Invalid file or request"
 // @Failure 401 {object} utils.Response "Unauthorized"
 // @Failure 500 {object} utils.Response "Internal server error or AI API error"
+// @Failure 503 {object} utils.Response "AI model temporarily unavailable"
 // @Router /ai/predict/woman-body-scan [post]
 func (ctrl *aiController) PredictWomanBodyScan(c *fiber.Ctx) error {
 	// Get uploaded file
@@ -177,9 +193,9 @@ func (ctrl *aiController) PredictWomanBodyScan(c *fiber.Ctx) error {
 	defer src.Close()
 
 	// Call AI service
-	result, err := ctrl.aiService.PredictWomanBodyScan(src, file.Filename)
+	result, err := ctrl.aiService.PredictWomanBodyScan(c.UserContext(), src, file.Filename)
 	if err != nil {
-		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to scan woman body: "+err.Error(), nil)
+		return sendPredictionError(c, "Failed to scan woman body", err)
 	}
 
 	// Auto-save to history if user is authenticated and scanHistoryService is available
@@ -214,6 +230,7 @@ func (ctrl *aiController) PredictWomanBodyScan(c *fiber.Ctx) error {
 // @Failure 400 {object} utils.Response "Invalid file or request"
 // @Failure 401 {object} utils.Response "Unauthorized"
 // @Failure 500 {object} utils.Response "Internal server error or AI API error"
+// @Failure 503 {object} utils.Response "AI model temporarily unavailable"
 // @Router /ai/predict/men-body-scan [post]
 func (ctrl *aiController) PredictMenBodyScan(c *fiber.Ctx) error {
 	// Get uploaded file
@@ -235,9 +252,9 @@ func (ctrl *aiController) PredictMenBodyScan(c *fiber.Ctx) error {
 	defer src.Close()
 
 	// Call AI service
-	result, err := ctrl.aiService.PredictMenBodyScan(src, file.Filename)
+	result, err := ctrl.aiService.PredictMenBodyScan(c.UserContext(), src, file.Filename)
 	if err != nil {
-		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to scan body measurements: "+err.Error(), nil)
+		return sendPredictionError(c, "Failed to scan body measurements", err)
 	}
 
 	// Auto-save to history if user is authenticated and scanHistoryService is available
@@ -260,6 +277,191 @@ func (ctrl *aiController) PredictMenBodyScan(c *fiber.Ctx) error {
 	return utils.SendResponse(c, http.StatusOK, "Body measurements predicted successfully", result)
 }
 
+// SubmitSkinColorToneJob queues a skin color tone prediction.
+// @Summary Queue skin color tone prediction
+// @Description Upload an image and return at once with a job to poll at /ai/jobs/{id} or follow at /ai/jobs/{id}/events. The job's result is the response of /ai/predict/skin-color-tone and is saved to the scan history.
+// @Tags AI Predictions
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Image file for skin color tone analysis"
+// @Success 202 {object} utils.Response{data=dtos.AIJobDTO} "Prediction queued"
+// @Failure 400 {object} utils.Response "Invalid file or request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 503 {object} utils.Response "Too many predictions waiting"
+// @Router /ai/jobs/skin-color-tone [post]
+func (ctrl *aiController) SubmitSkinColorToneJob(c *fiber.Ctx) error {
+	return ctrl.submitJob(c, constants.AIRequestSkinColorTone)
+}
+
+// SubmitWomanBodyScanJob queues a woman body scan.
+// @Summary Queue woman body scan
+// @Description Upload an image and return at once with a job to poll at /ai/jobs/{id} or follow at /ai/jobs/{id}/events. The job's result is the response of /ai/predict/woman-body-scan and is saved to the scan history.
+// @Tags AI Predictions
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Image file for body scanning"
+// @Success 202 {object} utils.Response{data=dtos.AIJobDTO} "Prediction queued"
+// @Failure 400 {object} utils.Response "Invalid file or request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 503 {object} utils.Response "Too many predictions waiting"
+// @Router /ai/jobs/woman-body-scan [post]
+func (ctrl *aiController) SubmitWomanBodyScanJob(c *fiber.Ctx) error {
+	return ctrl.submitJob(c, constants.AIRequestWomanBodyScan)
+}
+
+// SubmitMenBodyScanJob queues a men's body scan.
+// @Summary Queue men's body scan
+// @Description Upload an image and return at once with a job to poll at /ai/jobs/{id} or follow at /ai/jobs/{id}/events. The job's result is the response of /ai/predict/men-body-scan and is saved to the scan history.
+// @Tags AI Predictions
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Image file for body scanning"
+// @Success 202 {object} utils.Response{data=dtos.AIJobDTO} "Prediction queued"
+// @Failure 400 {object} utils.Response "Invalid file or request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 503 {object} utils.Response "Too many predictions waiting"
+// @Router /ai/jobs/men-body-scan [post]
+func (ctrl *aiController) SubmitMenBodyScanJob(c *fiber.Ctx) error {
+	return ctrl.submitJob(c, constants.AIRequestMenBodyScan)
+}
+
+// GetJob returns the status, and once finished the result, of a prediction job.
+// @Summary Get AI prediction job
+// @Description Status of a prediction job of the current user: queued (with its queue position), running, succeeded (with the result) or failed (with the error). Finished jobs are kept for 30 minutes.
+// @Tags AI Predictions
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID"
+// @Success 200 {object} utils.Response{data=dtos.AIJobDTO} "Job retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Job not found"
+// @Router /ai/jobs/{id} [get]
+func (ctrl *aiController) GetJob(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+
+	job, err := ctrl.aiJobService.GetJob(c.Params("id"), userID)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Job retrieved successfully", job)
+}
+
+// StreamJob streams the changes of a prediction job as server-sent events.
+// @Summary Follow AI prediction job
+// @Description Server-sent events stream of a prediction job of the current user. Every change is sent as a "job" event whose data is the job, starting with its current state; the stream ends after the job succeeded or failed.
+// @Tags AI Predictions
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param id path string true "Job ID"
+// @Success 200 {object} dtos.AIJobDTO "Stream of job events"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Job not found"
+// @Router /ai/jobs/{id}/events [get]
+func (ctrl *aiController) StreamJob(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	jobID := c.Params("id")
+
+	// Look the job up before streaming so unknown jobs get a regular error response
+	job, changed, err := ctrl.aiJobService.WatchJob(jobID, userID)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		keepAlive := time.NewTicker(constants.AIJobKeepAliveInterval)
+		defer keepAlive.Stop()
+		for {
+			if err := writeJobEvent(w, job); err != nil {
+				return // Client went away
+			}
+			if job.Status == constants.AIJobStatusSucceeded || job.Status == constants.AIJobStatusFailed {
+				return
+			}
+
+			select {
+			case <-changed:
+			case <-keepAlive.C:
+				if _, err := w.WriteString(": keep-alive\n\n"); err != nil {
+					return
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+				continue
+			}
+			if job, changed, err = ctrl.aiJobService.WatchJob(jobID, userID); err != nil {
+				return // Pruned while streaming
+			}
+		}
+	})
+	return nil
+}
+
+// submitJob reads the uploaded image and queues a prediction of kind on it
+func (ctrl *aiController) submitJob(c *fiber.Ctx, kind string) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "No file uploaded or invalid file", nil)
+	}
+	if !isValidImageType(file.Header.Get("Content-Type")) {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid file type. Only image files are allowed", nil)
+	}
+
+	// The upload is gone once the request ends, so the job keeps its own copy
+	src, err := file.Open()
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Failed to open uploaded file", nil)
+	}
+	defer src.Close()
+	image, err := io.ReadAll(src)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Failed to read uploaded file", nil)
+	}
+
+	job, err := ctrl.aiJobService.SubmitJob(userID, kind, image, file.Filename)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusAccepted, "Prediction queued", job)
+}
+
+// sendPredictionError responds to a failed prediction: 503 while the model API is unavailable, 500 otherwise
+func sendPredictionError(c *fiber.Ctx, message string, err error) error {
+	if errors.Is(err, services.ErrAIModelUnavailable) {
+		return utils.SendAppError(c, err, http.StatusServiceUnavailable)
+	}
+	return utils.SendResponse(c, http.StatusInternalServerError, message+": "+err.Error(), nil)
+}
+
+// writeJobEvent writes a job as a server-sent "job" event and flushes it
+func writeJobEvent(w *bufio.Writer, job interface{}) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: job\ndata: %s\n\n", data); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
 // isValidImageType checks if the file type is a valid image format
 func isValidImageType(contentType string) bool {
 	validTypes := map[string]bool{
@@ -0,0 +1,237 @@
+package controllers
+
+import (
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/models"
+	"flicknfit_backend/services"
+	"flicknfit_backend/utils"
+	"io"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// BrandApplicationController defines the HTTP handlers for brand applications and their review.
+type BrandApplicationController interface {
+	SubmitApplication(c *fiber.Ctx) error
+	GetMyApplications(c *fiber.Ctx) error
+
+	// Admin Routes
+	AdminGetApplications(c *fiber.Ctx) error
+	AdminGetApplication(c *fiber.Ctx) error
+	AdminApproveApplication(c *fiber.Ctx) error
+	AdminRejectApplication(c *fiber.Ctx) error
+}
+
+// brandApplicationController is the implementation of BrandApplicationController.
+type brandApplicationController struct {
+	service   services.BrandApplicationService
+	validator *validator.Validate
+}
+
+// NewBrandApplicationController creates and returns a new instance of BrandApplicationController.
+func NewBrandApplicationController(service services.BrandApplicationService, validator *validator.Validate) BrandApplicationController {
+	return &brandApplicationController{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// SubmitApplication handles a user applying to list a brand, or a member applying to verify their brand.
+// @Summary Submit a brand application
+// @Description Apply to list a new brand, or set brand_id to get an existing brand you are a member of verified. Send JSON, or multipart form fields with an optional logo file (jpg, jpeg, png, webp; max 2MB). WhatsApp numbers must be Indonesian mobile numbers and marketplace links must point to instagram.com, tokopedia.com or shopee.co.id.
+// @Tags Brand Applications
+// @Accept mpfd
+// @Produce json
+// @Security BearerAuth
+// @Param brand_id formData int false "Existing brand to verify"
+// @Param name formData string true "Brand name"
+// @Param description formData string true "Brand description"
+// @Param logo formData file false "Brand logo"
+// @Param logo_url formData string false "Logo URL, when no logo file is sent"
+// @Param website_url formData string false "Website URL"
+// @Param whatsapp_number formData string false "WhatsApp number"
+// @Param instagram_url formData string false "Instagram profile URL"
+// @Param tokopedia_url formData string false "Tokopedia shop URL"
+// @Param shopee_url formData string false "Shopee shop URL"
+// @Success 201 {object} utils.Response{data=dtos.BrandApplicationResponseDTO} "Application submitted successfully"
+// @Failure 400 {object} utils.Response "Invalid request body, logo or links"
+// @Failure 403 {object} utils.Response "Not a member of this brand"
+// @Failure 404 {object} utils.Response "Brand not found"
+// @Failure 409 {object} utils.Response "Pending application exists or brand already verified"
+// @Failure 503 {object} utils.Response "Logo storage is not configured"
+// @Router /brand-applications [post]
+func (ctrl *brandApplicationController) SubmitApplication(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+
+	var dto dtos.BrandApplicationRequestDTO
+	if err := c.BodyParser(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	var logo *services.BrandLogo
+	if file, err := c.FormFile("logo"); err == nil {
+		src, err := file.Open()
+		if err != nil {
+			return utils.SendResponse(c, http.StatusBadRequest, "Failed to open uploaded logo: "+err.Error(), nil)
+		}
+		defer src.Close()
+		data, err := io.ReadAll(src)
+		if err != nil {
+			return utils.SendResponse(c, http.StatusBadRequest, "Failed to read uploaded logo: "+err.Error(), nil)
+		}
+		logo = &services.BrandLogo{Filename: file.Filename, Data: data}
+	}
+
+	application, err := ctrl.service.SubmitApplication(userID, &dto, logo)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusCreated, "Application submitted successfully", dtos.ToBrandApplicationResponseDTO(*application))
+}
+
+// GetMyApplications lists the current user's brand applications.
+// @Summary Get my brand applications
+// @Description Retrieve the current user's brand applications with their review status and notes, newest first
+// @Tags Brand Applications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]dtos.BrandApplicationResponseDTO} "Applications retrieved successfully"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /brand-applications/me [get]
+func (ctrl *brandApplicationController) GetMyApplications(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+
+	applications, err := ctrl.service.GetMyApplications(userID)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Applications retrieved successfully", dtos.ToBrandApplicationResponseDTOs(applications))
+}
+
+// AdminGetApplications lists the brand application review queue.
+// @Summary Get brand applications (Admin only)
+// @Description Retrieve a page of brand applications, oldest first
+// @Tags Admin - Brand Management
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Filter by status: pending, approved or rejected"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page (max 100)" default(10)
+// @Success 200 {object} utils.Response{data=dtos.BrandApplicationListResponseDTO} "Applications retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid query parameters"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/brand-applications [get]
+func (ctrl *brandApplicationController) AdminGetApplications(c *fiber.Ctx) error {
+	var query dtos.BrandApplicationQueryDTO
+	if err := c.QueryParser(&query); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid query parameters: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&query); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	applications, err := ctrl.service.AdminGetApplications(&query)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Applications retrieved successfully", applications)
+}
+
+// AdminGetApplication retrieves a single brand application.
+// @Summary Get brand application (Admin only)
+// @Description Retrieve a brand application with its applicant
+// @Tags Admin - Brand Management
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Application ID"
+// @Success 200 {object} utils.Response{data=dtos.BrandApplicationResponseDTO} "Application retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid application ID"
+// @Failure 404 {object} utils.Response "Application not found"
+// @Router /admin/brand-applications/{id} [get]
+func (ctrl *brandApplicationController) AdminGetApplication(c *fiber.Ctx) error {
+	id, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid application ID", nil)
+	}
+
+	application, err := ctrl.service.AdminGetApplication(id)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Application retrieved successfully", dtos.ToBrandApplicationResponseDTO(*application))
+}
+
+// AdminApproveApplication approves a pending brand application.
+// @Summary Approve brand application (Admin only)
+// @Description Verify the brand of a pending application. A new brand is created with the applicant as owner; an existing brand takes over the submitted details. The applicant is notified.
+// @Tags Admin - Brand Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Application ID"
+// @Param review body dtos.BrandApplicationReviewRequestDTO false "Review notes"
+// @Success 200 {object} utils.Response{data=dtos.BrandApplicationResponseDTO} "Application approved successfully"
+// @Failure 400 {object} utils.Response "Invalid request or links"
+// @Failure 404 {object} utils.Response "Application not found"
+// @Failure 409 {object} utils.Response "Application already reviewed"
+// @Router /admin/brand-applications/{id}/approve [post]
+func (ctrl *brandApplicationController) AdminApproveApplication(c *fiber.Ctx) error {
+	return ctrl.review(c, ctrl.service.AdminApproveApplication, "Application approved successfully")
+}
+
+// AdminRejectApplication rejects a pending brand application.
+// @Summary Reject brand application (Admin only)
+// @Description Reject a pending application. Notes are required and are sent to the applicant.
+// @Tags Admin - Brand Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Application ID"
+// @Param review body dtos.BrandApplicationReviewRequestDTO true "Review notes"
+// @Success 200 {object} utils.Response{data=dtos.BrandApplicationResponseDTO} "Application rejected successfully"
+// @Failure 400 {object} utils.Response "Invalid request or missing notes"
+// @Failure 404 {object} utils.Response "Application not found"
+// @Failure 409 {object} utils.Response "Application already reviewed"
+// @Router /admin/brand-applications/{id}/reject [post]
+func (ctrl *brandApplicationController) AdminRejectApplication(c *fiber.Ctx) error {
+	return ctrl.review(c, ctrl.service.AdminRejectApplication, "Application rejected successfully")
+}
+
+// review parses an admin review request and applies the decision
+func (ctrl *brandApplicationController) review(c *fiber.Ctx, decide func(id, adminID uint64, notes string) (*models.BrandApplication, error), message string) error {
+	adminID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	id, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid application ID", nil)
+	}
+
+	var dto dtos.BrandApplicationReviewRequestDTO
+	if len(c.Body()) > 0 {
+		if err := utils.StrictBodyParser(c, &dto); err != nil {
+			return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+		}
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	application, err := decide(id, adminID, dto.Notes)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, message, dtos.ToBrandApplicationResponseDTO(*application))
+}
@@ -21,6 +21,11 @@ type BrandController interface {
 	// Public Routes
 	GetAllBrands(c *fiber.Ctx) error
 	GetBrandByID(c *fiber.Ctx) error
+
+	// Follow Routes
+	FollowBrand(c *fiber.Ctx) error
+	UnfollowBrand(c *fiber.Ctx) error
+	GetFollowedBrands(c *fiber.Ctx) error
 }
 
 // brandController is the implementation of BrandController.
@@ -63,7 +68,7 @@ func (ctrl *brandController) AdminCreateBrand(c *fiber.Ctx) error {
 
 	brand, err := ctrl.service.AdminCreateBrand(&dto)
 	if err != nil {
-		return utils.SendResponse(c, http.StatusInternalServerError, err.Error(), nil)
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
 	}
 
 	return utils.SendResponse(c, http.StatusCreated, "Brand created successfully", dtos.ToBrandResponseDTO(*brand))
@@ -103,7 +108,7 @@ func (ctrl *brandController) AdminUpdateBrand(c *fiber.Ctx) error {
 
 	brand, err := ctrl.service.AdminUpdateBrand(id, &dto)
 	if err != nil {
-		return utils.SendResponse(c, http.StatusInternalServerError, err.Error(), nil)
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
 	}
 
 	return utils.SendResponse(c, http.StatusOK, "Brand updated successfully", dtos.ToBrandResponseDTO(*brand))
@@ -138,47 +143,144 @@ func (ctrl *brandController) AdminDeleteBrand(c *fiber.Ctx) error {
 	return utils.SendResponse(c, http.StatusOK, "Brand deleted successfully", nil)
 }
 
-// GetAllBrands retrieves all brands for public display.
-// @Summary Get all brands
-// @Description Retrieve a list of all available brands
+// GetAllBrands retrieves a page of the brand directory for public display.
+// @Summary Get brands
+// @Description Retrieve a page of brands, optionally searched by name and sorted
 // @Tags Brands
 // @Accept json
 // @Produce json
-// @Success 200 {object} utils.Response{data=[]dtos.BrandResponseDTO} "Brands retrieved successfully"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page (max 100)" default(10)
+// @Param search query string false "Search brands by name"
+// @Param sort query string false "Sort order: name, rating, followers, products or newest" default(name)
+// @Success 200 {object} utils.Response{data=dtos.BrandListResponseDTO} "Brands retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid query parameters"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /brands [get]
 func (ctrl *brandController) GetAllBrands(c *fiber.Ctx) error {
-	brands, err := ctrl.service.GetAllBrands()
+	var query dtos.BrandListQueryDTO
+	if err := c.QueryParser(&query); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid query parameters: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&query); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	brands, err := ctrl.service.GetAllBrands(&query)
 	if err != nil {
-		return utils.SendResponse(c, http.StatusInternalServerError, err.Error(), nil)
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
 	}
 
 	return utils.SendResponse(c, http.StatusOK, "Brands retrieved successfully", brands)
 }
 
-// GetBrandByID retrieves a single brand by its ID for public display.
+// GetBrandByID retrieves a brand's detail page for public display.
 // @Summary Get brand by ID
-// @Description Retrieve detailed information about a specific brand
+// @Description Retrieve a brand with its best-selling products, the categories it sells in, its average rating and marketplace links. When a valid token is sent, is_following tells whether the user follows the brand.
 // @Tags Brands
 // @Accept json
 // @Produce json
 // @Param id path string true "Brand ID"
-// @Success 200 {object} utils.Response{data=dtos.BrandResponseDTO} "Brand retrieved successfully"
+// @Success 200 {object} utils.Response{data=dtos.BrandDetailResponseDTO} "Brand retrieved successfully"
 // @Failure 400 {object} utils.Response "Invalid brand ID"
 // @Failure 404 {object} utils.Response "Brand not found"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /brands/{id} [get]
 func (ctrl *brandController) GetBrandByID(c *fiber.Ctx) error {
-	idStr := c.Params("id")
-	id, err := strconv.ParseUint(idStr, 10, 64)
+	id, err := utils.GetUintParam(c, "id")
 	if err != nil {
 		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
 	}
+	// Anonymous visitors have no user ID and are shown as not following the brand
+	userID, _ := utils.GetUserID(c)
 
-	brand, err := ctrl.service.GetBrandByID(id)
+	brand, err := ctrl.service.GetBrandByID(id, userID)
 	if err != nil {
-		return utils.SendResponse(c, http.StatusInternalServerError, err.Error(), nil)
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
 	}
 
 	return utils.SendResponse(c, http.StatusOK, "Brand retrieved successfully", brand)
 }
+
+// FollowBrand makes the current user follow a brand.
+// @Summary Follow a brand
+// @Description Follow a brand. Following a brand that is already followed has no effect.
+// @Tags Brands
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Brand ID"
+// @Success 200 {object} utils.Response{data=dtos.BrandFollowResponseDTO} "Brand followed successfully"
+// @Failure 400 {object} utils.Response "Invalid brand ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Brand not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /brands/{id}/follow [post]
+func (ctrl *brandController) FollowBrand(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	id, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+
+	follow, err := ctrl.service.FollowBrand(id, userID)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Brand followed successfully", follow)
+}
+
+// UnfollowBrand makes the current user stop following a brand.
+// @Summary Unfollow a brand
+// @Description Stop following a brand. Unfollowing a brand that is not followed has no effect.
+// @Tags Brands
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Brand ID"
+// @Success 200 {object} utils.Response{data=dtos.BrandFollowResponseDTO} "Brand unfollowed successfully"
+// @Failure 400 {object} utils.Response "Invalid brand ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Brand not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /brands/{id}/follow [delete]
+func (ctrl *brandController) UnfollowBrand(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	id, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+
+	follow, err := ctrl.service.UnfollowBrand(id, userID)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Brand unfollowed successfully", follow)
+}
+
+// GetFollowedBrands lists the brands the current user follows.
+// @Summary Get followed brands
+// @Description Retrieve the brands the current user follows, most recently followed first
+// @Tags Brands
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]dtos.BrandResponseDTO} "Followed brands retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /brands/following [get]
+func (ctrl *brandController) GetFollowedBrands(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+
+	brands, err := ctrl.service.GetFollowedBrands(userID)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Followed brands retrieved successfully", brands)
+}
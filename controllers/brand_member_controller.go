@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/services"
+	"flicknfit_backend/utils"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// BrandMemberController defines the HTTP handlers for managing brand members.
+type BrandMemberController interface {
+	AdminGetBrandMembers(c *fiber.Ctx) error
+	AdminAddBrandMember(c *fiber.Ctx) error
+	AdminRemoveBrandMember(c *fiber.Ctx) error
+}
+
+// brandMemberController is the implementation of BrandMemberController.
+type brandMemberController struct {
+	service   services.BrandMemberService
+	validator *validator.Validate
+}
+
+// NewBrandMemberController creates and returns a new instance of BrandMemberController.
+func NewBrandMemberController(service services.BrandMemberService, validator *validator.Validate) BrandMemberController {
+	return &brandMemberController{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// AdminGetBrandMembers lists the members of a brand.
+// @Summary Get brand members (Admin only)
+// @Description Retrieve the users who manage a brand and receive its stock alerts
+// @Tags Admin - Brand Management
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Brand ID"
+// @Success 200 {object} utils.Response{data=[]dtos.BrandMemberResponseDTO} "Brand members retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid brand ID"
+// @Failure 404 {object} utils.Response "Brand not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/brands/{id}/members [get]
+func (ctrl *brandMemberController) AdminGetBrandMembers(c *fiber.Ctx) error {
+	brandID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+
+	members, err := ctrl.service.GetBrandMembers(brandID)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Brand members retrieved successfully", dtos.ToBrandMemberResponseDTOs(members))
+}
+
+// AdminAddBrandMember adds a user to a brand.
+// @Summary Add brand member (Admin only)
+// @Description Add a user to a brand as owner or staff; adding an existing member updates their role
+// @Tags Admin - Brand Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Brand ID"
+// @Param member body dtos.BrandMemberRequestDTO true "Brand member data"
+// @Success 200 {object} utils.Response{data=dtos.BrandMemberResponseDTO} "Brand member added successfully"
+// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 404 {object} utils.Response "Brand or user not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/brands/{id}/members [post]
+func (ctrl *brandMemberController) AdminAddBrandMember(c *fiber.Ctx) error {
+	brandID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+
+	var dto dtos.BrandMemberRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	member, err := ctrl.service.AddBrandMember(brandID, &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Brand member added successfully", dtos.ToBrandMemberResponseDTO(*member))
+}
+
+// AdminRemoveBrandMember removes a user from a brand.
+// @Summary Remove brand member (Admin only)
+// @Description Remove a user from a brand
+// @Tags Admin - Brand Management
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Brand ID"
+// @Param userId path int true "User ID"
+// @Success 200 {object} utils.Response "Brand member removed successfully"
+// @Failure 400 {object} utils.Response "Invalid brand or user ID"
+// @Failure 404 {object} utils.Response "Brand member not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/brands/{id}/members/{userId} [delete]
+func (ctrl *brandMemberController) AdminRemoveBrandMember(c *fiber.Ctx) error {
+	brandID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+	userID, err := utils.GetUintParam(c, "userId")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid user ID", nil)
+	}
+
+	if err := ctrl.service.RemoveBrandMember(brandID, userID); err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Brand member removed successfully", nil)
+}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/services"
+	"flicknfit_backend/utils"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BrandReportController defines the HTTP handlers for exporting brand performance reports.
+type BrandReportController interface {
+	GetBrandReport(c *fiber.Ctx) error
+	AdminGetBrandReport(c *fiber.Ctx) error
+}
+
+// brandReportController is the implementation of BrandReportController.
+type brandReportController struct {
+	service services.BrandReportService
+}
+
+// NewBrandReportController creates and returns a new instance of BrandReportController.
+func NewBrandReportController(service services.BrandReportService) BrandReportController {
+	return &brandReportController{service: service}
+}
+
+// GetBrandReport exports the performance report of a brand.
+// @Summary Export brand report (Brand members only)
+// @Description Downloads the brand's clicks, daily click series, top 10 products, channel split, product views, favorites added and reviews as a CSV, XLSX or PDF file. Dates are YYYY-MM-DD, both included; without dates the report covers the previous calendar month.
+// @Tags Brand Analytics
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param id path int true "Brand ID"
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD)"
+// @Param format query string false "File format" Enums(csv, xlsx, pdf) default(csv)
+// @Success 200 {file} file "Report file"
+// @Failure 400 {object} utils.Response "Invalid brand ID, date range or format"
+// @Failure 403 {object} utils.Response "Not a member of this brand"
+// @Failure 404 {object} utils.Response "Brand not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /brands/{id}/reports [get]
+func (ctrl *brandReportController) GetBrandReport(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	brandID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+	var query dtos.BrandReportQueryDTO
+	if err := c.QueryParser(&query); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid query parameters: "+err.Error(), nil)
+	}
+
+	file, err := ctrl.service.GetBrandReport(brandID, userID, &query)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return sendReportFile(c, file)
+}
+
+// AdminGetBrandReport exports the performance report of any brand.
+// @Summary Export brand report (Admin only)
+// @Description Downloads a brand's clicks, daily click series, top 10 products, channel split, product views, favorites added and reviews as a CSV, XLSX or PDF file. Dates are YYYY-MM-DD, both included; without dates the report covers the previous calendar month.
+// @Tags Admin - Analytics
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param id path int true "Brand ID"
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD)"
+// @Param format query string false "File format" Enums(csv, xlsx, pdf) default(csv)
+// @Success 200 {file} file "Report file"
+// @Failure 400 {object} utils.Response "Invalid brand ID, date range or format"
+// @Failure 404 {object} utils.Response "Brand not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/brands/{id}/reports [get]
+func (ctrl *brandReportController) AdminGetBrandReport(c *fiber.Ctx) error {
+	brandID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+	var query dtos.BrandReportQueryDTO
+	if err := c.QueryParser(&query); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid query parameters: "+err.Error(), nil)
+	}
+
+	file, err := ctrl.service.AdminGetBrandReport(brandID, &query)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return sendReportFile(c, file)
+}
+
+// sendReportFile sends a rendered report as a download.
+func sendReportFile(c *fiber.Ctx, file *services.ReportFile) error {
+	c.Attachment(file.FileName)
+	c.Set(fiber.HeaderContentType, file.ContentType)
+	return c.Status(http.StatusOK).Send(file.Content)
+}
@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/services"
+	"flicknfit_backend/utils"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CampaignController defines the HTTP handlers for managing discount campaigns.
+type CampaignController interface {
+	AdminGetAllCampaigns(c *fiber.Ctx) error
+	AdminGetCampaignByID(c *fiber.Ctx) error
+	AdminCreateCampaign(c *fiber.Ctx) error
+	AdminUpdateCampaign(c *fiber.Ctx) error
+	AdminDeleteCampaign(c *fiber.Ctx) error
+}
+
+// campaignController is the implementation of CampaignController.
+type campaignController struct {
+	service   services.CampaignService
+	validator *validator.Validate
+}
+
+// NewCampaignController creates and returns a new instance of CampaignController.
+func NewCampaignController(service services.CampaignService, validator *validator.Validate) CampaignController {
+	return &campaignController{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// AdminGetAllCampaigns lists all discount campaigns.
+// @Summary Get all campaigns (Admin only)
+// @Description Retrieve all discount campaigns with their targets, newest first
+// @Tags Admin - Campaign Management
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]dtos.CampaignResponseDTO} "Campaigns retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Forbidden - Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/campaigns [get]
+func (ctrl *campaignController) AdminGetAllCampaigns(c *fiber.Ctx) error {
+	campaigns, err := ctrl.service.GetAllCampaigns()
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Campaigns retrieved successfully", dtos.ToCampaignResponseDTOs(campaigns))
+}
+
+// AdminGetCampaignByID retrieves a single campaign.
+// @Summary Get campaign by ID (Admin only)
+// @Description Retrieve a discount campaign with its targets
+// @Tags Admin - Campaign Management
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Campaign ID"
+// @Success 200 {object} utils.Response{data=dtos.CampaignResponseDTO} "Campaign retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid campaign ID"
+// @Failure 404 {object} utils.Response "Campaign not found"
+// @Router /admin/campaigns/{id} [get]
+func (ctrl *campaignController) AdminGetCampaignByID(c *fiber.Ctx) error {
+	id, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid campaign ID", nil)
+	}
+
+	campaign, err := ctrl.service.GetCampaignByID(id)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusNotFound)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Campaign retrieved successfully", dtos.ToCampaignResponseDTO(*campaign))
+}
+
+// AdminCreateCampaign handles the creation of a discount campaign.
+// @Summary Create campaign (Admin only)
+// @Description Create a percentage or fixed discount campaign for a time window. Targets may be brands, categories or products; a campaign without targets applies to every product. Campaigns never stack: each item gets the lowest of its product discount and matching campaign prices.
+// @Tags Admin - Campaign Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param campaign body dtos.CampaignRequestDTO true "Campaign data"
+// @Success 201 {object} utils.Response{data=dtos.CampaignResponseDTO} "Campaign created successfully"
+// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/campaigns [post]
+func (ctrl *campaignController) AdminCreateCampaign(c *fiber.Ctx) error {
+	var dto dtos.CampaignRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	campaign, err := ctrl.service.CreateCampaign(&dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusCreated, "Campaign created successfully", dtos.ToCampaignResponseDTO(*campaign))
+}
+
+// AdminUpdateCampaign handles replacing a campaign's settings and targets.
+// @Summary Update campaign (Admin only)
+// @Description Replace a campaign's discount, time window, status and targets
+// @Tags Admin - Campaign Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Campaign ID"
+// @Param campaign body dtos.CampaignRequestDTO true "Campaign data"
+// @Success 200 {object} utils.Response{data=dtos.CampaignResponseDTO} "Campaign updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 404 {object} utils.Response "Campaign not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/campaigns/{id} [put]
+func (ctrl *campaignController) AdminUpdateCampaign(c *fiber.Ctx) error {
+	id, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid campaign ID", nil)
+	}
+
+	var dto dtos.CampaignRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	campaign, err := ctrl.service.UpdateCampaign(id, &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Campaign updated successfully", dtos.ToCampaignResponseDTO(*campaign))
+}
+
+// AdminDeleteCampaign handles deleting a campaign.
+// @Summary Delete campaign (Admin only)
+// @Description Delete a discount campaign and its targets
+// @Tags Admin - Campaign Management
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Campaign ID"
+// @Success 200 {object} utils.Response "Campaign deleted successfully"
+// @Failure 400 {object} utils.Response "Invalid campaign ID"
+// @Failure 404 {object} utils.Response "Campaign not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/campaigns/{id} [delete]
+func (ctrl *campaignController) AdminDeleteCampaign(c *fiber.Ctx) error {
+	id, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid campaign ID", nil)
+	}
+
+	if err := ctrl.service.DeleteCampaign(id); err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Campaign deleted successfully", nil)
+}
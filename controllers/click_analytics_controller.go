@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/services"
+	"flicknfit_backend/utils"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ClickAnalyticsController defines the HTTP handlers for the click analytics of admins and brands.
+type ClickAnalyticsController interface {
+	AdminGetClickAnalytics(c *fiber.Ctx) error
+	GetBrandClickAnalytics(c *fiber.Ctx) error
+}
+
+// clickAnalyticsController is the implementation of ClickAnalyticsController.
+type clickAnalyticsController struct {
+	service services.ClickAnalyticsService
+}
+
+// NewClickAnalyticsController creates and returns a new instance of ClickAnalyticsController.
+func NewClickAnalyticsController(service services.ClickAnalyticsService) ClickAnalyticsController {
+	return &clickAnalyticsController{service: service}
+}
+
+// AdminGetClickAnalytics analyses the outbound clicks of all brands or of one brand.
+// @Summary Get click analytics (Admin only)
+// @Description Clicks per day, week or month with signed-in, anonymous and unique users, plus breakdowns by product (top 20), category, device class parsed from the User-Agent and region, and the view → favorite → click funnel. Dates are YYYY-MM-DD, both included; the range defaults to the last 30 days and cannot exceed 366 days.
+// @Tags Admin - Analytics
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD), defaults to today"
+// @Param granularity query string false "Period length" Enums(day, week, month) default(day)
+// @Param brand_id query int false "Only analyse the clicks of this brand"
+// @Success 200 {object} utils.Response{data=dtos.ClickAnalyticsDTO} "Click analytics retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid date range or granularity"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/analytics/clicks [get]
+func (ctrl *clickAnalyticsController) AdminGetClickAnalytics(c *fiber.Ctx) error {
+	var query dtos.ClickAnalyticsQueryDTO
+	if err := c.QueryParser(&query); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid query parameters: "+err.Error(), nil)
+	}
+
+	analytics, err := ctrl.service.GetClickAnalytics(&query)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Click analytics retrieved successfully", analytics)
+}
+
+// GetBrandClickAnalytics analyses the outbound clicks of a brand.
+// @Summary Get brand click analytics (Brand members only)
+// @Description Clicks on the brand's products per day, week or month with signed-in, anonymous and unique users, plus breakdowns by product (top 20), category, device class and region, and the view → favorite → click funnel. Dates are YYYY-MM-DD, both included; the range defaults to the last 30 days.
+// @Tags Brand Analytics
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Brand ID"
+// @Param from query string false "First day (YYYY-MM-DD)"
+// @Param to query string false "Last day (YYYY-MM-DD), defaults to today"
+// @Param granularity query string false "Period length" Enums(day, week, month) default(day)
+// @Success 200 {object} utils.Response{data=dtos.ClickAnalyticsDTO} "Click analytics retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid brand ID, date range or granularity"
+// @Failure 403 {object} utils.Response "Not a member of this brand"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /brands/{id}/analytics/clicks [get]
+func (ctrl *clickAnalyticsController) GetBrandClickAnalytics(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	brandID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+	var query dtos.ClickAnalyticsQueryDTO
+	if err := c.QueryParser(&query); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid query parameters: "+err.Error(), nil)
+	}
+
+	analytics, err := ctrl.service.GetBrandClickAnalytics(brandID, userID, &query)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Click analytics retrieved successfully", analytics)
+}
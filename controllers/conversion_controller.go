@@ -0,0 +1,203 @@
+package controllers
+
+import (
+	"encoding/json"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/services"
+	"flicknfit_backend/utils"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ConversionController defines the HTTP handlers for brand-confirmed sales and counter reconciliation.
+type ConversionController interface {
+	RecordConversion(c *fiber.Ctx) error
+	GetBrandConversions(c *fiber.Ctx) error
+	AdminReconcileCounters(c *fiber.Ctx) error
+	RotatePostbackSecret(c *fiber.Ctx) error
+	RecordPostback(c *fiber.Ctx) error
+}
+
+// conversionController is the implementation of ConversionController.
+type conversionController struct {
+	service   services.ConversionService
+	validator *validator.Validate
+}
+
+// NewConversionController creates and returns a new instance of ConversionController.
+func NewConversionController(service services.ConversionService, validator *validator.Validate) ConversionController {
+	return &conversionController{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// RecordConversion handles a brand member confirming a sale.
+// @Summary Record a sale (Brand members only)
+// @Description Confirm that a product item was sold. The quantity is added to the sold counters of the item and product. Reporting the same SKU twice for one order_ref is rejected, so retries are safe.
+// @Tags Brand Conversions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Brand ID"
+// @Param conversion body dtos.ConversionRequestDTO true "Confirmed sale"
+// @Success 201 {object} utils.Response{data=dtos.ConversionResponseDTO} "Conversion recorded successfully"
+// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 403 {object} utils.Response "Not a member of this brand"
+// @Failure 404 {object} utils.Response "Product item not found"
+// @Failure 409 {object} utils.Response "Order line already recorded"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /brands/{id}/conversions [post]
+func (ctrl *conversionController) RecordConversion(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	brandID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+
+	var dto dtos.ConversionRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	conversion, err := ctrl.service.RecordConversion(brandID, userID, &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusCreated, "Conversion recorded successfully", dtos.ToConversionResponseDTO(*conversion))
+}
+
+// GetBrandConversions lists a brand's latest recorded sales.
+// @Summary Get brand conversions (Brand members only)
+// @Description Retrieve the latest conversions of a brand, newest first
+// @Tags Brand Conversions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Brand ID"
+// @Param limit query int false "Maximum number of entries (max 50)" default(50)
+// @Success 200 {object} utils.Response{data=[]dtos.ConversionResponseDTO} "Conversions retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid brand ID"
+// @Failure 403 {object} utils.Response "Not a member of this brand"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /brands/{id}/conversions [get]
+func (ctrl *conversionController) GetBrandConversions(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	brandID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+
+	conversions, err := ctrl.service.GetBrandConversions(brandID, userID, c.QueryInt("limit", 0))
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Conversions retrieved successfully", dtos.ToConversionResponseDTOs(conversions))
+}
+
+// AdminReconcileCounters recomputes all sold and total-product counters on demand.
+// @Summary Reconcile counters (Admin only)
+// @Description Recompute the sold counters of items and products from conversions and the total products of brands from their published products. The same job runs hourly in the background.
+// @Tags Admin - Product Management
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=models.CounterReconciliation} "Counters reconciled successfully"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/counters/reconcile [post]
+func (ctrl *conversionController) AdminReconcileCounters(c *fiber.Ctx) error {
+	result, err := ctrl.service.ReconcileCounters()
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Counters reconciled successfully", result)
+}
+
+// RotatePostbackSecret generates the secret a brand uses to sign conversion postbacks.
+// @Summary Generate postback secret (Brand members only)
+// @Description Generate a new secret for signing conversion postbacks and return it with the postback URL. The secret is only shown once; the previous secret stops working immediately.
+// @Tags Brand Conversions
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Brand ID"
+// @Success 201 {object} utils.Response{data=dtos.PostbackSecretResponseDTO} "Postback secret generated successfully"
+// @Failure 400 {object} utils.Response "Invalid brand ID"
+// @Failure 403 {object} utils.Response "Not a member of this brand"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /brands/{id}/conversions/postback-secret [post]
+func (ctrl *conversionController) RotatePostbackSecret(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	brandID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+
+	secret, err := ctrl.service.RotatePostbackSecret(brandID, userID)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusCreated, "Postback secret generated successfully", secret)
+}
+
+// RecordPostback handles an order reported by a brand's shop system or a marketplace integration.
+// @Summary Conversion postback (signed)
+// @Description Report an order with one or more lines. The request must carry X-FlickNFit-Timestamp (Unix seconds, within 5 minutes of server time) and X-FlickNFit-Signature, the hex HMAC-SHA256 of "<timestamp>.<raw body>" under the brand's postback secret. An optional Idempotency-Key makes retries return the first result. Lines already recorded for the order are skipped. Include the click_id from the outbound link to attribute the sale to the click, its channel and user.
+// @Tags Brand Conversions
+// @Accept json
+// @Produce json
+// @Param id path int true "Brand ID"
+// @Param X-FlickNFit-Timestamp header string true "Unix timestamp in seconds"
+// @Param X-FlickNFit-Signature header string true "Hex HMAC-SHA256 signature, optionally prefixed with sha256="
+// @Param Idempotency-Key header string false "Unique key of this delivery"
+// @Param postback body dtos.ConversionPostbackDTO true "Reported order"
+// @Success 201 {object} utils.Response{data=dtos.ConversionPostbackResponseDTO} "Postback recorded successfully"
+// @Success 200 {object} utils.Response{data=dtos.ConversionPostbackResponseDTO} "Postback already recorded"
+// @Failure 400 {object} utils.Response "Invalid request body, validation failed or invalid click_id"
+// @Failure 401 {object} utils.Response "Missing, expired or invalid signature"
+// @Failure 403 {object} utils.Response "Postbacks are not enabled for this brand"
+// @Failure 404 {object} utils.Response "Brand or product item not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /webhooks/brands/{id}/conversions [post]
+func (ctrl *conversionController) RecordPostback(c *fiber.Ctx) error {
+	brandID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+
+	// The signature covers the raw body, so it is checked before the body is parsed
+	body := c.Body()
+	if err := ctrl.service.VerifyPostback(brandID, c.Get(constants.HeaderPostbackTimestamp), c.Get(constants.HeaderPostbackSignature), body); err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+
+	// Integrations may send extra fields, so unknown fields are ignored here
+	var dto dtos.ConversionPostbackDTO
+	if err := json.Unmarshal(body, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	result, err := ctrl.service.RecordPostback(brandID, c.Get(constants.HeaderIdempotencyKey), &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	if result.Recorded == 0 {
+		return utils.SendResponse(c, http.StatusOK, "Postback already recorded", result)
+	}
+	return utils.SendResponse(c, http.StatusCreated, "Postback recorded successfully", result)
+}
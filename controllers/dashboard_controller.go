@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"flicknfit_backend/dtos"
 	"flicknfit_backend/services"
 	"flicknfit_backend/utils"
 	"net/http"
@@ -16,33 +17,39 @@ type DashboardController interface {
 	GetUserAnalytics(c *fiber.Ctx) error
 	GetProductAnalytics(c *fiber.Ctx) error
 	GetRevenueAnalytics(c *fiber.Ctx) error
+	GetTimeSeries(c *fiber.Ctx) error
 }
 
 type dashboardController struct {
-	db           *gorm.DB
-	userService  services.UserService
-	brandService services.BrandService
+	db               *gorm.DB
+	userService      services.UserService
+	brandService     services.BrandService
+	analyticsService services.AnalyticsService
 }
 
 // NewDashboardController creates a new dashboard controller
-func NewDashboardController(db *gorm.DB, userService services.UserService, brandService services.BrandService) DashboardController {
+func NewDashboardController(db *gorm.DB, userService services.UserService, brandService services.BrandService, analyticsService services.AnalyticsService) DashboardController {
 	return &dashboardController{
-		db:           db,
-		userService:  userService,
-		brandService: brandService,
+		db:               db,
+		userService:      userService,
+		brandService:     brandService,
+		analyticsService: analyticsService,
 	}
 }
 
 // DashboardStats represents dashboard statistics
 type DashboardStats struct {
-	TotalUsers       int64   `json:"total_users"`
-	TotalProducts    int64   `json:"total_products"`
-	TotalBrands      int64   `json:"total_brands"`
-	TotalReviews     int64   `json:"total_reviews"`
-	ActiveUsers      int64   `json:"active_users"`
-	RevenueThisMonth float64 `json:"revenue_this_month"`
-	NewUsersToday    int64   `json:"new_users_today"`
-	AIRequestsToday  int64   `json:"ai_requests_today"`
+	TotalUsers           int64   `json:"total_users"`
+	TotalProducts        int64   `json:"total_products"`
+	TotalBrands          int64   `json:"total_brands"`
+	TotalReviews         int64   `json:"total_reviews"`
+	TotalSold            int64   `json:"total_sold"`
+	ActiveUsers          int64   `json:"active_users"`
+	RevenueThisMonth     float64 `json:"revenue_this_month"`     // Confirmed GMV from conversions, in rupiah
+	ClickValueThisMonth  float64 `json:"click_value_this_month"` // Estimated GMV from outbound clicks, in rupiah
+	ConversionsThisMonth int64   `json:"conversions_this_month"`
+	NewUsersToday        int64   `json:"new_users_today"`
+	AIRequestsToday      int64   `json:"ai_requests_today"`
 }
 
 // UserAnalytics represents user analytics data
@@ -66,6 +73,7 @@ type UserFavoriteCount struct {
 // ProductAnalytics represents product analytics
 type ProductAnalytics struct {
 	TopProductsByReviews []ProductReviewCount `json:"top_products_by_reviews"`
+	TopSellingProducts   []ProductSoldCount   `json:"top_selling_products"`
 	ProductsByCategory   []CategoryCount      `json:"products_by_category"`
 	TopBrands            []BrandProductCount  `json:"top_brands"`
 	RecentProducts       []RecentProduct      `json:"recent_products"`
@@ -77,6 +85,12 @@ type ProductReviewCount struct {
 	AverageRating float64 `json:"average_rating"`
 }
 
+type ProductSoldCount struct {
+	ProductName string `json:"product_name"`
+	Brand       string `json:"brand"`
+	Sold        int64  `json:"sold"`
+}
+
 type CategoryCount struct {
 	Category string `json:"category"`
 	Count    int64  `json:"count"`
@@ -119,6 +133,9 @@ func (ctrl *dashboardController) GetDashboardStats(c *fiber.Ctx) error {
 	// Get total reviews
 	ctrl.db.Table("reviews").Count(&stats.TotalReviews)
 
+	// Get total units sold, recorded from brand-confirmed conversions
+	ctrl.db.Table("products").Select("COALESCE(SUM(sold), 0)").Where("deleted_at IS NULL").Scan(&stats.TotalSold)
+
 	// Get active users (users who logged in in last 24 hours)
 	yesterday := time.Now().AddDate(0, 0, -1)
 	ctrl.db.Table("users").Where("last_login > ? AND deleted_at IS NULL", yesterday).Count(&stats.ActiveUsers)
@@ -127,11 +144,15 @@ func (ctrl *dashboardController) GetDashboardStats(c *fiber.Ctx) error {
 	today := time.Now().Truncate(24 * time.Hour)
 	ctrl.db.Table("users").Where("created_at >= ? AND deleted_at IS NULL", today).Count(&stats.NewUsersToday)
 
-	// Mock revenue data (you can implement actual revenue calculation)
-	stats.RevenueThisMonth = 15420.50
-
-	// Mock AI requests (you can implement actual AI request tracking)
-	stats.AIRequestsToday = 127
+	// Get revenue from conversions and AI requests from the AI request log
+	overview, err := ctrl.analyticsService.GetOverview()
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	stats.RevenueThisMonth = overview.RevenueThisMonth
+	stats.ClickValueThisMonth = overview.ClickValueThisMonth
+	stats.ConversionsThisMonth = overview.ConversionsThisMonth
+	stats.AIRequestsToday = overview.AIRequestsToday
 
 	return utils.SendResponse(c, http.StatusOK, "Dashboard statistics retrieved successfully", stats)
 }
@@ -218,6 +239,17 @@ func (ctrl *dashboardController) GetProductAnalytics(c *fiber.Ctx) error {
 		Scan(&topProducts)
 	analytics.TopProductsByReviews = topProducts
 
+	// Get best-selling products
+	var topSelling []ProductSoldCount
+	ctrl.db.Table("products").
+		Select("products.name as product_name, brands.name as brand, products.sold").
+		Joins("LEFT JOIN brands ON products.brand_id = brands.id").
+		Where("products.deleted_at IS NULL AND products.sold > 0").
+		Order("products.sold DESC").
+		Limit(10).
+		Scan(&topSelling)
+	analytics.TopSellingProducts = topSelling
+
 	// Get products by category
 	var categoryCounts []CategoryCount
 	ctrl.db.Table("products").
@@ -227,12 +259,11 @@ func (ctrl *dashboardController) GetProductAnalytics(c *fiber.Ctx) error {
 		Scan(&categoryCounts)
 	analytics.ProductsByCategory = categoryCounts
 
-	// Get top brands by product count
+	// Get top brands by published product count
 	var topBrands []BrandProductCount
 	ctrl.db.Table("brands").
-		Select("brands.name as brand_name, COUNT(products.id) as product_count").
-		Joins("LEFT JOIN products ON brands.id = products.brand_id").
-		Group("brands.id, brands.name").
+		Select("brands.name as brand_name, brands.total_products as product_count").
+		Where("brands.deleted_at IS NULL").
 		Order("product_count DESC").
 		Limit(10).
 		Scan(&topBrands)
@@ -251,30 +282,50 @@ func (ctrl *dashboardController) GetProductAnalytics(c *fiber.Ctx) error {
 	return utils.SendResponse(c, http.StatusOK, "Product analytics retrieved successfully", analytics)
 }
 
-// GetRevenueAnalytics returns revenue analytics (mock data for now)
+// GetRevenueAnalytics returns revenue analytics computed from conversions
 // @Summary Get revenue analytics
-// @Description Get revenue analytics for admin dashboard
+// @Description Get the confirmed revenue (GMV) of the last 6 months per month, revenue per product category over the same months, all-time revenue and growth of this month over last month. Revenue is in rupiah.
 // @Tags Dashboard
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} utils.Response "Revenue analytics retrieved successfully"
+// @Success 200 {object} utils.Response{data=dtos.RevenueAnalyticsDTO} "Revenue analytics retrieved successfully"
 // @Failure 401 {object} utils.Response "Unauthorized"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /admin/dashboard/revenue-analytics [get]
 func (ctrl *dashboardController) GetRevenueAnalytics(c *fiber.Ctx) error {
-	// Mock revenue data - in real app, you'd calculate from orders/payments table
-	revenueData := fiber.Map{
-		"monthly_revenue": []float64{12340.50, 15670.25, 18920.75, 22150.00, 19875.50, 25340.25},
-		"revenue_by_category": []fiber.Map{
-			{"category": "Clothing", "revenue": 15420.75},
-			{"category": "Shoes", "revenue": 8750.25},
-			{"category": "Accessories", "revenue": 3290.50},
-			{"category": "Bags", "revenue": 2180.00},
-		},
-		"total_revenue":  154250.75,
-		"revenue_growth": 12.5, // percentage
+	revenueData, err := ctrl.analyticsService.GetRevenueAnalytics()
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
 	}
-
 	return utils.SendResponse(c, http.StatusOK, "Revenue analytics retrieved successfully", revenueData)
 }
+
+// GetTimeSeries returns a dashboard metric per day, week or month
+// @Summary Get metric time series
+// @Description Get a metric per period over a date range. Metrics: gmv (confirmed revenue), click_value (outbound clicks times the lowest item price), conversions, units_sold, clicks, ai_requests, new_users. Periods without data are returned with value 0.
+// @Tags Dashboard
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param metric query string true "Metric" Enums(gmv, click_value, conversions, units_sold, clicks, ai_requests, new_users)
+// @Param from query string false "First day (YYYY-MM-DD), defaults to 29 days before to"
+// @Param to query string false "Last day (YYYY-MM-DD), defaults to today"
+// @Param granularity query string false "Period length" Enums(day, week, month) default(day)
+// @Success 200 {object} utils.Response{data=dtos.TimeSeriesResponseDTO} "Time series retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid metric, granularity or date range"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/dashboard/timeseries [get]
+func (ctrl *dashboardController) GetTimeSeries(c *fiber.Ctx) error {
+	var query dtos.TimeSeriesQueryDTO
+	if err := c.QueryParser(&query); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid query parameters: "+err.Error(), nil)
+	}
+
+	series, err := ctrl.analyticsService.GetTimeSeries(&query)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Time series retrieved successfully", series)
+}
@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/services"
+	"flicknfit_backend/utils"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HealthController defines the HTTP handler reporting the health of the API and its dependencies.
+type HealthController interface {
+	GetHealth(c *fiber.Ctx) error
+}
+
+// healthController is the implementation of HealthController.
+type healthController struct {
+	aiModel services.AIModelClient
+}
+
+// NewHealthController creates and returns a new instance of HealthController.
+func NewHealthController(aiModel services.AIModelClient) HealthController {
+	return &healthController{aiModel: aiModel}
+}
+
+// GetHealth reports the health of the API and the model API.
+// @Summary Get API health
+// @Description Status "ok", or "degraded" while the model API is failing: its circuit breaker is open or half-open, or its last periodic health check failed. AI predictions are refused with 503 while the breaker is open; everything else keeps working.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} utils.Response{data=dtos.HealthDTO} "Health retrieved successfully"
+// @Router /health [get]
+func (ctrl *healthController) GetHealth(c *fiber.Ctx) error {
+	health := dtos.HealthDTO{Status: "ok", AIModel: ctrl.aiModel.Health()}
+	if health.AIModel.Breaker != constants.CircuitClosed || health.AIModel.LastCheckError != "" {
+		health.Status = "degraded"
+	}
+	return utils.SendResponse(c, http.StatusOK, "Health retrieved successfully", health)
+}
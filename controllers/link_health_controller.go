@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"flicknfit_backend/services"
+	"flicknfit_backend/utils"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LinkHealthController defines the HTTP handlers for the marketplace link health report.
+type LinkHealthController interface {
+	AdminGetBrokenLinks(c *fiber.Ctx) error
+	AdminCheckLinks(c *fiber.Ctx) error
+}
+
+// linkHealthController is the implementation of LinkHealthController.
+type linkHealthController struct {
+	service services.LinkHealthService
+}
+
+// NewLinkHealthController creates and returns a new instance of LinkHealthController.
+func NewLinkHealthController(service services.LinkHealthService) LinkHealthController {
+	return &linkHealthController{service: service}
+}
+
+// AdminGetBrokenLinks lists the product and brand links that are currently broken.
+// @Summary Get broken links (Admin only)
+// @Description Retrieve the product and brand links that failed their last checks, longest failing first. Broken links are skipped when redirecting clicks.
+// @Tags Admin - Product Management
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page (max 100)" default(10)
+// @Success 200 {object} utils.Response{data=dtos.BrokenLinkListResponseDTO} "Broken links retrieved successfully"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/links/broken [get]
+func (ctrl *linkHealthController) AdminGetBrokenLinks(c *fiber.Ctx) error {
+	links, err := ctrl.service.GetBrokenLinks(c.QueryInt("page", 1), c.QueryInt("limit", 0))
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Broken links retrieved successfully", links)
+}
+
+// AdminCheckLinks checks all stored links on demand.
+// @Summary Check links (Admin only)
+// @Description Request every stored product and brand link and update the broken link report. The same job runs every 6 hours in the background.
+// @Tags Admin - Product Management
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=models.LinkCheckSummary} "Links checked successfully"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/links/check [post]
+func (ctrl *linkHealthController) AdminCheckLinks(c *fiber.Ctx) error {
+	summary, err := ctrl.service.CheckAllLinks()
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Links checked successfully", summary)
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"flicknfit_backend/services"
+	"flicknfit_backend/utils"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// NotificationController defines the HTTP handlers for the user's notification inbox.
+type NotificationController interface {
+	GetNotifications(c *fiber.Ctx) error
+	MarkAsRead(c *fiber.Ctx) error
+	MarkAllAsRead(c *fiber.Ctx) error
+}
+
+// notificationController is the implementation of NotificationController.
+type notificationController struct {
+	service services.NotificationService
+}
+
+// NewNotificationController creates and returns a new instance of NotificationController.
+func NewNotificationController(service services.NotificationService) NotificationController {
+	return &notificationController{service: service}
+}
+
+// GetNotifications lists the authenticated user's notifications.
+// @Summary Get notifications
+// @Description Retrieve the authenticated user's notification inbox, newest first, with the unread count
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param unread query bool false "Only unread notifications"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page (max 100)" default(20)
+// @Success 200 {object} utils.Response{data=dtos.NotificationListResponseDTO} "Notifications retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /notifications [get]
+func (ctrl *notificationController) GetNotifications(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+
+	result, err := ctrl.service.GetUserNotifications(userID, c.QueryBool("unread", false), c.QueryInt("page", 1), c.QueryInt("limit", 20))
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Notifications retrieved successfully", result)
+}
+
+// MarkAsRead marks a notification as read.
+// @Summary Mark notification as read
+// @Description Mark one of the authenticated user's notifications as read
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Notification ID"
+// @Success 200 {object} utils.Response "Notification marked as read"
+// @Failure 400 {object} utils.Response "Invalid notification ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Notification not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /notifications/{id}/read [put]
+func (ctrl *notificationController) MarkAsRead(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	notificationID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid notification ID", nil)
+	}
+
+	if err := ctrl.service.MarkAsRead(userID, notificationID); err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Notification marked as read", nil)
+}
+
+// MarkAllAsRead marks all notifications as read.
+// @Summary Mark all notifications as read
+// @Description Mark all of the authenticated user's notifications as read
+// @Tags Notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response "All notifications marked as read"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /notifications/read-all [put]
+func (ctrl *notificationController) MarkAllAsRead(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+
+	if err := ctrl.service.MarkAllAsRead(userID); err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "All notifications marked as read", nil)
+}
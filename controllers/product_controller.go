@@ -1,11 +1,13 @@
 package controllers
 
 import (
+	"flicknfit_backend/constants"
 	"flicknfit_backend/dtos"
 	"flicknfit_backend/services"
 	"flicknfit_backend/utils"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -19,6 +21,10 @@ type ProductController interface {
 	AdminDeleteProduct(c *fiber.Ctx) error
 	AdminGetProductByID(c *fiber.Ctx) error
 	AdminGetAllProducts(c *fiber.Ctx) error
+	AdminUpdateProductStatus(c *fiber.Ctx) error
+	AdminCreateProductFull(c *fiber.Ctx) error
+	AdminUpdateProductFull(c *fiber.Ctx) error
+	AdminGetProductDetails(c *fiber.Ctx) error
 
 	// Public Routes
 	GetProductPublicByID(c *fiber.Ctx) error
@@ -27,18 +33,22 @@ type ProductController interface {
 	CreateReview(c *fiber.Ctx) error                // Endpoint baru
 	SearchProductsPublic(c *fiber.Ctx) error
 	GetAllProductsPublicWithFilter(c *fiber.Ctx) error
+	GetProductPriceChart(c *fiber.Ctx) error
+	GetRecentlyViewed(c *fiber.Ctx) error
 }
 
 // productController is the implementation of ProductController.
 type productController struct {
 	productService services.ProductService
+	viewService    services.ProductViewService
 	validator      *validator.Validate
 }
 
 // NewProductController creates and returns a new instance of ProductController.
-func NewProductController(productService services.ProductService, validator *validator.Validate) ProductController {
+func NewProductController(productService services.ProductService, viewService services.ProductViewService, validator *validator.Validate) ProductController {
 	return &productController{
 		productService: productService,
+		viewService:    viewService,
 		validator:      validator,
 	}
 }
@@ -176,18 +186,26 @@ func (ctrl *productController) AdminGetProductByID(c *fiber.Ctx) error {
 
 // AdminGetAllProducts handles fetching all products for admin users.
 // @Summary Get all products (Admin only)
-// @Description Retrieve a list of all products for admin management
+// @Description Retrieve a list of all products for admin management, optionally filtered by lifecycle status
 // @Tags Admin - Product Management
 // @Accept json
 // @Produce json
 // @Security BearerAuth
+// @Param status query string false "Filter by status (draft, active, inactive, scheduled)"
 // @Success 200 {object} utils.Response{data=[]dtos.AdminProductResponseDTO} "Products retrieved successfully"
 // @Failure 401 {object} utils.Response "Unauthorized"
 // @Failure 403 {object} utils.Response "Forbidden - Admin access required"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /admin/products [get]
 func (ctrl *productController) AdminGetAllProducts(c *fiber.Ctx) error {
-	products, err := ctrl.productService.AdminGetAllProducts()
+	status := strings.ToLower(c.Query("status"))
+	if status != "" {
+		if err := ctrl.validator.Var(status, "product_status"); err != nil {
+			return utils.SendResponse(c, http.StatusBadRequest, "Invalid status filter", nil)
+		}
+	}
+
+	products, err := ctrl.productService.AdminGetAllProducts(status)
 	if err != nil {
 		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to retrieve products", nil)
 	}
@@ -200,13 +218,165 @@ func (ctrl *productController) AdminGetAllProducts(c *fiber.Ctx) error {
 	return utils.SendResponse(c, http.StatusOK, "Products retrieved successfully", response)
 }
 
+// AdminUpdateProductStatus handles publishing, unpublishing or scheduling a product.
+// @Summary Update product status (Admin only)
+// @Description Change a product's lifecycle status. Only active products (and scheduled products whose publish time has passed) are visible in public endpoints. Scheduled products require a future publish_at and are published automatically by a background job.
+// @Tags Admin - Product Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param status body dtos.AdminProductStatusRequestDTO true "New status"
+// @Success 200 {object} utils.Response{data=dtos.AdminProductResponseDTO} "Product status updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Forbidden - Admin access required"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/products/{id}/status [put]
+func (ctrl *productController) AdminUpdateProductStatus(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid product ID", nil)
+	}
+
+	var dto dtos.AdminProductStatusRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	dto.Status = strings.ToLower(dto.Status)
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	product, err := ctrl.productService.AdminSetProductStatus(id, &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Product status updated successfully", dtos.ToAdminProductResponseDTO(product))
+}
+
+// AdminCreateProductFull handles creating a product together with its items, variations, categories and styles.
+// @Summary Create a full product (Admin only)
+// @Description Create a product with items (SKU, price, stock, photo), variation option combinations, categories, styles and marketplace links in one transaction
+// @Tags Admin - Product Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param product body dtos.AdminProductFullRequestDTO true "Full product data"
+// @Success 201 {object} utils.Response{data=dtos.AdminProductDetailsDTO} "Product created successfully"
+// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Forbidden - Admin access required"
+// @Failure 409 {object} utils.Response "SKU already used by another product"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/products/full [post]
+func (ctrl *productController) AdminCreateProductFull(c *fiber.Ctx) error {
+	actorID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+
+	var dto dtos.AdminProductFullRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	product, err := ctrl.productService.AdminCreateProductFull(&dto, actorID)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+
+	response := dtos.ToAdminProductDetailsDTO(product)
+	return utils.SendResponse(c, http.StatusCreated, "Product created successfully", response)
+}
+
+// AdminUpdateProductFull handles replacing a product together with its items, variations, categories and styles.
+// @Summary Update a full product (Admin only)
+// @Description Replace a product's data, items, variation option combinations, categories, styles and marketplace links in one transaction. Items are matched by ID or SKU; items missing from the request are removed.
+// @Tags Admin - Product Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param product body dtos.AdminProductFullRequestDTO true "Full product data"
+// @Success 200 {object} utils.Response{data=dtos.AdminProductDetailsDTO} "Product updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Forbidden - Admin access required"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Failure 409 {object} utils.Response "SKU already used by another product"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/products/{id}/full [put]
+func (ctrl *productController) AdminUpdateProductFull(c *fiber.Ctx) error {
+	actorID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid product ID", nil)
+	}
+
+	var dto dtos.AdminProductFullRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	product, err := ctrl.productService.AdminUpdateProductFull(id, &dto, actorID)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+
+	response := dtos.ToAdminProductDetailsDTO(product)
+	return utils.SendResponse(c, http.StatusOK, "Product updated successfully", response)
+}
+
+// AdminGetProductDetails handles fetching a product with its items, variations, categories and styles for editing.
+// @Summary Get full product details (Admin only)
+// @Description Retrieve a product with items, variation combinations, categories, styles and marketplace links for the admin editor
+// @Tags Admin - Product Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Success 200 {object} utils.Response{data=dtos.AdminProductDetailsDTO} "Product retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid product ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Forbidden - Admin access required"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Router /admin/products/{id}/full [get]
+func (ctrl *productController) AdminGetProductDetails(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid product ID", nil)
+	}
+
+	product, err := ctrl.productService.AdminGetProductDetailsByID(id)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusNotFound)
+	}
+
+	response := dtos.ToAdminProductDetailsDTO(product)
+	return utils.SendResponse(c, http.StatusOK, "Product retrieved successfully", response)
+}
+
 // GetProductPublicByID handles fetching a single product by ID for public users.
 // @Summary Get product by ID
-// @Description Retrieve detailed product information for public viewing
+// @Description Retrieve detailed product information for public viewing. The view is recorded for popularity, analytics and the recently-viewed list; repeated views by the same user or session within 30 minutes count once.
 // @Tags Products
 // @Accept json
 // @Produce json
 // @Param id path string true "Product ID"
+// @Param X-Session-ID header string false "App session ID, used to count views of anonymous users once"
 // @Success 200 {object} utils.Response{data=dtos.ProductResponseDTO} "Product found"
 // @Failure 400 {object} utils.Response "Invalid product ID"
 // @Failure 404 {object} utils.Response "Product not found"
@@ -223,10 +393,63 @@ func (ctrl *productController) GetProductPublicByID(c *fiber.Ctx) error {
 		return utils.SendResponse(c, http.StatusNotFound, "Product not found", nil)
 	}
 
+	// Views are written in the background, so recording one does not slow down this response
+	userID, _ := utils.GetUserID(c) // 0 for anonymous users
+	ctrl.viewService.RecordView(product, userID, c.Get(constants.HeaderSessionID), c.IP(), c.Get(constants.HeaderUserAgent))
+
 	response := dtos.ToProductPublicResponseDTO(product)
 	return utils.SendResponse(c, http.StatusOK, "Product found", response)
 }
 
+// GetRecentlyViewed handles fetching the products the current user viewed most recently.
+// @Summary Get recently viewed products
+// @Description Retrieve the products the authenticated user viewed most recently, newest first, each once. Products that are no longer published are left out. A view can take a few seconds to appear.
+// @Tags Products
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Maximum number of products (max 50)" default(20)
+// @Success 200 {object} utils.Response{data=[]dtos.RecentlyViewedProductDTO} "Recently viewed products retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /users/me/recently-viewed [get]
+func (ctrl *productController) GetRecentlyViewed(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+
+	products, err := ctrl.viewService.GetRecentlyViewed(userID, c.QueryInt("limit", 0))
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Recently viewed products retrieved successfully", products)
+}
+
+// GetProductPriceChart handles fetching the price history chart of a product.
+// @Summary Get product price history
+// @Description Retrieve the price history of every item of a product for a price chart, together with current and effective prices
+// @Tags Products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param days query int false "Number of days of history (max 365)" default(90)
+// @Success 200 {object} utils.Response{data=dtos.ProductPriceChartDTO} "Price history retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid product ID"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /products/{id}/price-history [get]
+func (ctrl *productController) GetProductPriceChart(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid product ID", nil)
+	}
+
+	chart, err := ctrl.productService.GetProductPriceChart(id, c.QueryInt("days", 0))
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Price history retrieved successfully", chart)
+}
+
 // GetAllProductsPublic handles fetching all products for public users.
 // @Summary Get all products
 // @Description Retrieve all available products for public viewing with optional filters
@@ -238,16 +461,21 @@ func (ctrl *productController) GetProductPublicByID(c *fiber.Ctx) error {
 // @Param min_price query number false "Minimum price"
 // @Param max_price query number false "Maximum price"
 // @Param min_rating query number false "Minimum rating"
+// @Param sort query string false "Sort by (newest, best_selling, rating, popular)"
 // @Success 200 {object} utils.Response{data=[]dtos.ProductResponseDTO} "Products retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid sort"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /products [get]
 func (ctrl *productController) GetAllProductsPublic(c *fiber.Ctx) error {
 	// Check if any filter query params exist
 	var filterParams dtos.ProductFilterRequestDTO
 	if err := c.QueryParser(&filterParams); err == nil {
-		// If filters are provided, use filtered query
+		// If filters or a sort order are provided, use filtered query
 		if filterParams.BrandID > 0 || filterParams.Category != "" ||
-			filterParams.MinPrice > 0 || filterParams.MaxPrice > 0 || filterParams.MinRating > 0 {
+			filterParams.MinPrice > 0 || filterParams.MaxPrice > 0 || filterParams.MinRating > 0 || filterParams.Sort != "" {
+			if err := ctrl.validator.Struct(&filterParams); err != nil {
+				return utils.SendResponse(c, http.StatusBadRequest, "Invalid filter parameters: "+err.Error(), nil)
+			}
 			products, err := ctrl.productService.GetAllProductsPublicWithFilter(&filterParams)
 			if err != nil {
 				return utils.SendResponse(c, http.StatusInternalServerError, "Failed to retrieve products", nil)
@@ -378,8 +606,7 @@ func (ctrl *productController) SearchProductsPublic(c *fiber.Ctx) error {
 // @Param brand query string false "Brand name"
 // @Param min_price query number false "Minimum price"
 // @Param max_price query number false "Maximum price"
-// @Param sort query string false "Sort by (price, name, created_at)"
-// @Param order query string false "Order direction (asc, desc)"
+// @Param sort query string false "Sort by (newest, best_selling, rating, popular)"
 // @Success 200 {object} utils.Response{data=[]dtos.ProductResponseDTO} "Products retrieved successfully with filters"
 // @Failure 400 {object} utils.Response "Invalid filter parameters"
 // @Failure 500 {object} utils.Response "Internal server error"
@@ -389,6 +616,9 @@ func (ctrl *productController) GetAllProductsPublicWithFilter(c *fiber.Ctx) erro
 	if err := c.QueryParser(&filterParams); err != nil {
 		return utils.SendResponse(c, http.StatusBadRequest, "Invalid filter parameters: "+err.Error(), nil)
 	}
+	if err := ctrl.validator.Struct(&filterParams); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid filter parameters: "+err.Error(), nil)
+	}
 
 	products, err := ctrl.productService.GetAllProductsPublicWithFilter(&filterParams)
 	if err != nil {
@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"bytes"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/services"
+	"flicknfit_backend/utils"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ProductImportController defines the HTTP handlers for bulk product import and export.
+type ProductImportController interface {
+	AdminImportProducts(c *fiber.Ctx) error
+	AdminExportProducts(c *fiber.Ctx) error
+}
+
+// productImportController is the implementation of ProductImportController.
+type productImportController struct {
+	service services.ProductImportService
+}
+
+// NewProductImportController creates and returns a new instance of ProductImportController.
+func NewProductImportController(service services.ProductImportService) ProductImportController {
+	return &productImportController{service: service}
+}
+
+// AdminImportProducts handles bulk product import from a CSV or JSON file.
+// @Summary Import products (Admin only)
+// @Description Import products from CSV or JSON, one row per item (SKU). Rows with the same brand and product name form one product. Items are upserted by SKU in a single transaction; nothing is saved when any row is invalid or when dry_run is true.
+// @Tags Admin - Product Management
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV or JSON file"
+// @Param format query string false "File format (csv or json); defaults to the file extension"
+// @Param dry_run query bool false "Validate only without saving"
+// @Success 200 {object} utils.Response{data=dtos.ProductImportResultDTO} "Import completed or dry run report"
+// @Failure 400 {object} utils.Response{data=dtos.ProductImportResultDTO} "Invalid file or row-level validation errors"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Forbidden - Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/products/import [post]
+func (ctrl *productImportController) AdminImportProducts(c *fiber.Ctx) error {
+	actorID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+
+	var reader io.Reader
+	format := strings.ToLower(c.Query("format"))
+
+	if file, err := c.FormFile("file"); err == nil {
+		src, err := file.Open()
+		if err != nil {
+			return utils.SendResponse(c, http.StatusBadRequest, "Failed to open uploaded file: "+err.Error(), nil)
+		}
+		defer src.Close()
+		reader = src
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(file.Filename)), ".")
+		}
+	} else {
+		// Tanpa multipart, body request dibaca langsung sebagai isi file
+		if len(c.Body()) == 0 {
+			return utils.SendResponse(c, http.StatusBadRequest, "No file uploaded", nil)
+		}
+		reader = bytes.NewReader(c.Body())
+		if format == "" {
+			if strings.Contains(c.Get(fiber.HeaderContentType), "json") {
+				format = dtos.ProductImportFormatJSON
+			} else {
+				format = dtos.ProductImportFormatCSV
+			}
+		}
+	}
+
+	dryRun := c.QueryBool("dry_run", false)
+	result, err := ctrl.service.ImportProducts(reader, format, dryRun, &actorID)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+
+	if len(result.Errors) > 0 {
+		return utils.SendResponse(c, http.StatusBadRequest, "Import validation failed, no products were saved", result)
+	}
+	if dryRun {
+		return utils.SendResponse(c, http.StatusOK, "Dry run completed, no products were saved", result)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Products imported successfully", result)
+}
+
+// AdminExportProducts handles exporting all product items as CSV or JSON.
+// @Summary Export products (Admin only)
+// @Description Export all product items in the same format accepted by the import endpoint
+// @Tags Admin - Product Management
+// @Produce text/csv
+// @Produce json
+// @Security BearerAuth
+// @Param format query string false "File format (csv or json)" default(csv)
+// @Success 200 {file} file "Exported products"
+// @Failure 400 {object} utils.Response "Unsupported format"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Forbidden - Admin access required"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/products/export [get]
+func (ctrl *productImportController) AdminExportProducts(c *fiber.Ctx) error {
+	format := strings.ToLower(c.Query("format", dtos.ProductImportFormatCSV))
+
+	var buf bytes.Buffer
+	if err := ctrl.service.ExportProducts(&buf, format); err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == dtos.ProductImportFormatJSON {
+		contentType = fiber.MIMEApplicationJSONCharsetUTF8
+	}
+	filename := "products-" + time.Now().Format("20060102-150405") + "." + format
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+	return c.Status(http.StatusOK).Send(buf.Bytes())
+}
@@ -0,0 +1,203 @@
+package controllers
+
+import (
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/services"
+	"flicknfit_backend/utils"
+	"io"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ProductMediaController defines the HTTP handlers for managing product galleries.
+type ProductMediaController interface {
+	AdminGetProductMedia(c *fiber.Ctx) error
+	AdminUploadProductMedia(c *fiber.Ctx) error
+	AdminReorderProductMedia(c *fiber.Ctx) error
+	AdminUpdateProductMedia(c *fiber.Ctx) error
+	AdminDeleteProductMedia(c *fiber.Ctx) error
+}
+
+// productMediaController is the implementation of ProductMediaController.
+type productMediaController struct {
+	service   services.ProductMediaService
+	validator *validator.Validate
+}
+
+// NewProductMediaController creates and returns a new instance of ProductMediaController.
+func NewProductMediaController(service services.ProductMediaService, validator *validator.Validate) ProductMediaController {
+	return &productMediaController{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// AdminGetProductMedia lists a product's gallery.
+// @Summary Get product media
+// @Description Retrieve all images and videos of a product and its items in display order
+// @Tags Admin - Product Management
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Success 200 {object} utils.Response{data=[]dtos.ProductMediaDTO} "Product media retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid product ID"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Router /admin/products/{id}/media [get]
+func (ctrl *productMediaController) AdminGetProductMedia(c *fiber.Ctx) error {
+	productID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid product ID", nil)
+	}
+
+	media, err := ctrl.service.GetProductMedia(productID)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Product media retrieved successfully", dtos.ToProductMediaDTOs(media))
+}
+
+// AdminUploadProductMedia handles uploading an image or video to a product gallery.
+// @Summary Upload product media
+// @Description Upload an image (jpg, jpeg, png, webp; max 10MB) or video (mp4, webm, mov; max 50MB) to a product or one of its items. Images get a generated thumbnail.
+// @Tags Admin - Product Management
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param file formData file true "Image or video file"
+// @Param product_item_id formData int false "Product item the media belongs to"
+// @Param alt_text formData string false "Alternative text"
+// @Param is_primary formData bool false "Make this the primary media"
+// @Success 201 {object} utils.Response{data=dtos.ProductMediaDTO} "Media uploaded successfully"
+// @Failure 400 {object} utils.Response "Invalid file or request"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Failure 502 {object} utils.Response "Storage upload failed"
+// @Failure 503 {object} utils.Response "Media storage is not configured"
+// @Router /admin/products/{id}/media [post]
+func (ctrl *productMediaController) AdminUploadProductMedia(c *fiber.Ctx) error {
+	productID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid product ID", nil)
+	}
+
+	var dto dtos.ProductMediaUploadRequestDTO
+	if err := c.BodyParser(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid form data: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "No file uploaded or invalid file", nil)
+	}
+	src, err := file.Open()
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Failed to open uploaded file: "+err.Error(), nil)
+	}
+	defer src.Close()
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Failed to read uploaded file: "+err.Error(), nil)
+	}
+
+	media, err := ctrl.service.UploadMedia(productID, &dto, file.Filename, data)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusCreated, "Media uploaded successfully", dtos.ToProductMediaDTO(*media))
+}
+
+// AdminReorderProductMedia handles setting the display order of a product's gallery.
+// @Summary Reorder product media
+// @Description Set the display order of a product's media; every media of the product must be listed exactly once
+// @Tags Admin - Product Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product ID"
+// @Param request body dtos.ProductMediaOrderRequestDTO true "Media IDs in display order"
+// @Success 200 {object} utils.Response{data=[]dtos.ProductMediaDTO} "Media reordered successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Router /admin/products/{id}/media/order [put]
+func (ctrl *productMediaController) AdminReorderProductMedia(c *fiber.Ctx) error {
+	productID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid product ID", nil)
+	}
+
+	var dto dtos.ProductMediaOrderRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	media, err := ctrl.service.ReorderMedia(productID, &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Media reordered successfully", dtos.ToProductMediaDTOs(media))
+}
+
+// AdminUpdateProductMedia handles updating a media's alt text or primary flag.
+// @Summary Update product media
+// @Description Update the alt text of a media or make it the primary media of its product or item
+// @Tags Admin - Product Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param mediaId path int true "Media ID"
+// @Param request body dtos.ProductMediaUpdateRequestDTO true "Media update data"
+// @Success 200 {object} utils.Response{data=dtos.ProductMediaDTO} "Media updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 404 {object} utils.Response "Media not found"
+// @Router /admin/product-media/{mediaId} [put]
+func (ctrl *productMediaController) AdminUpdateProductMedia(c *fiber.Ctx) error {
+	mediaID, err := utils.GetUintParam(c, "mediaId")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid media ID", nil)
+	}
+
+	var dto dtos.ProductMediaUpdateRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	media, err := ctrl.service.UpdateMedia(mediaID, &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Media updated successfully", dtos.ToProductMediaDTO(*media))
+}
+
+// AdminDeleteProductMedia handles deleting a media and its stored files.
+// @Summary Delete product media
+// @Description Delete a media and its files; the next media of the same product or item becomes primary if needed
+// @Tags Admin - Product Management
+// @Produce json
+// @Security BearerAuth
+// @Param mediaId path int true "Media ID"
+// @Success 200 {object} utils.Response "Media deleted successfully"
+// @Failure 400 {object} utils.Response "Invalid media ID"
+// @Failure 404 {object} utils.Response "Media not found"
+// @Router /admin/product-media/{mediaId} [delete]
+func (ctrl *productMediaController) AdminDeleteProductMedia(c *fiber.Ctx) error {
+	mediaID, err := utils.GetUintParam(c, "mediaId")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid media ID", nil)
+	}
+
+	if err := ctrl.service.DeleteMedia(mediaID); err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Media deleted successfully", nil)
+}
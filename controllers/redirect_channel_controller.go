@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/services"
+	"flicknfit_backend/utils"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RedirectChannelController defines the HTTP handlers for brand redirect settings and the channel report.
+type RedirectChannelController interface {
+	GetBrandChannels(c *fiber.Ctx) error
+	UpdateBrandChannels(c *fiber.Ctx) error
+	GetChannelReport(c *fiber.Ctx) error
+	GetLinkParams(c *fiber.Ctx) error
+	UpdateLinkParams(c *fiber.Ctx) error
+}
+
+// redirectChannelController is the implementation of RedirectChannelController.
+type redirectChannelController struct {
+	service   services.RedirectChannelService
+	validator *validator.Validate
+}
+
+// NewRedirectChannelController creates and returns a new instance of RedirectChannelController.
+func NewRedirectChannelController(service services.RedirectChannelService, validator *validator.Validate) RedirectChannelController {
+	return &redirectChannelController{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetBrandChannels returns the order in which a brand's outbound links are tried.
+// @Summary Get redirect channels (Brand members only)
+// @Description Retrieve the preferred order of outbound channels and their A/B weights. Brands without settings get the default order.
+// @Tags Brand Redirect Channels
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Brand ID"
+// @Success 200 {object} utils.Response{data=dtos.RedirectChannelsResponseDTO} "Redirect channels retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid brand ID"
+// @Failure 403 {object} utils.Response "Not a member of this brand"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /brands/{id}/redirect-channels [get]
+func (ctrl *redirectChannelController) GetBrandChannels(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	brandID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+
+	channels, err := ctrl.service.GetBrandChannels(brandID, userID)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Redirect channels retrieved successfully", channels)
+}
+
+// UpdateBrandChannels replaces a brand's redirect order and A/B weights.
+// @Summary Update redirect channels (Brand members only)
+// @Description Set the preferred order of outbound channels. Channels with a weight above 0 split the clicks between them in proportion to their weights; the others are tried in order when no weighted channel has a usable link. An empty list restores the default order.
+// @Tags Brand Redirect Channels
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Brand ID"
+// @Param channels body dtos.RedirectChannelsRequestDTO true "Channel order and weights"
+// @Success 200 {object} utils.Response{data=dtos.RedirectChannelsResponseDTO} "Redirect channels updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 403 {object} utils.Response "Not a member of this brand"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /brands/{id}/redirect-channels [put]
+func (ctrl *redirectChannelController) UpdateBrandChannels(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	brandID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+
+	var dto dtos.RedirectChannelsRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	channels, err := ctrl.service.UpdateBrandChannels(brandID, userID, &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Redirect channels updated successfully", channels)
+}
+
+// GetChannelReport reports clicks and conversions per outbound channel.
+// @Summary Get channel report (Brand members only)
+// @Description Compare clicks, conversions, units sold, revenue and conversion rate per outbound channel over the last days. Clicks and conversions recorded without a channel are reported as "unknown".
+// @Tags Brand Redirect Channels
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Brand ID"
+// @Param days query int false "Number of days to report (max 365)" default(30)
+// @Success 200 {object} utils.Response{data=dtos.ChannelReportResponseDTO} "Channel report retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid brand ID"
+// @Failure 403 {object} utils.Response "Not a member of this brand"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /brands/{id}/channel-report [get]
+func (ctrl *redirectChannelController) GetChannelReport(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	brandID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+
+	report, err := ctrl.service.GetChannelReport(brandID, userID, c.QueryInt("days", 0))
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Channel report retrieved successfully", report)
+}
+
+// GetLinkParams returns the UTM tags and affiliate IDs a brand set for its outbound links.
+// @Summary Get outbound link parameters (Brand members only)
+// @Description Retrieve the brand's overrides for the UTM tags and marketplace affiliate IDs added to outbound links. Empty values use the FlickNFit defaults.
+// @Tags Brand Redirect Channels
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Brand ID"
+// @Success 200 {object} utils.Response{data=dtos.BrandLinkParamsResponseDTO} "Link parameters retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid brand ID"
+// @Failure 403 {object} utils.Response "Not a member of this brand"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /brands/{id}/link-params [get]
+func (ctrl *redirectChannelController) GetLinkParams(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	brandID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+
+	params, err := ctrl.service.GetLinkParams(brandID, userID)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Link parameters retrieved successfully", dtos.ToBrandLinkParamsResponseDTO(*params))
+}
+
+// UpdateLinkParams replaces the UTM tags and affiliate IDs a brand set for its outbound links.
+// @Summary Update outbound link parameters (Brand members only)
+// @Description Override the UTM source, medium and campaign and the Tokopedia and Shopee affiliate IDs added to the brand's outbound links. utm_content is always the product ID. Parameters already present in a stored link are never replaced.
+// @Tags Brand Redirect Channels
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Brand ID"
+// @Param params body dtos.BrandLinkParamsDTO true "Link parameter overrides"
+// @Success 200 {object} utils.Response{data=dtos.BrandLinkParamsResponseDTO} "Link parameters updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 403 {object} utils.Response "Not a member of this brand"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /brands/{id}/link-params [put]
+func (ctrl *redirectChannelController) UpdateLinkParams(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	brandID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid brand ID", nil)
+	}
+
+	var dto dtos.BrandLinkParamsDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	params, err := ctrl.service.UpdateLinkParams(brandID, userID, &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Link parameters updated successfully", dtos.ToBrandLinkParamsResponseDTO(*params))
+}
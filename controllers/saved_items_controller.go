@@ -68,8 +68,9 @@ func (ctrl *savedItemsController) GetUserSavedItems(c *fiber.Ctx) error {
 // @Security BearerAuth
 // @Param item body dtos.AddProductItemToSavedItemsRequestDTO true "Product item to add"
 // @Success 200 {object} utils.Response{data=dtos.SavedItemsDTO} "Product item added to savedItems successfully"
-// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 400 {object} utils.Response "Invalid request body, validation failed or quantity above available stock"
 // @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 409 {object} utils.Response "Product item is out of stock"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /savedItems [post]
 func (ctrl *savedItemsController) AddProductItemToSavedItems(c *fiber.Ctx) error {
@@ -88,7 +89,7 @@ func (ctrl *savedItemsController) AddProductItemToSavedItems(c *fiber.Ctx) error
 
 	savedItems, err := ctrl.SavedItemsService.AddProductItemToSavedItems(userID, &dto)
 	if err != nil {
-		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to add item to savedItems: "+err.Error(), nil)
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
 	}
 
 	response := dtos.ToSavedItemsDTO(savedItems)
@@ -105,9 +106,10 @@ func (ctrl *savedItemsController) AddProductItemToSavedItems(c *fiber.Ctx) error
 // @Param itemId path string true "savedItems Item ID"
 // @Param item body dtos.UpdateProductItemInSavedItemsRequestDTO true "Updated item data"
 // @Success 200 {object} utils.Response{data=dtos.SavedItemsDTO} "savedItems item updated successfully"
-// @Failure 400 {object} utils.Response "Invalid request body or item ID"
+// @Failure 400 {object} utils.Response "Invalid request body, item ID or quantity above available stock"
 // @Failure 401 {object} utils.Response "Unauthorized"
 // @Failure 404 {object} utils.Response "savedItems item not found"
+// @Failure 409 {object} utils.Response "Product item is out of stock"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /savedItems/{itemId} [put]
 func (ctrl *savedItemsController) UpdateProductItemInSavedItems(c *fiber.Ctx) error {
@@ -131,7 +133,7 @@ func (ctrl *savedItemsController) UpdateProductItemInSavedItems(c *fiber.Ctx) er
 
 	savedItems, err := ctrl.SavedItemsService.UpdateProductItemInSavedItems(userID, savedItemsItemID, &dto)
 	if err != nil {
-		return utils.SendResponse(c, http.StatusUnauthorized, "Failed to update savedItems item: "+err.Error(), nil)
+		return utils.SendAppError(c, err, http.StatusUnauthorized)
 	}
 
 	response := dtos.ToSavedItemsDTO(savedItems)
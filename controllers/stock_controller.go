@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/services"
+	"flicknfit_backend/utils"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// StockController defines the HTTP handlers for product item stock management.
+type StockController interface {
+	AdminAdjustStock(c *fiber.Ctx) error
+	AdminGetStockMovements(c *fiber.Ctx) error
+	AdminSetLowStockThreshold(c *fiber.Ctx) error
+	AdminGetLowStockItems(c *fiber.Ctx) error
+}
+
+// stockController is the implementation of StockController.
+type stockController struct {
+	service   services.StockService
+	validator *validator.Validate
+}
+
+// NewStockController creates and returns a new instance of StockController.
+func NewStockController(service services.StockService, validator *validator.Validate) StockController {
+	return &stockController{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// AdminAdjustStock handles adding or removing stock from a product item.
+// @Summary Adjust item stock (Admin only)
+// @Description Add (positive delta) or remove (negative delta) stock from a product item. Every adjustment is recorded in the stock ledger with its reason and the acting admin. Brand members are notified when the item reaches its low-stock threshold or runs out.
+// @Tags Admin - Stock Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product item ID"
+// @Param adjustment body dtos.StockAdjustmentRequestDTO true "Stock adjustment"
+// @Success 200 {object} utils.Response{data=dtos.StockMovementResponseDTO} "Stock adjusted successfully"
+// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 404 {object} utils.Response "Product item not found"
+// @Failure 409 {object} utils.Response "Stock cannot go below zero"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/product-items/{id}/stock [post]
+func (ctrl *stockController) AdminAdjustStock(c *fiber.Ctx) error {
+	actorID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusInternalServerError, "Failed to get user ID from token", nil)
+	}
+	itemID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid product item ID", nil)
+	}
+
+	var dto dtos.StockAdjustmentRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	_, movement, err := ctrl.service.AdjustStock(itemID, actorID, &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Stock adjusted successfully", dtos.ToStockMovementResponseDTO(*movement))
+}
+
+// AdminGetStockMovements lists the stock ledger of a product item.
+// @Summary Get stock movements (Admin only)
+// @Description Retrieve the latest stock ledger entries of a product item, newest first
+// @Tags Admin - Stock Management
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product item ID"
+// @Param limit query int false "Maximum number of entries (max 50)" default(50)
+// @Success 200 {object} utils.Response{data=[]dtos.StockMovementResponseDTO} "Stock movements retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid product item ID"
+// @Failure 404 {object} utils.Response "Product item not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/product-items/{id}/stock/movements [get]
+func (ctrl *stockController) AdminGetStockMovements(c *fiber.Ctx) error {
+	itemID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid product item ID", nil)
+	}
+
+	movements, err := ctrl.service.GetStockMovements(itemID, c.QueryInt("limit", 0))
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Stock movements retrieved successfully", dtos.ToStockMovementResponseDTOs(movements))
+}
+
+// AdminSetLowStockThreshold sets the low-stock threshold of a product item.
+// @Summary Set low-stock threshold (Admin only)
+// @Description Set the stock level at which brand members are alerted for a product item; a null threshold resets it to the default
+// @Tags Admin - Stock Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Product item ID"
+// @Param threshold body dtos.LowStockThresholdRequestDTO true "Low-stock threshold"
+// @Success 200 {object} utils.Response{data=dtos.StockLevelResponseDTO} "Low-stock threshold updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 404 {object} utils.Response "Product item not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/product-items/{id}/low-stock-threshold [put]
+func (ctrl *stockController) AdminSetLowStockThreshold(c *fiber.Ctx) error {
+	itemID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid product item ID", nil)
+	}
+
+	var dto dtos.LowStockThresholdRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	item, err := ctrl.service.SetLowStockThreshold(itemID, &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Low-stock threshold updated successfully", dtos.ToStockLevelResponseDTO(*item))
+}
+
+// AdminGetLowStockItems lists product items at or below their low-stock threshold.
+// @Summary Get low-stock items (Admin only)
+// @Description Retrieve all product items whose stock is at or below their low-stock threshold, lowest stock first
+// @Tags Admin - Stock Management
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]dtos.StockLevelResponseDTO} "Low-stock items retrieved successfully"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/stock/low [get]
+func (ctrl *stockController) AdminGetLowStockItems(c *fiber.Ctx) error {
+	items, err := ctrl.service.GetLowStockItems()
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Low-stock items retrieved successfully", dtos.ToStockLevelResponseDTOs(items))
+}
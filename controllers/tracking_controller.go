@@ -1,12 +1,14 @@
 package controllers
 
 import (
+	"flicknfit_backend/constants"
 	"flicknfit_backend/repositories"
 	"flicknfit_backend/services"
-	"log"
+	"flicknfit_backend/utils"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
 )
 
 // TrackingController handles product click tracking endpoints
@@ -40,6 +42,7 @@ func NewTrackingController(
 // @Produce html
 // @Security BearerAuth
 // @Param id path int true "Product ID"
+// @Param X-Client-Region header string false "Region of the user, e.g. their province; the CDN's CF-IPCountry is used when absent"
 // @Success 302 "Redirect to brand store"
 // @Failure 404 {string} string "Product not found"
 // @Failure 500 {string} string "Internal server error"
@@ -48,7 +51,7 @@ func (ctrl *trackingController) TrackProductClick(c *fiber.Ctx) error {
 	// Get product ID from URL
 	productID, err := strconv.ParseUint(c.Params("id"), 10, 64)
 	if err != nil {
-		log.Printf("[TrackingController] Invalid product ID: %v", err)
+		utils.GetLogger().WithError(err).Warn("Invalid product ID")
 		return c.Status(fiber.StatusBadRequest).SendString("Invalid product ID")
 	}
 
@@ -61,41 +64,54 @@ func (ctrl *trackingController) TrackProductClick(c *fiber.Ctx) error {
 	// Get product details
 	product, err := ctrl.productService.GetProductPublicByID(productID)
 	if err != nil {
-		log.Printf("[TrackingController] Product not found: %v", err)
+		utils.GetLogger().WithError(err).Warn("Product not found")
 		return c.Status(fiber.StatusNotFound).SendString("Product not found")
 	}
 
 	// Get brand details
 	brand, err := ctrl.brandRepo.GetBrandByID(product.BrandID)
 	if err != nil {
-		log.Printf("[TrackingController] Brand not found: %v", err)
+		utils.GetLogger().WithError(err).Warn("Brand not found")
 		return c.Status(fiber.StatusNotFound).SendString("Brand not found")
 	}
 
+	// Determine redirect URL and the channel it belongs to
+	redirectURL, channel := ctrl.trackingService.GetRedirectURL(product, brand)
+
 	// Track the click (best effort - don't fail if tracking fails)
 	// Support both authenticated and anonymous tracking
-	err = ctrl.trackingService.TrackClick(
+	click, err := ctrl.trackingService.TrackClick(
 		userID, // 0 for anonymous users
 		productID,
 		c.IP(),
 		c.Get("User-Agent"),
+		channel,
+		clientRegion(c),
 	)
 	if err != nil {
-		log.Printf("[TrackingController] Failed to track click: %v", err)
+		utils.GetLogger().WithError(err).Error("Failed to track click")
 		// Continue with redirect even if tracking fails
 	} else {
-		if userID > 0 {
-			log.Printf("[TrackingController] Click tracked: user=%d, product=%d, brand=%d", userID, productID, product.BrandID)
-		} else {
-			log.Printf("[TrackingController] Anonymous click tracked: product=%d, brand=%d", productID, product.BrandID)
-		}
+		// The user is left out, so do-not-track preferences also hold for the logs
+		utils.GetLogger().WithFields(logrus.Fields{
+			"product":   productID,
+			"brand":     product.BrandID,
+			"channel":   channel,
+			"anonymous": userID == 0,
+		}).Info("Click tracked")
 	}
 
-	// Determine redirect URL based on available platforms
-	redirectURL := ctrl.trackingService.GetRedirectURL(product, brand)
-
-	log.Printf("[TrackingController] Redirecting to: %s", redirectURL)
+	// Tag the link so the brand can attribute the visit and report the sale back
+	redirectURL = ctrl.trackingService.BuildOutboundURL(redirectURL, channel, product, brand, click)
 
 	// Redirect to brand store
 	return c.Redirect(redirectURL, fiber.StatusFound) // 302 redirect
 }
+
+// clientRegion returns the region the app reported for the request, falling back to the CDN's country code.
+func clientRegion(c *fiber.Ctx) string {
+	if region := c.Get(constants.HeaderClientRegion); region != "" {
+		return region
+	}
+	return c.Get(constants.HeaderCDNCountry)
+}
@@ -0,0 +1,293 @@
+package controllers
+
+import (
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/services"
+	"flicknfit_backend/utils"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// VariationController defines the HTTP handlers for managing product variations and their options.
+type VariationController interface {
+	GetAllVariations(c *fiber.Ctx) error
+	GetVariationByID(c *fiber.Ctx) error
+
+	// Admin Routes
+	AdminCreateVariation(c *fiber.Ctx) error
+	AdminUpdateVariation(c *fiber.Ctx) error
+	AdminDeleteVariation(c *fiber.Ctx) error
+	AdminCreateOption(c *fiber.Ctx) error
+	AdminUpdateOption(c *fiber.Ctx) error
+	AdminDeleteOption(c *fiber.Ctx) error
+	AdminReorderOptions(c *fiber.Ctx) error
+}
+
+// variationController is the implementation of VariationController.
+type variationController struct {
+	service   services.VariationService
+	validator *validator.Validate
+}
+
+// NewVariationController creates and returns a new instance of VariationController.
+func NewVariationController(service services.VariationService, validator *validator.Validate) VariationController {
+	return &variationController{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetAllVariations lists all variations with their options in display order.
+// @Summary Get all variations
+// @Description Retrieve all product variations (e.g. Size, Colour, Material) with their ordered options
+// @Tags Variations
+// @Produce json
+// @Success 200 {object} utils.Response{data=[]dtos.VariationResponseDTO} "Variations retrieved successfully"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /variations [get]
+func (ctrl *variationController) GetAllVariations(c *fiber.Ctx) error {
+	variations, err := ctrl.service.GetAllVariations()
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Variations retrieved successfully", dtos.ToVariationResponseDTOs(variations))
+}
+
+// GetVariationByID retrieves a single variation with its options.
+// @Summary Get variation by ID
+// @Description Retrieve a product variation with its ordered options
+// @Tags Variations
+// @Produce json
+// @Param id path int true "Variation ID"
+// @Success 200 {object} utils.Response{data=dtos.VariationResponseDTO} "Variation retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid variation ID"
+// @Failure 404 {object} utils.Response "Variation not found"
+// @Router /variations/{id} [get]
+func (ctrl *variationController) GetVariationByID(c *fiber.Ctx) error {
+	id, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid variation ID", nil)
+	}
+
+	variation, err := ctrl.service.GetVariationByID(id)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusNotFound)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Variation retrieved successfully", dtos.ToVariationResponseDTO(*variation))
+}
+
+// AdminCreateVariation handles the creation of a variation and its initial options.
+// @Summary Create variation (Admin only)
+// @Description Create a product variation such as Size, Colour or Material with optional initial options. Colour variations require a hex value on every option.
+// @Tags Admin - Variation Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param variation body dtos.VariationCreateRequestDTO true "Variation data"
+// @Success 201 {object} utils.Response{data=dtos.VariationResponseDTO} "Variation created successfully"
+// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 409 {object} utils.Response "Variation already exists"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /admin/variations [post]
+func (ctrl *variationController) AdminCreateVariation(c *fiber.Ctx) error {
+	var dto dtos.VariationCreateRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	variation, err := ctrl.service.CreateVariation(&dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusCreated, "Variation created successfully", dtos.ToVariationResponseDTO(*variation))
+}
+
+// AdminUpdateVariation handles updating a variation's name or colour flag.
+// @Summary Update variation (Admin only)
+// @Description Rename a variation or mark it as a colour variation
+// @Tags Admin - Variation Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Variation ID"
+// @Param variation body dtos.VariationUpdateRequestDTO true "Variation update data"
+// @Success 200 {object} utils.Response{data=dtos.VariationResponseDTO} "Variation updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 404 {object} utils.Response "Variation not found"
+// @Failure 409 {object} utils.Response "Variation name already used"
+// @Router /admin/variations/{id} [put]
+func (ctrl *variationController) AdminUpdateVariation(c *fiber.Ctx) error {
+	id, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid variation ID", nil)
+	}
+
+	var dto dtos.VariationUpdateRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	variation, err := ctrl.service.UpdateVariation(id, &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Variation updated successfully", dtos.ToVariationResponseDTO(*variation))
+}
+
+// AdminDeleteVariation handles deleting a variation and its options.
+// @Summary Delete variation (Admin only)
+// @Description Delete a variation and its options. Refused while any option is used by a product item.
+// @Tags Admin - Variation Management
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Variation ID"
+// @Success 200 {object} utils.Response "Variation deleted successfully"
+// @Failure 404 {object} utils.Response "Variation not found"
+// @Failure 409 {object} utils.Response "Variation still in use"
+// @Router /admin/variations/{id} [delete]
+func (ctrl *variationController) AdminDeleteVariation(c *fiber.Ctx) error {
+	id, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid variation ID", nil)
+	}
+
+	if err := ctrl.service.DeleteVariation(id); err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Variation deleted successfully", nil)
+}
+
+// AdminCreateOption handles adding an option to a variation.
+// @Summary Create variation option (Admin only)
+// @Description Add an option to a variation. Options are appended to the end of the display order unless sort_order is given.
+// @Tags Admin - Variation Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Variation ID"
+// @Param option body dtos.VariationOptionCreateRequestDTO true "Option data"
+// @Success 201 {object} utils.Response{data=dtos.VariationOptionResponseDTO} "Option created successfully"
+// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 404 {object} utils.Response "Variation not found"
+// @Failure 409 {object} utils.Response "Option already exists"
+// @Router /admin/variations/{id}/options [post]
+func (ctrl *variationController) AdminCreateOption(c *fiber.Ctx) error {
+	variationID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid variation ID", nil)
+	}
+
+	var dto dtos.VariationOptionCreateRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	option, err := ctrl.service.CreateOption(variationID, &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusCreated, "Option created successfully", dtos.ToVariationOptionResponseDTO(*option))
+}
+
+// AdminUpdateOption handles updating a variation option.
+// @Summary Update variation option (Admin only)
+// @Description Update an option's value, hex value or sort order
+// @Tags Admin - Variation Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param optionId path int true "Option ID"
+// @Param option body dtos.VariationOptionUpdateRequestDTO true "Option update data"
+// @Success 200 {object} utils.Response{data=dtos.VariationOptionResponseDTO} "Option updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 404 {object} utils.Response "Option not found"
+// @Failure 409 {object} utils.Response "Option value already used"
+// @Router /admin/variation-options/{optionId} [put]
+func (ctrl *variationController) AdminUpdateOption(c *fiber.Ctx) error {
+	optionID, err := utils.GetUintParam(c, "optionId")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid option ID", nil)
+	}
+
+	var dto dtos.VariationOptionUpdateRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	option, err := ctrl.service.UpdateOption(optionID, &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Option updated successfully", dtos.ToVariationOptionResponseDTO(*option))
+}
+
+// AdminDeleteOption handles deleting a variation option.
+// @Summary Delete variation option (Admin only)
+// @Description Delete a variation option. Refused while the option is used by a product item configuration.
+// @Tags Admin - Variation Management
+// @Produce json
+// @Security BearerAuth
+// @Param optionId path int true "Option ID"
+// @Success 200 {object} utils.Response "Option deleted successfully"
+// @Failure 404 {object} utils.Response "Option not found"
+// @Failure 409 {object} utils.Response "Option still in use"
+// @Router /admin/variation-options/{optionId} [delete]
+func (ctrl *variationController) AdminDeleteOption(c *fiber.Ctx) error {
+	optionID, err := utils.GetUintParam(c, "optionId")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid option ID", nil)
+	}
+
+	if err := ctrl.service.DeleteOption(optionID); err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Option deleted successfully", nil)
+}
+
+// AdminReorderOptions handles setting the display order of a variation's options.
+// @Summary Reorder variation options (Admin only)
+// @Description Set the display order of all options of a variation (e.g. S < M < L < XL)
+// @Tags Admin - Variation Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Variation ID"
+// @Param order body dtos.VariationOptionOrderRequestDTO true "Option IDs in display order"
+// @Success 200 {object} utils.Response{data=dtos.VariationResponseDTO} "Options reordered successfully"
+// @Failure 400 {object} utils.Response "Invalid request body or validation failed"
+// @Failure 404 {object} utils.Response "Variation not found"
+// @Router /admin/variations/{id}/options/order [put]
+func (ctrl *variationController) AdminReorderOptions(c *fiber.Ctx) error {
+	variationID, err := utils.GetUintParam(c, "id")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid variation ID", nil)
+	}
+
+	var dto dtos.VariationOptionOrderRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	variation, err := ctrl.service.ReorderOptions(variationID, &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Options reordered successfully", dtos.ToVariationResponseDTO(*variation))
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/services"
+	"flicknfit_backend/utils"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// WatchController defines the HTTP handlers for back-in-stock and price-drop alert settings.
+type WatchController interface {
+	UpdateFavoriteWatch(c *fiber.Ctx) error
+	UpdateSavedItemWatch(c *fiber.Ctx) error
+}
+
+// watchController is the implementation of WatchController.
+type watchController struct {
+	service   services.WatchService
+	validator *validator.Validate
+}
+
+// NewWatchController creates and returns a new instance of WatchController.
+func NewWatchController(service services.WatchService, validator *validator.Validate) WatchController {
+	return &watchController{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// UpdateFavoriteWatch updates the alert settings of a favorite.
+// @Summary Update favorite alerts
+// @Description Configure back-in-stock and price-drop alerts for a favorited product item
+// @Tags Favorites
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param productId path int true "Favorited Product Item ID"
+// @Param request body dtos.WatchPreferencesRequestDTO true "Alert settings"
+// @Success 200 {object} utils.Response{data=dtos.WatchPreferencesDTO} "Favorite alerts updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Favorite not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /favorites/{productId}/watch [put]
+func (ctrl *watchController) UpdateFavoriteWatch(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusUnauthorized, err.Error(), nil)
+	}
+	productItemID, err := utils.GetUintParam(c, "productId")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid product item ID", nil)
+	}
+
+	var dto dtos.WatchPreferencesRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	favorite, err := ctrl.service.UpdateFavoriteWatch(userID, productItemID, &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Favorite alerts updated successfully", dtos.ToWatchPreferencesDTO(favorite.WatchPreferences))
+}
+
+// UpdateSavedItemWatch updates the alert settings of a saved item.
+// @Summary Update saved item alerts
+// @Description Configure back-in-stock and price-drop alerts for an item in the saved items list
+// @Tags SavedItems
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param itemId path int true "Saved Item ID"
+// @Param request body dtos.WatchPreferencesRequestDTO true "Alert settings"
+// @Success 200 {object} utils.Response{data=dtos.WatchPreferencesDTO} "Saved item alerts updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Saved item not found"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /savedItems/{itemId}/watch [put]
+func (ctrl *watchController) UpdateSavedItemWatch(c *fiber.Ctx) error {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		return utils.SendResponse(c, http.StatusUnauthorized, err.Error(), nil)
+	}
+	itemID, err := utils.GetUintParam(c, "itemId")
+	if err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid item ID", nil)
+	}
+
+	var dto dtos.WatchPreferencesRequestDTO
+	if err := utils.StrictBodyParser(c, &dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Invalid request body: "+err.Error(), nil)
+	}
+	if err := ctrl.validator.Struct(&dto); err != nil {
+		return utils.SendResponse(c, http.StatusBadRequest, "Validation failed: "+err.Error(), nil)
+	}
+
+	item, err := ctrl.service.UpdateSavedItemWatch(userID, itemID, &dto)
+	if err != nil {
+		return utils.SendAppError(c, err, http.StatusInternalServerError)
+	}
+	return utils.SendResponse(c, http.StatusOK, "Saved item alerts updated successfully", dtos.ToWatchPreferencesDTO(item.WatchPreferences))
+}
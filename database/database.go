@@ -67,6 +67,7 @@ func Migrate(db *gorm.DB, logger *logrus.Logger) {
 		&models.ProductVariation{},
 		&models.ProductVariationOption{},
 		&models.ProductConfiguration{},
+		&models.ProductMedia{},
 		&models.Review{},
 		&models.SavedItems{},
 		&models.SavedItemsList{},
@@ -77,6 +78,22 @@ func Migrate(db *gorm.DB, logger *logrus.Logger) {
 		&models.FaceScanHistory{},
 		&models.ColorToneRecommendation{},
 		&models.ProductClick{},
+		&models.PriceHistory{},
+		&models.Campaign{},
+		&models.CampaignTarget{},
+		&models.StockMovement{},
+		&models.BrandMember{},
+		&models.Notification{},
+		&models.Conversion{},
+		&models.BrandFollow{},
+		&models.BrandApplication{},
+		&models.LinkCheck{},
+		&models.BrandRedirectChannel{},
+		&models.BrandLinkParams{},
+		&models.ConversionPostback{},
+		&models.AIRequestLog{},
+		&models.ProductView{},
+		&models.BrandReportDelivery{},
 	)
 	if err != nil {
 		logger.Error("Failed to migrate database schema!", slog.Any("error", err))
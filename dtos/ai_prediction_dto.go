@@ -1,5 +1,7 @@
 package dtos
 
+import "time"
+
 // AI Prediction DTOs
 
 // SkinColorTonePredictionResponseDTO represents the response from skin color tone prediction
@@ -31,3 +33,17 @@ type AIErrorResponseDTO struct {
 	Message string `json:"message"`
 	Code    int    `json:"code,omitempty"`
 }
+
+// AIJobDTO represents an asynchronous prediction job. QueuePosition is set while the job is queued (1 is
+// next); Result holds the same object the synchronous endpoint of Kind returns once the job succeeded.
+type AIJobDTO struct {
+	ID            string      `json:"id"`
+	Kind          string      `json:"kind"`
+	Status        string      `json:"status"`
+	QueuePosition int         `json:"queue_position,omitempty"`
+	Result        interface{} `json:"result,omitempty"`
+	Error         string      `json:"error,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	StartedAt     *time.Time  `json:"started_at,omitempty"`
+	FinishedAt    *time.Time  `json:"finished_at,omitempty"`
+}
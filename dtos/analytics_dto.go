@@ -0,0 +1,44 @@
+package dtos
+
+import "flicknfit_backend/models"
+
+// TimeSeriesQueryDTO selects a dashboard metric over a date range. Dates are YYYY-MM-DD and both ends are
+// included; the range defaults to the last 30 days.
+type TimeSeriesQueryDTO struct {
+	Metric      string `query:"metric"`
+	From        string `query:"from"`
+	To          string `query:"to"`
+	Granularity string `query:"granularity"` // day (default), week or month
+}
+
+// TimeSeriesPointDTO is the value of a metric in one period. Period is the first day of the period.
+type TimeSeriesPointDTO struct {
+	Period string  `json:"period"`
+	Value  float64 `json:"value"`
+}
+
+// TimeSeriesResponseDTO represents a metric per period, including periods without data.
+type TimeSeriesResponseDTO struct {
+	Metric      string               `json:"metric"`
+	Granularity string               `json:"granularity"`
+	From        string               `json:"from"`
+	To          string               `json:"to"`
+	Total       float64              `json:"total"`
+	Points      []TimeSeriesPointDTO `json:"points"`
+}
+
+// AnalyticsOverviewDTO holds the real-data figures of the dashboard statistics.
+type AnalyticsOverviewDTO struct {
+	RevenueThisMonth     float64 `json:"revenue_this_month"`     // Confirmed GMV, in rupiah
+	ClickValueThisMonth  float64 `json:"click_value_this_month"` // Estimated GMV from outbound clicks, in rupiah
+	ConversionsThisMonth int64   `json:"conversions_this_month"`
+	AIRequestsToday      int64   `json:"ai_requests_today"`
+}
+
+// RevenueAnalyticsDTO represents the revenue section of the dashboard.
+type RevenueAnalyticsDTO struct {
+	MonthlyRevenue    []TimeSeriesPointDTO     `json:"monthly_revenue"`
+	RevenueByCategory []models.CategoryRevenue `json:"revenue_by_category"` // Over the months of MonthlyRevenue
+	TotalRevenue      float64                  `json:"total_revenue"`       // All time
+	RevenueGrowth     *float64                 `json:"revenue_growth"`      // Percentage change of this month over last month; null when last month had none
+}
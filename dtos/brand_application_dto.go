@@ -0,0 +1,93 @@
+package dtos
+
+import (
+	"flicknfit_backend/models"
+	"time"
+)
+
+// BrandApplicationRequestDTO holds the brand details of an application, sent as JSON or as multipart
+// form fields with an optional "logo" file. BrandID is set by members applying to verify a brand that
+// already exists.
+type BrandApplicationRequestDTO struct {
+	BrandID        *uint64 `json:"brand_id" form:"brand_id"`
+	Name           string  `json:"name" form:"name" validate:"required,min=1,max=100"`
+	Description    string  `json:"description" form:"description" validate:"required,min=1,max=255"`
+	LogoURL        string  `json:"logo_url" form:"logo_url" validate:"omitempty,url,max=500"`
+	WebsiteURL     string  `json:"website_url" form:"website_url" validate:"omitempty,url,max=255"`
+	WhatsAppNumber string  `json:"whatsapp_number" form:"whatsapp_number" validate:"omitempty,max=20"`
+	InstagramURL   string  `json:"instagram_url" form:"instagram_url" validate:"omitempty,max=255"`
+	TokopediaURL   string  `json:"tokopedia_url" form:"tokopedia_url" validate:"omitempty,max=255"`
+	ShopeeURL      string  `json:"shopee_url" form:"shopee_url" validate:"omitempty,max=255"`
+}
+
+// BrandApplicationReviewRequestDTO is used by an admin to approve or reject an application.
+// Notes are shown to the applicant and are required when rejecting.
+type BrandApplicationReviewRequestDTO struct {
+	Notes string `json:"notes" validate:"max=1000"`
+}
+
+// BrandApplicationQueryDTO holds the query parameters of the admin review queue.
+type BrandApplicationQueryDTO struct {
+	Status string `query:"status" validate:"omitempty,oneof=pending approved rejected"`
+	Page   int    `query:"page"`
+	Limit  int    `query:"limit"`
+}
+
+// BrandApplicationResponseDTO represents a brand application and its review.
+type BrandApplicationResponseDTO struct {
+	ID             uint64     `json:"id"`
+	UserID         uint64     `json:"user_id"`
+	Username       string     `json:"username,omitempty"`
+	BrandID        *uint64    `json:"brand_id"`
+	Name           string     `json:"name"`
+	Description    string     `json:"description"`
+	LogoURL        string     `json:"logo_url"`
+	WebsiteURL     string     `json:"website_url"`
+	WhatsAppNumber string     `json:"whatsapp_number"`
+	InstagramURL   string     `json:"instagram_url"`
+	TokopediaURL   string     `json:"tokopedia_url"`
+	ShopeeURL      string     `json:"shopee_url"`
+	Status         string     `json:"status"`
+	ReviewNotes    string     `json:"review_notes"`
+	ReviewedBy     *uint64    `json:"reviewed_by"`
+	ReviewedAt     *time.Time `json:"reviewed_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// BrandApplicationListResponseDTO represents a page of the admin review queue.
+type BrandApplicationListResponseDTO struct {
+	Applications []BrandApplicationResponseDTO `json:"applications"`
+	Pagination   PaginationDTO                 `json:"pagination"`
+}
+
+// ToBrandApplicationResponseDTO converts a models.BrandApplication to a BrandApplicationResponseDTO.
+func ToBrandApplicationResponseDTO(application models.BrandApplication) BrandApplicationResponseDTO {
+	return BrandApplicationResponseDTO{
+		ID:             application.ID,
+		UserID:         application.UserID,
+		Username:       application.User.Username,
+		BrandID:        application.BrandID,
+		Name:           application.Name,
+		Description:    application.Description,
+		LogoURL:        application.LogoURL,
+		WebsiteURL:     application.WebsiteURL,
+		WhatsAppNumber: application.WhatsAppNumber,
+		InstagramURL:   application.InstagramURL,
+		TokopediaURL:   application.TokopediaURL,
+		ShopeeURL:      application.ShopeeURL,
+		Status:         application.Status,
+		ReviewNotes:    application.ReviewNotes,
+		ReviewedBy:     application.ReviewedBy,
+		ReviewedAt:     application.ReviewedAt,
+		CreatedAt:      application.CreatedAt,
+	}
+}
+
+// ToBrandApplicationResponseDTOs converts a slice of models.BrandApplication to a slice of BrandApplicationResponseDTO.
+func ToBrandApplicationResponseDTOs(applications []models.BrandApplication) []BrandApplicationResponseDTO {
+	result := make([]BrandApplicationResponseDTO, 0, len(applications))
+	for _, application := range applications {
+		result = append(result, ToBrandApplicationResponseDTO(application))
+	}
+	return result
+}
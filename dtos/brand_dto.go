@@ -7,32 +7,43 @@ import (
 
 // BrandCreateRequestDTO is used for creating a new brand by an admin.
 type BrandCreateRequestDTO struct {
-	Name        string `json:"name" validate:"required,min=1,max=100"`
-	Description string `json:"description" validate:"required,min=1,max=255"`
-	LogoURL     string `json:"logo_url" validate:"omitempty,url"`
-	WebsiteURL  string `json:"website_url" validate:"omitempty,url"`
+	Name           string `json:"name" validate:"required,min=1,max=100"`
+	Description    string `json:"description" validate:"required,min=1,max=255"`
+	LogoURL        string `json:"logo_url" validate:"omitempty,url"`
+	WebsiteURL     string `json:"website_url" validate:"omitempty,url"`
+	WhatsAppNumber string `json:"whatsapp_number" validate:"omitempty,max=20"`
+	InstagramURL   string `json:"instagram_url" validate:"omitempty,url"`
+	TokopediaURL   string `json:"tokopedia_url" validate:"omitempty,url"`
+	ShopeeURL      string `json:"shopee_url" validate:"omitempty,url"`
 }
 
 // BrandUpdateRequestDTO is used for updating an existing brand by an admin.
 type BrandUpdateRequestDTO struct {
-	Name        string `json:"name" validate:"omitempty,min=1,max=100"`
-	Description string `json:"description" validate:"omitempty,min=1,max=255"`
-	LogoURL     string `json:"logo_url" validate:"omitempty,url"`
-	WebsiteURL  string `json:"website_url" validate:"omitempty,url"`
+	Name           string `json:"name" validate:"omitempty,min=1,max=100"`
+	Description    string `json:"description" validate:"omitempty,min=1,max=255"`
+	LogoURL        string `json:"logo_url" validate:"omitempty,url"`
+	WebsiteURL     string `json:"website_url" validate:"omitempty,url"`
+	WhatsAppNumber string `json:"whatsapp_number" validate:"omitempty,max=20"`
+	InstagramURL   string `json:"instagram_url" validate:"omitempty,url"`
+	TokopediaURL   string `json:"tokopedia_url" validate:"omitempty,url"`
+	ShopeeURL      string `json:"shopee_url" validate:"omitempty,url"`
 }
 
 // BrandResponseDTO represents the brand data returned to any user.
 type BrandResponseDTO struct {
-	ID            uint64    `json:"id"`
-	Name          string    `json:"name"`
-	Description   string    `json:"description"`
-	Rating        float64   `json:"rating"`
-	Reviewer      uint      `json:"reviewer"`
-	LogoURL       string    `json:"logo_url"`
-	WebsiteURL    string    `json:"website_url"`
-	TotalProducts uint      `json:"total_products"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID            uint64     `json:"id"`
+	Name          string     `json:"name"`
+	Description   string     `json:"description"`
+	Rating        float64    `json:"rating"`
+	Reviewer      uint       `json:"reviewer"`
+	LogoURL       string     `json:"logo_url"`
+	WebsiteURL    string     `json:"website_url"`
+	TotalProducts uint       `json:"total_products"`
+	Followers     uint       `json:"followers"`
+	Verified      bool       `json:"verified"`
+	VerifiedAt    *time.Time `json:"verified_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
 }
 
 // ToBrandResponseDTO converts a models.Brand to a BrandResponseDTO.
@@ -46,6 +57,9 @@ func ToBrandResponseDTO(brand models.Brand) BrandResponseDTO {
 		LogoURL:       brand.LogoURL,
 		WebsiteURL:    brand.WebsiteURL,
 		TotalProducts: brand.TotalProducts,
+		Followers:     brand.Followers,
+		Verified:      brand.Verified,
+		VerifiedAt:    brand.VerifiedAt,
 		CreatedAt:     brand.CreatedAt,
 		UpdatedAt:     brand.UpdatedAt,
 	}
@@ -59,3 +73,43 @@ func ToBrandResponseDTOs(brands []models.Brand) []BrandResponseDTO {
 	}
 	return result
 }
+
+// BrandListQueryDTO holds the query parameters of the public brand directory.
+type BrandListQueryDTO struct {
+	Page   int    `query:"page"`
+	Limit  int    `query:"limit"`
+	Search string `query:"search" validate:"omitempty,max=100"`
+	Sort   string `query:"sort" validate:"omitempty,oneof=name rating followers products newest"`
+}
+
+// BrandListResponseDTO represents a page of the brand directory.
+type BrandListResponseDTO struct {
+	Brands     []BrandResponseDTO `json:"brands"`
+	Pagination PaginationDTO      `json:"pagination"`
+}
+
+// BrandMarketplaceLinksDTO holds the places where a brand can be contacted or bought from.
+// Links the brand has not set are omitted.
+type BrandMarketplaceLinksDTO struct {
+	WhatsApp  string `json:"whatsapp,omitempty"`
+	Instagram string `json:"instagram,omitempty"`
+	Tokopedia string `json:"tokopedia,omitempty"`
+	Shopee    string `json:"shopee,omitempty"`
+	Website   string `json:"website,omitempty"`
+}
+
+// BrandDetailResponseDTO represents a brand's public detail page.
+type BrandDetailResponseDTO struct {
+	BrandResponseDTO
+	Categories  []string                 `json:"categories"`
+	TopProducts []ProductResponseDTO     `json:"top_products"`
+	Links       BrandMarketplaceLinksDTO `json:"links"`
+	IsFollowing bool                     `json:"is_following"`
+}
+
+// BrandFollowResponseDTO reports a user's follow state of a brand after following or unfollowing it.
+type BrandFollowResponseDTO struct {
+	BrandID   uint64 `json:"brand_id"`
+	Following bool   `json:"following"`
+	Followers uint   `json:"followers"`
+}
@@ -0,0 +1,45 @@
+package dtos
+
+import (
+	"flicknfit_backend/models"
+	"time"
+)
+
+// BrandMemberRequestDTO is used by an admin to add a user to a brand.
+type BrandMemberRequestDTO struct {
+	UserID uint64 `json:"user_id" validate:"required"`
+	Role   string `json:"role" validate:"omitempty,oneof=owner staff"`
+}
+
+// BrandMemberResponseDTO represents a brand member.
+type BrandMemberResponseDTO struct {
+	ID        uint64    `json:"id"`
+	BrandID   uint64    `json:"brand_id"`
+	UserID    uint64    `json:"user_id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToBrandMemberResponseDTO converts a BrandMember model into a BrandMemberResponseDTO.
+func ToBrandMemberResponseDTO(member models.BrandMember) BrandMemberResponseDTO {
+	return BrandMemberResponseDTO{
+		ID:        member.ID,
+		BrandID:   member.BrandID,
+		UserID:    member.UserID,
+		Username:  member.User.Username,
+		Email:     member.User.Email,
+		Role:      member.Role,
+		CreatedAt: member.CreatedAt,
+	}
+}
+
+// ToBrandMemberResponseDTOs converts a slice of BrandMember models into BrandMemberResponseDTOs.
+func ToBrandMemberResponseDTOs(members []models.BrandMember) []BrandMemberResponseDTO {
+	result := make([]BrandMemberResponseDTO, len(members))
+	for i, member := range members {
+		result[i] = ToBrandMemberResponseDTO(member)
+	}
+	return result
+}
@@ -0,0 +1,35 @@
+package dtos
+
+import (
+	"flicknfit_backend/models"
+	"time"
+)
+
+// BrandReportQueryDTO selects the period and file format of a brand report. Dates are YYYY-MM-DD and both
+// ends are included; without them the report covers the previous calendar month.
+type BrandReportQueryDTO struct {
+	From   string `query:"from"`
+	To     string `query:"to"`
+	Format string `query:"format"` // csv (default), xlsx or pdf
+}
+
+// BrandReportDTO represents the performance of a brand over a period. Suspicious clicks are left out.
+type BrandReportDTO struct {
+	BrandID         uint64                     `json:"brand_id"`
+	BrandName       string                     `json:"brand_name"`
+	From            string                     `json:"from"`
+	To              string                     `json:"to"`
+	GeneratedAt     time.Time                  `json:"generated_at"`
+	TotalClicks     int64                      `json:"total_clicks"`
+	SignedInClicks  int64                      `json:"signed_in_clicks"`
+	AnonymousClicks int64                      `json:"anonymous_clicks"`
+	UniqueUsers     int64                      `json:"unique_users"`
+	ProductViews    int64                      `json:"product_views"`
+	FavoritesAdded  int64                      `json:"favorites_added"`
+	Reviews         int64                      `json:"reviews"`
+	AverageRating   *float64                   `json:"average_rating"` // null without reviews
+	DailyClicks     []ClickSeriesPointDTO      `json:"daily_clicks"`
+	TopProducts     []models.ProductClickCount `json:"top_products"` // Top 10
+	Channels        []ClickBreakdownDTO        `json:"channels"`
+	Ratings         []models.RatingCount       `json:"ratings"` // 5 to 1 stars, including ratings without reviews
+}
@@ -0,0 +1,80 @@
+package dtos
+
+import (
+	"flicknfit_backend/models"
+	"time"
+)
+
+// CampaignTargetDTO describes one campaign target. Brand and product targets use TargetID,
+// category targets use Category.
+type CampaignTargetDTO struct {
+	TargetType string `json:"target_type" validate:"required,oneof=brand category product"`
+	TargetID   uint64 `json:"target_id,omitempty" validate:"required_unless=TargetType category"`
+	Category   string `json:"category,omitempty" validate:"required_if=TargetType category,max=100"`
+}
+
+// CampaignRequestDTO is used by an admin to create or replace a discount campaign.
+// A percentage discount uses DiscountValue as 0-100 percent; a fixed discount uses it as an amount in rupiah.
+// A campaign without targets applies to every product.
+type CampaignRequestDTO struct {
+	Name          string              `json:"name" validate:"required,max=100"`
+	Description   string              `json:"description"`
+	DiscountType  string              `json:"discount_type" validate:"required,oneof=percentage fixed"`
+	DiscountValue float64             `json:"discount_value" validate:"required,gt=0"`
+	StartAt       time.Time           `json:"start_at" validate:"required"`
+	EndAt         time.Time           `json:"end_at" validate:"required,gtfield=StartAt"`
+	IsActive      *bool               `json:"is_active"`
+	Targets       []CampaignTargetDTO `json:"targets" validate:"omitempty,dive"`
+}
+
+// CampaignResponseDTO represents a campaign returned to admins.
+type CampaignResponseDTO struct {
+	ID            uint64              `json:"id"`
+	Name          string              `json:"name"`
+	Description   string              `json:"description"`
+	DiscountType  string              `json:"discount_type"`
+	DiscountValue float64             `json:"discount_value"`
+	StartAt       time.Time           `json:"start_at"`
+	EndAt         time.Time           `json:"end_at"`
+	IsActive      bool                `json:"is_active"`
+	IsRunning     bool                `json:"is_running"` // Active and within its time window right now
+	Targets       []CampaignTargetDTO `json:"targets"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+// ToCampaignResponseDTO converts a Campaign model into a CampaignResponseDTO.
+func ToCampaignResponseDTO(campaign models.Campaign) CampaignResponseDTO {
+	targets := make([]CampaignTargetDTO, len(campaign.Targets))
+	for i, target := range campaign.Targets {
+		targets[i] = CampaignTargetDTO{
+			TargetType: target.TargetType,
+			TargetID:   target.TargetID,
+			Category:   target.Category,
+		}
+	}
+	now := time.Now()
+	return CampaignResponseDTO{
+		ID:            campaign.ID,
+		Name:          campaign.Name,
+		Description:   campaign.Description,
+		DiscountType:  campaign.DiscountType,
+		DiscountValue: campaign.DiscountValue,
+		StartAt:       campaign.StartAt,
+		EndAt:         campaign.EndAt,
+		IsActive:      campaign.IsActive,
+		IsRunning:     campaign.IsActive && !campaign.StartAt.After(now) && campaign.EndAt.After(now),
+		Targets:       targets,
+		CreatedAt:     campaign.CreatedAt,
+		UpdatedAt:     campaign.UpdatedAt,
+	}
+}
+
+// ToCampaignResponseDTOs converts a slice of Campaign models into CampaignResponseDTOs.
+func ToCampaignResponseDTOs(campaigns []models.Campaign) []CampaignResponseDTO {
+	result := make([]CampaignResponseDTO, len(campaigns))
+	for i, campaign := range campaigns {
+		result[i] = ToCampaignResponseDTO(campaign)
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+package dtos
+
+import "flicknfit_backend/models"
+
+// ClickAnalyticsQueryDTO selects the clicks to analyse. Dates are YYYY-MM-DD and both ends are included;
+// the range defaults to the last 30 days. BrandID is only used by the admin endpoint.
+type ClickAnalyticsQueryDTO struct {
+	From        string `query:"from"`
+	To          string `query:"to"`
+	Granularity string `query:"granularity"` // day (default), week or month
+	BrandID     uint64 `query:"brand_id"`
+}
+
+// ClickSeriesPointDTO counts the clicks in one period. Period is the first day of the period.
+type ClickSeriesPointDTO struct {
+	Period          string `json:"period"`
+	Clicks          int64  `json:"clicks"`
+	SignedInClicks  int64  `json:"signed_in_clicks"`
+	AnonymousClicks int64  `json:"anonymous_clicks"`
+	UniqueUsers     int64  `json:"unique_users"`
+}
+
+// ClickBreakdownDTO counts the clicks of one category, device class, region or channel.
+type ClickBreakdownDTO struct {
+	Key    string  `json:"key"`
+	Clicks int64   `json:"clicks"`
+	Share  float64 `json:"share"` // Percentage of all clicks in the range
+}
+
+// FunnelStageDTO counts the events of one funnel stage.
+type FunnelStageDTO struct {
+	Stage string   `json:"stage"`
+	Count int64    `json:"count"`
+	Rate  *float64 `json:"rate"` // Percentage of the previous stage; null for the first stage or when the previous stage is empty
+}
+
+// ClickAnalyticsDTO represents the clicks over a date range with their breakdowns and the funnel leading to them.
+// Clicks flagged as suspicious are only counted in ExcludedClicks.
+type ClickAnalyticsDTO struct {
+	BrandID         uint64                     `json:"brand_id,omitempty"`
+	From            string                     `json:"from"`
+	To              string                     `json:"to"`
+	Granularity     string                     `json:"granularity"`
+	TotalClicks     int64                      `json:"total_clicks"`
+	SignedInClicks  int64                      `json:"signed_in_clicks"`
+	AnonymousClicks int64                      `json:"anonymous_clicks"`
+	UniqueUsers     int64                      `json:"unique_users"` // Distinct signed-in users
+	ExcludedClicks  int64                      `json:"excluded_clicks"`
+	ExcludedReasons []models.ClickCount        `json:"excluded_reasons"` // Suspicious clicks left out of every figure, per reason: bot, duplicate or rate
+	Series          []ClickSeriesPointDTO      `json:"series"`
+	ByProduct       []models.ProductClickCount `json:"by_product"`  // Top 20
+	ByCategory      []ClickBreakdownDTO        `json:"by_category"` // A product in several categories counts towards each
+	ByDevice        []ClickBreakdownDTO        `json:"by_device"`   // mobile, tablet, desktop, bot or unknown, parsed from the User-Agent
+	ByRegion        []ClickBreakdownDTO        `json:"by_region"`   // Clicks without a region are reported as "unknown"
+	ByChannel       []ClickBreakdownDTO        `json:"by_channel"`  // Outbound channel; clicks recorded before channels were tracked are "unknown"
+	Funnel          []FunnelStageDTO           `json:"funnel"`
+}
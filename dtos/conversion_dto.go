@@ -0,0 +1,99 @@
+package dtos
+
+import (
+	"flicknfit_backend/models"
+	"time"
+)
+
+// ConversionRequestDTO is a sale confirmed by a brand member. The order reference makes retries safe:
+// the same SKU reported twice for one order is rejected.
+type ConversionRequestDTO struct {
+	SKU         string     `json:"sku" validate:"required,max=20"`
+	Quantity    int        `json:"quantity" validate:"required,min=1,max=10000"`
+	Amount      *int       `json:"amount" validate:"omitempty,min=0"` // Order line total in rupiah; defaults to the item price times quantity
+	OrderRef    string     `json:"order_ref" validate:"required,max=100"`
+	ConvertedAt *time.Time `json:"converted_at"`                                                                   // Defaults to now
+	Channel     string     `json:"channel" validate:"omitempty,oneof=whatsapp tokopedia shopee instagram website"` // Outbound channel the sale came through, if known
+	ClickID     string     `json:"click_id" validate:"omitempty,max=64"`                                           // Signed click ID from the fnf_click_id parameter or WhatsApp "Ref:" line
+}
+
+// ConversionResponseDTO represents a recorded conversion.
+type ConversionResponseDTO struct {
+	ID            uint64    `json:"id"`
+	BrandID       uint64    `json:"brand_id"`
+	ProductID     uint64    `json:"product_id"`
+	ProductName   string    `json:"product_name,omitempty"`
+	ProductItemID uint64    `json:"product_item_id"`
+	SKU           string    `json:"sku,omitempty"`
+	Quantity      int       `json:"quantity"`
+	Revenue       int       `json:"revenue"`
+	OrderRef      string    `json:"order_ref"`
+	Source        string    `json:"source"`
+	Channel       string    `json:"channel,omitempty"`
+	ClickID       *uint64   `json:"click_id,omitempty"`
+	UserID        *uint64   `json:"user_id,omitempty"`
+	ConvertedAt   time.Time `json:"converted_at"`
+}
+
+// ConversionPostbackDTO is an order reported by a brand's shop system or a marketplace integration.
+// Each item becomes one conversion; items already recorded for the order are skipped.
+type ConversionPostbackDTO struct {
+	OrderRef    string                      `json:"order_ref" validate:"required,max=100"`
+	ClickID     string                      `json:"click_id" validate:"omitempty,max=64"`
+	Channel     string                      `json:"channel" validate:"omitempty,oneof=whatsapp tokopedia shopee instagram website"`
+	ConvertedAt *time.Time                  `json:"converted_at"` // Defaults to now
+	Items       []ConversionPostbackItemDTO `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// ConversionPostbackItemDTO is one order line of a postback.
+type ConversionPostbackItemDTO struct {
+	SKU      string `json:"sku" validate:"required,max=20"`
+	Quantity int    `json:"quantity" validate:"required,min=1,max=10000"`
+	Amount   *int   `json:"amount" validate:"omitempty,min=0"` // Line total in rupiah; defaults to the item price times quantity
+}
+
+// ConversionPostbackResponseDTO reports what a postback recorded. Replayed is true when the idempotency key
+// was already used; the counts are then those of the first request.
+type ConversionPostbackResponseDTO struct {
+	OrderRef    string                  `json:"order_ref"`
+	Recorded    int                     `json:"recorded"`
+	Duplicates  int                     `json:"duplicates"`
+	Replayed    bool                    `json:"replayed"`
+	Conversions []ConversionResponseDTO `json:"conversions,omitempty"`
+}
+
+// PostbackSecretResponseDTO returns a newly generated postback secret. It is only shown once.
+type PostbackSecretResponseDTO struct {
+	BrandID     uint64 `json:"brand_id"`
+	Secret      string `json:"secret"`
+	PostbackURL string `json:"postback_url"`
+}
+
+// ToConversionResponseDTO converts a Conversion model to its DTO.
+func ToConversionResponseDTO(conversion models.Conversion) ConversionResponseDTO {
+	return ConversionResponseDTO{
+		ID:            conversion.ID,
+		BrandID:       conversion.BrandID,
+		ProductID:     conversion.ProductID,
+		ProductName:   conversion.Product.Name,
+		ProductItemID: conversion.ProductItemID,
+		SKU:           conversion.ProductItem.SKU,
+		Quantity:      conversion.Quantity,
+		Revenue:       conversion.Revenue,
+		OrderRef:      conversion.OrderRef,
+		Source:        conversion.Source,
+		Channel:       conversion.Channel,
+		ClickID:       conversion.ClickID,
+		UserID:        conversion.UserID,
+		ConvertedAt:   conversion.ConvertedAt,
+	}
+}
+
+// ToConversionResponseDTOs converts a slice of Conversion models to DTOs.
+func ToConversionResponseDTOs(conversions []models.Conversion) []ConversionResponseDTO {
+	result := make([]ConversionResponseDTO, len(conversions))
+	for i, conversion := range conversions {
+		result[i] = ToConversionResponseDTO(conversion)
+	}
+	return result
+}
@@ -15,6 +15,7 @@ type FavoriteResponseDTO struct {
 	ID            uint64                 `json:"id"`
 	ProductItemID uint64                 `json:"product_item_id"`
 	ProductItem   ProductItemResponseDTO `json:"product_item"`
+	Watch         WatchPreferencesDTO    `json:"watch"`
 	CreatedAt     time.Time              `json:"created_at"`
 }
 
@@ -51,6 +52,7 @@ func ToFavoriteResponseDTO(favorite models.Favorite) FavoriteResponseDTO {
 		ID:            favorite.ID,
 		ProductItemID: favorite.ProductItemID,
 		ProductItem:   ToProductItemResponseDTO(favorite.ProductItem),
+		Watch:         ToWatchPreferencesDTO(favorite.WatchPreferences),
 		CreatedAt:     favorite.CreatedAt,
 	}
 }
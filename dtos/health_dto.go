@@ -0,0 +1,22 @@
+package dtos
+
+import "time"
+
+// HealthDTO represents the health of the API and the services it depends on. Status is "ok", or "degraded"
+// while a dependency is failing; the API itself keeps serving either way.
+type HealthDTO struct {
+	Status  string           `json:"status"`
+	AIModel AIModelHealthDTO `json:"ai_model"`
+}
+
+// AIModelHealthDTO represents the health of the model API: the state of the client's circuit breaker and the
+// outcome of the last periodic health check.
+type AIModelHealthDTO struct {
+	Breaker             string     `json:"breaker"` // closed, open or half_open
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	Healthy             bool       `json:"healthy"`
+	LastCheckAt         *time.Time `json:"last_check_at,omitempty"`
+	LastCheckLatencyMs  int64      `json:"last_check_latency_ms,omitempty"`
+	LastCheckError      string     `json:"last_check_error,omitempty"`
+}
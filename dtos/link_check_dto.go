@@ -0,0 +1,9 @@
+package dtos
+
+import "flicknfit_backend/models"
+
+// BrokenLinkListResponseDTO represents a page of the broken link report.
+type BrokenLinkListResponseDTO struct {
+	Links      []models.LinkCheck `json:"links"`
+	Pagination PaginationDTO      `json:"pagination"`
+}
@@ -0,0 +1,50 @@
+package dtos
+
+import (
+	"flicknfit_backend/models"
+	"time"
+)
+
+// NotificationResponseDTO represents a notification in the user's inbox.
+type NotificationResponseDTO struct {
+	ID            uint64     `json:"id"`
+	Type          string     `json:"type"`
+	Title         string     `json:"title"`
+	Message       string     `json:"message"`
+	ProductID     *uint64    `json:"product_id,omitempty"`
+	ProductItemID *uint64    `json:"product_item_id,omitempty"`
+	IsRead        bool       `json:"is_read"`
+	ReadAt        *time.Time `json:"read_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// NotificationListResponseDTO represents a paginated notification inbox.
+type NotificationListResponseDTO struct {
+	Notifications []NotificationResponseDTO `json:"notifications"`
+	UnreadCount   int64                     `json:"unread_count"`
+	Pagination    PaginationDTO             `json:"pagination"`
+}
+
+// ToNotificationResponseDTO converts a Notification model into a NotificationResponseDTO.
+func ToNotificationResponseDTO(notification models.Notification) NotificationResponseDTO {
+	return NotificationResponseDTO{
+		ID:            notification.ID,
+		Type:          notification.Type,
+		Title:         notification.Title,
+		Message:       notification.Message,
+		ProductID:     notification.ProductID,
+		ProductItemID: notification.ProductItemID,
+		IsRead:        notification.ReadAt != nil,
+		ReadAt:        notification.ReadAt,
+		CreatedAt:     notification.CreatedAt,
+	}
+}
+
+// ToNotificationResponseDTOs converts a slice of Notification models into NotificationResponseDTOs.
+func ToNotificationResponseDTOs(notifications []models.Notification) []NotificationResponseDTO {
+	result := make([]NotificationResponseDTO, len(notifications))
+	for i, notification := range notifications {
+		result[i] = ToNotificationResponseDTO(notification)
+	}
+	return result
+}
@@ -2,24 +2,27 @@ package dtos
 
 import (
 	"flicknfit_backend/models"
+	"sort"
 	"time"
 )
 
 // ProductResponseDTO digunakan untuk menampilkan data produk publik.
 type ProductResponseDTO struct {
-	ID              uint64    `json:"id"`
-	BrandID         uint64    `json:"brand_id"`
-	Name            string    `json:"name"`
-	Description     string    `json:"description"`
-	Discount        float64   `json:"discount"`
-	Rating          float64   `json:"rating"`
-	Reviewer        int       `json:"reviewer"`
-	Sold            int       `json:"sold"`
-	PreviewImageURL string    `json:"preview_image_url"` // First product item photo
-	MinPrice        int       `json:"min_price"`         // Lowest price from variants
-	MaxPrice        int       `json:"max_price"`         // Highest price from variants
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID                uint64    `json:"id"`
+	BrandID           uint64    `json:"brand_id"`
+	Name              string    `json:"name"`
+	Description       string    `json:"description"`
+	Discount          float64   `json:"discount"`
+	Rating            float64   `json:"rating"`
+	Reviewer          int       `json:"reviewer"`
+	Sold              int       `json:"sold"`
+	Views             int       `json:"views"`
+	PreviewImageURL   string    `json:"preview_image_url"`   // First product item photo
+	MinPrice          int       `json:"min_price"`           // Lowest price from variants
+	MaxPrice          int       `json:"max_price"`           // Highest price from variants
+	MinEffectivePrice int       `json:"min_effective_price"` // Lowest price after product discount and active campaigns
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 // ToProductResponseDTO mengonversi model Product menjadi ProductResponseDTO.
@@ -33,6 +36,7 @@ func ToProductResponseDTO(product *models.Product) ProductResponseDTO {
 		Rating:      product.Rating,
 		Reviewer:    product.Reviewer,
 		Sold:        product.Sold,
+		Views:       product.Views,
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
 	}
@@ -43,6 +47,8 @@ func ToProductResponseDTO(product *models.Product) ProductResponseDTO {
 		maxPrice := product.ProductItems[0].Price
 		dto.PreviewImageURL = product.ProductItems[0].PhotoURL
 
+		minEffectivePrice := effectiveItemPrice(product.ProductItems[0])
+
 		for _, item := range product.ProductItems {
 			if item.Price < minPrice {
 				minPrice = item.Price
@@ -50,10 +56,14 @@ func ToProductResponseDTO(product *models.Product) ProductResponseDTO {
 			if item.Price > maxPrice {
 				maxPrice = item.Price
 			}
+			if effective := effectiveItemPrice(item); effective < minEffectivePrice {
+				minEffectivePrice = effective
+			}
 		}
 
 		dto.MinPrice = minPrice
 		dto.MaxPrice = maxPrice
+		dto.MinEffectivePrice = minEffectivePrice
 	}
 
 	return dto
@@ -74,43 +84,91 @@ type AdminProductCreateRequestDTO struct {
 	Name        string  `json:"name" validate:"required"`
 	Description string  `json:"description" validate:"required"`
 	Discount    float64 `json:"discount" validate:"min=0,max=1"`
+	// Status awal produk; default draft. Status scheduled memerlukan PublishAt.
+	Status    string     `json:"status" validate:"omitempty,product_status"`
+	PublishAt *time.Time `json:"publish_at" validate:"required_if=Status scheduled"`
 }
 
 // AdminProductUpdateRequestDTO digunakan untuk memperbarui produk oleh admin.
+// Rating dan jumlah reviewer tidak bisa diubah manual karena dihitung dari ulasan.
 type AdminProductUpdateRequestDTO struct {
 	BrandID     uint64  `json:"brand_id" validate:"omitempty"`
 	Name        string  `json:"name" validate:"omitempty"`
 	Description string  `json:"description" validate:"omitempty"`
 	Discount    float64 `json:"discount" validate:"omitempty,min=0,max=1"`
-	Rating      float64 `json:"rating" validate:"omitempty,min=0,max=5"`
-	Reviewer    int     `json:"reviewer" validate:"omitempty,min=0"`
+}
+
+// AdminProductItemInputDTO digunakan untuk mendefinisikan satu item produk (SKU) beserta kombinasi variasinya.
+// ID diisi saat memperbarui item yang sudah ada agar relasi favorit dan saved items tetap terjaga.
+type AdminProductItemInputDTO struct {
+	ID                 uint64   `json:"id" validate:"omitempty"`
+	SKU                string   `json:"sku" validate:"required,max=20"`
+	Price              int      `json:"price" validate:"min=0"`
+	Stock              int      `json:"stock" validate:"min=0"`
+	PhotoURL           string   `json:"photo_url" validate:"omitempty,url,max=255"`
+	VariationOptionIDs []uint64 `json:"variation_option_ids" validate:"omitempty,dive,required"`
+}
+
+// AdminProductFullRequestDTO digunakan untuk membuat atau memperbarui produk lengkap oleh admin,
+// termasuk item, kombinasi variasi, kategori, style, dan tautan marketplace dalam satu transaksi.
+type AdminProductFullRequestDTO struct {
+	BrandID             uint64                     `json:"brand_id" validate:"required"`
+	Name                string                     `json:"name" validate:"required"`
+	Description         string                     `json:"description" validate:"required"`
+	Discount            float64                    `json:"discount" validate:"min=0,max=1"`
+	Categories          []string                   `json:"categories" validate:"omitempty,dive,required,max=100"`
+	Styles              []string                   `json:"styles" validate:"omitempty,dive,required,max=100"`
+	BrandProductURL     string                     `json:"brand_product_url" validate:"omitempty,url,max=512"`
+	WhatsAppTemplate    string                     `json:"whatsapp_template" validate:"omitempty"`
+	InstagramProductURL string                     `json:"instagram_product_url" validate:"omitempty,url,max=255"`
+	TokopediaProductURL string                     `json:"tokopedia_product_url" validate:"omitempty,url,max=255"`
+	ShopeeProductURL    string                     `json:"shopee_product_url" validate:"omitempty,url,max=255"`
+	Items               []AdminProductItemInputDTO `json:"items" validate:"required,min=1,dive"`
+	// Status produk; jika kosong, produk baru dibuat sebagai draft dan produk lama mempertahankan statusnya.
+	Status    string     `json:"status" validate:"omitempty,product_status"`
+	PublishAt *time.Time `json:"publish_at" validate:"required_if=Status scheduled"`
+}
+
+// AdminProductStatusRequestDTO digunakan admin untuk mengubah status produk
+// (publish, unpublish, kembali ke draft, atau menjadwalkan publikasi).
+type AdminProductStatusRequestDTO struct {
+	Status    string     `json:"status" validate:"required,product_status"`
+	PublishAt *time.Time `json:"publish_at" validate:"required_if=Status scheduled"`
 }
 
 // AdminProductResponseDTO merepresentasikan data produk yang dikembalikan ke admin.
 type AdminProductResponseDTO struct {
-	ID          uint64    `json:"id"`
-	BrandID     uint64    `json:"brand_id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Discount    float64   `json:"discount"`
-	Rating      float64   `json:"rating"`
-	Reviewer    int       `json:"reviewer"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID            uint64     `json:"id"`
+	BrandID       uint64     `json:"brand_id"`
+	Name          string     `json:"name"`
+	Description   string     `json:"description"`
+	Discount      float64    `json:"discount"`
+	Rating        float64    `json:"rating"`
+	Reviewer      int        `json:"reviewer"`
+	Status        string     `json:"status"`
+	PublishAt     *time.Time `json:"publish_at"`
+	PublishedAt   *time.Time `json:"published_at"`
+	UnpublishedAt *time.Time `json:"unpublished_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
 }
 
 // ToAdminProductResponseDTO mengonversi model Product menjadi AdminProductResponseDTO.
 func ToAdminProductResponseDTO(product *models.Product) AdminProductResponseDTO {
 	return AdminProductResponseDTO{
-		ID:          product.ID,
-		BrandID:     product.BrandID,
-		Name:        product.Name,
-		Description: product.Description,
-		Discount:    product.Discount,
-		Rating:      product.Rating,
-		Reviewer:    product.Reviewer,
-		CreatedAt:   product.CreatedAt,
-		UpdatedAt:   product.UpdatedAt,
+		ID:            product.ID,
+		BrandID:       product.BrandID,
+		Name:          product.Name,
+		Description:   product.Description,
+		Discount:      product.Discount,
+		Rating:        product.Rating,
+		Reviewer:      product.Reviewer,
+		Status:        product.Status,
+		PublishAt:     product.PublishAt,
+		PublishedAt:   product.PublishedAt,
+		UnpublishedAt: product.UnpublishedAt,
+		CreatedAt:     product.CreatedAt,
+		UpdatedAt:     product.UpdatedAt,
 	}
 }
 
@@ -125,22 +183,23 @@ func ToAdminProductResponseDTOs(products []*models.Product) []AdminProductRespon
 
 // ProductPublicResponseDTO mewakili data produk lengkap untuk pengguna publik, termasuk item produk dan ulasan.
 type ProductPublicResponseDTO struct {
-	ID           uint64           `json:"id"`
-	BrandID      uint64           `json:"brand_id"`
-	Name         string           `json:"name"`
-	Description  string           `json:"description"`
-	Discount     float64          `json:"discount"`
-	Rating       float64          `json:"rating"`
-	Reviewer     int              `json:"reviewer"`
-	Sold         int              `json:"sold"`
-	CreatedAt    time.Time        `json:"created_at"`
-	UpdatedAt    time.Time        `json:"updated_at"`
-	Brand        BrandDTO         `json:"brand"`
-	ProductItems []ProductItemDTO `json:"product_items"`
-	Categories   []string         `json:"categories"`
-	Styles       []string         `json:"styles"`
-	Variations   []VariationDTO   `json:"variations"`
-	Reviews      []ReviewDTO      `json:"reviews"`
+	ID           uint64            `json:"id"`
+	BrandID      uint64            `json:"brand_id"`
+	Name         string            `json:"name"`
+	Description  string            `json:"description"`
+	Discount     float64           `json:"discount"`
+	Rating       float64           `json:"rating"`
+	Reviewer     int               `json:"reviewer"`
+	Sold         int               `json:"sold"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	Brand        BrandDTO          `json:"brand"`
+	ProductItems []ProductItemDTO  `json:"product_items"`
+	Media        []ProductMediaDTO `json:"media"` // Galeri produk dan item sesuai urutan tampil
+	Categories   []string          `json:"categories"`
+	Styles       []string          `json:"styles"`
+	Variations   []VariationDTO    `json:"variations"`
+	Reviews      []ReviewDTO       `json:"reviews"`
 }
 
 // BrandDTO untuk response ringkas brand
@@ -160,18 +219,28 @@ type VariationDTO struct {
 
 // AdminProductDetailsDTO mewakili data produk lengkap untuk admin, termasuk item produk, kategori, dan ulasan.
 type AdminProductDetailsDTO struct {
-	ID                uint64               `json:"id"`
-	BrandID           uint64               `json:"brand_id"`
-	Name              string               `json:"name"`
-	Description       string               `json:"description"`
-	Discount          float64              `json:"discount"`
-	Rating            float64              `json:"rating"`
-	Reviewer          int                  `json:"reviewer"`
-	CreatedAt         time.Time            `json:"created_at"`
-	UpdatedAt         time.Time            `json:"updated_at"`
-	ProductItems      []ProductItemDTO     `json:"product_items"`
-	ProductCategories []ProductCategoryDTO `json:"product_categories"`
-	Reviews           []ReviewDTO          `json:"reviews"`
+	ID                  uint64               `json:"id"`
+	BrandID             uint64               `json:"brand_id"`
+	Name                string               `json:"name"`
+	Description         string               `json:"description"`
+	Discount            float64              `json:"discount"`
+	Rating              float64              `json:"rating"`
+	Reviewer            int                  `json:"reviewer"`
+	BrandProductURL     string               `json:"brand_product_url"`
+	WhatsAppTemplate    string               `json:"whatsapp_template"`
+	InstagramProductURL string               `json:"instagram_product_url"`
+	TokopediaProductURL string               `json:"tokopedia_product_url"`
+	ShopeeProductURL    string               `json:"shopee_product_url"`
+	Status              string               `json:"status"`
+	PublishAt           *time.Time           `json:"publish_at"`
+	PublishedAt         *time.Time           `json:"published_at"`
+	UnpublishedAt       *time.Time           `json:"unpublished_at"`
+	CreatedAt           time.Time            `json:"created_at"`
+	UpdatedAt           time.Time            `json:"updated_at"`
+	ProductItems        []ProductItemDTO     `json:"product_items"`
+	ProductCategories   []ProductCategoryDTO `json:"product_categories"`
+	Styles              []string             `json:"styles"`
+	Reviews             []ReviewDTO          `json:"reviews"`
 }
 
 // ToProductPublicResponseDTO mengonversi model Product menjadi ProductPublicResponseDTO.
@@ -208,6 +277,7 @@ func ToProductPublicResponseDTO(product *models.Product) ProductPublicResponseDT
 
 	// Variations (dari ProductItems -> Configurations -> ProductVariationOption -> ProductVariation)
 	variationMap := map[uint64]VariationDTO{}
+	optionOrder := map[uint64]map[string]int{}
 	for _, item := range product.ProductItems {
 		for _, conf := range item.Configurations {
 			varOpt := conf.ProductVariationOption
@@ -222,6 +292,7 @@ func ToProductPublicResponseDTO(product *models.Product) ProductPublicResponseDT
 			v, ok := variationMap[varID]
 			if !ok {
 				v = VariationDTO{ID: varID, Name: varName, Values: []string{}}
+				optionOrder[varID] = map[string]int{}
 			}
 			// Hindari duplikat value
 			found := false
@@ -233,14 +304,21 @@ func ToProductPublicResponseDTO(product *models.Product) ProductPublicResponseDT
 			}
 			if !found {
 				v.Values = append(v.Values, varOpt.Value)
+				optionOrder[varID][varOpt.Value] = varOpt.SortOrder
 			}
 			variationMap[varID] = v
 		}
 	}
 	variations := make([]VariationDTO, 0, len(variationMap))
 	for _, v := range variationMap {
+		// Urutkan value sesuai SortOrder opsi (misal S < M < L)
+		order := optionOrder[v.ID]
+		sort.SliceStable(v.Values, func(i, j int) bool {
+			return order[v.Values[i]] < order[v.Values[j]]
+		})
 		variations = append(variations, v)
 	}
+	sort.Slice(variations, func(i, j int) bool { return variations[i].ID < variations[j].ID })
 
 	return ProductPublicResponseDTO{
 		ID:           product.ID,
@@ -255,6 +333,7 @@ func ToProductPublicResponseDTO(product *models.Product) ProductPublicResponseDT
 		UpdatedAt:    product.UpdatedAt,
 		Brand:        brand,
 		ProductItems: productItems,
+		Media:        ToProductMediaDTOs(product.Media),
 		Categories:   categories,
 		Styles:       styles,
 		Variations:   variations,
@@ -272,24 +351,38 @@ func ToAdminProductDetailsDTO(product *models.Product) AdminProductDetailsDTO {
 	for i, category := range product.ProductCategories {
 		productCategories[i] = ToProductCategoryDTO(&category)
 	}
+	styles := make([]string, len(product.ProductStyles))
+	for i, style := range product.ProductStyles {
+		styles[i] = style.Style
+	}
 	reviews := make([]ReviewDTO, len(product.Reviews))
 	for i, review := range product.Reviews {
 		reviews[i] = ToReviewDTO(&review)
 	}
 
 	return AdminProductDetailsDTO{
-		ID:                product.ID,
-		BrandID:           product.BrandID,
-		Name:              product.Name,
-		Description:       product.Description,
-		Discount:          product.Discount,
-		Rating:            product.Rating,
-		Reviewer:          product.Reviewer,
-		CreatedAt:         product.CreatedAt,
-		UpdatedAt:         product.UpdatedAt,
-		ProductItems:      productItems,
-		ProductCategories: productCategories,
-		Reviews:           reviews,
+		ID:                  product.ID,
+		BrandID:             product.BrandID,
+		Name:                product.Name,
+		Description:         product.Description,
+		Discount:            product.Discount,
+		Rating:              product.Rating,
+		Reviewer:            product.Reviewer,
+		BrandProductURL:     product.BrandProductURL,
+		WhatsAppTemplate:    product.WhatsAppTemplate,
+		InstagramProductURL: product.InstagramProductURL,
+		TokopediaProductURL: product.TokopediaProductURL,
+		ShopeeProductURL:    product.ShopeeProductURL,
+		Status:              product.Status,
+		PublishAt:           product.PublishAt,
+		PublishedAt:         product.PublishedAt,
+		UnpublishedAt:       product.UnpublishedAt,
+		CreatedAt:           product.CreatedAt,
+		UpdatedAt:           product.UpdatedAt,
+		ProductItems:        productItems,
+		ProductCategories:   productCategories,
+		Styles:              styles,
+		Reviews:             reviews,
 	}
 }
 
@@ -327,6 +420,10 @@ type ProductItemDTO struct {
 	ProductID      uint64                 `json:"product_id"`
 	SKU            string                 `json:"sku"`
 	Price          int                    `json:"price"`
+	OriginalPrice  int                    `json:"original_price"`
+	EffectivePrice int                    `json:"effective_price"` // Harga setelah diskon produk dan kampanye aktif
+	CampaignID     *uint64                `json:"campaign_id,omitempty"`
+	CampaignName   string                 `json:"campaign_name,omitempty"`
 	Stock          int                    `json:"stock"`
 	Sold           int                    `json:"sold"`
 	PhotoURL       string                 `json:"photo_url"`
@@ -368,16 +465,55 @@ func ToProductItemDTO(item models.ProductItem) ProductItemDTO {
 			Value:     conf.ProductVariationOption.Value,
 		})
 	}
-	return ProductItemDTO{
+	dto := ProductItemDTO{
 		ID:             item.ID,
 		ProductID:      item.ProductID,
 		SKU:            item.SKU,
 		Price:          item.Price,
+		OriginalPrice:  item.Price,
+		EffectivePrice: effectiveItemPrice(item),
 		Stock:          item.Stock,
 		Sold:           item.Sold,
 		PhotoURL:       item.PhotoURL,
 		Configurations: configs,
 	}
+	if item.AppliedCampaign != nil {
+		campaignID := item.AppliedCampaign.ID
+		dto.CampaignID = &campaignID
+		dto.CampaignName = item.AppliedCampaign.Name
+	}
+	return dto
+}
+
+// effectiveItemPrice mengembalikan harga efektif item jika sudah dihitung, atau harga aslinya.
+func effectiveItemPrice(item models.ProductItem) int {
+	if item.EffectivePrice != nil {
+		return *item.EffectivePrice
+	}
+	return item.Price
+}
+
+// PricePointDTO adalah satu titik pada grafik harga item.
+type PricePointDTO struct {
+	Price     int       `json:"price"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// ItemPriceChartDTO berisi riwayat harga satu item beserta harga saat ini.
+type ItemPriceChartDTO struct {
+	ProductItemID  uint64          `json:"product_item_id"`
+	SKU            string          `json:"sku"`
+	CurrentPrice   int             `json:"current_price"`
+	EffectivePrice int             `json:"effective_price"`
+	Points         []PricePointDTO `json:"points"`
+}
+
+// ProductPriceChartDTO adalah data grafik harga sebuah produk dalam rentang hari tertentu.
+type ProductPriceChartDTO struct {
+	ProductID uint64              `json:"product_id"`
+	From      time.Time           `json:"from"`
+	To        time.Time           `json:"to"`
+	Items     []ItemPriceChartDTO `json:"items"`
 }
 
 // ProductFilterRequestDTO is used for filtering products.
@@ -389,6 +525,8 @@ type ProductFilterRequestDTO struct {
 	BrandName string  `query:"brand_name"` // Filter by brand name (alternative)
 	Category  string  `query:"category"`
 	MinRating float64 `query:"min_rating"`
+	Sort      string  `query:"sort" validate:"omitempty,oneof=newest best_selling rating popular"` // Urutan: newest, best_selling (dari jumlah terjual), rating, atau popular (dilihat dan terjual)
+	Limit     int     `query:"-"`                                                                  // Jumlah produk maksimum, 0 untuk semua; hanya diisi oleh service
 }
 
 // ReviewDTO represents a product review.
@@ -424,3 +562,9 @@ func ToProductCategoryDTO(category *models.ProductCategory) ProductCategoryDTO {
 		Category:  category.Category,
 	}
 }
+
+// RecentlyViewedProductDTO adalah produk yang baru dilihat pengguna beserta waktu terakhir dilihat.
+type RecentlyViewedProductDTO struct {
+	ProductResponseDTO
+	ViewedAt time.Time `json:"viewed_at"`
+}
@@ -0,0 +1,50 @@
+package dtos
+
+// ProductImportFormatCSV dan ProductImportFormatJSON adalah format file yang didukung untuk impor/ekspor produk.
+const (
+	ProductImportFormatCSV  = "csv"
+	ProductImportFormatJSON = "json"
+)
+
+// ProductImportRowDTO merepresentasikan satu baris impor/ekspor produk, yaitu satu item (SKU).
+// Baris dengan brand dan nama produk yang sama digabung menjadi satu produk; data tingkat produk
+// (deskripsi, diskon, kategori, style, tautan marketplace) diambil dari baris pertama produk tersebut.
+// Variations memetakan nama variasi ke nilai opsinya, misal {"Ukuran": "M", "Warna": "Hitam"}.
+type ProductImportRowDTO struct {
+	SKU                 string            `json:"sku" validate:"required,max=20"`
+	ProductName         string            `json:"product_name" validate:"required,max=255"`
+	Brand               string            `json:"brand" validate:"required"`
+	Description         string            `json:"description" validate:"required"`
+	Discount            float64           `json:"discount" validate:"min=0,max=1"`
+	Categories          []string          `json:"categories" validate:"omitempty,dive,required,max=100"`
+	Styles              []string          `json:"styles" validate:"omitempty,dive,required,max=100"`
+	Variations          map[string]string `json:"variations" validate:"omitempty,dive,keys,required,endkeys,required"`
+	Price               int               `json:"price" validate:"min=0"`
+	Stock               int               `json:"stock" validate:"min=0"`
+	PhotoURL            string            `json:"photo_url" validate:"omitempty,url,max=255"`
+	BrandProductURL     string            `json:"brand_product_url" validate:"omitempty,url,max=512"`
+	InstagramProductURL string            `json:"instagram_product_url" validate:"omitempty,url,max=255"`
+	TokopediaProductURL string            `json:"tokopedia_product_url" validate:"omitempty,url,max=255"`
+	ShopeeProductURL    string            `json:"shopee_product_url" validate:"omitempty,url,max=255"`
+	WhatsAppTemplate    string            `json:"whatsapp_template"`
+}
+
+// ProductImportRowErrorDTO menjelaskan kesalahan validasi pada satu baris impor.
+// Row dihitung mulai dari 1 (untuk CSV, baris header tidak dihitung).
+type ProductImportRowErrorDTO struct {
+	Row     int    `json:"row"`
+	SKU     string `json:"sku,omitempty"`
+	Message string `json:"message"`
+}
+
+// ProductImportResultDTO adalah ringkasan hasil impor produk.
+// Jika Errors tidak kosong, tidak ada data yang disimpan.
+type ProductImportResultDTO struct {
+	DryRun          bool                       `json:"dry_run"`
+	TotalRows       int                        `json:"total_rows"`
+	ProductsCreated int                        `json:"products_created"`
+	ProductsUpdated int                        `json:"products_updated"`
+	ItemsCreated    int                        `json:"items_created"`
+	ItemsUpdated    int                        `json:"items_updated"`
+	Errors          []ProductImportRowErrorDTO `json:"errors"`
+}
@@ -0,0 +1,57 @@
+package dtos
+
+import "flicknfit_backend/models"
+
+// ProductMediaUploadRequestDTO holds the form fields sent with a product media upload.
+// Media without product_item_id belongs to the product gallery itself.
+type ProductMediaUploadRequestDTO struct {
+	ProductItemID *uint64 `form:"product_item_id"`
+	AltText       string  `form:"alt_text" validate:"max=255"`
+	IsPrimary     bool    `form:"is_primary"`
+}
+
+// ProductMediaUpdateRequestDTO is used by an admin to update a media's alt text or primary flag.
+type ProductMediaUpdateRequestDTO struct {
+	AltText   *string `json:"alt_text" validate:"omitempty,max=255"`
+	IsPrimary *bool   `json:"is_primary"`
+}
+
+// ProductMediaOrderRequestDTO lists a product's media IDs in their new display order.
+type ProductMediaOrderRequestDTO struct {
+	MediaIDs []uint64 `json:"media_ids" validate:"required,min=1,dive,required"`
+}
+
+// ProductMediaDTO represents one image or video of a product gallery.
+type ProductMediaDTO struct {
+	ID            uint64  `json:"id"`
+	ProductItemID *uint64 `json:"product_item_id"`
+	MediaType     string  `json:"media_type"`
+	URL           string  `json:"url"`
+	ThumbnailURL  string  `json:"thumbnail_url"`
+	AltText       string  `json:"alt_text"`
+	SortOrder     int     `json:"sort_order"`
+	IsPrimary     bool    `json:"is_primary"`
+}
+
+// ToProductMediaDTO converts a models.ProductMedia to a ProductMediaDTO.
+func ToProductMediaDTO(media models.ProductMedia) ProductMediaDTO {
+	return ProductMediaDTO{
+		ID:            media.ID,
+		ProductItemID: media.ProductItemID,
+		MediaType:     media.MediaType,
+		URL:           media.URL,
+		ThumbnailURL:  media.ThumbnailURL,
+		AltText:       media.AltText,
+		SortOrder:     media.SortOrder,
+		IsPrimary:     media.IsPrimary,
+	}
+}
+
+// ToProductMediaDTOs converts a slice of models.ProductMedia to ProductMediaDTOs.
+func ToProductMediaDTOs(media []models.ProductMedia) []ProductMediaDTO {
+	result := make([]ProductMediaDTO, len(media))
+	for i, m := range media {
+		result[i] = ToProductMediaDTO(m)
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+package dtos
+
+import "flicknfit_backend/models"
+
+// RedirectChannelDTO is one channel in a brand's redirect order. Channels with a weight share the clicks
+// between them in proportion to their weights.
+type RedirectChannelDTO struct {
+	Channel string `json:"channel" validate:"required,oneof=whatsapp tokopedia shopee instagram website"`
+	Weight  int    `json:"weight" validate:"min=0,max=100"`
+}
+
+// RedirectChannelsRequestDTO replaces the redirect order of a brand. The list order is the preferred
+// order; an empty list restores the default order.
+type RedirectChannelsRequestDTO struct {
+	Channels []RedirectChannelDTO `json:"channels" validate:"max=5,unique=Channel,dive"`
+}
+
+// RedirectChannelsResponseDTO represents the redirect order of a brand.
+type RedirectChannelsResponseDTO struct {
+	BrandID  uint64               `json:"brand_id"`
+	Channels []RedirectChannelDTO `json:"channels"`
+	Default  bool                 `json:"default"` // True when the brand has no order of its own
+}
+
+// ChannelReportRowDTO represents the clicks and conversions of one channel.
+type ChannelReportRowDTO struct {
+	models.ChannelStat
+	ConversionRate float64 `json:"conversion_rate"` // Conversions per click
+}
+
+// ChannelReportResponseDTO represents the per-channel performance of a brand over a period.
+type ChannelReportResponseDTO struct {
+	BrandID  uint64                `json:"brand_id"`
+	Days     int                   `json:"days"`
+	Channels []ChannelReportRowDTO `json:"channels"`
+}
+
+// BrandLinkParamsDTO holds a brand's overrides for the parameters added to its outbound links.
+// Empty values use the FlickNFit defaults.
+type BrandLinkParamsDTO struct {
+	UTMSource            string `json:"utm_source" validate:"max=100"`
+	UTMMedium            string `json:"utm_medium" validate:"max=100"`
+	UTMCampaign          string `json:"utm_campaign" validate:"max=100"`
+	TokopediaAffiliateID string `json:"tokopedia_affiliate_id" validate:"max=100"`
+	ShopeeAffiliateID    string `json:"shopee_affiliate_id" validate:"max=100"`
+}
+
+// BrandLinkParamsResponseDTO represents a brand's link parameter overrides.
+type BrandLinkParamsResponseDTO struct {
+	BrandID uint64 `json:"brand_id"`
+	BrandLinkParamsDTO
+}
+
+// ToBrandLinkParamsResponseDTO converts a BrandLinkParams model to its DTO.
+func ToBrandLinkParamsResponseDTO(params models.BrandLinkParams) BrandLinkParamsResponseDTO {
+	return BrandLinkParamsResponseDTO{
+		BrandID: params.BrandID,
+		BrandLinkParamsDTO: BrandLinkParamsDTO{
+			UTMSource:            params.UTMSource,
+			UTMMedium:            params.UTMMedium,
+			UTMCampaign:          params.UTMCampaign,
+			TokopediaAffiliateID: params.TokopediaAffiliateID,
+			ShopeeAffiliateID:    params.ShopeeAffiliateID,
+		},
+	}
+}
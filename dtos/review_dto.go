@@ -44,6 +44,14 @@ type ReviewStatsResponseDTO struct {
 	RatingCounts  map[string]int `json:"rating_counts"`
 }
 
+// RatingRecalculationResultDTO reports product and brand rating aggregates that drifted from their reviews
+type RatingRecalculationResultDTO struct {
+	DryRun          bool                 `json:"dry_run"`
+	ProductsDrifted int                  `json:"products_drifted"`
+	BrandsDrifted   int                  `json:"brands_drifted"`
+	Drifts          []models.RatingDrift `json:"drifts"`
+}
+
 // UserBasicResponseDTO represents basic user info
 type UserBasicResponseDTO struct {
 	ID       uint64 `json:"id"`
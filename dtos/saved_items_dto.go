@@ -13,11 +13,12 @@ type SavedItemsDTO struct {
 
 // SavedItemDTO represents a single item in the shopping cart.
 type SavedItemDTO struct {
-	ID            uint64         `json:"id"`
-	ProductItemID uint64         `json:"product_item_id"`
-	Quantity      int            `json:"quantity"`
-	ItemPrice     int            `json:"item_price"`
-	ProductItem   ProductItemDTO `json:"product_item"`
+	ID            uint64              `json:"id"`
+	ProductItemID uint64              `json:"product_item_id"`
+	Quantity      int                 `json:"quantity"`
+	ItemPrice     int                 `json:"item_price"`
+	ProductItem   ProductItemDTO      `json:"product_item"`
+	Watch         WatchPreferencesDTO `json:"watch"`
 }
 
 // AddProductItemToSavedItemsRequestDTO is used for adding a new product item to the shopping cart.
@@ -53,5 +54,6 @@ func ToSavedItemDTO(item *models.SavedItemsList) SavedItemDTO {
 		Quantity:      item.Quantity,
 		ItemPrice:     item.ProductItem.Price,
 		ProductItem:   ToProductItemDTO(item.ProductItem),
+		Watch:         ToWatchPreferencesDTO(item.WatchPreferences),
 	}
 }
@@ -0,0 +1,100 @@
+package dtos
+
+import (
+	"flicknfit_backend/constants"
+	"flicknfit_backend/models"
+	"time"
+)
+
+// StockAdjustmentRequestDTO adalah permintaan admin untuk menambah atau mengurangi stok item.
+// Delta positif menambah stok, negatif menguranginya; stok tidak boleh menjadi negatif.
+type StockAdjustmentRequestDTO struct {
+	Delta  int    `json:"delta" validate:"required,ne=0"`
+	Reason string `json:"reason" validate:"required,oneof=restock sale return damaged correction"`
+	Note   string `json:"note" validate:"max=255"`
+}
+
+// LowStockThresholdRequestDTO mengatur ambang stok rendah item; null mengembalikannya ke nilai default.
+type LowStockThresholdRequestDTO struct {
+	Threshold *int `json:"threshold" validate:"omitempty,min=0"`
+}
+
+// StockMovementResponseDTO merepresentasikan satu baris ledger stok.
+type StockMovementResponseDTO struct {
+	ID            uint64    `json:"id"`
+	ProductItemID uint64    `json:"product_item_id"`
+	Delta         int       `json:"delta"`
+	StockBefore   int       `json:"stock_before"`
+	StockAfter    int       `json:"stock_after"`
+	Reason        string    `json:"reason"`
+	Note          string    `json:"note,omitempty"`
+	ActorID       *uint64   `json:"actor_id"`
+	ActorUsername string    `json:"actor_username,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// StockLevelResponseDTO merepresentasikan level stok item beserta status stok rendahnya.
+type StockLevelResponseDTO struct {
+	ProductItemID     uint64 `json:"product_item_id"`
+	ProductID         uint64 `json:"product_id"`
+	ProductName       string `json:"product_name"`
+	BrandID           uint64 `json:"brand_id"`
+	SKU               string `json:"sku"`
+	Stock             int    `json:"stock"`
+	LowStockThreshold int    `json:"low_stock_threshold"`
+	IsLowStock        bool   `json:"is_low_stock"`
+	IsOutOfStock      bool   `json:"is_out_of_stock"`
+}
+
+// ToStockMovementResponseDTO mengonversi model StockMovement ke DTO.
+func ToStockMovementResponseDTO(movement models.StockMovement) StockMovementResponseDTO {
+	dto := StockMovementResponseDTO{
+		ID:            movement.ID,
+		ProductItemID: movement.ProductItemID,
+		Delta:         movement.Delta,
+		StockBefore:   movement.StockBefore,
+		StockAfter:    movement.StockAfter,
+		Reason:        movement.Reason,
+		Note:          movement.Note,
+		ActorID:       movement.ActorID,
+		CreatedAt:     movement.CreatedAt,
+	}
+	if movement.Actor != nil {
+		dto.ActorUsername = movement.Actor.Username
+	}
+	return dto
+}
+
+// ToStockMovementResponseDTOs mengonversi slice StockMovement ke DTO.
+func ToStockMovementResponseDTOs(movements []models.StockMovement) []StockMovementResponseDTO {
+	result := make([]StockMovementResponseDTO, len(movements))
+	for i, movement := range movements {
+		result[i] = ToStockMovementResponseDTO(movement)
+	}
+	return result
+}
+
+// ToStockLevelResponseDTO mengonversi ProductItem (dengan Product ter-preload) ke DTO level stok.
+func ToStockLevelResponseDTO(item models.ProductItem) StockLevelResponseDTO {
+	threshold := item.EffectiveLowStockThreshold(constants.DefaultLowStockThreshold)
+	return StockLevelResponseDTO{
+		ProductItemID:     item.ID,
+		ProductID:         item.ProductID,
+		ProductName:       item.Product.Name,
+		BrandID:           item.Product.BrandID,
+		SKU:               item.SKU,
+		Stock:             item.Stock,
+		LowStockThreshold: threshold,
+		IsLowStock:        item.Stock <= threshold,
+		IsOutOfStock:      item.Stock == 0,
+	}
+}
+
+// ToStockLevelResponseDTOs mengonversi slice ProductItem ke DTO level stok.
+func ToStockLevelResponseDTOs(items []models.ProductItem) []StockLevelResponseDTO {
+	result := make([]StockLevelResponseDTO, len(items))
+	for i, item := range items {
+		result[i] = ToStockLevelResponseDTO(item)
+	}
+	return result
+}
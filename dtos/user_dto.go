@@ -101,6 +101,7 @@ type UserResponseDTO struct {
 	Birthday          *time.Time `json:"birthday,omitempty"`
 	Region            string     `json:"region"`
 	Role              string     `json:"role"`
+	DoNotTrack        bool       `json:"do_not_track"`
 	ProfilePictureURL string     `json:"profile_picture_url"` // From Google OAuth or manual upload
 	CreatedAt         time.Time  `json:"created_at"`
 	UpdatedAt         time.Time  `json:"updated_at"`
@@ -116,6 +117,7 @@ func ToUserResponseDTO(u *models.User) UserResponseDTO {
 		Birthday:          u.Birthday,
 		Region:            u.Region,
 		Role:              string(u.Role),
+		DoNotTrack:        u.DoNotTrack,
 		ProfilePictureURL: u.ProfilePictureURL,
 		CreatedAt:         u.CreatedAt,
 		UpdatedAt:         u.UpdatedAt,
@@ -179,6 +181,7 @@ type UserEditProfileRequestDTO struct {
 	Gender      string     `json:"gender" validate:"omitempty,oneof=male female other"`
 	Birthday    *time.Time `json:"birthday,omitempty" validate:"omitempty"`
 	Region      string     `json:"region" validate:"omitempty,min=2,max=100"`
+	DoNotTrack  *bool      `json:"do_not_track,omitempty"` // Opt out of having outbound clicks linked to the account
 }
 
 type UserEditProfileResponseDTO struct {
@@ -190,6 +193,7 @@ type UserEditProfileResponseDTO struct {
 	Birthday    *time.Time `json:"birthday,omitempty"`
 	Region      string     `json:"region"`
 	Role        string     `json:"role"`
+	DoNotTrack  bool       `json:"do_not_track"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 }
 
@@ -203,6 +207,7 @@ func ToUserEditProfileResponseDTO(u models.User) UserEditProfileResponseDTO {
 		Birthday:    u.Birthday,
 		Region:      u.Region,
 		Role:        string(u.Role),
+		DoNotTrack:  u.DoNotTrack,
 		UpdatedAt:   u.UpdatedAt,
 	}
 }
@@ -0,0 +1,87 @@
+package dtos
+
+import "flicknfit_backend/models"
+
+// VariationCreateRequestDTO is used by an admin to create a variation such as Size, Colour or Material.
+type VariationCreateRequestDTO struct {
+	Name    string                            `json:"name" validate:"required,min=1,max=50"`
+	IsColor bool                              `json:"is_color"`
+	Options []VariationOptionCreateRequestDTO `json:"options" validate:"omitempty,dive"`
+}
+
+// VariationUpdateRequestDTO is used by an admin to rename a variation or change its colour flag.
+type VariationUpdateRequestDTO struct {
+	Name    string `json:"name" validate:"omitempty,min=1,max=50"`
+	IsColor *bool  `json:"is_color" validate:"omitempty"`
+}
+
+// VariationOptionCreateRequestDTO is used by an admin to add an option to a variation.
+// HexValue is required for options of colour variations.
+type VariationOptionCreateRequestDTO struct {
+	Value     string `json:"value" validate:"required,min=1,max=50"`
+	HexValue  string `json:"hex_value" validate:"omitempty,hexcolor,len=4|len=7"`
+	SortOrder int    `json:"sort_order" validate:"omitempty,min=0"`
+}
+
+// VariationOptionUpdateRequestDTO is used by an admin to update a variation option.
+type VariationOptionUpdateRequestDTO struct {
+	Value     string `json:"value" validate:"omitempty,min=1,max=50"`
+	HexValue  string `json:"hex_value" validate:"omitempty,hexcolor,len=4|len=7"`
+	SortOrder *int   `json:"sort_order" validate:"omitempty,min=0"`
+}
+
+// VariationOptionOrderRequestDTO lists a variation's option IDs in their new display order.
+type VariationOptionOrderRequestDTO struct {
+	OptionIDs []uint64 `json:"option_ids" validate:"required,min=1,dive,required"`
+}
+
+// VariationResponseDTO represents a variation and its ordered options.
+type VariationResponseDTO struct {
+	ID      uint64                       `json:"id"`
+	Name    string                       `json:"name"`
+	IsColor bool                         `json:"is_color"`
+	Options []VariationOptionResponseDTO `json:"options"`
+}
+
+// VariationOptionResponseDTO represents a single variation option.
+type VariationOptionResponseDTO struct {
+	ID          uint64 `json:"id"`
+	VariationID uint64 `json:"variation_id"`
+	Value       string `json:"value"`
+	HexValue    string `json:"hex_value,omitempty"`
+	SortOrder   int    `json:"sort_order"`
+}
+
+// ToVariationOptionResponseDTO converts a models.ProductVariationOption to a VariationOptionResponseDTO.
+func ToVariationOptionResponseDTO(option models.ProductVariationOption) VariationOptionResponseDTO {
+	return VariationOptionResponseDTO{
+		ID:          option.ID,
+		VariationID: option.ProductAttributeID,
+		Value:       option.Value,
+		HexValue:    option.HexValue,
+		SortOrder:   option.SortOrder,
+	}
+}
+
+// ToVariationResponseDTO converts a models.ProductVariation to a VariationResponseDTO.
+func ToVariationResponseDTO(variation models.ProductVariation) VariationResponseDTO {
+	options := make([]VariationOptionResponseDTO, 0, len(variation.Options))
+	for _, option := range variation.Options {
+		options = append(options, ToVariationOptionResponseDTO(option))
+	}
+	return VariationResponseDTO{
+		ID:      variation.ID,
+		Name:    variation.Name,
+		IsColor: variation.IsColor,
+		Options: options,
+	}
+}
+
+// ToVariationResponseDTOs converts a slice of models.ProductVariation to a slice of VariationResponseDTO.
+func ToVariationResponseDTOs(variations []models.ProductVariation) []VariationResponseDTO {
+	result := make([]VariationResponseDTO, 0, len(variations))
+	for _, variation := range variations {
+		result = append(result, ToVariationResponseDTO(variation))
+	}
+	return result
+}
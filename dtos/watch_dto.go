@@ -0,0 +1,30 @@
+package dtos
+
+import "flicknfit_backend/models"
+
+// WatchPreferencesRequestDTO replaces the alert settings of a favorite or saved item.
+// TargetPrice limits price-drop alerts to prices at or below it; null alerts on any drop.
+type WatchPreferencesRequestDTO struct {
+	NotifyBackInStock bool `json:"notify_back_in_stock"`
+	NotifyPriceDrop   bool `json:"notify_price_drop"`
+	NotifyByEmail     bool `json:"notify_by_email"`
+	TargetPrice       *int `json:"target_price" validate:"omitempty,min=0"`
+}
+
+// WatchPreferencesDTO represents the alert settings of a favorite or saved item.
+type WatchPreferencesDTO struct {
+	NotifyBackInStock bool `json:"notify_back_in_stock"`
+	NotifyPriceDrop   bool `json:"notify_price_drop"`
+	NotifyByEmail     bool `json:"notify_by_email"`
+	TargetPrice       *int `json:"target_price"`
+}
+
+// ToWatchPreferencesDTO converts WatchPreferences into a WatchPreferencesDTO.
+func ToWatchPreferencesDTO(prefs models.WatchPreferences) WatchPreferencesDTO {
+	return WatchPreferencesDTO{
+		NotifyBackInStock: prefs.NotifyBackInStock,
+		NotifyPriceDrop:   prefs.NotifyPriceDrop,
+		NotifyByEmail:     prefs.NotifyByEmail,
+		TargetPrice:       prefs.TargetPrice,
+	}
+}
@@ -2,13 +2,18 @@ package main
 
 import (
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"flicknfit_backend/admin"
 	"flicknfit_backend/config"
+	"flicknfit_backend/constants"
 	"flicknfit_backend/container"
 	"flicknfit_backend/database"
 	"flicknfit_backend/routes"
 	"flicknfit_backend/seeders"
+	"flicknfit_backend/services"
 	"flicknfit_backend/utils"
 	"log/slog"
 
@@ -54,6 +59,12 @@ func main() {
 		appLogger.Fatalf("Error loading configuration: %v", err)
 	}
 
+	// Optional CLI subcommand, e.g. "migrate" or "import-products" (see commands.go).
+	command := ""
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+
 	// Initialize the database connection.
 	db, err := database.ConnectDB(cfg, appLogger)
 	if err != nil {
@@ -61,6 +72,10 @@ func main() {
 		return
 	} // Automatically migrate the database schema.
 	database.Migrate(db, appLogger)
+	if command == "migrate" {
+		log.Println("Migrations completed.")
+		return
+	}
 
 	// Jalankan semua seeder sekaligus
 	if err := seeders.SeedAll(db); err != nil {
@@ -74,6 +89,53 @@ func main() {
 		return
 	}
 
+	if command != "" {
+		if err := runCommand(appContainer, os.Args[1:]); err != nil {
+			appLogger.Fatalf("Command %s failed: %v", command, err)
+		}
+		return
+	}
+
+	// Publish scheduled products in the background while the server is running.
+	publishScheduler := services.NewProductPublishScheduler(appContainer.Services.Product, constants.ProductPublishCheckInterval)
+	publishScheduler.Start()
+	defer publishScheduler.Stop()
+
+	// Reconcile sold and total-product counters in the background.
+	counterScheduler := services.NewCounterReconcileScheduler(appContainer.Services.Conversion, constants.CounterReconcileInterval)
+	counterScheduler.Start()
+	defer counterScheduler.Stop()
+
+	// Check stored marketplace links in the background so redirects skip dead ones.
+	linkScheduler := services.NewLinkHealthScheduler(appContainer.Services.LinkHealth, constants.LinkCheckInterval)
+	linkScheduler.Start()
+	defer linkScheduler.Stop()
+
+	// Anonymise or delete clicks past the retention period, when one is configured.
+	if cfg.ClickRetentionDays > 0 {
+		retentionScheduler := services.NewClickRetentionScheduler(appContainer.Services.Tracking, constants.ClickRetentionInterval)
+		retentionScheduler.Start()
+		defer retentionScheduler.Stop()
+	}
+
+	// Email brands their previous month's report once a new month starts.
+	reportScheduler := services.NewBrandReportScheduler(appContainer.Services.BrandReport, constants.BrandReportCheckInterval)
+	reportScheduler.Start()
+	defer reportScheduler.Stop()
+
+	// Store published events, such as product clicks and views, in batches; stopping handles the events still queued.
+	appContainer.Services.Events.Start()
+	defer appContainer.Services.Events.Stop()
+
+	// Check the model API at startup and periodically so outages open its circuit breaker early.
+	aiHealthScheduler := services.NewAIHealthScheduler(appContainer.Services.AIModel, constants.AIModelHealthCheckInterval)
+	aiHealthScheduler.Start()
+	defer aiHealthScheduler.Stop()
+
+	// Run queued AI predictions in the background; stopping cancels the running ones and waits for them.
+	appContainer.Services.AIJobs.Start()
+	defer appContainer.Services.AIJobs.Stop()
+
 	// Create a new Fiber app instance with custom configurations.
 	app := fiber.New(fiber.Config{
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
@@ -87,7 +149,8 @@ func main() {
 				"message": err.Error(),
 			})
 		},
-		AppName: "FlickNFit API v1.0",
+		AppName:   "FlickNFit API v1.0",
+		BodyLimit: constants.MaxRequestBodySize,
 	})
 	// Set up the API routes with middlewares
 	routes.SetupRoutes(app, db, appContainer)
@@ -105,6 +168,17 @@ func main() {
 		log.Printf("✅ Admin dashboard available at http://localhost:%s/admin", cfg.AppPort)
 	}
 
+	// Shut down gracefully on SIGINT/SIGTERM so the background jobs above can stop and flush.
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-quit
+		log.Println("Shutting down server...")
+		if err := app.Shutdown(); err != nil {
+			log.Printf("Error shutting down server: %v", err)
+		}
+	}()
+
 	// Start the server and listen on the specified port.
 	log.Printf("Server starting on %s:%s", cfg.AppHost, cfg.AppPort)
 	if err := app.Listen(cfg.AppHost + ":" + cfg.AppPort); err != nil {
@@ -46,3 +46,30 @@ func AuthMiddleware() fiber.Handler {
 		return c.Next()
 	}
 }
+
+// OptionalAuthMiddleware sets the user context when a valid access token is sent, but never rejects
+// the request. It is used on public endpoints that show extra information to signed-in users.
+func OptionalAuthMiddleware() fiber.Handler {
+	appLogger := utils.NewLogger()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		appLogger.Fatalf("Error loading configuration: %v", err)
+	}
+
+	return func(c *fiber.Ctx) error {
+		parts := strings.Split(c.Get("Authorization"), " ")
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			return c.Next()
+		}
+		claims := &services.CustomClaims{}
+		token, err := jwt.ParseWithClaims(parts[1], claims, func(token *jwt.Token) (interface{}, error) {
+			return []byte(cfg.JwtSecretKey), nil
+		})
+		if err == nil && token.Valid {
+			c.Locals("userID", claims.UserID)
+			c.Locals("role", claims.Role)
+		}
+		return c.Next()
+	}
+}
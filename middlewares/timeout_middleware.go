@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestTimeout gives handlers a request context with a deadline, since Fiber's user context is
+// context.Background by default. Calls made with c.UserContext() stop once the deadline passes, and the
+// context is cancelled when the handler returns.
+func RequestTimeout(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
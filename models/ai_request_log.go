@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AIRequestLog records one call to the AI prediction API, for usage analytics.
+type AIRequestLog struct {
+	gorm.Model
+	ID         uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	Kind       string    `gorm:"size:30;not null;index" json:"kind"` // skin_color_tone, woman_body_scan or men_body_scan
+	Success    bool      `gorm:"not null" json:"success"`
+	DurationMs int64     `gorm:"not null" json:"duration_ms"`
+	Error      string    `gorm:"size:255" json:"error,omitempty"`
+	CreatedAt  time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+}
@@ -0,0 +1,59 @@
+package models
+
+// DailyValue is the value of a metric on one day. It is a query result, not a table.
+type DailyValue struct {
+	Day   string  `json:"day"` // YYYY-MM-DD
+	Value float64 `json:"value"`
+}
+
+// CategoryRevenue is the confirmed revenue of a product category. It is a query result, not a table.
+type CategoryRevenue struct {
+	Category string `json:"category"`
+	Revenue  int64  `json:"revenue"`
+}
+
+// ClickTotals counts the clicks in a period and who made them. It is a query result, not a table.
+type ClickTotals struct {
+	Clicks          int64 `json:"clicks"`
+	AnonymousClicks int64 `json:"anonymous_clicks"`
+	UniqueUsers     int64 `json:"unique_users"` // Signed-in users; anonymous clicks cannot be told apart
+}
+
+// DailyClicks counts the clicks of one day. It is a query result, not a table.
+type DailyClicks struct {
+	Day             string `json:"day"` // YYYY-MM-DD
+	Clicks          int64  `json:"clicks"`
+	AnonymousClicks int64  `json:"anonymous_clicks"`
+}
+
+// DailyUser is a signed-in user who clicked on a day. It is a query result, not a table.
+type DailyUser struct {
+	Day    string `json:"day"` // YYYY-MM-DD
+	UserID uint64 `json:"user_id"`
+}
+
+// ProductClickCount counts the clicks on one product. It is a query result, not a table.
+type ProductClickCount struct {
+	ProductID   uint64 `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Clicks      int64  `json:"clicks"`
+	UniqueUsers int64  `json:"unique_users"`
+}
+
+// ClickCount counts the clicks sharing a key such as a category, user agent or region. It is a query result, not a table.
+type ClickCount struct {
+	Key    string `json:"key"`
+	Clicks int64  `json:"clicks"`
+}
+
+// ReviewSummary counts the reviews in a period and their average rating. It is a query result, not a table.
+type ReviewSummary struct {
+	Reviews       int64   `json:"reviews"`
+	AverageRating float64 `json:"average_rating"`
+}
+
+// RatingCount counts the reviews with one star rating. It is a query result, not a table.
+type RatingCount struct {
+	Rating  int   `json:"rating"`
+	Reviews int64 `json:"reviews"`
+}
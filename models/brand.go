@@ -18,6 +18,11 @@ type Brand struct {
 	LogoURL       string  `json:"logo_url"`
 	WebsiteURL    string  `json:"website_url"`
 	TotalProducts uint    `gorm:"default:0" json:"total_products"`
+	Followers     uint    `gorm:"default:0" json:"followers"`
+
+	// Set when an admin approves the brand's application
+	Verified   bool       `gorm:"default:false;index" json:"verified"`
+	VerifiedAt *time.Time `json:"verified_at"`
 
 	// Multi-platform links for UMKM
 	WhatsAppNumber string `gorm:"size:20" json:"whatsapp_number"`
@@ -25,6 +30,9 @@ type Brand struct {
 	TokopediaURL   string `gorm:"size:255" json:"tokopedia_url"`
 	ShopeeURL      string `gorm:"size:255" json:"shopee_url"`
 
+	// Shared secret that signs the brand's conversion postbacks; empty until a member generates one
+	PostbackSecret string `gorm:"size:64" json:"-"`
+
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BrandApplication is a request to list a new brand, or to verify an existing one, waiting for admin review.
+// BrandID is set up front when a member applies for their brand, and after approval for new brands.
+type BrandApplication struct {
+	gorm.Model
+	ID          uint64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID      uint64  `gorm:"not null;index" json:"user_id"`
+	BrandID     *uint64 `gorm:"index" json:"brand_id"`
+	Name        string  `gorm:"size:100;not null" json:"name"`
+	Description string  `gorm:"size:255;not null" json:"description"`
+	LogoURL     string  `gorm:"size:500" json:"logo_url"`
+	WebsiteURL  string  `gorm:"size:255" json:"website_url"`
+
+	WhatsAppNumber string `gorm:"size:20" json:"whatsapp_number"`
+	InstagramURL   string `gorm:"size:255" json:"instagram_url"`
+	TokopediaURL   string `gorm:"size:255" json:"tokopedia_url"`
+	ShopeeURL      string `gorm:"size:255" json:"shopee_url"`
+
+	Status      string     `gorm:"size:20;not null;default:'pending';index" json:"status"`
+	ReviewNotes string     `gorm:"type:text" json:"review_notes"`
+	ReviewedBy  *uint64    `json:"reviewed_by"`
+	ReviewedAt  *time.Time `json:"reviewed_at"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	User  User   `gorm:"foreignKey:UserID"`
+	Brand *Brand `gorm:"foreignKey:BrandID"`
+}
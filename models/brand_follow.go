@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BrandFollow records a user following a brand.
+type BrandFollow struct {
+	gorm.Model
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	BrandID   uint64    `gorm:"not null;uniqueIndex:idx_brand_follow" json:"brand_id"`
+	UserID    uint64    `gorm:"not null;uniqueIndex:idx_brand_follow;index" json:"user_id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationships
+	Brand Brand `gorm:"foreignKey:BrandID"`
+	User  User  `gorm:"foreignKey:UserID"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BrandLinkParams holds a brand's overrides for the UTM tags and affiliate IDs added to its outbound links.
+// Empty fields fall back to the application defaults.
+type BrandLinkParams struct {
+	gorm.Model
+	ID                   uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	BrandID              uint64    `gorm:"not null;uniqueIndex" json:"brand_id"`
+	UTMSource            string    `gorm:"size:100" json:"utm_source"`
+	UTMMedium            string    `gorm:"size:100" json:"utm_medium"`
+	UTMCampaign          string    `gorm:"size:100" json:"utm_campaign"`
+	TokopediaAffiliateID string    `gorm:"size:100" json:"tokopedia_affiliate_id"`
+	ShopeeAffiliateID    string    `gorm:"size:100" json:"shopee_affiliate_id"`
+	CreatedAt            time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt            time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BrandMember links a user to a brand they manage.
+// Brand members receive operational notifications such as low-stock alerts.
+type BrandMember struct {
+	gorm.Model
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	BrandID   uint64    `gorm:"not null;uniqueIndex:idx_brand_member" json:"brand_id"`
+	UserID    uint64    `gorm:"not null;uniqueIndex:idx_brand_member;index" json:"user_id"`
+	Role      string    `gorm:"size:20;not null;default:'staff'" json:"role"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relationships
+	Brand Brand `gorm:"foreignKey:BrandID"`
+	User  User  `gorm:"foreignKey:UserID"`
+}
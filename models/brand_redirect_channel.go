@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BrandRedirectChannel is one entry of a brand's preferred order of outbound channels. Channels with a
+// weight take part in an A/B split that sends that share of clicks to them.
+type BrandRedirectChannel struct {
+	gorm.Model
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	BrandID   uint64    `gorm:"not null;uniqueIndex:idx_brand_redirect_channel" json:"brand_id"`
+	Channel   string    `gorm:"size:20;not null;uniqueIndex:idx_brand_redirect_channel" json:"channel"`
+	Position  int       `gorm:"not null" json:"position"`
+	Weight    int       `gorm:"default:0" json:"weight"` // 0 keeps the channel out of the A/B split
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// ChannelStat counts the clicks sent to a channel and the conversions attributed to it.
+// It is a query result, not a table.
+type ChannelStat struct {
+	Channel     string `json:"channel"`
+	Clicks      int64  `json:"clicks"`
+	Conversions int64  `json:"conversions"`
+	UnitsSold   int64  `json:"units_sold"`
+	Revenue     int64  `json:"revenue"` // Rupiah
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// BrandReportDelivery records that the monthly report of a brand was emailed, so it is sent once per month
+// even when the job runs again.
+type BrandReportDelivery struct {
+	gorm.Model
+	ID         uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	BrandID    uint64    `gorm:"not null;uniqueIndex:idx_brand_report_period" json:"brand_id"`
+	Period     string    `gorm:"size:7;not null;uniqueIndex:idx_brand_report_period" json:"period"` // YYYY-MM
+	Recipients int       `gorm:"not null" json:"recipients"`
+	SentAt     time.Time `gorm:"not null" json:"sent_at"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Campaign represents the campaigns table.
+// Kampanye diskon berlaku dalam rentang StartAt-EndAt untuk brand, kategori, atau produk yang ditargetkan.
+// Kampanye tanpa target berlaku untuk semua produk.
+type Campaign struct {
+	gorm.Model
+	ID            uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name          string    `gorm:"size:100;not null" json:"name"`
+	Description   string    `gorm:"type:text" json:"description"`
+	DiscountType  string    `gorm:"size:20;not null" json:"discount_type"` // percentage atau fixed
+	DiscountValue float64   `gorm:"not null" json:"discount_value"`        // Persen (0-100) atau nominal rupiah
+	StartAt       time.Time `gorm:"not null;index" json:"start_at"`
+	EndAt         time.Time `gorm:"not null;index" json:"end_at"`
+	IsActive      bool      `gorm:"default:true" json:"is_active"`
+
+	// Relationships
+	Targets []CampaignTarget `gorm:"foreignKey:CampaignID"`
+}
+
+// CampaignTarget represents the campaign_targets table.
+// TargetType menentukan kolom yang dipakai: brand dan product memakai TargetID, category memakai Category.
+type CampaignTarget struct {
+	ID         uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+	CampaignID uint64 `gorm:"not null;index" json:"campaign_id"`
+	TargetType string `gorm:"size:20;not null" json:"target_type"`
+	TargetID   uint64 `gorm:"default:0" json:"target_id"`
+	Category   string `gorm:"size:100" json:"category"`
+}
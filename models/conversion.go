@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Conversion represents the conversions table.
+// Each row is a sale confirmed by a brand; the Sold counters of the item and product are derived from it.
+type Conversion struct {
+	gorm.Model
+	ID            uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	BrandID       uint64    `gorm:"not null;uniqueIndex:idx_conversion_order" json:"brand_id"`
+	ProductID     uint64    `gorm:"not null;index" json:"product_id"`
+	ProductItemID uint64    `gorm:"not null;uniqueIndex:idx_conversion_order" json:"product_item_id"`
+	Quantity      int       `gorm:"not null" json:"quantity"`
+	Revenue       int       `gorm:"not null;default:0" json:"revenue"`                                   // Order line total in rupiah
+	OrderRef      string    `gorm:"size:100;not null;uniqueIndex:idx_conversion_order" json:"order_ref"` // Brand's order reference, used to ignore duplicate reports
+	Source        string    `gorm:"size:20;not null" json:"source"`                                      // api or webhook
+	RecordedBy    *uint64   `gorm:"index" json:"recorded_by"`                                            // Nullable for webhook conversions
+	Channel       string    `gorm:"size:20;index" json:"channel"`                                        // Outbound channel the sale came through, if known
+	ClickID       *uint64   `gorm:"index" json:"click_id"`                                               // Click the brand attributed the sale to, if reported
+	UserID        *uint64   `gorm:"index" json:"user_id"`                                                // User of the attributed click, if signed in
+	ConvertedAt   time.Time `gorm:"not null;index" json:"converted_at"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relationships
+	Brand       Brand       `gorm:"foreignKey:BrandID"`
+	Product     Product     `gorm:"foreignKey:ProductID"`
+	ProductItem ProductItem `gorm:"foreignKey:ProductItemID"`
+}
+
+// ConversionPostback records a postback accepted under an idempotency key, so a retried request returns
+// the first result instead of being processed again.
+type ConversionPostback struct {
+	gorm.Model
+	ID             uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	BrandID        uint64    `gorm:"not null;uniqueIndex:idx_postback_key" json:"brand_id"`
+	IdempotencyKey string    `gorm:"size:100;not null;uniqueIndex:idx_postback_key" json:"idempotency_key"`
+	OrderRef       string    `gorm:"size:100;not null" json:"order_ref"`
+	Recorded       int       `gorm:"not null" json:"recorded"`
+	Duplicates     int       `gorm:"not null" json:"duplicates"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// CounterReconciliation reports how many denormalised counters were corrected by a reconciliation run.
+// It is a query result, not a table.
+type CounterReconciliation struct {
+	ItemsCorrected    int64 `json:"items_corrected"`
+	ProductsCorrected int64 `json:"products_corrected"`
+	BrandsCorrected   int64 `json:"brands_corrected"`
+}
@@ -15,6 +15,9 @@ type Favorite struct {
 	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 
+	// Back-in-stock and price-drop alert settings
+	WatchPreferences
+
 	// Relationships
 	User        User        `gorm:"foreignKey:UserID"`
 	ProductItem ProductItem `gorm:"foreignKey:ProductItemID"`
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LinkCheck records the latest health check of one stored product or brand link, such as a product's
+// Tokopedia URL. Field is the JSON name of the link on its product or brand.
+type LinkCheck struct {
+	gorm.Model
+	ID                  uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	EntityType          string     `gorm:"size:20;not null;uniqueIndex:idx_link_check" json:"entity_type"`
+	EntityID            uint64     `gorm:"not null;uniqueIndex:idx_link_check" json:"entity_id"`
+	Field               string     `gorm:"size:50;not null;uniqueIndex:idx_link_check" json:"field"`
+	EntityName          string     `gorm:"size:255" json:"entity_name"`
+	URL                 string     `gorm:"size:512;not null" json:"url"`
+	StatusCode          int        `json:"status_code"`
+	Error               string     `gorm:"size:255" json:"error"`
+	ConsecutiveFailures int        `gorm:"default:0" json:"consecutive_failures"`
+	Broken              bool       `gorm:"default:false;index" json:"broken"`
+	FailingSince        *time.Time `json:"failing_since"`
+	CheckedAt           time.Time  `gorm:"index" json:"checked_at"`
+}
+
+// LinkCheckSummary reports the outcome of one run of the link checker.
+type LinkCheckSummary struct {
+	LinksChecked int `json:"links_checked"`
+	URLsChecked  int `json:"urls_checked"`
+	Broken       int `json:"broken"`
+}
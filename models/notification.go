@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Notification represents a message in a user's in-app inbox.
+type Notification struct {
+	gorm.Model
+	ID            uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID        uint64     `gorm:"not null;index" json:"user_id"`
+	Type          string     `gorm:"size:50;not null;index" json:"type"`
+	Title         string     `gorm:"size:255;not null" json:"title"`
+	Message       string     `gorm:"type:text" json:"message"`
+	ProductID     *uint64    `gorm:"index" json:"product_id"`
+	ProductItemID *uint64    `gorm:"index" json:"product_item_id"`
+	DedupKey      string     `gorm:"size:100;index" json:"-"` // Identifies repeated alerts so they can be suppressed
+	ReadAt        *time.Time `json:"read_at"`
+	CreatedAt     time.Time  `gorm:"autoCreateTime;index" json:"created_at"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PriceHistory represents the price_history table.
+// Satu baris dicatat setiap kali harga ProductItem berubah (termasuk harga awal saat item dibuat).
+type PriceHistory struct {
+	gorm.Model
+	ID            uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductItemID uint64    `gorm:"not null;index" json:"product_item_id"`
+	ProductID     uint64    `gorm:"not null;index" json:"product_id"`
+	OldPrice      int       `gorm:"not null" json:"old_price"`
+	NewPrice      int       `gorm:"not null" json:"new_price"`
+	ChangedAt     time.Time `gorm:"not null;index" json:"changed_at"`
+
+	// Relationships
+	ProductItem ProductItem `gorm:"foreignKey:ProductItemID"`
+}
+
+// TableName specifies the table name for PriceHistory model
+func (PriceHistory) TableName() string {
+	return "price_history"
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"flicknfit_backend/constants"
 	"time"
 
 	"gorm.io/gorm"
@@ -17,6 +18,14 @@ type Product struct {
 	Rating      float64 `gorm:"default:0.0" json:"rating"`
 	Reviewer    int     `gorm:"default:0" json:"reviewer"`
 	Sold        int     `gorm:"default:0" json:"sold"`
+	Views       int     `gorm:"default:0" json:"views"` // Debounced product detail views
+
+	// Status siklus hidup produk: draft, active, inactive, atau scheduled.
+	// Hanya produk active (atau scheduled yang PublishAt-nya sudah lewat) yang tampil di query publik.
+	Status        string     `gorm:"size:20;not null;default:'active';index" json:"status"`
+	PublishAt     *time.Time `gorm:"index" json:"publish_at"` // Jadwal publikasi untuk status scheduled
+	PublishedAt   *time.Time `json:"published_at"`            // Waktu terakhir produk dipublikasikan
+	UnpublishedAt *time.Time `json:"unpublished_at"`          // Waktu terakhir produk ditarik dari publik
 
 	// External links for product discovery platform
 	BrandProductURL     string `gorm:"size:512" json:"brand_product_url"`     // Primary link to brand store
@@ -34,4 +43,17 @@ type Product struct {
 	ProductStyles     []ProductStyle    `gorm:"foreignKey:ProductID"`
 	ProductItems      []ProductItem     `gorm:"foreignKey:ProductID"`
 	Reviews           []Review          `gorm:"foreignKey:ProductID"`
+	Media             []ProductMedia    `gorm:"foreignKey:ProductID"`
+}
+
+// IsPublished mengembalikan true jika produk boleh ditampilkan ke publik pada waktu now.
+func (p *Product) IsPublished(now time.Time) bool {
+	switch p.Status {
+	case constants.ProductStatusActive:
+		return true
+	case constants.ProductStatusScheduled:
+		return p.PublishAt != nil && !p.PublishAt.After(now)
+	default:
+		return false
+	}
 }
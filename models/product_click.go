@@ -10,12 +10,19 @@ import (
 type ProductClick struct {
 	gorm.Model
 	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
-	UserID    *uint64   `gorm:"index" json:"user_id"` // Nullable for anonymous users
+	Ref       *string   `gorm:"size:32;uniqueIndex" json:"-"` // Signed into the click ID handed to brands, since clicks are stored after the redirect; nil for older clicks
+	UserID    *uint64   `gorm:"index" json:"user_id"`         // Nullable for anonymous users, users who opted out of tracking and clicks past retention
 	ProductID uint64    `gorm:"not null;index" json:"product_id"`
 	BrandID   uint64    `gorm:"not null;index" json:"brand_id"`
 	ClickedAt time.Time `gorm:"autoCreateTime;index" json:"clicked_at"`
-	IPAddress string    `gorm:"size:45" json:"ip_address"` // IPv6 max length
+	IPAddress string    `gorm:"size:45" json:"ip_address"` // IPv6 max length; truncated or hashed depending on CLICK_IP_MODE
 	UserAgent string    `gorm:"type:text" json:"user_agent"`
+	Channel   string    `gorm:"size:20;index" json:"channel"` // Outbound channel the click was redirected to
+	Region    string    `gorm:"size:64;index" json:"region"`  // Reported by the app or the CDN; empty when unknown
+
+	// Suspicious clicks are kept as raw data but left out of analytics
+	Suspicious      bool   `gorm:"not null;default:false;index" json:"suspicious"`
+	SuspicionReason string `gorm:"size:20" json:"suspicion_reason,omitempty"` // bot, duplicate or rate
 
 	// Relationships
 	User    *User   `gorm:"foreignKey:UserID"`
@@ -12,9 +12,24 @@ type ProductItem struct {
 	Sold      int    `gorm:"default:0" json:"sold"`
 	PhotoURL  string `gorm:"size:255" json:"photo_url"`
 
+	// Ambang stok rendah per item; nil berarti memakai constants.DefaultLowStockThreshold
+	LowStockThreshold *int `gorm:"default:NULL" json:"low_stock_threshold"`
+
+	// Harga efektif dihitung saat request dari diskon produk dan kampanye aktif (tidak disimpan di database)
+	EffectivePrice  *int      `gorm:"-" json:"-"`
+	AppliedCampaign *Campaign `gorm:"-" json:"-"`
+
 	// Relationships
 	Product        Product                `gorm:"foreignKey:ProductID"`
 	SavedItemsList []SavedItemsList       `gorm:"foreignKey:ProductItemID"`
 	Favorites      []Favorite             `gorm:"foreignKey:ProductItemID"`
 	Configurations []ProductConfiguration `gorm:"foreignKey:ProductItemID"`
 }
+
+// EffectiveLowStockThreshold mengembalikan ambang stok rendah item atau nilai default.
+func (i *ProductItem) EffectiveLowStockThreshold(defaultThreshold int) int {
+	if i.LowStockThreshold != nil {
+		return *i.LowStockThreshold
+	}
+	return defaultThreshold
+}
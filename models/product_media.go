@@ -0,0 +1,32 @@
+package models
+
+import "gorm.io/gorm"
+
+// ProductMedia represents the product_media table.
+// Satu baris adalah satu gambar atau video galeri produk. Media dengan ProductItemID kosong milik produk,
+// sedangkan media dengan ProductItemID khusus untuk item tersebut (misalnya foto per warna).
+type ProductMedia struct {
+	gorm.Model
+	ID            uint64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductID     uint64  `gorm:"not null;index" json:"product_id"`
+	ProductItemID *uint64 `gorm:"index" json:"product_item_id"`
+	MediaType     string  `gorm:"size:10;not null" json:"media_type"` // image atau video
+	URL           string  `gorm:"size:512;not null" json:"url"`
+	ThumbnailURL  string  `gorm:"size:512" json:"thumbnail_url"`
+	AltText       string  `gorm:"size:255" json:"alt_text"`
+	SortOrder     int     `gorm:"default:0" json:"sort_order"`
+	IsPrimary     bool    `gorm:"default:false" json:"is_primary"` // Media utama per produk atau per item
+
+	// Lokasi file di storage, dipakai saat media dihapus
+	StoragePath   string `gorm:"size:255" json:"-"`
+	ThumbnailPath string `gorm:"size:255" json:"-"`
+
+	// Relationships
+	Product     Product      `gorm:"foreignKey:ProductID"`
+	ProductItem *ProductItem `gorm:"foreignKey:ProductItemID"`
+}
+
+// TableName specifies the table name for ProductMedia model
+func (ProductMedia) TableName() string {
+	return "product_media"
+}
@@ -8,7 +8,9 @@ import (
 type ProductVariation struct {
 	gorm.Model
 	ID   uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
-	Name string `gorm:"size:50;not null" json:"name"`
+	Name string `gorm:"size:50;not null;uniqueIndex" json:"name"`
+	// IsColor menandai variasi warna; opsi variasi warna wajib memiliki HexValue
+	IsColor bool `gorm:"default:false" json:"is_color"`
 	// Hubungan ke ProductVariationOption
 	Options []ProductVariationOption `gorm:"foreignKey:ProductAttributeID"`
 }
@@ -5,8 +5,12 @@ import "gorm.io/gorm"
 type ProductVariationOption struct {
 	gorm.Model
 	ID                 uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
-	ProductAttributeID uint64 `gorm:"not null" json:"product_attribute_id"`
-	Value              string `gorm:"size:50;not null" json:"value"`
+	ProductAttributeID uint64 `gorm:"not null;uniqueIndex:idx_variation_option_value" json:"product_attribute_id"`
+	Value              string `gorm:"size:50;not null;uniqueIndex:idx_variation_option_value" json:"value"`
+	// HexValue menyimpan kode warna (#RRGGBB) agar opsi warna dapat dicocokkan dengan FaceScanHistory.ColorRecommendations
+	HexValue string `gorm:"size:7" json:"hex_value"`
+	// SortOrder menentukan urutan tampilan opsi dalam satu variasi (mis. S < M < L)
+	SortOrder int `gorm:"default:0" json:"sort_order"`
 
 	// Relationships
 	ProductVariation ProductVariation `gorm:"foreignKey:ProductAttributeID"`
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ProductView records a product detail view. Repeated views by the same user or session within the
+// debounce window are recorded once.
+type ProductView struct {
+	gorm.Model
+	ID         uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID     *uint64   `gorm:"index" json:"user_id"` // Nullable for anonymous users
+	SessionKey string    `gorm:"size:64;not null" json:"-"`
+	ProductID  uint64    `gorm:"not null;index" json:"product_id"`
+	BrandID    uint64    `gorm:"not null;index" json:"brand_id"`
+	ViewedAt   time.Time `gorm:"not null;index" json:"viewed_at"` // Time of the request; the row is written later in a batch
+
+	// Relationships
+	User    *User   `gorm:"foreignKey:UserID"`
+	Product Product `gorm:"foreignKey:ProductID"`
+}
+
+// RecentView is a product a user viewed with the last time they viewed it. It is a query result, not a table.
+type RecentView struct {
+	ProductID uint64    `json:"product_id"`
+	ViewedAt  time.Time `json:"viewed_at"`
+}
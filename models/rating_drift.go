@@ -0,0 +1,19 @@
+package models
+
+// RatingDrift entity kinds
+const (
+	RatingDriftEntityProduct = "product"
+	RatingDriftEntityBrand   = "brand"
+)
+
+// RatingDrift is a product or brand whose stored Rating/Reviewer aggregates differ from its reviews.
+// It is a query result, not a table.
+type RatingDrift struct {
+	Entity         string  `json:"entity"`
+	ID             uint64  `json:"id"`
+	Name           string  `json:"name"`
+	StoredRating   float64 `json:"stored_rating"`
+	StoredReviewer int     `json:"stored_reviewer"`
+	ActualRating   float64 `json:"actual_rating"`
+	ActualReviewer int     `json:"actual_reviewer"`
+}
@@ -15,6 +15,9 @@ type SavedItemsList struct {
 	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 
+	// Back-in-stock and price-drop alert settings
+	WatchPreferences
+
 	// Relationships
 	SavedItems  SavedItems  `gorm:"foreignKey:SavedItemsID;references:ID"`
 	ProductItem ProductItem `gorm:"foreignKey:ProductItemID"`
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StockMovement represents the stock_movements table.
+// Setiap perubahan stok ProductItem dicatat sebagai satu baris ledger beserta alasan dan pelakunya.
+type StockMovement struct {
+	gorm.Model
+	ID            uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProductItemID uint64    `gorm:"not null;index" json:"product_item_id"`
+	Delta         int       `gorm:"not null" json:"delta"`
+	StockBefore   int       `gorm:"not null" json:"stock_before"`
+	StockAfter    int       `gorm:"not null" json:"stock_after"`
+	Reason        string    `gorm:"size:50;not null" json:"reason"`
+	Note          string    `gorm:"size:255" json:"note"`
+	ActorID       *uint64   `gorm:"index" json:"actor_id"` // Nullable for system changes
+	CreatedAt     time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+
+	// Relationships
+	ProductItem ProductItem `gorm:"foreignKey:ProductItemID"`
+	Actor       *User       `gorm:"foreignKey:ActorID"`
+}
@@ -19,6 +19,7 @@ type User struct {
 	Birthday    *time.Time `gorm:"default:NULL" json:"birthday"`
 	Region      string     `json:"region"`
 	Role        Role       `gorm:"type:ENUM('admin', 'user');not null;default:'user'" json:"role"`
+	DoNotTrack  bool       `gorm:"default:false" json:"do_not_track"` // Record the user's outbound clicks without their account or IP address
 
 	// OAuth Authentication Fields
 	AuthProvider          AuthProvider `gorm:"type:ENUM('local', 'google', 'facebook');default:'local'" json:"auth_provider"`
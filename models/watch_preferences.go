@@ -0,0 +1,19 @@
+package models
+
+// WatchPreferences holds a user's alert settings for a favorited or saved product item.
+// It is embedded in Favorite and SavedItemsList.
+type WatchPreferences struct {
+	NotifyBackInStock bool `gorm:"default:true" json:"notify_back_in_stock"`
+	NotifyPriceDrop   bool `gorm:"default:true" json:"notify_price_drop"`
+	NotifyByEmail     bool `gorm:"default:false" json:"notify_by_email"`
+	TargetPrice       *int `gorm:"default:NULL" json:"target_price"` // Only alert on price drops to or below this price; nil alerts on any drop
+}
+
+// ItemWatcher is a user watching a product item through a favorite or saved item.
+// It is a query result, not a table.
+type ItemWatcher struct {
+	UserID        uint64
+	ProductItemID uint64
+	Email         string
+	WatchPreferences
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"errors"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrUnknownMetric is returned for an analytics metric without a query.
+var ErrUnknownMetric = errors.New("unknown analytics metric")
+
+// AnalyticsRepository defines data access operations for the admin dashboard analytics and the AI request log.
+type AnalyticsRepository interface {
+	LogAIRequest(log *models.AIRequestLog) error
+	GetMetricTotal(metric string, from, to time.Time) (float64, error)
+	GetDailySeries(metric string, from, to time.Time) ([]models.DailyValue, error)
+	GetRevenueByCategory(from, to time.Time) ([]models.CategoryRevenue, error)
+}
+
+// analyticsRepository is the implementation of AnalyticsRepository.
+type analyticsRepository struct {
+	BaseRepository
+}
+
+// NewAnalyticsRepository creates and returns a new instance of AnalyticsRepository.
+func NewAnalyticsRepository(db *gorm.DB) AnalyticsRepository {
+	return &analyticsRepository{BaseRepository{DB: db}}
+}
+
+// LogAIRequest stores one call to the AI prediction API.
+func (r *analyticsRepository) LogAIRequest(log *models.AIRequestLog) error {
+	return r.DB.Create(log).Error
+}
+
+// metricQuery returns the base query of a metric with the column holding its date and the aggregate of its value.
+func (r *analyticsRepository) metricQuery(metric string) (query *gorm.DB, dateColumn, valueExpr string, err error) {
+	switch metric {
+	case constants.AnalyticsMetricGMV:
+		return r.DB.Model(&models.Conversion{}), "conversions.converted_at", "COALESCE(SUM(conversions.revenue), 0)", nil
+	case constants.AnalyticsMetricConversions:
+		return r.DB.Model(&models.Conversion{}), "conversions.converted_at", "COUNT(*)", nil
+	case constants.AnalyticsMetricUnitsSold:
+		return r.DB.Model(&models.Conversion{}), "conversions.converted_at", "COALESCE(SUM(conversions.quantity), 0)", nil
+	case constants.AnalyticsMetricClicks:
+		return r.DB.Model(&models.ProductClick{}).Scopes(genuineClicks), "product_clicks.clicked_at", "COUNT(*)", nil
+	case constants.AnalyticsMetricClickValue:
+		lowestPrices := r.DB.Model(&models.ProductItem{}).Select("product_id, MIN(price) AS price").Group("product_id")
+		query := r.DB.Model(&models.ProductClick{}).Scopes(genuineClicks).
+			Joins("JOIN (?) AS lowest_prices ON lowest_prices.product_id = product_clicks.product_id", lowestPrices)
+		return query, "product_clicks.clicked_at", "COALESCE(SUM(lowest_prices.price), 0)", nil
+	case constants.AnalyticsMetricAIRequests:
+		return r.DB.Model(&models.AIRequestLog{}), "ai_request_logs.created_at", "COUNT(*)", nil
+	case constants.AnalyticsMetricNewUsers:
+		return r.DB.Table("users").Where("users.deleted_at IS NULL"), "users.created_at", "COUNT(*)", nil
+	}
+	return nil, "", "", ErrUnknownMetric
+}
+
+// GetMetricTotal aggregates a metric over [from, to).
+func (r *analyticsRepository) GetMetricTotal(metric string, from, to time.Time) (float64, error) {
+	query, dateColumn, valueExpr, err := r.metricQuery(metric)
+	if err != nil {
+		return 0, err
+	}
+	var total float64
+	err = query.Select(valueExpr).
+		Where(dateColumn+" >= ? AND "+dateColumn+" < ?", from, to).
+		Scan(&total).Error
+	return total, err
+}
+
+// GetDailySeries aggregates a metric per day over [from, to). Days without data are omitted.
+func (r *analyticsRepository) GetDailySeries(metric string, from, to time.Time) ([]models.DailyValue, error) {
+	query, dateColumn, valueExpr, err := r.metricQuery(metric)
+	if err != nil {
+		return nil, err
+	}
+	var series []models.DailyValue
+	if err := query.Select("DATE("+dateColumn+") AS day, "+valueExpr+" AS value").
+		Where(dateColumn+" >= ? AND "+dateColumn+" < ?", from, to).
+		Group("DATE(" + dateColumn + ")").
+		Order("day").
+		Scan(&series).Error; err != nil {
+		return nil, err
+	}
+	for i := range series {
+		series[i].Day = trimDay(series[i].Day)
+	}
+	return series, nil
+}
+
+// trimDay keeps the YYYY-MM-DD part of a DATE value, which MySQL returns as a timestamp.
+func trimDay(day string) string {
+	if len(day) > len(constants.AnalyticsDateLayout) {
+		return day[:len(constants.AnalyticsDateLayout)]
+	}
+	return day
+}
+
+// GetRevenueByCategory sums the confirmed revenue per product category over [from, to). A product in several
+// categories counts towards each of them.
+func (r *analyticsRepository) GetRevenueByCategory(from, to time.Time) ([]models.CategoryRevenue, error) {
+	var revenue []models.CategoryRevenue
+	err := r.DB.Model(&models.Conversion{}).
+		Select("product_categories.category AS category, COALESCE(SUM(conversions.revenue), 0) AS revenue").
+		Joins("JOIN product_categories ON product_categories.product_id = conversions.product_id AND product_categories.deleted_at IS NULL").
+		Where("conversions.converted_at >= ? AND conversions.converted_at < ?", from, to).
+		Group("product_categories.category").
+		Order("revenue DESC").
+		Scan(&revenue).Error
+	return revenue, err
+}
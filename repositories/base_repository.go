@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"errors"
+
 	"gorm.io/gorm"
 )
 
@@ -9,3 +11,12 @@ import (
 type BaseRepository struct {
 	DB *gorm.DB
 }
+
+// isDuplicateKey reports whether err is a unique index violation, translating the driver's error so it works
+// with MySQL as well as the SQLite database used in tests.
+func isDuplicateKey(db *gorm.DB, err error) bool {
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+		err = translator.Translate(err)
+	}
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
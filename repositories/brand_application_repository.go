@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"flicknfit_backend/constants"
+	"flicknfit_backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BrandApplicationRepository defines data access operations for brand applications and their review.
+type BrandApplicationRepository interface {
+	CreateApplication(application *models.BrandApplication) error
+	GetApplicationByID(id uint64) (*models.BrandApplication, error)
+	GetApplications(status string, limit, offset int) ([]models.BrandApplication, int64, error)
+	GetApplicationsByUserID(userID uint64) ([]models.BrandApplication, error)
+	HasPendingApplication(userID uint64, brandID *uint64, name string) (bool, error)
+	ApproveApplication(application *models.BrandApplication, brand *models.Brand) error
+	UpdateApplication(application *models.BrandApplication) error
+}
+
+// brandApplicationRepository is the implementation of BrandApplicationRepository.
+type brandApplicationRepository struct {
+	BaseRepository
+}
+
+// NewBrandApplicationRepository creates and returns a new instance of BrandApplicationRepository.
+func NewBrandApplicationRepository(db *gorm.DB) BrandApplicationRepository {
+	return &brandApplicationRepository{BaseRepository{DB: db}}
+}
+
+// CreateApplication stores a new brand application.
+func (r *brandApplicationRepository) CreateApplication(application *models.BrandApplication) error {
+	return r.DB.Omit(clause.Associations).Create(application).Error
+}
+
+// GetApplicationByID retrieves an application with its applicant and brand.
+func (r *brandApplicationRepository) GetApplicationByID(id uint64) (*models.BrandApplication, error) {
+	var application models.BrandApplication
+	if err := r.DB.Preload("User").Preload("Brand").First(&application, id).Error; err != nil {
+		return nil, err
+	}
+	return &application, nil
+}
+
+// GetApplications retrieves a page of applications, oldest first so the review queue is worked in order,
+// with the total number of matches. An empty status matches every application.
+func (r *brandApplicationRepository) GetApplications(status string, limit, offset int) ([]models.BrandApplication, int64, error) {
+	query := r.DB.Model(&models.BrandApplication{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var applications []models.BrandApplication
+	if err := query.Preload("User").Order("created_at ASC, id ASC").Limit(limit).Offset(offset).Find(&applications).Error; err != nil {
+		return nil, 0, err
+	}
+	return applications, total, nil
+}
+
+// GetApplicationsByUserID retrieves a user's applications, newest first.
+func (r *brandApplicationRepository) GetApplicationsByUserID(userID uint64) ([]models.BrandApplication, error) {
+	var applications []models.BrandApplication
+	if err := r.DB.Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&applications).Error; err != nil {
+		return nil, err
+	}
+	return applications, nil
+}
+
+// HasPendingApplication reports whether a user already waits for review of an application for the same
+// existing brand or, for new brands, the same brand name.
+func (r *brandApplicationRepository) HasPendingApplication(userID uint64, brandID *uint64, name string) (bool, error) {
+	query := r.DB.Model(&models.BrandApplication{}).
+		Where("user_id = ? AND status = ?", userID, constants.BrandApplicationStatusPending)
+	if brandID != nil {
+		query = query.Where("brand_id = ?", *brandID)
+	} else {
+		query = query.Where("brand_id IS NULL AND LOWER(name) = LOWER(?)", name)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ApproveApplication saves the verified brand and the reviewed application in one transaction.
+// A new brand is created with the applicant as its owner; an existing brand is updated.
+func (r *brandApplicationRepository) ApproveApplication(application *models.BrandApplication, brand *models.Brand) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if brand.ID == 0 {
+			if err := tx.Omit(clause.Associations).Create(brand).Error; err != nil {
+				return err
+			}
+			owner := models.BrandMember{BrandID: brand.ID, UserID: application.UserID, Role: constants.BrandMemberRoleOwner}
+			if err := tx.Omit(clause.Associations).Create(&owner).Error; err != nil {
+				return err
+			}
+		} else if err := tx.Omit(clause.Associations, "Rating", "Reviewer", "TotalProducts", "Followers").Save(brand).Error; err != nil {
+			return err
+		}
+
+		application.BrandID = &brand.ID
+		return tx.Omit(clause.Associations).Save(application).Error
+	})
+}
+
+// UpdateApplication saves the review fields of an application.
+func (r *brandApplicationRepository) UpdateApplication(application *models.BrandApplication) error {
+	return r.DB.Omit(clause.Associations).Save(application).Error
+}
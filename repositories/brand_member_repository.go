@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"flicknfit_backend/models"
+
+	"gorm.io/gorm"
+)
+
+// BrandMemberRepository defines data access operations for the users managing a brand.
+type BrandMemberRepository interface {
+	GetMembersByBrandID(brandID uint64) ([]models.BrandMember, error)
+	GetMember(brandID, userID uint64) (*models.BrandMember, error)
+	AddMember(member *models.BrandMember) error
+	RemoveMember(brandID, userID uint64) error
+	GetMemberUserIDs(brandID uint64) ([]uint64, error)
+}
+
+// brandMemberRepository is the implementation of BrandMemberRepository.
+type brandMemberRepository struct {
+	BaseRepository
+}
+
+// NewBrandMemberRepository creates and returns a new instance of BrandMemberRepository.
+func NewBrandMemberRepository(db *gorm.DB) BrandMemberRepository {
+	return &brandMemberRepository{BaseRepository{DB: db}}
+}
+
+// GetMembersByBrandID retrieves all members of a brand with their user accounts.
+func (r *brandMemberRepository) GetMembersByBrandID(brandID uint64) ([]models.BrandMember, error) {
+	var members []models.BrandMember
+	if err := r.DB.Preload("User").Where("brand_id = ?", brandID).Order("id ASC").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// GetMember retrieves a single brand membership.
+func (r *brandMemberRepository) GetMember(brandID, userID uint64) (*models.BrandMember, error) {
+	var member models.BrandMember
+	if err := r.DB.Preload("User").Where("brand_id = ? AND user_id = ?", brandID, userID).First(&member).Error; err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// AddMember creates a brand membership, restoring a previously removed one for the same user.
+func (r *brandMemberRepository) AddMember(member *models.BrandMember) error {
+	var existing models.BrandMember
+	err := r.DB.Unscoped().Where("brand_id = ? AND user_id = ?", member.BrandID, member.UserID).First(&existing).Error
+	if err == nil {
+		member.ID = existing.ID
+		member.CreatedAt = existing.CreatedAt
+		member.DeletedAt = gorm.DeletedAt{}
+		return r.DB.Unscoped().Omit("Brand", "User").Save(member).Error
+	}
+	if err != gorm.ErrRecordNotFound {
+		return err
+	}
+	return r.DB.Omit("Brand", "User").Create(member).Error
+}
+
+// RemoveMember deletes a brand membership.
+func (r *brandMemberRepository) RemoveMember(brandID, userID uint64) error {
+	return r.DB.Where("brand_id = ? AND user_id = ?", brandID, userID).Delete(&models.BrandMember{}).Error
+}
+
+// GetMemberUserIDs retrieves the user IDs of all members of a brand.
+func (r *brandMemberRepository) GetMemberUserIDs(brandID uint64) ([]uint64, error) {
+	var userIDs []uint64
+	if err := r.DB.Model(&models.BrandMember{}).Where("brand_id = ?", brandID).Pluck("user_id", &userIDs).Error; err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"flicknfit_backend/models"
+
+	"gorm.io/gorm"
+)
+
+// BrandReportRepository defines the queries behind brand performance reports that click analytics does not
+// cover, and the record of emailed monthly reports.
+type BrandReportRepository interface {
+	GetReviewSummary(scope ClickScope) (*models.ReviewSummary, error)
+	GetRatingCounts(scope ClickScope) ([]models.RatingCount, error)
+	HasDelivery(brandID uint64, period string) (bool, error)
+	RecordDelivery(delivery *models.BrandReportDelivery) error
+}
+
+// brandReportRepository is the implementation of BrandReportRepository.
+type brandReportRepository struct {
+	BaseRepository
+}
+
+// NewBrandReportRepository creates and returns a new instance of BrandReportRepository.
+func NewBrandReportRepository(db *gorm.DB) BrandReportRepository {
+	return &brandReportRepository{BaseRepository{DB: db}}
+}
+
+// reviews returns the query of the reviews written in scope on the brand's products.
+func (r *brandReportRepository) reviews(scope ClickScope) *gorm.DB {
+	return r.DB.Model(&models.Review{}).
+		Joins("JOIN products ON products.id = reviews.product_id").
+		Where("products.brand_id = ?", scope.BrandID).
+		Where("reviews.created_at >= ? AND reviews.created_at < ?", scope.From, scope.To)
+}
+
+// GetReviewSummary counts the reviews in scope and averages their rating.
+func (r *brandReportRepository) GetReviewSummary(scope ClickScope) (*models.ReviewSummary, error) {
+	var summary models.ReviewSummary
+	err := r.reviews(scope).
+		Select("COUNT(*) AS reviews, COALESCE(AVG(reviews.rating), 0) AS average_rating").
+		Scan(&summary).Error
+	return &summary, err
+}
+
+// GetRatingCounts counts the reviews in scope per star rating. Ratings without reviews are omitted.
+func (r *brandReportRepository) GetRatingCounts(scope ClickScope) ([]models.RatingCount, error) {
+	var ratings []models.RatingCount
+	err := r.reviews(scope).
+		Select("reviews.rating AS rating, COUNT(*) AS reviews").
+		Group("reviews.rating").
+		Scan(&ratings).Error
+	return ratings, err
+}
+
+// HasDelivery reports whether the report of a brand for a period was already emailed.
+func (r *brandReportRepository) HasDelivery(brandID uint64, period string) (bool, error) {
+	var count int64
+	err := r.DB.Model(&models.BrandReportDelivery{}).
+		Where("brand_id = ? AND period = ?", brandID, period).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// RecordDelivery stores that a report was emailed.
+func (r *brandReportRepository) RecordDelivery(delivery *models.BrandReportDelivery) error {
+	return r.DB.Create(delivery).Error
+}
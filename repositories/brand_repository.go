@@ -1,6 +1,7 @@
 package repositories
 
 import (
+	"flicknfit_backend/constants"
 	"flicknfit_backend/models"
 
 	"gorm.io/gorm"
@@ -15,6 +16,14 @@ type BrandRepository interface {
 	GetBrandByID(id uint64) (*models.Brand, error)
 	UpdateBrand(brand *models.Brand) error
 	DeleteBrand(brand *models.Brand) error
+
+	// Brand directory and follows
+	SearchBrands(search, sort string, limit, offset int) ([]models.Brand, int64, error)
+	GetBrandCategories(brandID uint64) ([]string, error)
+	FollowBrand(brandID, userID uint64) (uint, error)
+	UnfollowBrand(brandID, userID uint64) (uint, error)
+	IsFollowingBrand(brandID, userID uint64) (bool, error)
+	GetFollowedBrands(userID uint64) ([]models.Brand, error)
 }
 
 // brandRepository is the implementation of BrandRepository.
@@ -51,11 +60,127 @@ func (r *brandRepository) GetBrandByID(id uint64) (*models.Brand, error) {
 }
 
 // UpdateBrand updates an existing brand record in the database.
+// Rating, Reviewer, TotalProducts and Followers are maintained from reviews, products and follows
+// and are never overwritten here.
 func (r *brandRepository) UpdateBrand(brand *models.Brand) error {
-	return r.DB.Save(brand).Error
+	return r.DB.Omit("Rating", "Reviewer", "TotalProducts", "Followers").Save(brand).Error
 }
 
 // DeleteBrand deletes a brand record from the database.
 func (r *brandRepository) DeleteBrand(brand *models.Brand) error {
 	return r.DB.Delete(brand).Error
 }
+
+// brandOrder maps a brand directory sort option to its ORDER BY clause; unknown options sort by name.
+func brandOrder(sort string) string {
+	switch sort {
+	case constants.BrandSortRating:
+		return "rating DESC, reviewer DESC, id ASC"
+	case constants.BrandSortFollowers:
+		return "followers DESC, id ASC"
+	case constants.BrandSortProducts:
+		return "total_products DESC, id ASC"
+	case constants.BrandSortNewest:
+		return "created_at DESC, id DESC"
+	default:
+		return "name ASC, id ASC"
+	}
+}
+
+// SearchBrands retrieves a page of brands whose name matches search, with the total number of matches.
+func (r *brandRepository) SearchBrands(search, sort string, limit, offset int) ([]models.Brand, int64, error) {
+	query := r.DB.Model(&models.Brand{})
+	if search != "" {
+		query = query.Where("LOWER(name) LIKE LOWER(?)", "%"+search+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var brands []models.Brand
+	if err := query.Order(brandOrder(sort)).Limit(limit).Offset(offset).Find(&brands).Error; err != nil {
+		return nil, 0, err
+	}
+	return brands, total, nil
+}
+
+// GetBrandCategories retrieves the distinct categories of a brand's published products, alphabetically.
+func (r *brandRepository) GetBrandCategories(brandID uint64) ([]string, error) {
+	var categories []string
+	if err := r.DB.Model(&models.ProductCategory{}).
+		Joins("JOIN products ON products.id = product_categories.product_id AND products.deleted_at IS NULL").
+		Scopes(publishedProducts).
+		Where("products.brand_id = ?", brandID).
+		Distinct().
+		Order("product_categories.category ASC").
+		Pluck("product_categories.category", &categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// FollowBrand makes a user follow a brand and returns the brand's follower count. Following twice is a no-op.
+func (r *brandRepository) FollowBrand(brandID, userID uint64) (uint, error) {
+	var followers uint
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		follow := models.BrandFollow{BrandID: brandID, UserID: userID}
+		if err := tx.Omit("Brand", "User").
+			Where("brand_id = ? AND user_id = ?", brandID, userID).
+			FirstOrCreate(&follow).Error; err != nil {
+			return err
+		}
+		var err error
+		followers, err = syncBrandFollowers(tx, brandID)
+		return err
+	})
+	return followers, err
+}
+
+// UnfollowBrand removes a user's follow of a brand and returns the brand's follower count.
+func (r *brandRepository) UnfollowBrand(brandID, userID uint64) (uint, error) {
+	var followers uint
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("brand_id = ? AND user_id = ?", brandID, userID).Delete(&models.BrandFollow{}).Error; err != nil {
+			return err
+		}
+		var err error
+		followers, err = syncBrandFollowers(tx, brandID)
+		return err
+	})
+	return followers, err
+}
+
+// syncBrandFollowers recounts a brand's followers and stores the count on the brand.
+func syncBrandFollowers(tx *gorm.DB, brandID uint64) (uint, error) {
+	var count int64
+	if err := tx.Model(&models.BrandFollow{}).Where("brand_id = ?", brandID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Model(&models.Brand{}).Where("id = ?", brandID).UpdateColumn("followers", count).Error; err != nil {
+		return 0, err
+	}
+	return uint(count), nil
+}
+
+// IsFollowingBrand reports whether a user follows a brand.
+func (r *brandRepository) IsFollowingBrand(brandID, userID uint64) (bool, error) {
+	var count int64
+	if err := r.DB.Model(&models.BrandFollow{}).Where("brand_id = ? AND user_id = ?", brandID, userID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// GetFollowedBrands retrieves the brands a user follows, most recently followed first.
+func (r *brandRepository) GetFollowedBrands(userID uint64) ([]models.Brand, error) {
+	var brands []models.Brand
+	if err := r.DB.
+		Joins("JOIN brand_follows ON brand_follows.brand_id = brands.id AND brand_follows.deleted_at IS NULL").
+		Where("brand_follows.user_id = ?", userID).
+		Order("brand_follows.created_at DESC, brand_follows.id DESC").
+		Find(&brands).Error; err != nil {
+		return nil, err
+	}
+	return brands, nil
+}
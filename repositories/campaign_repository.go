@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"flicknfit_backend/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CampaignRepository defines data access operations for discount campaigns and their targets.
+type CampaignRepository interface {
+	GetAllCampaigns() ([]models.Campaign, error)
+	GetCampaignByID(id uint64) (*models.Campaign, error)
+	GetActiveCampaigns(now time.Time) ([]models.Campaign, error)
+	CreateCampaign(campaign *models.Campaign) error
+	UpdateCampaign(campaign *models.Campaign) error
+	DeleteCampaign(id uint64) error
+}
+
+// campaignRepository is the implementation of CampaignRepository.
+type campaignRepository struct {
+	BaseRepository
+}
+
+// NewCampaignRepository creates and returns a new instance of CampaignRepository.
+func NewCampaignRepository(db *gorm.DB) CampaignRepository {
+	return &campaignRepository{BaseRepository{DB: db}}
+}
+
+// GetAllCampaigns retrieves all campaigns with their targets, newest first.
+func (r *campaignRepository) GetAllCampaigns() ([]models.Campaign, error) {
+	var campaigns []models.Campaign
+	if err := r.DB.Preload("Targets").Order("start_at DESC, id DESC").Find(&campaigns).Error; err != nil {
+		return nil, err
+	}
+	return campaigns, nil
+}
+
+// GetCampaignByID retrieves a campaign with its targets.
+func (r *campaignRepository) GetCampaignByID(id uint64) (*models.Campaign, error) {
+	var campaign models.Campaign
+	if err := r.DB.Preload("Targets").First(&campaign, id).Error; err != nil {
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+// GetActiveCampaigns retrieves enabled campaigns whose time window contains now.
+func (r *campaignRepository) GetActiveCampaigns(now time.Time) ([]models.Campaign, error) {
+	var campaigns []models.Campaign
+	if err := r.DB.Preload("Targets").
+		Where("is_active = ? AND start_at <= ? AND end_at > ?", true, now, now).
+		Find(&campaigns).Error; err != nil {
+		return nil, err
+	}
+	return campaigns, nil
+}
+
+// CreateCampaign creates a campaign together with its targets.
+func (r *campaignRepository) CreateCampaign(campaign *models.Campaign) error {
+	return r.DB.Create(campaign).Error
+}
+
+// UpdateCampaign updates a campaign and replaces its targets in a single transaction.
+func (r *campaignRepository) UpdateCampaign(campaign *models.Campaign) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(campaign).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("campaign_id = ?", campaign.ID).Delete(&models.CampaignTarget{}).Error; err != nil {
+			return err
+		}
+		for i := range campaign.Targets {
+			campaign.Targets[i].ID = 0
+			campaign.Targets[i].CampaignID = campaign.ID
+			if err := tx.Create(&campaign.Targets[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteCampaign deletes a campaign and its targets.
+func (r *campaignRepository) DeleteCampaign(id uint64) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("campaign_id = ?", id).Delete(&models.CampaignTarget{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Campaign{}, id).Error
+	})
+}
@@ -0,0 +1,196 @@
+package repositories
+
+import (
+	"flicknfit_backend/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ClickScope selects the clicks of one brand, or of all brands when BrandID is 0, over [From, To).
+type ClickScope struct {
+	BrandID uint64
+	From    time.Time
+	To      time.Time
+}
+
+// ClickAnalyticsRepository defines the queries behind the click analytics of admins and brands.
+type ClickAnalyticsRepository interface {
+	GetClickTotals(scope ClickScope) (*models.ClickTotals, error)
+	GetDailyClicks(scope ClickScope) ([]models.DailyClicks, error)
+	GetDailyClickUsers(scope ClickScope) ([]models.DailyUser, error)
+	GetClicksByProduct(scope ClickScope, limit int) ([]models.ProductClickCount, error)
+	GetClicksByCategory(scope ClickScope) ([]models.ClickCount, error)
+	GetClicksByUserAgent(scope ClickScope) ([]models.ClickCount, error)
+	GetClicksByRegion(scope ClickScope) ([]models.ClickCount, error)
+	GetClicksByChannel(scope ClickScope) ([]models.ClickCount, error)
+	GetSuspiciousClicks(scope ClickScope) ([]models.ClickCount, error)
+	CountViews(scope ClickScope) (int64, error)
+	CountFavorites(scope ClickScope) (int64, error)
+}
+
+// clickAnalyticsRepository is the implementation of ClickAnalyticsRepository.
+type clickAnalyticsRepository struct {
+	BaseRepository
+}
+
+// NewClickAnalyticsRepository creates and returns a new instance of ClickAnalyticsRepository.
+func NewClickAnalyticsRepository(db *gorm.DB) ClickAnalyticsRepository {
+	return &clickAnalyticsRepository{BaseRepository{DB: db}}
+}
+
+// clicks returns the query of the clicks in scope, leaving out suspicious clicks.
+func (r *clickAnalyticsRepository) clicks(scope ClickScope) *gorm.DB {
+	query := r.DB.Model(&models.ProductClick{}).Scopes(genuineClicks).
+		Where("product_clicks.clicked_at >= ? AND product_clicks.clicked_at < ?", scope.From, scope.To)
+	if scope.BrandID != 0 {
+		query = query.Where("product_clicks.brand_id = ?", scope.BrandID)
+	}
+	return query
+}
+
+// GetClickTotals counts all clicks, anonymous clicks and distinct signed-in users in scope.
+func (r *clickAnalyticsRepository) GetClickTotals(scope ClickScope) (*models.ClickTotals, error) {
+	var totals models.ClickTotals
+	err := r.clicks(scope).
+		Select("COUNT(*) AS clicks, " +
+			"COALESCE(SUM(CASE WHEN product_clicks.user_id IS NULL THEN 1 ELSE 0 END), 0) AS anonymous_clicks, " +
+			"COUNT(DISTINCT product_clicks.user_id) AS unique_users").
+		Scan(&totals).Error
+	return &totals, err
+}
+
+// GetDailyClicks counts the clicks and anonymous clicks per day. Days without clicks are omitted.
+func (r *clickAnalyticsRepository) GetDailyClicks(scope ClickScope) ([]models.DailyClicks, error) {
+	var days []models.DailyClicks
+	if err := r.clicks(scope).
+		Select("DATE(product_clicks.clicked_at) AS day, COUNT(*) AS clicks, " +
+			"COALESCE(SUM(CASE WHEN product_clicks.user_id IS NULL THEN 1 ELSE 0 END), 0) AS anonymous_clicks").
+		Group("DATE(product_clicks.clicked_at)").
+		Order("day").
+		Scan(&days).Error; err != nil {
+		return nil, err
+	}
+	for i := range days {
+		days[i].Day = trimDay(days[i].Day)
+	}
+	return days, nil
+}
+
+// GetDailyClickUsers lists each signed-in user once per day they clicked, so unique users can be counted
+// over weeks and months without counting a returning user twice.
+func (r *clickAnalyticsRepository) GetDailyClickUsers(scope ClickScope) ([]models.DailyUser, error) {
+	var users []models.DailyUser
+	if err := r.clicks(scope).
+		Select("DATE(product_clicks.clicked_at) AS day, product_clicks.user_id AS user_id").
+		Where("product_clicks.user_id IS NOT NULL").
+		Group("DATE(product_clicks.clicked_at), product_clicks.user_id").
+		Scan(&users).Error; err != nil {
+		return nil, err
+	}
+	for i := range users {
+		users[i].Day = trimDay(users[i].Day)
+	}
+	return users, nil
+}
+
+// GetClicksByProduct returns the most clicked products with their distinct signed-in users.
+func (r *clickAnalyticsRepository) GetClicksByProduct(scope ClickScope, limit int) ([]models.ProductClickCount, error) {
+	var products []models.ProductClickCount
+	err := r.clicks(scope).
+		Select("product_clicks.product_id AS product_id, products.name AS product_name, " +
+			"COUNT(*) AS clicks, COUNT(DISTINCT product_clicks.user_id) AS unique_users").
+		Joins("JOIN products ON products.id = product_clicks.product_id").
+		Group("product_clicks.product_id, products.name").
+		Order("clicks DESC").
+		Limit(limit).
+		Scan(&products).Error
+	return products, err
+}
+
+// GetClicksByCategory counts the clicks per product category. A product in several categories counts
+// towards each of them.
+func (r *clickAnalyticsRepository) GetClicksByCategory(scope ClickScope) ([]models.ClickCount, error) {
+	var categories []models.ClickCount
+	err := r.clicks(scope).
+		Select("product_categories.category AS `key`, COUNT(*) AS clicks").
+		Joins("JOIN product_categories ON product_categories.product_id = product_clicks.product_id AND product_categories.deleted_at IS NULL").
+		Group("product_categories.category").
+		Order("clicks DESC").
+		Scan(&categories).Error
+	return categories, err
+}
+
+// GetClicksByUserAgent counts the clicks per User-Agent header, for classifying devices.
+func (r *clickAnalyticsRepository) GetClicksByUserAgent(scope ClickScope) ([]models.ClickCount, error) {
+	var agents []models.ClickCount
+	err := r.clicks(scope).
+		Select("product_clicks.user_agent AS `key`, COUNT(*) AS clicks").
+		Group("product_clicks.user_agent").
+		Scan(&agents).Error
+	return agents, err
+}
+
+// GetClicksByRegion counts the clicks per region. Clicks without a region are grouped under an empty key.
+func (r *clickAnalyticsRepository) GetClicksByRegion(scope ClickScope) ([]models.ClickCount, error) {
+	var regions []models.ClickCount
+	err := r.clicks(scope).
+		Select("COALESCE(product_clicks.region, '') AS `key`, COUNT(*) AS clicks").
+		Group("COALESCE(product_clicks.region, '')").
+		Order("clicks DESC").
+		Scan(&regions).Error
+	return regions, err
+}
+
+// GetClicksByChannel counts the clicks per outbound channel. Clicks recorded without a channel are grouped
+// under an empty key.
+func (r *clickAnalyticsRepository) GetClicksByChannel(scope ClickScope) ([]models.ClickCount, error) {
+	var channels []models.ClickCount
+	err := r.clicks(scope).
+		Select("COALESCE(product_clicks.channel, '') AS `key`, COUNT(*) AS clicks").
+		Group("COALESCE(product_clicks.channel, '')").
+		Order("clicks DESC").
+		Scan(&channels).Error
+	return channels, err
+}
+
+// GetSuspiciousClicks counts the clicks left out of the analytics per suspicion reason.
+func (r *clickAnalyticsRepository) GetSuspiciousClicks(scope ClickScope) ([]models.ClickCount, error) {
+	var reasons []models.ClickCount
+	query := r.DB.Model(&models.ProductClick{}).
+		Select("product_clicks.suspicion_reason AS `key`, COUNT(*) AS clicks").
+		Where("product_clicks.suspicious = ?", true).
+		Where("product_clicks.clicked_at >= ? AND product_clicks.clicked_at < ?", scope.From, scope.To)
+	if scope.BrandID != 0 {
+		query = query.Where("product_clicks.brand_id = ?", scope.BrandID)
+	}
+	err := query.Group("product_clicks.suspicion_reason").Order("clicks DESC").Scan(&reasons).Error
+	return reasons, err
+}
+
+// CountViews counts the debounced product detail views in scope.
+func (r *clickAnalyticsRepository) CountViews(scope ClickScope) (int64, error) {
+	var count int64
+	query := r.DB.Model(&models.ProductView{}).
+		Where("product_views.viewed_at >= ? AND product_views.viewed_at < ?", scope.From, scope.To)
+	if scope.BrandID != 0 {
+		query = query.Where("product_views.brand_id = ?", scope.BrandID)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// CountFavorites counts the favorites added in scope that are still saved.
+func (r *clickAnalyticsRepository) CountFavorites(scope ClickScope) (int64, error) {
+	var count int64
+	query := r.DB.Model(&models.Favorite{}).
+		Where("favorites.created_at >= ? AND favorites.created_at < ?", scope.From, scope.To)
+	if scope.BrandID != 0 {
+		query = query.
+			Joins("JOIN product_items ON product_items.id = favorites.product_item_id").
+			Joins("JOIN products ON products.id = product_items.product_id").
+			Where("products.brand_id = ?", scope.BrandID)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"errors"
+	"flicknfit_backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrDuplicateConversion is returned when a brand reports the same order line twice.
+var ErrDuplicateConversion = errors.New("conversion already recorded")
+
+// ErrDuplicatePostback is returned when a postback with the same idempotency key was already recorded.
+var ErrDuplicatePostback = errors.New("postback already recorded")
+
+// ConversionRepository defines data access operations for confirmed sales and the counters derived from them.
+type ConversionRepository interface {
+	RecordConversion(conversion *models.Conversion) error
+	GetConversionsByBrandID(brandID uint64, limit int) ([]models.Conversion, error)
+	ReconcileCounters() (*models.CounterReconciliation, error)
+	GetPostbackSecret(brandID uint64) (string, error)
+	SetPostbackSecret(brandID uint64, secret string) error
+	GetPostback(brandID uint64, idempotencyKey string) (*models.ConversionPostback, error)
+	RecordPostback(postback *models.ConversionPostback, conversions []*models.Conversion) ([]*models.Conversion, error)
+}
+
+// conversionRepository is the implementation of ConversionRepository.
+type conversionRepository struct {
+	BaseRepository
+}
+
+// NewConversionRepository creates and returns a new instance of ConversionRepository.
+func NewConversionRepository(db *gorm.DB) ConversionRepository {
+	return &conversionRepository{BaseRepository{DB: db}}
+}
+
+// RecordConversion stores a conversion and adds its quantity to the Sold counters of the item and product
+// in one transaction. A conversion with the same brand, item and order reference is rejected by the unique
+// index, so concurrent duplicates are caught too.
+func (r *conversionRepository) RecordConversion(conversion *models.Conversion) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return recordConversion(tx, conversion)
+	})
+}
+
+// recordConversion stores a conversion and updates the Sold counters within the given transaction
+func recordConversion(tx *gorm.DB, conversion *models.Conversion) error {
+	if err := tx.Omit(clause.Associations).Create(conversion).Error; err != nil {
+		if isDuplicateKey(tx, err) {
+			return ErrDuplicateConversion
+		}
+		return err
+	}
+	if err := tx.Unscoped().Model(&models.ProductItem{}).Where("id = ?", conversion.ProductItemID).
+		UpdateColumn("sold", gorm.Expr("sold + ?", conversion.Quantity)).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Model(&models.Product{}).Where("id = ?", conversion.ProductID).
+		UpdateColumn("sold", gorm.Expr("sold + ?", conversion.Quantity)).Error
+}
+
+// GetConversionsByBrandID retrieves the latest conversions of a brand with their product and item.
+func (r *conversionRepository) GetConversionsByBrandID(brandID uint64, limit int) ([]models.Conversion, error) {
+	var conversions []models.Conversion
+	if err := r.DB.Preload("Product").Preload("ProductItem").
+		Where("brand_id = ?", brandID).
+		Order("converted_at DESC, id DESC").
+		Limit(limit).
+		Find(&conversions).Error; err != nil {
+		return nil, err
+	}
+	return conversions, nil
+}
+
+// ReconcileCounters recomputes the Sold counters of items and products from their conversions and the
+// TotalProducts of brands from their published products, and reports how many rows were corrected.
+func (r *conversionRepository) ReconcileCounters() (*models.CounterReconciliation, error) {
+	var result models.CounterReconciliation
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		itemSold := tx.Model(&models.Conversion{}).Select("COALESCE(SUM(conversions.quantity), 0)").
+			Where("conversions.product_item_id = product_items.id")
+		items := tx.Unscoped().Model(&models.ProductItem{}).Where("product_items.sold <> (?)", itemSold).
+			UpdateColumn("sold", itemSold)
+		if items.Error != nil {
+			return items.Error
+		}
+		result.ItemsCorrected = items.RowsAffected
+
+		productSold := tx.Model(&models.Conversion{}).Select("COALESCE(SUM(conversions.quantity), 0)").
+			Where("conversions.product_id = products.id")
+		products := tx.Unscoped().Model(&models.Product{}).Where("products.sold <> (?)", productSold).
+			UpdateColumn("sold", productSold)
+		if products.Error != nil {
+			return products.Error
+		}
+		result.ProductsCorrected = products.RowsAffected
+
+		totalProducts := tx.Model(&models.Product{}).Select("COUNT(*)").Scopes(publishedProducts).
+			Where("products.brand_id = brands.id")
+		brands := tx.Model(&models.Brand{}).Where("brands.total_products <> (?)", totalProducts).
+			UpdateColumn("total_products", totalProducts)
+		if brands.Error != nil {
+			return brands.Error
+		}
+		result.BrandsCorrected = brands.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPostbackSecret retrieves the secret that signs a brand's postbacks; it is empty when none was generated.
+func (r *conversionRepository) GetPostbackSecret(brandID uint64) (string, error) {
+	var brand models.Brand
+	if err := r.DB.Select("id", "postback_secret").First(&brand, brandID).Error; err != nil {
+		return "", err
+	}
+	return brand.PostbackSecret, nil
+}
+
+// SetPostbackSecret replaces the secret that signs a brand's postbacks.
+func (r *conversionRepository) SetPostbackSecret(brandID uint64, secret string) error {
+	return r.DB.Model(&models.Brand{}).Where("id = ?", brandID).UpdateColumn("postback_secret", secret).Error
+}
+
+// GetPostback retrieves a postback a brand already sent under an idempotency key.
+func (r *conversionRepository) GetPostback(brandID uint64, idempotencyKey string) (*models.ConversionPostback, error) {
+	var postback models.ConversionPostback
+	if err := r.DB.Where("brand_id = ? AND idempotency_key = ?", brandID, idempotencyKey).First(&postback).Error; err != nil {
+		return nil, err
+	}
+	return &postback, nil
+}
+
+// RecordPostback records the lines of a postback and, when it has an idempotency key, the postback itself in
+// one transaction, and returns the lines that were new. Lines already recorded for the order are counted in
+// the postback's Duplicates. A key that was already used rolls everything back with ErrDuplicatePostback.
+func (r *conversionRepository) RecordPostback(postback *models.ConversionPostback, conversions []*models.Conversion) ([]*models.Conversion, error) {
+	var recorded []*models.Conversion
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		for _, conversion := range conversions {
+			// A savepoint per line, so a duplicate line does not undo the others
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				return recordConversion(tx, conversion)
+			})
+			if errors.Is(err, ErrDuplicateConversion) {
+				postback.Duplicates++
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			postback.Recorded++
+			recorded = append(recorded, conversion)
+		}
+		if postback.IdempotencyKey == "" {
+			return nil
+		}
+		if err := tx.Create(postback).Error; err != nil {
+			if isDuplicateKey(tx, err) {
+				return ErrDuplicatePostback
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recorded, nil
+}
@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"flicknfit_backend/constants"
+	"flicknfit_backend/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LinkCheckRepository defines data access operations for the health checks of stored marketplace links.
+type LinkCheckRepository interface {
+	GetLinkTargets() ([]models.LinkCheck, error)
+	GetLinkChecks() ([]models.LinkCheck, error)
+	SaveLinkChecks(checks []models.LinkCheck, runStartedAt time.Time) error
+	GetBrokenURLs(productID, brandID uint64) ([]string, error)
+	GetBrokenLinks(limit, offset int) ([]models.LinkCheck, int64, error)
+}
+
+// linkCheckRepository is the implementation of LinkCheckRepository.
+type linkCheckRepository struct {
+	BaseRepository
+}
+
+// NewLinkCheckRepository creates and returns a new instance of LinkCheckRepository.
+func NewLinkCheckRepository(db *gorm.DB) LinkCheckRepository {
+	return &linkCheckRepository{BaseRepository{DB: db}}
+}
+
+// GetLinkTargets lists every non-empty link stored on products and brands as unchecked link checks.
+func (r *linkCheckRepository) GetLinkTargets() ([]models.LinkCheck, error) {
+	var products []models.Product
+	if err := r.DB.Select("id", "name", "brand_product_url", "instagram_product_url", "tokopedia_product_url", "shopee_product_url").
+		Find(&products).Error; err != nil {
+		return nil, err
+	}
+	var brands []models.Brand
+	if err := r.DB.Select("id", "name", "website_url", "instagram_url", "tokopedia_url", "shopee_url").
+		Find(&brands).Error; err != nil {
+		return nil, err
+	}
+
+	var targets []models.LinkCheck
+	add := func(entityType string, entityID uint64, name, field, url string) {
+		if url != "" {
+			targets = append(targets, models.LinkCheck{EntityType: entityType, EntityID: entityID, EntityName: name, Field: field, URL: url})
+		}
+	}
+	for _, p := range products {
+		add(constants.LinkEntityProduct, p.ID, p.Name, "brand_product_url", p.BrandProductURL)
+		add(constants.LinkEntityProduct, p.ID, p.Name, "instagram_product_url", p.InstagramProductURL)
+		add(constants.LinkEntityProduct, p.ID, p.Name, "tokopedia_product_url", p.TokopediaProductURL)
+		add(constants.LinkEntityProduct, p.ID, p.Name, "shopee_product_url", p.ShopeeProductURL)
+	}
+	for _, b := range brands {
+		add(constants.LinkEntityBrand, b.ID, b.Name, "website_url", b.WebsiteURL)
+		add(constants.LinkEntityBrand, b.ID, b.Name, "instagram_url", b.InstagramURL)
+		add(constants.LinkEntityBrand, b.ID, b.Name, "tokopedia_url", b.TokopediaURL)
+		add(constants.LinkEntityBrand, b.ID, b.Name, "shopee_url", b.ShopeeURL)
+	}
+	return targets, nil
+}
+
+// GetLinkChecks retrieves the latest check of every link.
+func (r *linkCheckRepository) GetLinkChecks() ([]models.LinkCheck, error) {
+	var checks []models.LinkCheck
+	if err := r.DB.Find(&checks).Error; err != nil {
+		return nil, err
+	}
+	return checks, nil
+}
+
+// SaveLinkChecks stores the results of a checker run in one transaction and removes the checks of links
+// that were not part of the run because they have since been cleared or their owner deleted.
+func (r *linkCheckRepository) SaveLinkChecks(checks []models.LinkCheck, runStartedAt time.Time) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if len(checks) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "entity_type"}, {Name: "entity_id"}, {Name: "field"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"entity_name", "url", "status_code", "error", "consecutive_failures", "broken",
+					"failing_since", "checked_at", "updated_at",
+				}),
+			}).CreateInBatches(checks, 200).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Where("checked_at < ?", runStartedAt).Delete(&models.LinkCheck{}).Error
+	})
+}
+
+// GetBrokenURLs retrieves the broken links of a product and its brand.
+func (r *linkCheckRepository) GetBrokenURLs(productID, brandID uint64) ([]string, error) {
+	var urls []string
+	if err := r.DB.Model(&models.LinkCheck{}).
+		Where("broken = ?", true).
+		Where("(entity_type = ? AND entity_id = ?) OR (entity_type = ? AND entity_id = ?)",
+			constants.LinkEntityProduct, productID, constants.LinkEntityBrand, brandID).
+		Pluck("url", &urls).Error; err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
+// GetBrokenLinks retrieves a page of broken links, longest failing first, with the total number of broken links.
+func (r *linkCheckRepository) GetBrokenLinks(limit, offset int) ([]models.LinkCheck, int64, error) {
+	query := r.DB.Model(&models.LinkCheck{}).Where("broken = ?", true)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var checks []models.LinkCheck
+	if err := query.Order("failing_since ASC, id ASC").Limit(limit).Offset(offset).Find(&checks).Error; err != nil {
+		return nil, 0, err
+	}
+	return checks, total, nil
+}
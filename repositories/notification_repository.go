@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"flicknfit_backend/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationRepository defines data access operations for in-app notifications.
+type NotificationRepository interface {
+	CreateNotifications(notifications []models.Notification) error
+	GetNotifiedUserIDs(userIDs []uint64, dedupKey string, since time.Time) ([]uint64, error)
+	GetNotificationsByUserID(userID uint64, unreadOnly bool, limit, offset int) ([]models.Notification, int64, error)
+	CountUnread(userID uint64) (int64, error)
+	MarkAsRead(userID, notificationID uint64, readAt time.Time) error
+	MarkAllAsRead(userID uint64, readAt time.Time) error
+}
+
+// notificationRepository is the implementation of NotificationRepository.
+type notificationRepository struct {
+	BaseRepository
+}
+
+// NewNotificationRepository creates and returns a new instance of NotificationRepository.
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{BaseRepository{DB: db}}
+}
+
+// CreateNotifications inserts a batch of notifications.
+func (r *notificationRepository) CreateNotifications(notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return r.DB.Omit("User").Create(&notifications).Error
+}
+
+// GetNotifiedUserIDs returns which of the given users already received a notification with the dedup key since the given time.
+func (r *notificationRepository) GetNotifiedUserIDs(userIDs []uint64, dedupKey string, since time.Time) ([]uint64, error) {
+	var notified []uint64
+	if len(userIDs) == 0 {
+		return notified, nil
+	}
+	if err := r.DB.Model(&models.Notification{}).
+		Where("user_id IN ? AND dedup_key = ? AND created_at >= ?", userIDs, dedupKey, since).
+		Distinct().
+		Pluck("user_id", &notified).Error; err != nil {
+		return nil, err
+	}
+	return notified, nil
+}
+
+// GetNotificationsByUserID retrieves a page of a user's notifications, newest first, with the total count.
+func (r *notificationRepository) GetNotificationsByUserID(userID uint64, unreadOnly bool, limit, offset int) ([]models.Notification, int64, error) {
+	query := r.DB.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notifications []models.Notification
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&notifications).Error; err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+// CountUnread counts a user's unread notifications.
+func (r *notificationRepository) CountUnread(userID uint64) (int64, error) {
+	var count int64
+	if err := r.DB.Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// MarkAsRead marks one of the user's notifications as read; already read notifications keep their read time.
+func (r *notificationRepository) MarkAsRead(userID, notificationID uint64, readAt time.Time) error {
+	var notification models.Notification
+	if err := r.DB.Where("id = ? AND user_id = ?", notificationID, userID).First(&notification).Error; err != nil {
+		return err
+	}
+	if notification.ReadAt != nil {
+		return nil
+	}
+	return r.DB.Model(&notification).Update("read_at", readAt).Error
+}
+
+// MarkAllAsRead marks all of the user's unread notifications as read.
+func (r *notificationRepository) MarkAllAsRead(userID uint64, readAt time.Time) error {
+	return r.DB.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt).Error
+}
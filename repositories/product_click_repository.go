@@ -10,7 +10,9 @@ import (
 // ProductClickRepository handles database operations for product clicks
 type ProductClickRepository interface {
 	Create(click *models.ProductClick) error
+	CreateBatch(clicks []models.ProductClick) error
 	GetByID(id uint64) (*models.ProductClick, error)
+	GetByRef(ref string) (*models.ProductClick, error)
 	GetByUserID(userID uint64, limit int) ([]models.ProductClick, error)
 	GetByProductID(productID uint64, limit int) ([]models.ProductClick, error)
 	GetByBrandID(brandID uint64, limit int) ([]models.ProductClick, error)
@@ -19,6 +21,10 @@ type ProductClickRepository interface {
 	CountByBrandIDAndDateRange(brandID uint64, startDate, endDate time.Time) (int64, error)
 	GetTopClickedProducts(limit int) ([]map[string]interface{}, error)
 	GetClickStatsByBrand(brandID uint64, startDate, endDate time.Time) ([]map[string]interface{}, error)
+	CountRecentDuplicates(click *models.ProductClick, since time.Time) (int64, error)
+	CountRecentByIP(ipAddress string, since time.Time) (int64, error)
+	AnonymizeClicksBefore(cutoff time.Time, limit int) (int64, error)
+	DeleteClicksBefore(cutoff time.Time, limit int) (int64, error)
 }
 
 type productClickRepository struct {
@@ -30,10 +36,23 @@ func NewProductClickRepository(db *gorm.DB) ProductClickRepository {
 	return &productClickRepository{db: db}
 }
 
+// genuineClicks leaves clicks flagged as suspicious out of aggregates.
+func genuineClicks(db *gorm.DB) *gorm.DB {
+	return db.Where("product_clicks.suspicious = ?", false)
+}
+
 func (r *productClickRepository) Create(click *models.ProductClick) error {
 	return r.db.Create(click).Error
 }
 
+// CreateBatch inserts clicks with multi-row inserts.
+func (r *productClickRepository) CreateBatch(clicks []models.ProductClick) error {
+	if len(clicks) == 0 {
+		return nil
+	}
+	return r.db.CreateInBatches(&clicks, 100).Error
+}
+
 func (r *productClickRepository) GetByID(id uint64) (*models.ProductClick, error) {
 	var click models.ProductClick
 	err := r.db.Preload("User").Preload("Product").Preload("Brand").
@@ -41,6 +60,13 @@ func (r *productClickRepository) GetByID(id uint64) (*models.ProductClick, error
 	return &click, err
 }
 
+// GetByRef retrieves a click by the reference signed into its click ID.
+func (r *productClickRepository) GetByRef(ref string) (*models.ProductClick, error) {
+	var click models.ProductClick
+	err := r.db.Where("ref = ?", ref).First(&click).Error
+	return &click, err
+}
+
 func (r *productClickRepository) GetByUserID(userID uint64, limit int) ([]models.ProductClick, error) {
 	var clicks []models.ProductClick
 	query := r.db.Where("user_id = ?", userID).
@@ -85,7 +111,7 @@ func (r *productClickRepository) GetByBrandID(brandID uint64, limit int) ([]mode
 
 func (r *productClickRepository) CountByProductID(productID uint64) (int64, error) {
 	var count int64
-	err := r.db.Model(&models.ProductClick{}).
+	err := r.db.Model(&models.ProductClick{}).Scopes(genuineClicks).
 		Where("product_id = ?", productID).
 		Count(&count).Error
 	return count, err
@@ -93,7 +119,7 @@ func (r *productClickRepository) CountByProductID(productID uint64) (int64, erro
 
 func (r *productClickRepository) CountByBrandID(brandID uint64) (int64, error) {
 	var count int64
-	err := r.db.Model(&models.ProductClick{}).
+	err := r.db.Model(&models.ProductClick{}).Scopes(genuineClicks).
 		Where("brand_id = ?", brandID).
 		Count(&count).Error
 	return count, err
@@ -101,7 +127,7 @@ func (r *productClickRepository) CountByBrandID(brandID uint64) (int64, error) {
 
 func (r *productClickRepository) CountByBrandIDAndDateRange(brandID uint64, startDate, endDate time.Time) (int64, error) {
 	var count int64
-	err := r.db.Model(&models.ProductClick{}).
+	err := r.db.Model(&models.ProductClick{}).Scopes(genuineClicks).
 		Where("brand_id = ? AND clicked_at BETWEEN ? AND ?", brandID, startDate, endDate).
 		Count(&count).Error
 	return count, err
@@ -111,7 +137,7 @@ func (r *productClickRepository) CountByBrandIDAndDateRange(brandID uint64, star
 func (r *productClickRepository) GetTopClickedProducts(limit int) ([]map[string]interface{}, error) {
 	var results []map[string]interface{}
 
-	err := r.db.Model(&models.ProductClick{}).
+	err := r.db.Model(&models.ProductClick{}).Scopes(genuineClicks).
 		Select("product_id, COUNT(*) as click_count").
 		Group("product_id").
 		Order("click_count DESC").
@@ -125,7 +151,7 @@ func (r *productClickRepository) GetTopClickedProducts(limit int) ([]map[string]
 func (r *productClickRepository) GetClickStatsByBrand(brandID uint64, startDate, endDate time.Time) ([]map[string]interface{}, error) {
 	var results []map[string]interface{}
 
-	err := r.db.Model(&models.ProductClick{}).
+	err := r.db.Model(&models.ProductClick{}).Scopes(genuineClicks).
 		Select("product_id, COUNT(*) as click_count, COUNT(DISTINCT user_id) as unique_users").
 		Where("brand_id = ? AND clicked_at BETWEEN ? AND ?", brandID, startDate, endDate).
 		Group("product_id").
@@ -134,3 +160,63 @@ func (r *productClickRepository) GetClickStatsByBrand(brandID uint64, startDate,
 
 	return results, err
 }
+
+// CountRecentDuplicates counts the earlier clicks on the same product since the given time by the same user,
+// or for anonymous clicks by the same IP address and user agent.
+func (r *productClickRepository) CountRecentDuplicates(click *models.ProductClick, since time.Time) (int64, error) {
+	var count int64
+	query := r.db.Model(&models.ProductClick{}).
+		Where("product_id = ? AND clicked_at >= ?", click.ProductID, since)
+	if click.UserID != nil {
+		query = query.Where("user_id = ?", *click.UserID)
+	} else {
+		query = query.Where("user_id IS NULL AND ip_address = ? AND user_agent = ?", click.IPAddress, click.UserAgent)
+	}
+	err := query.Count(&count).Error
+	return count, err
+}
+
+// CountRecentByIP counts all clicks from an IP address since the given time, including suspicious ones.
+func (r *productClickRepository) CountRecentByIP(ipAddress string, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.ProductClick{}).
+		Where("ip_address = ? AND clicked_at >= ?", ipAddress, since).
+		Count(&count).Error
+	return count, err
+}
+
+// AnonymizeClicksBefore clears the IP address and user of up to limit clicks made before cutoff that still
+// hold either, and returns the number of clicks anonymised.
+func (r *productClickRepository) AnonymizeClicksBefore(cutoff time.Time, limit int) (int64, error) {
+	var ids []uint64
+	if err := r.db.Model(&models.ProductClick{}).
+		Where("clicked_at < ? AND (ip_address <> '' OR user_id IS NOT NULL)", cutoff).
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.db.Model(&models.ProductClick{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{"ip_address": "", "user_id": nil})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteClicksBefore permanently deletes up to limit clicks made before cutoff, including soft-deleted ones,
+// and returns the number of clicks deleted.
+func (r *productClickRepository) DeleteClicksBefore(cutoff time.Time, limit int) (int64, error) {
+	var ids []uint64
+	if err := r.db.Unscoped().Model(&models.ProductClick{}).
+		Where("clicked_at < ?", cutoff).
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.db.Unscoped().Where("id IN ?", ids).Delete(&models.ProductClick{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"flicknfit_backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ProductMediaRepository defines the interface for product gallery data operations.
+type ProductMediaRepository interface {
+	GetMediaByProductID(productID uint64) ([]models.ProductMedia, error)
+	GetMediaByID(id uint64) (*models.ProductMedia, error)
+	CreateMedia(media *models.ProductMedia) error
+	UpdateMedia(media *models.ProductMedia) error
+	DeleteMedia(id uint64) error
+	UpdateMediaOrder(productID uint64, orderedMediaIDs []uint64) error
+}
+
+// productMediaRepository is the implementation of ProductMediaRepository.
+type productMediaRepository struct {
+	BaseRepository
+}
+
+// NewProductMediaRepository creates and returns a new instance of ProductMediaRepository.
+func NewProductMediaRepository(db *gorm.DB) ProductMediaRepository {
+	return &productMediaRepository{BaseRepository{DB: db}}
+}
+
+// orderedMedia orders gallery media for display.
+func orderedMedia(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order ASC, id ASC")
+}
+
+// mediaScope restricts a query to media of the same product and item (nil item = product-level media).
+func mediaScope(productID uint64, productItemID *uint64) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if productItemID == nil {
+			return db.Where("product_id = ? AND product_item_id IS NULL", productID)
+		}
+		return db.Where("product_id = ? AND product_item_id = ?", productID, *productItemID)
+	}
+}
+
+// GetMediaByProductID retrieves all media of a product, including item media, in display order.
+func (r *productMediaRepository) GetMediaByProductID(productID uint64) ([]models.ProductMedia, error) {
+	var media []models.ProductMedia
+	if err := r.DB.Scopes(orderedMedia).Where("product_id = ?", productID).Find(&media).Error; err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// GetMediaByID retrieves a single media record by ID.
+func (r *productMediaRepository) GetMediaByID(id uint64) (*models.ProductMedia, error) {
+	var media models.ProductMedia
+	if err := r.DB.First(&media, id).Error; err != nil {
+		return nil, err
+	}
+	return &media, nil
+}
+
+// CreateMedia creates a media record. A primary media replaces the previous primary of the same product or item.
+func (r *productMediaRepository) CreateMedia(media *models.ProductMedia) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := clearPrimaryMedia(tx, media); err != nil {
+			return err
+		}
+		return tx.Omit("Product", "ProductItem").Create(media).Error
+	})
+}
+
+// UpdateMedia updates a media record. A primary media replaces the previous primary of the same product or item.
+func (r *productMediaRepository) UpdateMedia(media *models.ProductMedia) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := clearPrimaryMedia(tx, media); err != nil {
+			return err
+		}
+		return tx.Omit("Product", "ProductItem").Save(media).Error
+	})
+}
+
+// clearPrimaryMedia unsets the primary flag of other media in the same scope when media is primary.
+func clearPrimaryMedia(tx *gorm.DB, media *models.ProductMedia) error {
+	if !media.IsPrimary {
+		return nil
+	}
+	return tx.Model(&models.ProductMedia{}).
+		Scopes(mediaScope(media.ProductID, media.ProductItemID)).
+		Where("id <> ? AND is_primary = ?", media.ID, true).
+		Update("is_primary", false).Error
+}
+
+// DeleteMedia deletes a media record.
+func (r *productMediaRepository) DeleteMedia(id uint64) error {
+	return r.DB.Delete(&models.ProductMedia{}, id).Error
+}
+
+// UpdateMediaOrder sets the sort order of a product's media following the given ID order.
+func (r *productMediaRepository) UpdateMediaOrder(productID uint64, orderedMediaIDs []uint64) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		for i, mediaID := range orderedMediaIDs {
+			if err := tx.Model(&models.ProductMedia{}).
+				Where("id = ? AND product_id = ?", mediaID, productID).
+				Update("sort_order", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -1,10 +1,14 @@
 package repositories
 
 import (
+	"flicknfit_backend/constants"
 	"flicknfit_backend/dtos"
 	"flicknfit_backend/models"
+	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ProductRepository mendefinisikan antarmuka untuk operasi akses data pada model Product.
@@ -14,7 +18,9 @@ type ProductRepository interface {
 	UpdateProduct(product *models.Product) error
 	DeleteProduct(id uint64) error
 	GetProductByID(id uint64) (*models.Product, error)
-	GetAllProducts() ([]*models.Product, error)
+	GetAllProducts(status string) ([]*models.Product, error)
+	UpdateProductStatus(product *models.Product) error
+	PublishDueProducts(now time.Time) (int64, error)
 	CreateReview(review *models.Review) error
 	GetReviewsByProductID(productID uint64) ([]*models.Review, error)
 	UpdateReview(review *models.Review) error
@@ -22,9 +28,26 @@ type ProductRepository interface {
 	GetReviewByID(reviewID uint64) (*models.Review, error)
 	GetProductItemByID(id uint64) (*models.ProductItem, error)
 
+	// Metode untuk editor produk lengkap (produk + item + variasi + kategori + style)
+	GetProductDetailsByID(id uint64) (*models.Product, error)
+	SaveProductWithDetails(product *models.Product, actorID *uint64) ([]models.StockMovement, error)
+	GetProductItemsBySKUs(skus []string) ([]models.ProductItem, error)
+	GetVariationOptionsByIDs(ids []uint64) ([]models.ProductVariationOption, error)
+
+	// Metode untuk impor/ekspor produk massal
+	GetAllProductDetails() ([]*models.Product, error)
+	GetProductByBrandAndName(brandID uint64, name string) (*models.Product, error)
+	SaveProductsWithDetails(products []*models.Product, actorID *uint64) ([]models.StockMovement, error)
+
+	// Metode untuk riwayat harga
+	GetPriceHistory(productID uint64, since time.Time) ([]models.PriceHistory, error)
+
 	// Metode untuk user biasa
 	GetProductPublicByID(id uint64) (*models.Product, error)
 	GetAllProductsPublic() ([]*models.Product, error)
+	GetProductsPublicByIDs(ids []uint64) ([]*models.Product, error)
+	GetPublishedProductByID(id uint64) (*models.Product, error)
+	GetPublishedProductItemByID(id uint64) (*models.ProductItem, error)
 
 	// Metode baru untuk pencarian produk.
 	// SearchProducts mencari produk berdasarkan nama atau deskripsi.
@@ -43,19 +66,73 @@ func NewProductRepository(db *gorm.DB) ProductRepository {
 	return &productRepository{BaseRepository: BaseRepository{DB: db}}
 }
 
-// CreateProduct membuat produk baru di database.
+// CreateProduct membuat produk baru di database dan memperbarui jumlah produk brand-nya.
 func (r *productRepository) CreateProduct(product *models.Product) error {
-	return r.DB.Create(product).Error
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(product).Error; err != nil {
+			return err
+		}
+		return syncBrandTotalProducts(tx, product.BrandID)
+	})
 }
 
 // UpdateProduct memperbarui produk yang sudah ada.
 func (r *productRepository) UpdateProduct(product *models.Product) error {
-	return r.DB.Save(product).Error
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return updateProductKeepingAggregates(tx, product)
+	})
 }
 
-// DeleteProduct menghapus produk dari database.
+// DeleteProduct menghapus produk dari database dan menghitung ulang rating serta jumlah produk brand-nya.
 func (r *productRepository) DeleteProduct(id uint64) error {
-	return r.DB.Delete(&models.Product{}, id).Error
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var product models.Product
+		if err := tx.Select("id", "brand_id").First(&product, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&models.Product{}, id).Error; err != nil {
+			return err
+		}
+		if err := syncBrandRating(tx, product.BrandID); err != nil {
+			return err
+		}
+		return syncBrandTotalProducts(tx, product.BrandID)
+	})
+}
+
+// updateProductKeepingAggregates menyimpan kolom produk tanpa menimpa agregat rating, jumlah terjual dan
+// jumlah dilihat yang dihitung dari ulasan, konversi dan tampilan produk. Jumlah produk brand dihitung ulang karena status bisa berubah;
+// jika produk pindah brand, rating dan jumlah produk kedua brand dihitung ulang.
+func updateProductKeepingAggregates(tx *gorm.DB, product *models.Product) error {
+	var stored models.Product
+	if err := tx.Select("id", "brand_id").First(&stored, product.ID).Error; err != nil {
+		return err
+	}
+	if err := tx.Omit(clause.Associations, "Rating", "Reviewer", "Sold", "Views").Save(product).Error; err != nil {
+		return err
+	}
+	if err := syncBrandTotalProducts(tx, product.BrandID); err != nil {
+		return err
+	}
+	if stored.BrandID == product.BrandID {
+		return nil
+	}
+	if err := syncBrandRating(tx, stored.BrandID); err != nil {
+		return err
+	}
+	if err := syncBrandRating(tx, product.BrandID); err != nil {
+		return err
+	}
+	return syncBrandTotalProducts(tx, stored.BrandID)
+}
+
+// syncBrandTotalProducts menghitung ulang TotalProducts brand dari produknya yang tampil di publik.
+func syncBrandTotalProducts(tx *gorm.DB, brandID uint64) error {
+	var total int64
+	if err := tx.Model(&models.Product{}).Scopes(publishedProducts).Where("products.brand_id = ?", brandID).Count(&total).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.Brand{}).Where("id = ?", brandID).UpdateColumn("total_products", total).Error
 }
 
 // GetProductByID mengambil produk berdasarkan ID-nya, memuat juga variasi dan konfigurasinya.
@@ -70,16 +147,73 @@ func (r *productRepository) GetProductByID(id uint64) (*models.Product, error) {
 }
 
 // GetAllProducts mengambil semua produk, memuat juga variasi dan konfigurasinya.
-func (r *productRepository) GetAllProducts() ([]*models.Product, error) {
+// Jika status tidak kosong, hanya produk dengan status tersebut yang dikembalikan.
+func (r *productRepository) GetAllProducts(status string) ([]*models.Product, error) {
 	var products []*models.Product
-	if err := r.DB.
-		Preload("ProductItems.Configurations.ProductVariationOption").
-		Find(&products).Error; err != nil {
+	tx := r.DB.Preload("ProductItems.Configurations.ProductVariationOption")
+	if status != "" {
+		tx = tx.Where("status = ?", status)
+	}
+	if err := tx.Find(&products).Error; err != nil {
 		return nil, err
 	}
 	return products, nil
 }
 
+// publishedProducts membatasi query hanya pada produk yang boleh tampil di publik:
+// produk active, atau produk scheduled yang jadwal publikasinya sudah lewat.
+func publishedProducts(db *gorm.DB) *gorm.DB {
+	return db.Where("(products.status = ? OR (products.status = ? AND products.publish_at <= ?))",
+		constants.ProductStatusActive, constants.ProductStatusScheduled, time.Now())
+}
+
+// UpdateProductStatus hanya menyimpan kolom status dan stempel waktu publikasi produk,
+// lalu menghitung ulang jumlah produk brand-nya.
+func (r *productRepository) UpdateProductStatus(product *models.Product) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(product).
+			Select("status", "publish_at", "published_at", "unpublished_at").
+			Updates(product).Error; err != nil {
+			return err
+		}
+		var stored models.Product
+		if err := tx.Select("id", "brand_id").First(&stored, product.ID).Error; err != nil {
+			return err
+		}
+		return syncBrandTotalProducts(tx, stored.BrandID)
+	})
+}
+
+// PublishDueProducts mengaktifkan semua produk scheduled yang jadwal publikasinya sudah lewat
+// dan menghitung ulang jumlah produk brand yang terdampak.
+func (r *productRepository) PublishDueProducts(now time.Time) (int64, error) {
+	var published int64
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		due := func() *gorm.DB {
+			return tx.Model(&models.Product{}).Where("status = ? AND publish_at <= ?", constants.ProductStatusScheduled, now)
+		}
+		var brandIDs []uint64
+		if err := due().Distinct().Pluck("brand_id", &brandIDs).Error; err != nil {
+			return err
+		}
+		result := due().Updates(map[string]interface{}{
+			"status":       constants.ProductStatusActive,
+			"published_at": gorm.Expr("publish_at"),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		published = result.RowsAffected
+		for _, brandID := range brandIDs {
+			if err := syncBrandTotalProducts(tx, brandID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return published, err
+}
+
 // GetProductPublicByID mengambil produk berdasarkan ID-nya untuk pengguna publik, memuat variasi, gambar, dan review.
 func (r *productRepository) GetProductPublicByID(id uint64) (*models.Product, error) {
 	var product models.Product
@@ -89,6 +223,8 @@ func (r *productRepository) GetProductPublicByID(id uint64) (*models.Product, er
 		Preload("ProductStyles").
 		Preload("ProductItems.Configurations.ProductVariationOption.ProductVariation").
 		Preload("Reviews").
+		Preload("Media", orderedMedia).
+		Scopes(publishedProducts).
 		First(&product, id).Error; err != nil {
 		return nil, err
 	}
@@ -107,15 +243,41 @@ func (r *productRepository) GetAllProductsPublic() ([]*models.Product, error) {
 		}).
 		Preload("ProductItems.Configurations.ProductVariationOption.ProductVariation").
 		Preload("Reviews").
+		Scopes(publishedProducts).
+		Find(&products).Error; err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// GetProductsPublicByIDs mengambil produk publik berdasarkan daftar ID beserta kategori dan item-nya.
+// Produk yang tidak dipublikasikan atau sudah dihapus dilewati; urutan hasil tidak dijamin.
+func (r *productRepository) GetProductsPublicByIDs(ids []uint64) ([]*models.Product, error) {
+	var products []*models.Product
+	if len(ids) == 0 {
+		return products, nil
+	}
+	if err := r.DB.
+		Preload("ProductCategories"). // Dibutuhkan untuk kampanye berbasis kategori
+		Preload("ProductItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("price ASC") // Order by price for preview image
+		}).
+		Scopes(publishedProducts).
+		Where("products.id IN ?", ids).
 		Find(&products).Error; err != nil {
 		return nil, err
 	}
 	return products, nil
 }
 
-// CreateReview membuat review baru di database.
+// CreateReview membuat review baru di database dan memperbarui agregat rating produk dan brand.
 func (r *productRepository) CreateReview(review *models.Review) error {
-	return r.DB.Create(review).Error
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User", "Product").Create(review).Error; err != nil {
+			return err
+		}
+		return syncRatingAggregates(tx, review.ProductID)
+	})
 }
 
 // GetReviewsByProductID mengambil semua review untuk suatu produk.
@@ -127,14 +289,21 @@ func (r *productRepository) GetReviewsByProductID(productID uint64) ([]*models.R
 	return reviews, nil
 }
 
-// UpdateReview memperbarui review yang sudah ada.
+// UpdateReview memperbarui review yang sudah ada dan memperbarui agregat rating produk dan brand.
 func (r *productRepository) UpdateReview(review *models.Review) error {
-	return r.DB.Save(review).Error
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User", "Product").Save(review).Error; err != nil {
+			return err
+		}
+		return syncRatingAggregates(tx, review.ProductID)
+	})
 }
 
-// DeleteReview menghapus review dari database.
+// DeleteReview menghapus review dari database dan memperbarui agregat rating produk dan brand.
 func (r *productRepository) DeleteReview(reviewID uint64) error {
-	return r.DB.Delete(&models.Review{}, reviewID).Error
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return deleteReviewAndSync(tx, reviewID)
+	})
 }
 
 // GetReviewByID mengambil review berdasarkan ID-nya.
@@ -155,6 +324,29 @@ func (r *productRepository) GetProductItemByID(id uint64) (*models.ProductItem,
 	return &item, nil
 }
 
+// GetPublishedProductByID mengambil produk berdasarkan ID-nya hanya jika produk sudah dipublikasikan.
+// Dipakai oleh alur user (ulasan) agar produk draft atau terjadwal tidak bisa diakses.
+func (r *productRepository) GetPublishedProductByID(id uint64) (*models.Product, error) {
+	var product models.Product
+	if err := r.DB.Preload("ProductItems.Configurations.ProductVariationOption").Scopes(publishedProducts).First(&product, id).Error; err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// GetPublishedProductItemByID mengambil item produk berdasarkan ID-nya hanya jika produk induknya sudah dipublikasikan.
+// Dipakai oleh alur user (favorit dan saved items).
+func (r *productRepository) GetPublishedProductItemByID(id uint64) (*models.ProductItem, error) {
+	var item models.ProductItem
+	if err := r.DB.Preload("Product").
+		Joins("JOIN products ON products.id = product_items.product_id AND products.deleted_at IS NULL").
+		Scopes(publishedProducts).
+		First(&item, "product_items.id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
 // SearchProducts mengimplementasikan pencarian produk berdasarkan nama atau deskripsi.
 func (r *productRepository) SearchProducts(query string) ([]*models.Product, error) {
 	var products []*models.Product
@@ -162,9 +354,12 @@ func (r *productRepository) SearchProducts(query string) ([]*models.Product, err
 	// Menggunakan `Where` dengan `OR` untuk mencari kecocokan pada nama atau deskripsi.
 	// `Preload` digunakan untuk memuat data terkait (product items).
 	if err := r.DB.
+		Preload("ProductCategories").
 		Preload("ProductItems.Configurations.ProductVariationOption").
 		Preload("Reviews").
-		Where("name ILIKE ? OR description ILIKE ?", searchTerm, searchTerm).Preload("ProductItems").Find(&products).Error; err != nil {
+		Where("name ILIKE ? OR description ILIKE ?", searchTerm, searchTerm).
+		Scopes(publishedProducts).
+		Preload("ProductItems").Find(&products).Error; err != nil {
 		return nil, err
 	}
 	return products, nil
@@ -173,13 +368,14 @@ func (r *productRepository) SearchProducts(query string) ([]*models.Product, err
 // GetAllProductsPublicWithFilter mengambil semua produk publik dengan filter.
 func (r *productRepository) GetAllProductsPublicWithFilter(filter *dtos.ProductFilterRequestDTO) ([]*models.Product, error) {
 	var products []*models.Product
-	tx := r.DB.Model(&models.Product{})
+	tx := r.DB.Model(&models.Product{}).Scopes(publishedProducts)
 
 	tx.
 		Preload("ProductItems", func(db *gorm.DB) *gorm.DB {
 			return db.Order("price ASC") // Order by price for preview image
 		}).
 		Preload("ProductItems.Configurations.ProductVariationOption").
+		Preload("ProductCategories").
 		Preload("Reviews")
 
 	// Filter berdasarkan nama produk (case-insensitive for MySQL)
@@ -221,8 +417,263 @@ func (r *productRepository) GetAllProductsPublicWithFilter(filter *dtos.ProductF
 	// Menghindari duplikat jika menggunakan Joins
 	tx = tx.Group("products.id")
 
+	// Urutan hasil; best_selling memakai jumlah terjual yang dihitung dari konversi,
+	// popular menggabungkan jumlah dilihat dengan jumlah terjual
+	switch filter.Sort {
+	case constants.ProductSortBestSelling:
+		tx = tx.Order("products.sold DESC, products.id DESC")
+	case constants.ProductSortRating:
+		tx = tx.Order("products.rating DESC, products.reviewer DESC, products.id DESC")
+	case constants.ProductSortNewest:
+		tx = tx.Order("products.created_at DESC, products.id DESC")
+	case constants.ProductSortPopular:
+		tx = tx.Order(fmt.Sprintf("products.views + products.sold * %d DESC, products.id DESC", constants.PopularitySoldWeight))
+	}
+	if filter.Limit > 0 {
+		tx = tx.Limit(filter.Limit)
+	}
+
 	if err := tx.Find(&products).Error; err != nil {
 		return nil, err
 	}
 	return products, nil
 }
+
+// GetProductDetailsByID mengambil produk beserta kategori, style, item, dan konfigurasi variasinya untuk editor admin.
+func (r *productRepository) GetProductDetailsByID(id uint64) (*models.Product, error) {
+	var product models.Product
+	if err := r.DB.
+		Preload("ProductCategories").
+		Preload("ProductStyles").
+		Preload("ProductItems.Configurations.ProductVariationOption.ProductVariation").
+		Preload("Reviews").
+		First(&product, id).Error; err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// SaveProductWithDetails menyimpan produk beserta kategori, style, item, dan konfigurasi variasinya dalam satu transaksi.
+// Kategori, style, dan konfigurasi diganti seluruhnya. Item dengan ID diperbarui, item tanpa ID dibuat,
+// dan item lama yang tidak lagi ada di produk akan di-soft delete. Perubahan stok dicatat ke ledger atas nama actorID
+// (nil untuk perubahan oleh sistem) dan dikembalikan setelah transaksi berhasil.
+func (r *productRepository) SaveProductWithDetails(product *models.Product, actorID *uint64) ([]models.StockMovement, error) {
+	var movements []models.StockMovement
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		movements, err = saveProductWithDetails(tx, product, actorID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// SaveProductsWithDetails menyimpan banyak produk lengkap dalam satu transaksi; jika satu gagal, semuanya dibatalkan.
+func (r *productRepository) SaveProductsWithDetails(products []*models.Product, actorID *uint64) ([]models.StockMovement, error) {
+	var movements []models.StockMovement
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		movements = nil
+		for _, product := range products {
+			saved, err := saveProductWithDetails(tx, product, actorID)
+			if err != nil {
+				return err
+			}
+			movements = append(movements, saved...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// saveProductWithDetails berisi logika penyimpanan produk lengkap di dalam transaksi yang diberikan
+// dan mengembalikan perubahan stok yang dicatat ke ledger.
+func saveProductWithDetails(tx *gorm.DB, product *models.Product, actorID *uint64) ([]models.StockMovement, error) {
+	if product.ID == 0 {
+		if err := tx.Omit(clause.Associations).Create(product).Error; err != nil {
+			return nil, err
+		}
+		if err := syncBrandTotalProducts(tx, product.BrandID); err != nil {
+			return nil, err
+		}
+	} else if err := updateProductKeepingAggregates(tx, product); err != nil {
+		return nil, err
+	}
+
+	// Ganti kategori dan style
+	if err := tx.Unscoped().Where("product_id = ?", product.ID).Delete(&models.ProductCategory{}).Error; err != nil {
+		return nil, err
+	}
+	for i := range product.ProductCategories {
+		product.ProductCategories[i].ProductID = product.ID
+		if err := tx.Omit(clause.Associations).Create(&product.ProductCategories[i]).Error; err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Unscoped().Where("product_id = ?", product.ID).Delete(&models.ProductStyle{}).Error; err != nil {
+		return nil, err
+	}
+	for i := range product.ProductStyles {
+		product.ProductStyles[i].ProductID = product.ID
+		if err := tx.Omit(clause.Associations).Create(&product.ProductStyles[i]).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	// Ambil harga dan stok lama item yang sudah ada untuk pencatatan riwayat harga dan ledger stok
+	existingItemIDs := make([]uint64, 0, len(product.ProductItems))
+	for _, item := range product.ProductItems {
+		if item.ID != 0 {
+			existingItemIDs = append(existingItemIDs, item.ID)
+		}
+	}
+	oldItems := make(map[uint64]models.ProductItem, len(existingItemIDs))
+	if len(existingItemIDs) > 0 {
+		var current []models.ProductItem
+		if err := tx.Unscoped().Select("id", "price", "stock").Where("id IN ?", existingItemIDs).Find(&current).Error; err != nil {
+			return nil, err
+		}
+		for _, item := range current {
+			oldItems[item.ID] = item
+		}
+	}
+
+	// Simpan item dan konfigurasi variasinya
+	now := time.Now()
+	var movements []models.StockMovement
+	keptItemIDs := make([]uint64, 0, len(product.ProductItems))
+	for i := range product.ProductItems {
+		item := &product.ProductItems[i]
+		item.ProductID = product.ID
+		configurations := item.Configurations
+
+		oldItem, existed := oldItems[item.ID]
+		if item.ID == 0 {
+			if err := tx.Omit(clause.Associations).Create(item).Error; err != nil {
+				return nil, err
+			}
+		} else if err := tx.Unscoped().Omit(clause.Associations, "Sold").Save(item).Error; err != nil {
+			// Unscoped agar item yang pernah di-soft delete dapat dipulihkan lewat SKU yang sama;
+			// Sold tidak ditimpa karena dihitung dari konversi
+			return nil, err
+		}
+		keptItemIDs = append(keptItemIDs, item.ID)
+
+		// Catat riwayat harga untuk item baru dan setiap perubahan harga
+		if !existed || oldItem.Price != item.Price {
+			history := models.PriceHistory{
+				ProductItemID: item.ID,
+				ProductID:     product.ID,
+				OldPrice:      oldItem.Price,
+				NewPrice:      item.Price,
+				ChangedAt:     now,
+			}
+			if err := tx.Create(&history).Error; err != nil {
+				return nil, err
+			}
+		}
+
+		// Catat perubahan stok lewat editor/impor ke ledger stok
+		if oldItem.Stock != item.Stock {
+			movement := models.StockMovement{
+				ProductItemID: item.ID,
+				Delta:         item.Stock - oldItem.Stock,
+				StockBefore:   oldItem.Stock,
+				StockAfter:    item.Stock,
+				Reason:        constants.StockReasonProductEdit,
+				ActorID:       actorID,
+			}
+			if err := tx.Omit(clause.Associations).Create(&movement).Error; err != nil {
+				return nil, err
+			}
+			movements = append(movements, movement)
+		}
+
+		if err := tx.Unscoped().Where("product_item_id = ?", item.ID).Delete(&models.ProductConfiguration{}).Error; err != nil {
+			return nil, err
+		}
+		for j := range configurations {
+			configurations[j].ProductItemID = item.ID
+			if err := tx.Omit(clause.Associations).Create(&configurations[j]).Error; err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Hapus item lama yang tidak lagi menjadi bagian dari produk
+	staleItems := tx.Where("product_id = ?", product.ID)
+	if len(keptItemIDs) > 0 {
+		staleItems = staleItems.Where("id NOT IN ?", keptItemIDs)
+	}
+	if err := staleItems.Delete(&models.ProductItem{}).Error; err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// GetPriceHistory mengambil riwayat harga semua item produk sejak waktu tertentu, diurutkan dari yang terlama.
+func (r *productRepository) GetPriceHistory(productID uint64, since time.Time) ([]models.PriceHistory, error) {
+	var history []models.PriceHistory
+	if err := r.DB.
+		Where("product_id = ? AND changed_at >= ?", productID, since).
+		Order("changed_at ASC, id ASC").
+		Find(&history).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// GetAllProductDetails mengambil semua produk lengkap (brand, kategori, style, item, variasi) untuk ekspor.
+func (r *productRepository) GetAllProductDetails() ([]*models.Product, error) {
+	var products []*models.Product
+	if err := r.DB.
+		Preload("Brand").
+		Preload("ProductCategories").
+		Preload("ProductStyles").
+		Preload("ProductItems", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("ProductItems.Configurations.ProductVariationOption.ProductVariation").
+		Order("id ASC").
+		Find(&products).Error; err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// GetProductByBrandAndName mencari produk milik brand tertentu berdasarkan nama (tidak peka huruf besar/kecil).
+func (r *productRepository) GetProductByBrandAndName(brandID uint64, name string) (*models.Product, error) {
+	var product models.Product
+	if err := r.DB.
+		Where("brand_id = ? AND LOWER(name) = LOWER(?)", brandID, name).
+		First(&product).Error; err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// GetProductItemsBySKUs mengambil item produk (termasuk yang sudah di-soft delete) yang memiliki salah satu SKU yang diberikan.
+func (r *productRepository) GetProductItemsBySKUs(skus []string) ([]models.ProductItem, error) {
+	var items []models.ProductItem
+	if len(skus) == 0 {
+		return items, nil
+	}
+	if err := r.DB.Unscoped().Where("sku IN ?", skus).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetVariationOptionsByIDs mengambil opsi variasi berdasarkan daftar ID, termasuk variasi induknya.
+func (r *productRepository) GetVariationOptionsByIDs(ids []uint64) ([]models.ProductVariationOption, error) {
+	var options []models.ProductVariationOption
+	if len(ids) == 0 {
+		return options, nil
+	}
+	if err := r.DB.Preload("ProductVariation").Where("id IN ?", ids).Find(&options).Error; err != nil {
+		return nil, err
+	}
+	return options, nil
+}
@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"flicknfit_backend/models"
+
+	"gorm.io/gorm"
+)
+
+// viewInsertBatchSize is the number of views inserted per statement.
+const viewInsertBatchSize = 100
+
+// ProductViewRepository defines data access operations for product detail views.
+type ProductViewRepository interface {
+	RecordViews(views []models.ProductView) error
+	GetRecentViews(userID uint64, limit int) ([]models.RecentView, error)
+}
+
+// productViewRepository is the implementation of ProductViewRepository.
+type productViewRepository struct {
+	BaseRepository
+}
+
+// NewProductViewRepository creates and returns a new instance of ProductViewRepository.
+func NewProductViewRepository(db *gorm.DB) ProductViewRepository {
+	return &productViewRepository{BaseRepository{DB: db}}
+}
+
+// RecordViews stores a batch of views and adds them to the view counters of their products in one transaction.
+func (r *productViewRepository) RecordViews(views []models.ProductView) error {
+	if len(views) == 0 {
+		return nil
+	}
+	perProduct := make(map[uint64]int)
+	for _, view := range views {
+		perProduct[view.ProductID]++
+	}
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User", "Product").CreateInBatches(&views, viewInsertBatchSize).Error; err != nil {
+			return err
+		}
+		for productID, count := range perProduct {
+			if err := tx.Model(&models.Product{}).Where("id = ?", productID).
+				UpdateColumn("views", gorm.Expr("views + ?", count)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetRecentViews returns the products a user viewed most recently, each once with its last view time.
+func (r *productViewRepository) GetRecentViews(userID uint64, limit int) ([]models.RecentView, error) {
+	var views []models.RecentView
+	err := r.DB.Model(&models.ProductView{}).
+		Select("product_id, MAX(viewed_at) AS viewed_at").
+		Where("user_id = ?", userID).
+		Group("product_id").
+		Order("viewed_at DESC").
+		Limit(limit).
+		Scan(&views).Error
+	return views, err
+}
@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"flicknfit_backend/constants"
+	"flicknfit_backend/models"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RedirectChannelRepository defines data access operations for brand redirect settings (channel order and
+// outbound link parameters) and their results.
+type RedirectChannelRepository interface {
+	GetChannels(brandID uint64) ([]models.BrandRedirectChannel, error)
+	ReplaceChannels(brandID uint64, channels []models.BrandRedirectChannel) error
+	GetChannelReport(brandID uint64, since time.Time) ([]models.ChannelStat, error)
+	GetLinkParams(brandID uint64) (*models.BrandLinkParams, error)
+	SaveLinkParams(params *models.BrandLinkParams) error
+}
+
+// redirectChannelRepository is the implementation of RedirectChannelRepository.
+type redirectChannelRepository struct {
+	BaseRepository
+}
+
+// NewRedirectChannelRepository creates and returns a new instance of RedirectChannelRepository.
+func NewRedirectChannelRepository(db *gorm.DB) RedirectChannelRepository {
+	return &redirectChannelRepository{BaseRepository{DB: db}}
+}
+
+// GetChannels retrieves the configured channels of a brand in their preferred order.
+func (r *redirectChannelRepository) GetChannels(brandID uint64) ([]models.BrandRedirectChannel, error) {
+	var channels []models.BrandRedirectChannel
+	err := r.DB.Where("brand_id = ?", brandID).Order("position ASC").Find(&channels).Error
+	return channels, err
+}
+
+// ReplaceChannels swaps the configured channels of a brand for the given list in a single transaction.
+func (r *redirectChannelRepository) ReplaceChannels(brandID uint64, channels []models.BrandRedirectChannel) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("brand_id = ?", brandID).Delete(&models.BrandRedirectChannel{}).Error; err != nil {
+			return err
+		}
+		if len(channels) == 0 {
+			return nil
+		}
+		for i := range channels {
+			channels[i].BrandID = brandID
+		}
+		return tx.Create(&channels).Error
+	})
+}
+
+// GetChannelReport counts the clicks and conversions of a brand per channel since the given time.
+// Suspicious clicks are left out. Rows recorded without a channel are reported under constants.RedirectChannelUnknown.
+func (r *redirectChannelRepository) GetChannelReport(brandID uint64, since time.Time) ([]models.ChannelStat, error) {
+	var clicks []struct {
+		Channel string
+		Clicks  int64
+	}
+	if err := r.DB.Model(&models.ProductClick{}).Scopes(genuineClicks).
+		Select("channel, COUNT(*) AS clicks").
+		Where("brand_id = ? AND clicked_at >= ?", brandID, since).
+		Group("channel").
+		Scan(&clicks).Error; err != nil {
+		return nil, err
+	}
+
+	var conversions []struct {
+		Channel     string
+		Conversions int64
+		UnitsSold   int64
+		Revenue     int64
+	}
+	if err := r.DB.Model(&models.Conversion{}).
+		Select("channel, COUNT(*) AS conversions, COALESCE(SUM(quantity), 0) AS units_sold, COALESCE(SUM(revenue), 0) AS revenue").
+		Where("brand_id = ? AND converted_at >= ?", brandID, since).
+		Group("channel").
+		Scan(&conversions).Error; err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]*models.ChannelStat)
+	stat := func(channel string) *models.ChannelStat {
+		if channel == "" {
+			channel = constants.RedirectChannelUnknown
+		}
+		if stats[channel] == nil {
+			stats[channel] = &models.ChannelStat{Channel: channel}
+		}
+		return stats[channel]
+	}
+	for _, c := range clicks {
+		stat(c.Channel).Clicks += c.Clicks
+	}
+	for _, c := range conversions {
+		s := stat(c.Channel)
+		s.Conversions += c.Conversions
+		s.UnitsSold += c.UnitsSold
+		s.Revenue += c.Revenue
+	}
+
+	report := make([]models.ChannelStat, 0, len(stats))
+	for _, s := range stats {
+		report = append(report, *s)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Clicks != report[j].Clicks {
+			return report[i].Clicks > report[j].Clicks
+		}
+		return report[i].Channel < report[j].Channel
+	})
+	return report, nil
+}
+
+// GetLinkParams retrieves the outbound link parameter overrides of a brand.
+func (r *redirectChannelRepository) GetLinkParams(brandID uint64) (*models.BrandLinkParams, error) {
+	var params models.BrandLinkParams
+	if err := r.DB.Where("brand_id = ?", brandID).First(&params).Error; err != nil {
+		return nil, err
+	}
+	return &params, nil
+}
+
+// SaveLinkParams creates or replaces the outbound link parameter overrides of a brand.
+func (r *redirectChannelRepository) SaveLinkParams(params *models.BrandLinkParams) error {
+	return r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "brand_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"utm_source", "utm_medium", "utm_campaign", "tokopedia_affiliate_id", "shopee_affiliate_id", "updated_at"}),
+	}).Create(params).Error
+}
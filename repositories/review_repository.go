@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"flicknfit_backend/models"
+	"math"
 
 	"gorm.io/gorm"
 )
@@ -16,6 +17,7 @@ type ReviewRepository interface {
 	GetUserReviews(userID uint64) ([]models.Review, error)
 	GetProductReviewStats(productID uint64) (map[string]interface{}, error)
 	HasUserReviewedProduct(userID, productID uint64) (bool, error)
+	RecalculateAllRatings(dryRun bool) ([]models.RatingDrift, error)
 }
 
 // reviewRepository implements ReviewRepository interface
@@ -48,19 +50,31 @@ func (r *reviewRepository) GetReviewByID(id uint64) (*models.Review, error) {
 	return &review, err
 }
 
-// CreateReview creates a new review
+// CreateReview creates a new review and refreshes the product and brand rating aggregates
 func (r *reviewRepository) CreateReview(review *models.Review) error {
-	return r.db.Create(review).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User", "Product").Create(review).Error; err != nil {
+			return err
+		}
+		return syncRatingAggregates(tx, review.ProductID)
+	})
 }
 
-// UpdateReview updates an existing review
+// UpdateReview updates an existing review and refreshes the product and brand rating aggregates
 func (r *reviewRepository) UpdateReview(review *models.Review) error {
-	return r.db.Save(review).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("User", "Product").Save(review).Error; err != nil {
+			return err
+		}
+		return syncRatingAggregates(tx, review.ProductID)
+	})
 }
 
-// DeleteReview deletes a review
+// DeleteReview deletes a review and refreshes the product and brand rating aggregates
 func (r *reviewRepository) DeleteReview(id uint64) error {
-	return r.db.Delete(&models.Review{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteReviewAndSync(tx, id)
+	})
 }
 
 // GetUserReviews retrieves all reviews by a user
@@ -119,3 +133,117 @@ func (r *reviewRepository) HasUserReviewedProduct(userID, productID uint64) (boo
 		Count(&count).Error
 	return count > 0, err
 }
+
+// RecalculateAllRatings compares the stored rating aggregates of every product and brand with their
+// reviews and returns the ones that drifted. Unless dryRun is set, drifted aggregates are corrected.
+func (r *reviewRepository) RecalculateAllRatings(dryRun bool) ([]models.RatingDrift, error) {
+	var drifts []models.RatingDrift
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var products []models.RatingDrift
+		if err := tx.Table("products").
+			Select("products.id, products.name, products.rating AS stored_rating, products.reviewer AS stored_reviewer, " +
+				"COUNT(reviews.id) AS actual_reviewer, COALESCE(AVG(reviews.rating), 0) AS actual_rating").
+			Joins("LEFT JOIN reviews ON reviews.product_id = products.id AND reviews.deleted_at IS NULL").
+			Where("products.deleted_at IS NULL").
+			Group("products.id, products.name, products.rating, products.reviewer").
+			Order("products.id").
+			Scan(&products).Error; err != nil {
+			return err
+		}
+
+		var brands []models.RatingDrift
+		if err := tx.Table("brands").
+			Select("brands.id, brands.name, brands.rating AS stored_rating, brands.reviewer AS stored_reviewer, " +
+				"COUNT(reviews.id) AS actual_reviewer, COALESCE(AVG(reviews.rating), 0) AS actual_rating").
+			Joins("LEFT JOIN products ON products.brand_id = brands.id AND products.deleted_at IS NULL").
+			Joins("LEFT JOIN reviews ON reviews.product_id = products.id AND reviews.deleted_at IS NULL").
+			Where("brands.deleted_at IS NULL").
+			Group("brands.id, brands.name, brands.rating, brands.reviewer").
+			Order("brands.id").
+			Scan(&brands).Error; err != nil {
+			return err
+		}
+
+		for _, group := range []struct {
+			entity string
+			model  interface{}
+			rows   []models.RatingDrift
+		}{{models.RatingDriftEntityProduct, &models.Product{}, products}, {models.RatingDriftEntityBrand, &models.Brand{}, brands}} {
+			for _, row := range group.rows {
+				row.Entity = group.entity
+				row.ActualRating = roundRating(row.ActualRating)
+				if row.StoredReviewer == row.ActualReviewer && math.Abs(row.StoredRating-row.ActualRating) < 0.005 {
+					continue
+				}
+				drifts = append(drifts, row)
+				if dryRun {
+					continue
+				}
+				if err := tx.Model(group.model).Where("id = ?", row.ID).
+					UpdateColumns(map[string]interface{}{"rating": row.ActualRating, "reviewer": row.ActualReviewer}).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	return drifts, err
+}
+
+// ratingAggregate is the review count and average rating of a product or brand
+type ratingAggregate struct {
+	Reviewer int
+	Rating   float64
+}
+
+// syncRatingAggregates recomputes Rating/Reviewer of a product and its brand from their reviews
+func syncRatingAggregates(tx *gorm.DB, productID uint64) error {
+	var product models.Product
+	if err := tx.Unscoped().Select("id", "brand_id").First(&product, productID).Error; err != nil {
+		return err
+	}
+
+	var productAgg ratingAggregate
+	if err := tx.Model(&models.Review{}).
+		Select("COUNT(*) AS reviewer, COALESCE(AVG(rating), 0) AS rating").
+		Where("product_id = ?", productID).
+		Scan(&productAgg).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&models.Product{}).Where("id = ?", productID).
+		UpdateColumns(map[string]interface{}{"rating": roundRating(productAgg.Rating), "reviewer": productAgg.Reviewer}).Error; err != nil {
+		return err
+	}
+	return syncBrandRating(tx, product.BrandID)
+}
+
+// syncBrandRating recomputes Rating/Reviewer of a brand from the reviews of its live products
+func syncBrandRating(tx *gorm.DB, brandID uint64) error {
+	var brandAgg ratingAggregate
+	if err := tx.Model(&models.Review{}).
+		Select("COUNT(reviews.id) AS reviewer, COALESCE(AVG(reviews.rating), 0) AS rating").
+		Joins("JOIN products ON products.id = reviews.product_id AND products.deleted_at IS NULL").
+		Where("products.brand_id = ?", brandID).
+		Scan(&brandAgg).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.Brand{}).Where("id = ?", brandID).
+		UpdateColumns(map[string]interface{}{"rating": roundRating(brandAgg.Rating), "reviewer": brandAgg.Reviewer}).Error
+}
+
+// deleteReviewAndSync deletes a review inside a transaction and refreshes the aggregates of its product
+func deleteReviewAndSync(tx *gorm.DB, id uint64) error {
+	var review models.Review
+	if err := tx.Select("id", "product_id").First(&review, id).Error; err != nil {
+		return err
+	}
+	if err := tx.Delete(&models.Review{}, id).Error; err != nil {
+		return err
+	}
+	return syncRatingAggregates(tx, review.ProductID)
+}
+
+// roundRating rounds an average rating to two decimals
+func roundRating(rating float64) float64 {
+	return math.Round(rating*100) / 100
+}
@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"errors"
+	"flicknfit_backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInsufficientStock is returned when a stock adjustment would make an item's stock negative.
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// StockRepository defines data access operations for product item stock and its movement ledger.
+type StockRepository interface {
+	AdjustStock(itemID uint64, delta int, movement *models.StockMovement) (*models.ProductItem, error)
+	GetMovementsByItemID(itemID uint64, limit int) ([]models.StockMovement, error)
+	SetLowStockThreshold(itemID uint64, threshold *int) error
+	GetLowStockItems(defaultThreshold int) ([]models.ProductItem, error)
+}
+
+// stockRepository is the implementation of StockRepository.
+type stockRepository struct {
+	BaseRepository
+}
+
+// NewStockRepository creates and returns a new instance of StockRepository.
+func NewStockRepository(db *gorm.DB) StockRepository {
+	return &stockRepository{BaseRepository{DB: db}}
+}
+
+// AdjustStock applies a stock delta to an item and records the movement in one transaction.
+// The item row is locked so concurrent adjustments cannot drive stock below zero.
+// The movement's Delta, StockBefore and StockAfter are filled in; the returned item has its product preloaded.
+func (r *stockRepository) AdjustStock(itemID uint64, delta int, movement *models.StockMovement) (*models.ProductItem, error) {
+	var item models.ProductItem
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, itemID).Error; err != nil {
+			return err
+		}
+		if item.Stock+delta < 0 {
+			return ErrInsufficientStock
+		}
+
+		movement.ProductItemID = item.ID
+		movement.Delta = delta
+		movement.StockBefore = item.Stock
+		movement.StockAfter = item.Stock + delta
+		if err := tx.Model(&item).Update("stock", movement.StockAfter).Error; err != nil {
+			return err
+		}
+		item.Stock = movement.StockAfter
+		return tx.Create(movement).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.DB.Preload("Product").First(&item, itemID).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// GetMovementsByItemID retrieves the latest stock movements of an item, newest first.
+func (r *stockRepository) GetMovementsByItemID(itemID uint64, limit int) ([]models.StockMovement, error) {
+	var movements []models.StockMovement
+	if err := r.DB.Preload("Actor").
+		Where("product_item_id = ?", itemID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&movements).Error; err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// SetLowStockThreshold sets an item's low-stock threshold; nil resets it to the default.
+func (r *stockRepository) SetLowStockThreshold(itemID uint64, threshold *int) error {
+	return r.DB.Model(&models.ProductItem{}).Where("id = ?", itemID).Update("low_stock_threshold", threshold).Error
+}
+
+// GetLowStockItems retrieves items whose stock is at or below their threshold (or the default), lowest stock first.
+func (r *stockRepository) GetLowStockItems(defaultThreshold int) ([]models.ProductItem, error) {
+	var items []models.ProductItem
+	if err := r.DB.Preload("Product").
+		Where("stock <= COALESCE(low_stock_threshold, ?)", defaultThreshold).
+		Order("stock ASC, id ASC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"errors"
+	"flicknfit_backend/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrDuplicateVariation is returned when a variation with the same name already exists.
+var ErrDuplicateVariation = errors.New("variation already exists")
+
+// ErrDuplicateVariationOption is returned when a variation already has an option with the same value.
+var ErrDuplicateVariationOption = errors.New("variation option already exists")
+
+// VariationRepository defines data access operations for product variations and their options.
+type VariationRepository interface {
+	GetAllVariations() ([]models.ProductVariation, error)
+	GetVariationByID(id uint64) (*models.ProductVariation, error)
+	GetVariationByName(name string) (*models.ProductVariation, error)
+	CreateVariation(variation *models.ProductVariation) error
+	UpdateVariation(variation *models.ProductVariation) error
+	DeleteVariation(id uint64) error
+
+	GetOptionByID(id uint64) (*models.ProductVariationOption, error)
+	GetOptionByValue(variationID uint64, value string) (*models.ProductVariationOption, error)
+	CreateOption(option *models.ProductVariationOption) error
+	UpdateOption(option *models.ProductVariationOption) error
+	DeleteOption(id uint64) error
+	UpdateOptionOrder(variationID uint64, orderedOptionIDs []uint64) error
+	CountConfigurationsByOptionIDs(optionIDs []uint64) (int64, error)
+}
+
+// variationRepository is the implementation of VariationRepository.
+type variationRepository struct {
+	BaseRepository
+}
+
+// NewVariationRepository creates and returns a new instance of VariationRepository.
+func NewVariationRepository(db *gorm.DB) VariationRepository {
+	return &variationRepository{BaseRepository{DB: db}}
+}
+
+// orderedOptions preloads variation options sorted by their display order.
+func orderedOptions(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order ASC, id ASC")
+}
+
+// GetAllVariations retrieves all variations with their options in display order.
+func (r *variationRepository) GetAllVariations() ([]models.ProductVariation, error) {
+	var variations []models.ProductVariation
+	if err := r.DB.Preload("Options", orderedOptions).Order("id ASC").Find(&variations).Error; err != nil {
+		return nil, err
+	}
+	return variations, nil
+}
+
+// GetVariationByID retrieves a variation with its options in display order.
+func (r *variationRepository) GetVariationByID(id uint64) (*models.ProductVariation, error) {
+	var variation models.ProductVariation
+	if err := r.DB.Preload("Options", orderedOptions).First(&variation, id).Error; err != nil {
+		return nil, err
+	}
+	return &variation, nil
+}
+
+// GetVariationByName retrieves a variation by its case-insensitive name, including deleted variations since
+// they still hold the name in the unique index.
+func (r *variationRepository) GetVariationByName(name string) (*models.ProductVariation, error) {
+	var variation models.ProductVariation
+	if err := r.DB.Unscoped().Where("LOWER(name) = LOWER(?)", name).First(&variation).Error; err != nil {
+		return nil, err
+	}
+	return &variation, nil
+}
+
+// CreateVariation creates a new variation record together with its options.
+func (r *variationRepository) CreateVariation(variation *models.ProductVariation) error {
+	if err := r.DB.Create(variation).Error; err != nil {
+		if isDuplicateKey(r.DB, err) {
+			return ErrDuplicateVariation
+		}
+		return err
+	}
+	return nil
+}
+
+// UpdateVariation updates an existing variation record.
+func (r *variationRepository) UpdateVariation(variation *models.ProductVariation) error {
+	if err := r.DB.Omit("Options").Save(variation).Error; err != nil {
+		if isDuplicateKey(r.DB, err) {
+			return ErrDuplicateVariation
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteVariation deletes a variation together with all of its options.
+func (r *variationRepository) DeleteVariation(id uint64) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_attribute_id = ?", id).Delete(&models.ProductVariationOption{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.ProductVariation{}, id).Error
+	})
+}
+
+// GetOptionByID retrieves a variation option with its parent variation.
+func (r *variationRepository) GetOptionByID(id uint64) (*models.ProductVariationOption, error) {
+	var option models.ProductVariationOption
+	if err := r.DB.Preload("ProductVariation").First(&option, id).Error; err != nil {
+		return nil, err
+	}
+	return &option, nil
+}
+
+// GetOptionByValue retrieves an option of a variation by its case-insensitive value, including deleted options
+// since they still hold the value in the unique index.
+func (r *variationRepository) GetOptionByValue(variationID uint64, value string) (*models.ProductVariationOption, error) {
+	var option models.ProductVariationOption
+	if err := r.DB.Unscoped().Where("product_attribute_id = ? AND LOWER(value) = LOWER(?)", variationID, value).
+		First(&option).Error; err != nil {
+		return nil, err
+	}
+	return &option, nil
+}
+
+// CreateOption creates a new variation option record.
+func (r *variationRepository) CreateOption(option *models.ProductVariationOption) error {
+	if err := r.DB.Omit("ProductVariation").Create(option).Error; err != nil {
+		if isDuplicateKey(r.DB, err) {
+			return ErrDuplicateVariationOption
+		}
+		return err
+	}
+	return nil
+}
+
+// UpdateOption updates an existing variation option record.
+func (r *variationRepository) UpdateOption(option *models.ProductVariationOption) error {
+	if err := r.DB.Omit("ProductVariation").Save(option).Error; err != nil {
+		if isDuplicateKey(r.DB, err) {
+			return ErrDuplicateVariationOption
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteOption deletes a variation option record.
+func (r *variationRepository) DeleteOption(id uint64) error {
+	return r.DB.Delete(&models.ProductVariationOption{}, id).Error
+}
+
+// UpdateOptionOrder sets the sort order of a variation's options following the given ID order.
+func (r *variationRepository) UpdateOptionOrder(variationID uint64, orderedOptionIDs []uint64) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		for i, optionID := range orderedOptionIDs {
+			if err := tx.Model(&models.ProductVariationOption{}).
+				Where("id = ? AND product_attribute_id = ?", optionID, variationID).
+				Update("sort_order", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CountConfigurationsByOptionIDs counts configurations of live product items that still reference any of the given options.
+func (r *variationRepository) CountConfigurationsByOptionIDs(optionIDs []uint64) (int64, error) {
+	var count int64
+	if len(optionIDs) == 0 {
+		return 0, nil
+	}
+	err := r.DB.Model(&models.ProductConfiguration{}).
+		Joins("JOIN product_items ON product_items.id = product_configurations.product_item_id AND product_items.deleted_at IS NULL").
+		Where("product_configurations.product_attribute_value_id IN ?", optionIDs).
+		Count(&count).Error
+	return count, err
+}
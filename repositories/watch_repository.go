@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"flicknfit_backend/models"
+
+	"gorm.io/gorm"
+)
+
+// WatchRepository defines data access operations for back-in-stock and price-drop watches
+// on favorites and saved items.
+type WatchRepository interface {
+	GetItemWatchers(itemIDs []uint64) ([]models.ItemWatcher, error)
+	GetUserFavoriteByItemID(userID, productItemID uint64) (*models.Favorite, error)
+	GetUserSavedItem(userID, savedItemID uint64) (*models.SavedItemsList, error)
+	UpdateFavoriteWatch(favorite *models.Favorite) error
+	UpdateSavedItemWatch(item *models.SavedItemsList) error
+}
+
+// watchRepository is the implementation of WatchRepository.
+type watchRepository struct {
+	BaseRepository
+}
+
+// NewWatchRepository creates and returns a new instance of WatchRepository.
+func NewWatchRepository(db *gorm.DB) WatchRepository {
+	return &watchRepository{BaseRepository{DB: db}}
+}
+
+// watchColumns are the preference columns written by watch updates.
+var watchColumns = []string{"notify_back_in_stock", "notify_price_drop", "notify_by_email", "target_price"}
+
+// GetItemWatchers retrieves everyone watching the given items through a favorite or a saved item.
+// A user watching the same item in both places is returned twice; callers merge the preferences.
+func (r *watchRepository) GetItemWatchers(itemIDs []uint64) ([]models.ItemWatcher, error) {
+	var watchers []models.ItemWatcher
+	if len(itemIDs) == 0 {
+		return watchers, nil
+	}
+
+	if err := r.DB.Table("favorites").
+		Select("favorites.user_id, favorites.product_item_id, users.email, favorites.notify_back_in_stock, favorites.notify_price_drop, favorites.notify_by_email, favorites.target_price").
+		Joins("JOIN users ON users.id = favorites.user_id AND users.deleted_at IS NULL").
+		Where("favorites.product_item_id IN ? AND favorites.deleted_at IS NULL", itemIDs).
+		Scan(&watchers).Error; err != nil {
+		return nil, err
+	}
+
+	var saved []models.ItemWatcher
+	if err := r.DB.Table("saved_items_items").
+		Select("saved_items.user_id, saved_items_items.product_item_id, users.email, saved_items_items.notify_back_in_stock, saved_items_items.notify_price_drop, saved_items_items.notify_by_email, saved_items_items.target_price").
+		Joins("JOIN saved_items ON saved_items.id = saved_items_items.saved_items_id AND saved_items.deleted_at IS NULL").
+		Joins("JOIN users ON users.id = saved_items.user_id AND users.deleted_at IS NULL").
+		Where("saved_items_items.product_item_id IN ? AND saved_items_items.deleted_at IS NULL", itemIDs).
+		Scan(&saved).Error; err != nil {
+		return nil, err
+	}
+	return append(watchers, saved...), nil
+}
+
+// GetUserFavoriteByItemID retrieves a user's favorite for a product item.
+func (r *watchRepository) GetUserFavoriteByItemID(userID, productItemID uint64) (*models.Favorite, error) {
+	var favorite models.Favorite
+	if err := r.DB.Where("user_id = ? AND product_item_id = ?", userID, productItemID).First(&favorite).Error; err != nil {
+		return nil, err
+	}
+	return &favorite, nil
+}
+
+// GetUserSavedItem retrieves a saved item that belongs to the user's saved items list.
+func (r *watchRepository) GetUserSavedItem(userID, savedItemID uint64) (*models.SavedItemsList, error) {
+	var item models.SavedItemsList
+	if err := r.DB.
+		Joins("JOIN saved_items ON saved_items.id = saved_items_items.saved_items_id").
+		Where("saved_items_items.id = ? AND saved_items.user_id = ?", savedItemID, userID).
+		First(&item).Error; err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// UpdateFavoriteWatch saves the watch preferences of a favorite, including false and nil values.
+func (r *watchRepository) UpdateFavoriteWatch(favorite *models.Favorite) error {
+	return r.DB.Model(favorite).Select(watchColumns).Updates(favorite).Error
+}
+
+// UpdateSavedItemWatch saves the watch preferences of a saved item, including false and nil values.
+func (r *watchRepository) UpdateSavedItemWatch(item *models.SavedItemsList) error {
+	return r.DB.Model(item).Select(watchColumns).Updates(item).Error
+}
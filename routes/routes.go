@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"flicknfit_backend/constants"
 	"flicknfit_backend/container"
 	"flicknfit_backend/middlewares"
 
@@ -31,6 +32,8 @@ func setupAPIRoutes(app *fiber.App, container *container.Container) {
 
 	// Setup API-specific middlewares
 	middlewares.SetupAPIMiddlewares(api)
+	// Health of the API and the model API, public for load balancers and monitoring
+	api.Get("/health", container.Controllers.Health.GetHealth)
 	// Setup user routes
 	setupUserRoutes(api, container)
 
@@ -39,6 +42,29 @@ func setupAPIRoutes(app *fiber.App, container *container.Container) {
 
 	// Setup brand routes
 	setupBrandRoutes(api, container)
+	setupBrandApplicationRoutes(api, container)
+
+	// Setup variation routes
+	setupVariationRoutes(api, container)
+
+	// Setup campaign routes
+	setupCampaignRoutes(api, container)
+
+	// Setup stock and notification routes
+	setupStockRoutes(api, container)
+	setupNotificationRoutes(api, container)
+
+	// Setup sales conversion routes
+	setupConversionRoutes(api, container)
+
+	// Setup link health routes
+	setupLinkHealthRoutes(api, container)
+
+	// Setup redirect channel routes
+	setupRedirectChannelRoutes(api, container)
+
+	// Setup click analytics routes
+	setupClickAnalyticsRoutes(api, container)
 
 	// Setup saved items routes
 	setupsavedItemsRoutes(api, container)
@@ -80,6 +106,7 @@ func setupUserRoutes(api fiber.Router, c *container.Container) {
 	userRoutes.Use(middlewares.AuthMiddleware())
 	userRoutes.Post("/logout", c.Controllers.User.LogoutUser)
 	userRoutes.Get("/me", c.Controllers.User.GetUserByAccessToken)
+	userRoutes.Get("/me/recently-viewed", c.Controllers.Product.GetRecentlyViewed)
 	userRoutes.Patch("/edit-profile", c.Controllers.User.EditProfile)
 
 	// Admin user routes (requires authentication + admin role)
@@ -97,7 +124,8 @@ func setupProductRoutes(api fiber.Router, c *container.Container) {
 	// Public product routes
 	productRoutes := api.Group("/products")
 	productRoutes.Get("/", c.Controllers.Product.GetAllProductsPublic)
-	productRoutes.Get("/:id", c.Controllers.Product.GetProductPublicByID)
+	productRoutes.Get("/:id", middlewares.OptionalAuthMiddleware(), c.Controllers.Product.GetProductPublicByID)
+	productRoutes.Get("/:id/price-history", c.Controllers.Product.GetProductPriceChart)
 	productRoutes.Get("/search", c.Controllers.Product.SearchProductsPublic)
 	productRoutes.Get("/filter", c.Controllers.Product.GetAllProductsPublicWithFilter)
 
@@ -106,9 +134,28 @@ func setupProductRoutes(api fiber.Router, c *container.Container) {
 	productAdminRoutes.Use(middlewares.AuthMiddleware(), middlewares.AdminMiddleware())
 	productAdminRoutes.Post("/", c.Controllers.Product.AdminCreateProduct)
 	productAdminRoutes.Get("/", c.Controllers.Product.AdminGetAllProducts)
+	// Bulk import/export must be registered before the /:id routes
+	productAdminRoutes.Post("/import", c.Controllers.ProductImport.AdminImportProducts)
+	productAdminRoutes.Get("/export", c.Controllers.ProductImport.AdminExportProducts)
 	productAdminRoutes.Get("/:id", c.Controllers.Product.AdminGetProductByID)
 	productAdminRoutes.Put("/:id", c.Controllers.Product.AdminUpdateProduct)
 	productAdminRoutes.Delete("/:id", c.Controllers.Product.AdminDeleteProduct)
+	productAdminRoutes.Put("/:id/status", c.Controllers.Product.AdminUpdateProductStatus)
+
+	// Full product editor (items, variations, categories, styles and marketplace links)
+	productAdminRoutes.Post("/full", c.Controllers.Product.AdminCreateProductFull)
+	productAdminRoutes.Get("/:id/full", c.Controllers.Product.AdminGetProductDetails)
+	productAdminRoutes.Put("/:id/full", c.Controllers.Product.AdminUpdateProductFull)
+
+	// Product gallery (images and videos per product and item)
+	productAdminRoutes.Get("/:id/media", c.Controllers.ProductMedia.AdminGetProductMedia)
+	productAdminRoutes.Post("/:id/media", c.Controllers.ProductMedia.AdminUploadProductMedia)
+	productAdminRoutes.Put("/:id/media/order", c.Controllers.ProductMedia.AdminReorderProductMedia)
+
+	mediaAdminRoutes := api.Group("/admin/product-media")
+	mediaAdminRoutes.Use(middlewares.AuthMiddleware(), middlewares.AdminMiddleware())
+	mediaAdminRoutes.Put("/:mediaId", c.Controllers.ProductMedia.AdminUpdateProductMedia)
+	mediaAdminRoutes.Delete("/:mediaId", c.Controllers.ProductMedia.AdminDeleteProductMedia)
 }
 
 // setupBrandRoutes configures all brand-related routes
@@ -116,7 +163,11 @@ func setupBrandRoutes(api fiber.Router, c *container.Container) {
 	// Public brand routes
 	brandRoutes := api.Group("/brands")
 	brandRoutes.Get("/", c.Controllers.Brand.GetAllBrands)
-	brandRoutes.Get("/:id", c.Controllers.Brand.GetBrandByID)
+	// Follows need a signed-in user; /following must be registered before /:id, which only reads the token when sent
+	brandRoutes.Get("/following", middlewares.AuthMiddleware(), c.Controllers.Brand.GetFollowedBrands)
+	brandRoutes.Get("/:id", middlewares.OptionalAuthMiddleware(), c.Controllers.Brand.GetBrandByID)
+	brandRoutes.Post("/:id/follow", middlewares.AuthMiddleware(), c.Controllers.Brand.FollowBrand)
+	brandRoutes.Delete("/:id/follow", middlewares.AuthMiddleware(), c.Controllers.Brand.UnfollowBrand)
 
 	// Admin brand routes
 	brandAdminRoutes := api.Group("/admin/brands")
@@ -124,6 +175,129 @@ func setupBrandRoutes(api fiber.Router, c *container.Container) {
 	brandAdminRoutes.Post("/", c.Controllers.Brand.AdminCreateBrand)
 	brandAdminRoutes.Put("/:id", c.Controllers.Brand.AdminUpdateBrand)
 	brandAdminRoutes.Delete("/:id", c.Controllers.Brand.AdminDeleteBrand)
+	brandAdminRoutes.Get("/:id/members", c.Controllers.BrandMember.AdminGetBrandMembers)
+	brandAdminRoutes.Post("/:id/members", c.Controllers.BrandMember.AdminAddBrandMember)
+	brandAdminRoutes.Delete("/:id/members/:userId", c.Controllers.BrandMember.AdminRemoveBrandMember)
+}
+
+// setupVariationRoutes configures product variation and variation option routes
+func setupVariationRoutes(api fiber.Router, c *container.Container) {
+	// Public variation routes
+	variationRoutes := api.Group("/variations")
+	variationRoutes.Get("/", c.Controllers.Variation.GetAllVariations)
+	variationRoutes.Get("/:id", c.Controllers.Variation.GetVariationByID)
+
+	// Admin variation routes
+	variationAdminRoutes := api.Group("/admin/variations")
+	variationAdminRoutes.Use(middlewares.AuthMiddleware(), middlewares.AdminMiddleware())
+	variationAdminRoutes.Post("/", c.Controllers.Variation.AdminCreateVariation)
+	variationAdminRoutes.Put("/:id", c.Controllers.Variation.AdminUpdateVariation)
+	variationAdminRoutes.Delete("/:id", c.Controllers.Variation.AdminDeleteVariation)
+	variationAdminRoutes.Post("/:id/options", c.Controllers.Variation.AdminCreateOption)
+	variationAdminRoutes.Put("/:id/options/order", c.Controllers.Variation.AdminReorderOptions)
+
+	optionAdminRoutes := api.Group("/admin/variation-options")
+	optionAdminRoutes.Use(middlewares.AuthMiddleware(), middlewares.AdminMiddleware())
+	optionAdminRoutes.Put("/:optionId", c.Controllers.Variation.AdminUpdateOption)
+	optionAdminRoutes.Delete("/:optionId", c.Controllers.Variation.AdminDeleteOption)
+}
+
+// setupCampaignRoutes configures discount campaign routes
+func setupCampaignRoutes(api fiber.Router, c *container.Container) {
+	campaignAdminRoutes := api.Group("/admin/campaigns")
+	campaignAdminRoutes.Use(middlewares.AuthMiddleware(), middlewares.AdminMiddleware())
+	campaignAdminRoutes.Get("/", c.Controllers.Campaign.AdminGetAllCampaigns)
+	campaignAdminRoutes.Post("/", c.Controllers.Campaign.AdminCreateCampaign)
+	campaignAdminRoutes.Get("/:id", c.Controllers.Campaign.AdminGetCampaignByID)
+	campaignAdminRoutes.Put("/:id", c.Controllers.Campaign.AdminUpdateCampaign)
+	campaignAdminRoutes.Delete("/:id", c.Controllers.Campaign.AdminDeleteCampaign)
+}
+
+// setupStockRoutes configures product item stock management routes
+func setupStockRoutes(api fiber.Router, c *container.Container) {
+	itemAdminRoutes := api.Group("/admin/product-items")
+	itemAdminRoutes.Use(middlewares.AuthMiddleware(), middlewares.AdminMiddleware())
+	itemAdminRoutes.Post("/:id/stock", c.Controllers.Stock.AdminAdjustStock)
+	itemAdminRoutes.Get("/:id/stock/movements", c.Controllers.Stock.AdminGetStockMovements)
+	itemAdminRoutes.Put("/:id/low-stock-threshold", c.Controllers.Stock.AdminSetLowStockThreshold)
+
+	stockAdminRoutes := api.Group("/admin/stock")
+	stockAdminRoutes.Use(middlewares.AuthMiddleware(), middlewares.AdminMiddleware())
+	stockAdminRoutes.Get("/low", c.Controllers.Stock.AdminGetLowStockItems)
+}
+
+// setupBrandApplicationRoutes configures brand onboarding and verification routes
+func setupBrandApplicationRoutes(api fiber.Router, c *container.Container) {
+	applicationRoutes := api.Group("/brand-applications")
+	applicationRoutes.Use(middlewares.AuthMiddleware())
+	applicationRoutes.Post("/", c.Controllers.BrandApplication.SubmitApplication)
+	applicationRoutes.Get("/me", c.Controllers.BrandApplication.GetMyApplications)
+
+	applicationAdminRoutes := api.Group("/admin/brand-applications")
+	applicationAdminRoutes.Use(middlewares.AuthMiddleware(), middlewares.AdminMiddleware())
+	applicationAdminRoutes.Get("/", c.Controllers.BrandApplication.AdminGetApplications)
+	applicationAdminRoutes.Get("/:id", c.Controllers.BrandApplication.AdminGetApplication)
+	applicationAdminRoutes.Post("/:id/approve", c.Controllers.BrandApplication.AdminApproveApplication)
+	applicationAdminRoutes.Post("/:id/reject", c.Controllers.BrandApplication.AdminRejectApplication)
+}
+
+// setupConversionRoutes configures brand-confirmed sales and counter reconciliation routes
+func setupConversionRoutes(api fiber.Router, c *container.Container) {
+	// Brand members record sales; membership is checked by the service
+	conversionRoutes := api.Group("/brands/:id/conversions")
+	conversionRoutes.Use(middlewares.AuthMiddleware())
+	conversionRoutes.Get("/", c.Controllers.Conversion.GetBrandConversions)
+	conversionRoutes.Post("/", c.Controllers.Conversion.RecordConversion)
+	conversionRoutes.Post("/postback-secret", c.Controllers.Conversion.RotatePostbackSecret)
+
+	// Brand systems and marketplace integrations report orders here; requests are authenticated by their HMAC signature
+	api.Post("/webhooks/brands/:id/conversions", c.Controllers.Conversion.RecordPostback)
+
+	counterAdminRoutes := api.Group("/admin/counters")
+	counterAdminRoutes.Use(middlewares.AuthMiddleware(), middlewares.AdminMiddleware())
+	counterAdminRoutes.Post("/reconcile", c.Controllers.Conversion.AdminReconcileCounters)
+}
+
+// setupRedirectChannelRoutes configures brand redirect order, A/B split, link parameter and channel report routes
+func setupRedirectChannelRoutes(api fiber.Router, c *container.Container) {
+	// Brand members manage their own channels; membership is checked by the service
+	channelRoutes := api.Group("/brands/:id")
+	channelRoutes.Get("/redirect-channels", middlewares.AuthMiddleware(), c.Controllers.RedirectChannel.GetBrandChannels)
+	channelRoutes.Put("/redirect-channels", middlewares.AuthMiddleware(), c.Controllers.RedirectChannel.UpdateBrandChannels)
+	channelRoutes.Get("/channel-report", middlewares.AuthMiddleware(), c.Controllers.RedirectChannel.GetChannelReport)
+	channelRoutes.Get("/link-params", middlewares.AuthMiddleware(), c.Controllers.RedirectChannel.GetLinkParams)
+	channelRoutes.Put("/link-params", middlewares.AuthMiddleware(), c.Controllers.RedirectChannel.UpdateLinkParams)
+}
+
+// setupClickAnalyticsRoutes configures the click analytics routes of admins and brands
+func setupClickAnalyticsRoutes(api fiber.Router, c *container.Container) {
+	analyticsAdminRoutes := api.Group("/admin/analytics")
+	analyticsAdminRoutes.Use(middlewares.AuthMiddleware(), middlewares.AdminMiddleware())
+	analyticsAdminRoutes.Get("/clicks", c.Controllers.ClickAnalytics.AdminGetClickAnalytics)
+
+	// Brand members see their own brand; membership is checked by the service
+	api.Get("/brands/:id/analytics/clicks", middlewares.AuthMiddleware(), c.Controllers.ClickAnalytics.GetBrandClickAnalytics)
+
+	// Performance report exports
+	api.Get("/admin/brands/:id/reports", middlewares.AuthMiddleware(), middlewares.AdminMiddleware(), c.Controllers.BrandReport.AdminGetBrandReport)
+	api.Get("/brands/:id/reports", middlewares.AuthMiddleware(), c.Controllers.BrandReport.GetBrandReport)
+}
+
+// setupLinkHealthRoutes configures the marketplace link health report routes
+func setupLinkHealthRoutes(api fiber.Router, c *container.Container) {
+	linkAdminRoutes := api.Group("/admin/links")
+	linkAdminRoutes.Use(middlewares.AuthMiddleware(), middlewares.AdminMiddleware())
+	linkAdminRoutes.Get("/broken", c.Controllers.LinkHealth.AdminGetBrokenLinks)
+	linkAdminRoutes.Post("/check", c.Controllers.LinkHealth.AdminCheckLinks)
+}
+
+// setupNotificationRoutes configures the user notification inbox routes
+func setupNotificationRoutes(api fiber.Router, c *container.Container) {
+	notificationRoutes := api.Group("/notifications")
+	notificationRoutes.Use(middlewares.AuthMiddleware())
+	notificationRoutes.Get("/", c.Controllers.Notification.GetNotifications)
+	notificationRoutes.Put("/read-all", c.Controllers.Notification.MarkAllAsRead)
+	notificationRoutes.Put("/:id/read", c.Controllers.Notification.MarkAsRead)
 }
 
 // setupsavedItemsRoutes configures all saved items routes
@@ -134,6 +308,7 @@ func setupsavedItemsRoutes(api fiber.Router, c *container.Container) {
 	savedItemsRoutes.Get("/", c.Controllers.SavedItems.GetUserSavedItems)
 	savedItemsRoutes.Post("/", c.Controllers.SavedItems.AddProductItemToSavedItems)
 	savedItemsRoutes.Put("/:itemId", c.Controllers.SavedItems.UpdateProductItemInSavedItems)
+	savedItemsRoutes.Put("/:itemId/watch", c.Controllers.Watch.UpdateSavedItemWatch)
 	savedItemsRoutes.Delete("/:itemId", c.Controllers.SavedItems.RemoveProductItemFromSavedItems)
 }
 
@@ -145,6 +320,7 @@ func setupFavoriteRoutes(api fiber.Router, c *container.Container) {
 	favoriteRoutes.Get("/", c.Controllers.Favorite.GetUserFavorites)
 	favoriteRoutes.Post("/:productId", c.Controllers.Favorite.ToggleFavorite)
 	favoriteRoutes.Delete("/:productId", c.Controllers.Favorite.RemoveFavorite)
+	favoriteRoutes.Put("/:productId/watch", c.Controllers.Watch.UpdateFavoriteWatch)
 }
 
 // setupReviewRoutes configures all review-related routes
@@ -183,9 +359,18 @@ func setupAIRoutes(api fiber.Router, c *container.Container) {
 
 	// AI prediction endpoints
 	predictionRoutes := aiRoutes.Group("/predict")
+	predictionRoutes.Use(middlewares.RequestTimeout(constants.AIPredictionRequestTimeout))
 	predictionRoutes.Post("/skin-color-tone", c.Controllers.AI.PredictSkinColorTone)
 	predictionRoutes.Post("/woman-body-scan", c.Controllers.AI.PredictWomanBodyScan)
 	predictionRoutes.Post("/men-body-scan", c.Controllers.AI.PredictMenBodyScan)
+
+	// Asynchronous predictions: uploads return a job to poll or follow over server-sent events
+	jobRoutes := aiRoutes.Group("/jobs")
+	jobRoutes.Post("/skin-color-tone", c.Controllers.AI.SubmitSkinColorToneJob)
+	jobRoutes.Post("/woman-body-scan", c.Controllers.AI.SubmitWomanBodyScanJob)
+	jobRoutes.Post("/men-body-scan", c.Controllers.AI.SubmitMenBodyScanJob)
+	jobRoutes.Get("/:id", c.Controllers.AI.GetJob)
+	jobRoutes.Get("/:id/events", c.Controllers.AI.StreamJob)
 }
 
 // setupScanHistoryRoutes configures all scan history-related routes
@@ -238,4 +423,5 @@ func setupDashboardRoutes(app fiber.Router, c *container.Container) {
 	dashboardRoutes.Get("/user-analytics", c.Controllers.Dashboard.GetUserAnalytics)
 	dashboardRoutes.Get("/product-analytics", c.Controllers.Dashboard.GetProductAnalytics)
 	dashboardRoutes.Get("/revenue-analytics", c.Controllers.Dashboard.GetRevenueAnalytics)
+	dashboardRoutes.Get("/timeseries", c.Controllers.Dashboard.GetTimeSeries)
 }
@@ -41,21 +41,33 @@ func SeedProductCategories(db *gorm.DB) error {
 }
 
 func SeedProductVariationsAndOptions(db *gorm.DB) error {
-	// Variasi: Warna dan Ukuran
-	colors := []string{"Merah", "Biru", "Hitam", "Putih", "Hijau", "Kuning"}
+	// Variasi: Warna (dengan hex untuk dicocokkan ke rekomendasi warna face scan) dan Ukuran
+	colors := []struct {
+		Value string
+		Hex   string
+	}{
+		{"Merah", "#FF0000"},
+		{"Biru", "#0000FF"},
+		{"Hitam", "#000000"},
+		{"Putih", "#FFFFFF"},
+		{"Hijau", "#008000"},
+		{"Kuning", "#FFFF00"},
+	}
 	sizes := []string{"S", "M", "L", "XL", "XXL"}
 
 	// Buat variation Warna
 	colorVar := models.ProductVariation{Name: "Warna"}
-	if err := db.Where(models.ProductVariation{Name: colorVar.Name}).FirstOrCreate(&colorVar).Error; err != nil {
+	if err := db.Where(models.ProductVariation{Name: colorVar.Name}).Assign(models.ProductVariation{IsColor: true}).FirstOrCreate(&colorVar).Error; err != nil {
 		return err
 	}
-	for _, c := range colors {
+	for i, c := range colors {
 		opt := models.ProductVariationOption{
 			ProductAttributeID: colorVar.ID,
-			Value:              c,
+			Value:              c.Value,
 		}
-		if err := db.Where(models.ProductVariationOption{ProductAttributeID: colorVar.ID, Value: c}).FirstOrCreate(&opt).Error; err != nil {
+		if err := db.Where(models.ProductVariationOption{ProductAttributeID: colorVar.ID, Value: c.Value}).
+			Assign(models.ProductVariationOption{HexValue: c.Hex, SortOrder: i + 1}).
+			FirstOrCreate(&opt).Error; err != nil {
 			return err
 		}
 	}
@@ -65,12 +77,14 @@ func SeedProductVariationsAndOptions(db *gorm.DB) error {
 	if err := db.Where(models.ProductVariation{Name: sizeVar.Name}).FirstOrCreate(&sizeVar).Error; err != nil {
 		return err
 	}
-	for _, s := range sizes {
+	for i, s := range sizes {
 		opt := models.ProductVariationOption{
 			ProductAttributeID: sizeVar.ID,
 			Value:              s,
 		}
-		if err := db.Where(models.ProductVariationOption{ProductAttributeID: sizeVar.ID, Value: s}).FirstOrCreate(&opt).Error; err != nil {
+		if err := db.Where(models.ProductVariationOption{ProductAttributeID: sizeVar.ID, Value: s}).
+			Assign(models.ProductVariationOption{SortOrder: i + 1}).
+			FirstOrCreate(&opt).Error; err != nil {
 			return err
 		}
 	}
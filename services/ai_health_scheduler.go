@@ -0,0 +1,23 @@
+package services
+
+import (
+	"context"
+	"flicknfit_backend/utils"
+	"time"
+)
+
+// NewAIHealthScheduler creates a job that checks the model API at the given interval, so its circuit breaker
+// and the health endpoint reflect outages even while no predictions are requested.
+func NewAIHealthScheduler(client AIModelClient, interval time.Duration) *PeriodicJob {
+	healthy := true // Outcome of the previous check, to log changes only
+	return NewPeriodicJob(interval, func() {
+		err := client.CheckHealth(context.Background())
+		switch {
+		case err != nil && healthy:
+			utils.GetLogger().WithError(err).Warn("AI model API is unreachable")
+		case err == nil && !healthy:
+			utils.GetLogger().Info("AI model API is reachable again")
+		}
+		healthy = err == nil
+	})
+}
@@ -0,0 +1,361 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"flicknfit_backend/utils"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// errAIJobsStopped fails the jobs still queued or running when the service stops.
+var errAIJobsStopped = errors.New("the server restarted before the prediction finished, please upload the image again")
+
+// errAIJobTimeout fails a job whose prediction ran longer than the job timeout.
+var errAIJobTimeout = errors.New("the prediction took too long, please try again later")
+
+// AIJobService runs AI predictions in the background so uploads return at once. Jobs are kept in memory, so
+// they are only visible on the instance that accepted them and are lost on restart.
+type AIJobService interface {
+	SubmitJob(userID uint64, kind string, image []byte, filename string) (*dtos.AIJobDTO, error)
+	GetJob(jobID string, userID uint64) (*dtos.AIJobDTO, error)
+	// WatchJob returns the job and a channel that is closed on its next change.
+	WatchJob(jobID string, userID uint64) (*dtos.AIJobDTO, <-chan struct{}, error)
+	Start()
+	// Stop cancels the running predictions, waits for them to return and fails the jobs still queued.
+	Stop()
+}
+
+// AIJobOptions configures the AI job service.
+type AIJobOptions struct {
+	Workers   int           // Predictions run at once
+	QueueSize int           // Jobs that can wait for a worker
+	Retention time.Duration // How long finished jobs are kept
+	Timeout   time.Duration // How long a prediction may run
+}
+
+// aiJob is a prediction job. Its fields are guarded by the service's mutex.
+type aiJob struct {
+	id         string
+	userID     uint64
+	kind       string
+	filename   string
+	image      []byte // Dropped once the job finished
+	seq        uint64 // Order in which the job was queued
+	status     string
+	result     interface{}
+	err        string
+	createdAt  time.Time
+	startedAt  time.Time
+	finishedAt time.Time
+	changed    chan struct{} // Closed and replaced on every change
+}
+
+// aiJobService implements AIJobService interface
+type aiJobService struct {
+	aiService          AIService
+	scanHistoryService ScanHistoryService
+	options            AIJobOptions
+	queue              chan *aiJob
+	stop               chan struct{}
+	ctx                context.Context // Cancelled by Stop, so running predictions return early
+	cancel             context.CancelFunc
+	wg                 sync.WaitGroup
+
+	mu       sync.Mutex
+	jobs     map[string]*aiJob
+	queued   uint64 // Jobs ever queued
+	taken    uint64 // Jobs ever taken by a worker
+	stopped  bool
+	stopOnce sync.Once
+}
+
+// NewAIJobService creates an AI job service running predictions with aiService. When scanHistoryService is
+// set, successful predictions are saved to the user's scan history like the synchronous endpoints do.
+func NewAIJobService(aiService AIService, scanHistoryService ScanHistoryService, options AIJobOptions) AIJobService {
+	if options.Workers < 1 {
+		options.Workers = 1
+	}
+	if options.QueueSize < 1 {
+		options.QueueSize = 1
+	}
+	if options.Retention <= 0 {
+		options.Retention = constants.AIJobRetention
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = constants.AIJobTimeout
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &aiJobService{
+		aiService:          aiService,
+		scanHistoryService: scanHistoryService,
+		options:            options,
+		queue:              make(chan *aiJob, options.QueueSize),
+		stop:               make(chan struct{}),
+		ctx:                ctx,
+		cancel:             cancel,
+		jobs:               make(map[string]*aiJob),
+	}
+}
+
+// SubmitJob queues a prediction of the given kind on an uploaded image
+func (s *aiJobService) SubmitJob(userID uint64, kind string, image []byte, filename string) (*dtos.AIJobDTO, error) {
+	switch kind {
+	case constants.AIRequestSkinColorTone, constants.AIRequestWomanBodyScan, constants.AIRequestMenBodyScan:
+	default:
+		return nil, apperrors.NewValidationError("Unknown prediction kind")
+	}
+	if len(image) == 0 {
+		return nil, apperrors.NewValidationError("Image is empty")
+	}
+
+	now := time.Now()
+	job := &aiJob{
+		id:        uuid.New().String(),
+		userID:    userID,
+		kind:      kind,
+		filename:  filename,
+		image:     image,
+		status:    constants.AIJobStatusQueued,
+		createdAt: now,
+		changed:   make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return nil, apperrors.New(apperrors.ErrorTypeExternal, http.StatusServiceUnavailable, "AI predictions are not accepted while the server shuts down")
+	}
+	s.pruneLocked(now)
+	select {
+	case s.queue <- job:
+	default:
+		return nil, apperrors.New(apperrors.ErrorTypeExternal, http.StatusServiceUnavailable, "Too many AI predictions are waiting, please try again later")
+	}
+	s.queued++
+	job.seq = s.queued
+	s.jobs[job.id] = job
+	return s.snapshotLocked(job), nil
+}
+
+// GetJob returns a job of the user
+func (s *aiJobService) GetJob(jobID string, userID uint64) (*dtos.AIJobDTO, error) {
+	job, _, err := s.WatchJob(jobID, userID)
+	return job, err
+}
+
+// WatchJob returns a job of the user and a channel closed on its next change. Jobs of other users are
+// reported as not found.
+func (s *aiJobService) WatchJob(jobID string, userID uint64) (*dtos.AIJobDTO, <-chan struct{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[jobID]
+	if !ok || job.userID != userID {
+		return nil, nil, apperrors.NewNotFoundError("AI job")
+	}
+	return s.snapshotLocked(job), job.changed, nil
+}
+
+// Start runs the workers.
+func (s *aiJobService) Start() {
+	for i := 0; i < s.options.Workers; i++ {
+		s.wg.Add(1)
+		go s.work()
+	}
+}
+
+// Stop stops accepting jobs, cancels the running predictions and waits for them to return, then fails the
+// jobs still queued.
+func (s *aiJobService) Stop() {
+	s.stopOnce.Do(func() {
+		s.mu.Lock()
+		s.stopped = true
+		s.mu.Unlock()
+		s.cancel()
+		close(s.stop)
+		s.wg.Wait()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for {
+			select {
+			case job := <-s.queue:
+				s.finishLocked(job, nil, errAIJobsStopped)
+			default:
+				return
+			}
+		}
+	})
+}
+
+// work runs queued jobs one at a time until the service stops.
+func (s *aiJobService) work() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case job := <-s.queue:
+			s.run(job)
+		}
+	}
+}
+
+// run marks a job running, runs its prediction within the job timeout and stores the outcome. A panicking
+// prediction fails the job.
+func (s *aiJobService) run(job *aiJob) {
+	s.mu.Lock()
+	s.taken++
+	job.status = constants.AIJobStatusRunning
+	job.startedAt = time.Now()
+	image, kind, filename, userID := job.image, job.kind, job.filename, job.userID
+	s.notifyLocked(job)
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(s.ctx, s.options.Timeout)
+	defer cancel()
+	var result interface{}
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("prediction panicked: %v", r)
+			}
+		}()
+		result, err = s.predict(ctx, userID, kind, image, filename)
+	}()
+	if err != nil {
+		switch {
+		case s.ctx.Err() != nil:
+			err = errAIJobsStopped
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			err = errAIJobTimeout
+		}
+		utils.GetLogger().WithError(err).WithFields(logrus.Fields{
+			"job_id": job.id,
+			"kind":   kind,
+		}).Warn("AI prediction job failed")
+	}
+
+	s.mu.Lock()
+	s.finishLocked(job, result, err)
+	s.mu.Unlock()
+}
+
+// predict calls the prediction of kind and saves a successful one to the user's scan history
+func (s *aiJobService) predict(ctx context.Context, userID uint64, kind string, image []byte, filename string) (interface{}, error) {
+	switch kind {
+	case constants.AIRequestSkinColorTone:
+		result, err := s.aiService.PredictSkinColorTone(ctx, newMemoryFile(image), filename)
+		if err != nil {
+			return nil, err
+		}
+		if s.scanHistoryService != nil && userID > 0 {
+			if err := s.scanHistoryService.SaveFaceScanHistory(userID, newMemoryFile(image), filename, result); err != nil {
+				utils.GetLogger().WithError(err).WithField("user_id", userID).Warn("Failed to save face scan history")
+			}
+		}
+		return result, nil
+	case constants.AIRequestWomanBodyScan:
+		result, err := s.aiService.PredictWomanBodyScan(ctx, newMemoryFile(image), filename)
+		if err != nil {
+			return nil, err
+		}
+		s.saveBodyScan(userID, image, filename, "woman", result)
+		return result, nil
+	default:
+		result, err := s.aiService.PredictMenBodyScan(ctx, newMemoryFile(image), filename)
+		if err != nil {
+			return nil, err
+		}
+		s.saveBodyScan(userID, image, filename, "man", result)
+		return result, nil
+	}
+}
+
+// saveBodyScan saves a body scan to the user's scan history; a failure only logs a warning
+func (s *aiJobService) saveBodyScan(userID uint64, image []byte, filename, gender string, result interface{}) {
+	if s.scanHistoryService == nil || userID == 0 {
+		return
+	}
+	if err := s.scanHistoryService.SaveBodyScanHistory(userID, newMemoryFile(image), filename, gender, result); err != nil {
+		utils.GetLogger().WithError(err).WithField("user_id", userID).Warn("Failed to save body scan history")
+	}
+}
+
+// finishLocked stores the outcome of a job and drops its image.
+func (s *aiJobService) finishLocked(job *aiJob, result interface{}, err error) {
+	job.finishedAt = time.Now()
+	job.image = nil
+	if err != nil {
+		job.status = constants.AIJobStatusFailed
+		job.err = err.Error()
+	} else {
+		job.status = constants.AIJobStatusSucceeded
+		job.result = result
+	}
+	s.notifyLocked(job)
+}
+
+// notifyLocked wakes the watchers of a job.
+func (s *aiJobService) notifyLocked(job *aiJob) {
+	close(job.changed)
+	job.changed = make(chan struct{})
+}
+
+// pruneLocked forgets the jobs that finished longer than the retention period ago.
+func (s *aiJobService) pruneLocked(now time.Time) {
+	for id, job := range s.jobs {
+		if !job.finishedAt.IsZero() && now.Sub(job.finishedAt) > s.options.Retention {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+// snapshotLocked copies a job into its DTO.
+func (s *aiJobService) snapshotLocked(job *aiJob) *dtos.AIJobDTO {
+	dto := &dtos.AIJobDTO{
+		ID:        job.id,
+		Kind:      job.kind,
+		Status:    job.status,
+		Result:    job.result,
+		Error:     job.err,
+		CreatedAt: job.createdAt,
+	}
+	if job.status == constants.AIJobStatusQueued {
+		dto.QueuePosition = int(job.seq - s.taken)
+	}
+	if !job.startedAt.IsZero() {
+		startedAt := job.startedAt
+		dto.StartedAt = &startedAt
+	}
+	if !job.finishedAt.IsZero() {
+		finishedAt := job.finishedAt
+		dto.FinishedAt = &finishedAt
+	}
+	return dto
+}
+
+// memoryFile serves an uploaded image kept in memory as a multipart.File, so it can be read again after the
+// request that uploaded it ended.
+type memoryFile struct {
+	*bytes.Reader
+}
+
+func newMemoryFile(content []byte) memoryFile {
+	return memoryFile{bytes.NewReader(content)}
+}
+
+// Close does nothing; the content is released with the job.
+func (memoryFile) Close() error {
+	return nil
+}
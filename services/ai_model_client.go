@@ -0,0 +1,249 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrAIModelUnavailable is returned without calling the model API while its circuit breaker is open.
+var ErrAIModelUnavailable = apperrors.New(apperrors.ErrorTypeExternal, http.StatusServiceUnavailable, "AI model is temporarily unavailable, please try again later")
+
+// AIModelClient calls the model API behind AI_API_URL. Requests are retried on transient failures and refused
+// while a circuit breaker is open, so an unreachable model API fails fast instead of holding requests.
+type AIModelClient interface {
+	// Post sends a request to path. The caller closes the body of the returned response.
+	Post(ctx context.Context, path string, body []byte, contentType string) (*http.Response, error)
+	// CheckHealth calls the model API's health check and feeds the outcome to the circuit breaker.
+	CheckHealth(ctx context.Context) error
+	Health() dtos.AIModelHealthDTO
+}
+
+// AIModelClientOptions configures the model API client.
+type AIModelClientOptions struct {
+	HealthPath         string        // Health check path, relative to the base URL
+	AttemptTimeout     time.Duration // Bound of a single request
+	CallTimeout        time.Duration // Bound of all attempts of a call whose context has no deadline
+	HealthCheckTimeout time.Duration
+	MaxAttempts        int
+	RetryBaseDelay     time.Duration // Backoff before the second attempt, doubled for each further one
+	RetryMaxDelay      time.Duration
+	FailureThreshold   int           // Failures in a row that open the circuit breaker
+	OpenDuration       time.Duration // How long the breaker stays open before probing
+}
+
+// aiModelClient implements AIModelClient interface
+type aiModelClient struct {
+	baseURL    string
+	options    AIModelClientOptions
+	httpClient *http.Client
+	breaker    *CircuitBreaker
+
+	mu          sync.Mutex // Guards the outcome of the last health check
+	lastCheckAt time.Time
+	lastLatency time.Duration
+	lastErr     error
+}
+
+// NewAIModelClient creates a client of the model API at baseURL.
+func NewAIModelClient(baseURL string, options AIModelClientOptions) AIModelClient {
+	if options.MaxAttempts < 1 {
+		options.MaxAttempts = 1
+	}
+	if options.HealthPath == "" {
+		options.HealthPath = constants.DefaultAIHealthPath
+	}
+	return &aiModelClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		options:    options,
+		httpClient: &http.Client{Timeout: options.AttemptTimeout},
+		breaker:    NewCircuitBreaker(options.FailureThreshold, options.OpenDuration),
+	}
+}
+
+// Post sends a request to path, retrying connection failures and 429, 502, 503 and 504 responses with
+// jittered exponential backoff. Predictions have no side effects, so repeating them is safe. The last
+// response is returned as is when every attempt failed with a status.
+func (c *aiModelClient) Post(ctx context.Context, path string, body []byte, contentType string) (*http.Response, error) {
+	cancel := context.CancelFunc(func() {})
+	if _, ok := ctx.Deadline(); !ok && c.options.CallTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.options.CallTimeout)
+	}
+
+	for attempt := 1; ; attempt++ {
+		if err := c.breaker.Allow(); err != nil {
+			cancel()
+			return nil, ErrAIModelUnavailable
+		}
+
+		resp, err := c.send(ctx, path, body, contentType)
+		retryable := c.record(ctx, resp, err)
+		if !retryable || attempt == c.options.MaxAttempts {
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			// The response body outlives this call, so the call timeout ends when it is closed
+			resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(c.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			cancel()
+			if err == nil {
+				err = fmt.Errorf("AI API error (status %d)", resp.StatusCode)
+			}
+			return nil, fmt.Errorf("gave up retrying the AI API: %w", err)
+		case <-timer.C:
+		}
+	}
+}
+
+// send makes a single request to path
+func (c *aiModelClient) send(ctx context.Context, path string, body []byte, contentType string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	return resp, nil
+}
+
+// record feeds the outcome of an attempt to the circuit breaker and reports whether it is worth retrying.
+// Errors and 5xx responses count as failures; a call cancelled by its caller says nothing about the model API.
+func (c *aiModelClient) record(ctx context.Context, resp *http.Response, err error) (retryable bool) {
+	if err != nil {
+		if errors.Is(ctx.Err(), context.Canceled) {
+			c.breaker.Skip()
+			return false
+		}
+		c.breaker.Failure()
+		return ctx.Err() == nil
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		c.breaker.Skip()
+		return true
+	case resp.StatusCode >= http.StatusInternalServerError:
+		c.breaker.Failure()
+		return resp.StatusCode == http.StatusBadGateway ||
+			resp.StatusCode == http.StatusServiceUnavailable ||
+			resp.StatusCode == http.StatusGatewayTimeout
+	default:
+		c.breaker.Success()
+		return false
+	}
+}
+
+// backoff returns the wait before the attempt after the given one: half the exponential delay plus a random
+// share of the other half, so clients that failed together do not retry together.
+func (c *aiModelClient) backoff(attempt int) time.Duration {
+	delay := c.options.RetryBaseDelay << (attempt - 1)
+	if delay <= 0 || (c.options.RetryMaxDelay > 0 && delay > c.options.RetryMaxDelay) {
+		delay = c.options.RetryMaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// CheckHealth calls the health check of the model API. Any response below 500 means the model API is up,
+// so a missing health route does not count against it. A healthy check lets an open breaker probe at once;
+// a failed one counts as a failure.
+func (c *aiModelClient) CheckHealth(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.options.HealthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.checkHealth(ctx)
+	if err != nil {
+		c.breaker.Failure()
+	} else {
+		c.breaker.MarkHealthy()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastCheckAt = start
+	c.lastLatency = time.Since(start)
+	c.lastErr = err
+	return err
+}
+
+// checkHealth makes the health check request
+func (c *aiModelClient) checkHealth(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+c.options.HealthPath, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("AI API health check failed (status %d)", resp.StatusCode)
+	}
+	return nil
+}
+
+// Health returns the state of the circuit breaker and the outcome of the last health check
+func (c *aiModelClient) Health() dtos.AIModelHealthDTO {
+	state := c.breaker.State()
+	health := dtos.AIModelHealthDTO{
+		Breaker:             state.State,
+		ConsecutiveFailures: state.Failures,
+	}
+	if !state.OpenedAt.IsZero() {
+		health.OpenedAt = &state.OpenedAt
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	health.Healthy = state.State == constants.CircuitClosed && c.lastErr == nil
+	if !c.lastCheckAt.IsZero() {
+		lastCheckAt := c.lastCheckAt
+		health.LastCheckAt = &lastCheckAt
+		health.LastCheckLatencyMs = c.lastLatency.Milliseconds()
+	}
+	if c.lastErr != nil {
+		health.LastCheckError = c.lastErr.Error()
+	}
+	return health
+}
+
+// cancelOnClose releases the context of a call once its response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
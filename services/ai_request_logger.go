@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/utils"
+	"mime/multipart"
+	"time"
+)
+
+// maxAIRequestErrorLength matches the size of the AIRequestLog.Error column
+const maxAIRequestErrorLength = 255
+
+// loggedAIService records every prediction call of the wrapped AIService in the AI request log
+type loggedAIService struct {
+	next          AIService
+	analyticsRepo repositories.AnalyticsRepository
+}
+
+// PredictSkinColorTone calls the wrapped service and logs the request
+func (s *loggedAIService) PredictSkinColorTone(ctx context.Context, file multipart.File, filename string) (*dtos.SkinColorTonePredictionResponseDTO, error) {
+	start := time.Now()
+	result, err := s.next.PredictSkinColorTone(ctx, file, filename)
+	s.logRequest(constants.AIRequestSkinColorTone, start, err)
+	return result, err
+}
+
+// PredictWomanBodyScan calls the wrapped service and logs the request
+func (s *loggedAIService) PredictWomanBodyScan(ctx context.Context, file multipart.File, filename string) (*dtos.WomanBodyScanPredictionResponseDTO, error) {
+	start := time.Now()
+	result, err := s.next.PredictWomanBodyScan(ctx, file, filename)
+	s.logRequest(constants.AIRequestWomanBodyScan, start, err)
+	return result, err
+}
+
+// PredictMenBodyScan calls the wrapped service and logs the request
+func (s *loggedAIService) PredictMenBodyScan(ctx context.Context, file multipart.File, filename string) (*dtos.MenBodyScanPredictionResponseDTO, error) {
+	start := time.Now()
+	result, err := s.next.PredictMenBodyScan(ctx, file, filename)
+	s.logRequest(constants.AIRequestMenBodyScan, start, err)
+	return result, err
+}
+
+// logRequest stores the outcome of a prediction call; a failure to store it never fails the prediction
+func (s *loggedAIService) logRequest(kind string, start time.Time, err error) {
+	entry := &models.AIRequestLog{
+		Kind:       kind,
+		Success:    err == nil,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		entry.Error = err.Error()
+		if len(entry.Error) > maxAIRequestErrorLength {
+			entry.Error = entry.Error[:maxAIRequestErrorLength]
+		}
+	}
+	if logErr := s.analyticsRepo.LogAIRequest(entry); logErr != nil {
+		utils.GetLogger().WithError(logErr).Warn("Failed to log AI request")
+	}
+}
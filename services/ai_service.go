@@ -2,39 +2,44 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"flicknfit_backend/config"
 	"flicknfit_backend/dtos"
+	"flicknfit_backend/repositories"
 	"fmt"
 	"io"
 	"log"
 	"mime/multipart"
 	"net/http"
-	"time"
 )
 
 // AIService defines the interface for AI prediction operations
 type AIService interface {
-	PredictSkinColorTone(file multipart.File, filename string) (*dtos.SkinColorTonePredictionResponseDTO, error)
-	PredictWomanBodyScan(file multipart.File, filename string) (*dtos.WomanBodyScanPredictionResponseDTO, error)
-	PredictMenBodyScan(file multipart.File, filename string) (*dtos.MenBodyScanPredictionResponseDTO, error)
+	PredictSkinColorTone(ctx context.Context, file multipart.File, filename string) (*dtos.SkinColorTonePredictionResponseDTO, error)
+	PredictWomanBodyScan(ctx context.Context, file multipart.File, filename string) (*dtos.WomanBodyScanPredictionResponseDTO, error)
+	PredictMenBodyScan(ctx context.Context, file multipart.File, filename string) (*dtos.MenBodyScanPredictionResponseDTO, error)
 }
 
 // aiService implements AIService
 type aiService struct {
-	config     *config.Config
-	httpClient *http.Client
-	llmChain   *LLMChain
+	config   *config.Config
+	client   AIModelClient
+	llmChain *LLMChain
 }
 
-// NewAIService creates a new AI service instance
-func NewAIService(cfg *config.Config) AIService {
+// NewAIService creates a new AI service instance calling the model API through client. When analyticsRepo
+// is set every prediction is recorded in the AI request log.
+func NewAIService(cfg *config.Config, client AIModelClient, analyticsRepo repositories.AnalyticsRepository) AIService {
 	if cfg == nil {
 		panic("config cannot be nil")
 	}
 	if cfg.AIApiURL == "" {
 		panic("AI_API_URL is required but not set in config")
 	}
+	if client == nil {
+		panic("AI model client cannot be nil")
+	}
 
 	// Initialize LLM providers in priority order: Groq -> Gemini -> Telkom
 	var providers []LLMProvider
@@ -77,17 +82,19 @@ func NewAIService(cfg *config.Config) AIService {
 		log.Printf("[AIService] WARNING: No LLM providers configured, recommendations will be disabled")
 	}
 
-	return &aiService{
-		config: cfg,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+	service := &aiService{
+		config:   cfg,
+		client:   client,
 		llmChain: llmChain,
 	}
+	if analyticsRepo == nil {
+		return service
+	}
+	return &loggedAIService{next: service, analyticsRepo: analyticsRepo}
 }
 
 // PredictSkinColorTone calls the skin color tone prediction API
-func (s *aiService) PredictSkinColorTone(file multipart.File, filename string) (*dtos.SkinColorTonePredictionResponseDTO, error) {
+func (s *aiService) PredictSkinColorTone(ctx context.Context, file multipart.File, filename string) (*dtos.SkinColorTonePredictionResponseDTO, error) {
 	log.Printf("DEBUG: AI Service PredictSkinColorTone called with filename: %s", filename)
 
 	// Nil checks
@@ -108,8 +115,7 @@ func (s *aiService) PredictSkinColorTone(file multipart.File, filename string) (
 		return nil, fmt.Errorf("filename is empty")
 	}
 
-	endpoint := fmt.Sprintf("%s/predict/sct", s.config.AIApiURL)
-	log.Printf("DEBUG: AI API endpoint: %s", endpoint)
+	log.Printf("DEBUG: AI API endpoint: %s/predict/sct", s.config.AIApiURL)
 
 	log.Printf("DEBUG: Creating multipart form...")
 	body, contentType, err := s.createMultipartForm(file, filename, "file")
@@ -119,7 +125,7 @@ func (s *aiService) PredictSkinColorTone(file multipart.File, filename string) (
 	}
 
 	log.Printf("DEBUG: Making HTTP request...")
-	resp, err := s.makeRequest("POST", endpoint, body, contentType)
+	resp, err := s.client.Post(ctx, "/predict/sct", body, contentType)
 	if err != nil {
 		log.Printf("ERROR: Failed to make request: %v", err)
 		return nil, err
@@ -149,9 +155,9 @@ func (s *aiService) PredictSkinColorTone(file multipart.File, filename string) (
 	log.Printf("DEBUG: AI Service success - Result: %+v", result)
 
 	// Enrich with LLM color recommendations if available
-	if s.llmChain != nil && result.SkinTone != "" {
+	if s.llmChain != nil && result.SkinTone != "" && ctx.Err() == nil {
 		log.Printf("DEBUG: Getting color recommendations for skin tone: %s", result.SkinTone)
-		colors, err := s.llmChain.GenerateColorRecommendations(result.SkinTone)
+		colors, err := s.llmChain.GenerateColorRecommendations(ctx, result.SkinTone)
 		if err != nil {
 			log.Printf("WARNING: Failed to get color recommendations: %v", err)
 			// Don't fail the whole request, just skip recommendations
@@ -165,15 +171,13 @@ func (s *aiService) PredictSkinColorTone(file multipart.File, filename string) (
 }
 
 // PredictWomanBodyScan calls the woman body scan prediction API
-func (s *aiService) PredictWomanBodyScan(file multipart.File, filename string) (*dtos.WomanBodyScanPredictionResponseDTO, error) {
-	endpoint := fmt.Sprintf("%s/wbs/predict", s.config.AIApiURL)
-
+func (s *aiService) PredictWomanBodyScan(ctx context.Context, file multipart.File, filename string) (*dtos.WomanBodyScanPredictionResponseDTO, error) {
 	body, contentType, err := s.createMultipartForm(file, filename, "file")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create multipart form: %w", err)
 	}
 
-	resp, err := s.makeRequest("POST", endpoint, body, contentType)
+	resp, err := s.client.Post(ctx, "/wbs/predict", body, contentType)
 	if err != nil {
 		return nil, err
 	}
@@ -194,9 +198,9 @@ func (s *aiService) PredictWomanBodyScan(file multipart.File, filename string) (
 	}
 
 	// Enrich with LLM style recommendations if available
-	if s.llmChain != nil && result.PredictedClass != "" {
+	if s.llmChain != nil && result.PredictedClass != "" && ctx.Err() == nil {
 		log.Printf("DEBUG: Getting style recommendations for body type: %s", result.PredictedClass)
-		styles, err := s.llmChain.GenerateStyleRecommendations(result.PredictedClass)
+		styles, err := s.llmChain.GenerateStyleRecommendations(ctx, result.PredictedClass)
 		if err != nil {
 			log.Printf("WARNING: Failed to get style recommendations: %v", err)
 			// Don't fail the whole request, just skip recommendations
@@ -210,15 +214,13 @@ func (s *aiService) PredictWomanBodyScan(file multipart.File, filename string) (
 }
 
 // PredictMenBodyScan calls the men's body scan prediction API
-func (s *aiService) PredictMenBodyScan(file multipart.File, filename string) (*dtos.MenBodyScanPredictionResponseDTO, error) {
-	endpoint := fmt.Sprintf("%s/mbs/predict", s.config.AIApiURL)
-
+func (s *aiService) PredictMenBodyScan(ctx context.Context, file multipart.File, filename string) (*dtos.MenBodyScanPredictionResponseDTO, error) {
 	body, contentType, err := s.createMultipartForm(file, filename, "file")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create multipart form: %w", err)
 	}
 
-	resp, err := s.makeRequest("POST", endpoint, body, contentType)
+	resp, err := s.client.Post(ctx, "/mbs/predict", body, contentType)
 	if err != nil {
 		return nil, err
 	}
@@ -239,9 +241,9 @@ func (s *aiService) PredictMenBodyScan(file multipart.File, filename string) (*d
 	}
 
 	// Enrich with LLM style recommendations if available
-	if s.llmChain != nil && result.PredictedClass != "" {
+	if s.llmChain != nil && result.PredictedClass != "" && ctx.Err() == nil {
 		log.Printf("DEBUG: Getting style recommendations for body type: %s", result.PredictedClass)
-		styles, err := s.llmChain.GenerateStyleRecommendations(result.PredictedClass)
+		styles, err := s.llmChain.GenerateStyleRecommendations(ctx, result.PredictedClass)
 		if err != nil {
 			log.Printf("WARNING: Failed to get style recommendations: %v", err)
 			// Don't fail the whole request, just skip recommendations
@@ -255,7 +257,7 @@ func (s *aiService) PredictMenBodyScan(file multipart.File, filename string) (*d
 }
 
 // createMultipartForm creates a multipart form with the uploaded file
-func (s *aiService) createMultipartForm(file multipart.File, filename, fieldName string) (io.Reader, string, error) {
+func (s *aiService) createMultipartForm(file multipart.File, filename, fieldName string) ([]byte, string, error) {
 	// Reset file position to beginning
 	if _, err := file.Seek(0, 0); err != nil {
 		return nil, "", fmt.Errorf("failed to seek file: %w", err)
@@ -280,24 +282,7 @@ func (s *aiService) createMultipartForm(file multipart.File, filename, fieldName
 		return nil, "", fmt.Errorf("failed to close multipart writer: %w", err)
 	}
 
-	return &body, writer.FormDataContentType(), nil
-}
-
-// makeRequest makes HTTP request to AI API
-func (s *aiService) makeRequest(method, url string, body io.Reader, contentType string) (*http.Response, error) {
-	req, err := http.NewRequest(method, url, body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", contentType)
-
-	resp, err := s.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-
-	return resp, nil
+	return body.Bytes(), writer.FormDataContentType(), nil
 }
 
 // handleErrorResponse handles error responses from AI API
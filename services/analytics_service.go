@@ -0,0 +1,242 @@
+package services
+
+import (
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/errors"
+	"flicknfit_backend/repositories"
+	"time"
+)
+
+// AnalyticsService defines business logic for the admin dashboard figures and metric time series.
+type AnalyticsService interface {
+	GetOverview() (*dtos.AnalyticsOverviewDTO, error)
+	GetTimeSeries(query *dtos.TimeSeriesQueryDTO) (*dtos.TimeSeriesResponseDTO, error)
+	GetRevenueAnalytics() (*dtos.RevenueAnalyticsDTO, error)
+}
+
+// analyticsService implements AnalyticsService interface
+type analyticsService struct {
+	analyticsRepo repositories.AnalyticsRepository
+}
+
+// analyticsMetrics lists the metrics a time series can be requested for
+var analyticsMetrics = map[string]bool{
+	constants.AnalyticsMetricGMV:         true,
+	constants.AnalyticsMetricClickValue:  true,
+	constants.AnalyticsMetricConversions: true,
+	constants.AnalyticsMetricUnitsSold:   true,
+	constants.AnalyticsMetricClicks:      true,
+	constants.AnalyticsMetricAIRequests:  true,
+	constants.AnalyticsMetricNewUsers:    true,
+}
+
+// NewAnalyticsService creates a new analytics service
+func NewAnalyticsService(analyticsRepo repositories.AnalyticsRepository) AnalyticsService {
+	return &analyticsService{analyticsRepo: analyticsRepo}
+}
+
+// GetOverview computes this month's revenue and conversions and today's AI requests
+func (s *analyticsService) GetOverview() (*dtos.AnalyticsOverviewDTO, error) {
+	now := time.Now()
+	today := startOfDay(now)
+	monthStart := startOfPeriod(today, constants.AnalyticsGranularityMonth)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	var overview dtos.AnalyticsOverviewDTO
+	var err error
+	if overview.RevenueThisMonth, err = s.total(constants.AnalyticsMetricGMV, monthStart, tomorrow); err != nil {
+		return nil, err
+	}
+	if overview.ClickValueThisMonth, err = s.total(constants.AnalyticsMetricClickValue, monthStart, tomorrow); err != nil {
+		return nil, err
+	}
+	conversions, err := s.total(constants.AnalyticsMetricConversions, monthStart, tomorrow)
+	if err != nil {
+		return nil, err
+	}
+	aiRequests, err := s.total(constants.AnalyticsMetricAIRequests, today, tomorrow)
+	if err != nil {
+		return nil, err
+	}
+	overview.ConversionsThisMonth = int64(conversions)
+	overview.AIRequestsToday = int64(aiRequests)
+	return &overview, nil
+}
+
+// GetTimeSeries aggregates a metric per day, week or month over a date range
+func (s *analyticsService) GetTimeSeries(query *dtos.TimeSeriesQueryDTO) (*dtos.TimeSeriesResponseDTO, error) {
+	if !analyticsMetrics[query.Metric] {
+		return nil, errors.NewValidationError("metric must be one of gmv, click_value, conversions, units_sold, clicks, ai_requests, new_users")
+	}
+	granularity, err := parseGranularity(query.Granularity)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := parseDateRange(query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	points, total, err := s.series(query.Metric, granularity, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.TimeSeriesResponseDTO{
+		Metric:      query.Metric,
+		Granularity: granularity,
+		From:        from.Format(constants.AnalyticsDateLayout),
+		To:          to.Format(constants.AnalyticsDateLayout),
+		Total:       total,
+		Points:      points,
+	}, nil
+}
+
+// GetRevenueAnalytics builds the monthly revenue trend, revenue per category, all-time revenue and month-over-month growth
+func (s *analyticsService) GetRevenueAnalytics() (*dtos.RevenueAnalyticsDTO, error) {
+	today := startOfDay(time.Now())
+	tomorrow := today.AddDate(0, 0, 1)
+	from := startOfPeriod(today, constants.AnalyticsGranularityMonth).AddDate(0, -(constants.RevenueTrendMonths - 1), 0)
+
+	monthly, _, err := s.series(constants.AnalyticsMetricGMV, constants.AnalyticsGranularityMonth, from, today)
+	if err != nil {
+		return nil, err
+	}
+	byCategory, err := s.analyticsRepo.GetRevenueByCategory(from, tomorrow)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get revenue by category", err)
+	}
+	total, err := s.total(constants.AnalyticsMetricGMV, time.Time{}, tomorrow)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := &dtos.RevenueAnalyticsDTO{
+		MonthlyRevenue:    monthly,
+		RevenueByCategory: byCategory,
+		TotalRevenue:      total,
+	}
+	if n := len(monthly); n >= 2 && monthly[n-2].Value > 0 {
+		growth := (monthly[n-1].Value - monthly[n-2].Value) / monthly[n-2].Value * 100
+		analytics.RevenueGrowth = &growth
+	}
+	return analytics, nil
+}
+
+// total aggregates a metric over [from, to)
+func (s *analyticsService) total(metric string, from, to time.Time) (float64, error) {
+	total, err := s.analyticsRepo.GetMetricTotal(metric, from, to)
+	if err != nil {
+		return 0, errors.NewDatabaseError("get "+metric, err)
+	}
+	return total, nil
+}
+
+// series rolls the daily values of a metric between from and to (both included) up into periods,
+// filling periods without data with zero
+func (s *analyticsService) series(metric, granularity string, from, to time.Time) ([]dtos.TimeSeriesPointDTO, float64, error) {
+	daily, err := s.analyticsRepo.GetDailySeries(metric, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, 0, errors.NewDatabaseError("get "+metric+" series", err)
+	}
+
+	labels, index := periodLabels(from, to, granularity)
+	points := make([]dtos.TimeSeriesPointDTO, len(labels))
+	for i, label := range labels {
+		points[i].Period = label
+	}
+
+	var total float64
+	for _, day := range daily {
+		if i, ok := periodOf(day.Day, granularity, index); ok {
+			points[i].Value += day.Value
+			total += day.Value
+		}
+	}
+	return points, total, nil
+}
+
+// periodLabels lists the first day of every period between from and to (both included) and indexes them
+func periodLabels(from, to time.Time, granularity string) ([]string, map[string]int) {
+	var labels []string
+	index := make(map[string]int)
+	for period := startOfPeriod(from, granularity); !period.After(to); period = nextPeriod(period, granularity) {
+		label := period.Format(constants.AnalyticsDateLayout)
+		index[label] = len(labels)
+		labels = append(labels, label)
+	}
+	return labels, index
+}
+
+// periodOf returns the position of the period containing a YYYY-MM-DD day in an index built by periodLabels
+func periodOf(day, granularity string, index map[string]int) (int, bool) {
+	date, err := time.ParseInLocation(constants.AnalyticsDateLayout, day, time.Local)
+	if err != nil {
+		return 0, false
+	}
+	i, ok := index[startOfPeriod(date, granularity).Format(constants.AnalyticsDateLayout)]
+	return i, ok
+}
+
+// parseGranularity validates a series granularity, defaulting to day
+func parseGranularity(granularity string) (string, error) {
+	switch granularity {
+	case "":
+		return constants.AnalyticsGranularityDay, nil
+	case constants.AnalyticsGranularityDay, constants.AnalyticsGranularityWeek, constants.AnalyticsGranularityMonth:
+		return granularity, nil
+	}
+	return "", errors.NewValidationError("granularity must be day, week or month")
+}
+
+// parseDateRange parses an inclusive YYYY-MM-DD date range, defaulting to the last 30 days up to today
+func parseDateRange(fromDate, toDate string) (from, to time.Time, err error) {
+	to = startOfDay(time.Now())
+	if toDate != "" {
+		if to, err = time.ParseInLocation(constants.AnalyticsDateLayout, toDate, time.Local); err != nil {
+			return from, to, errors.NewValidationError("to must be a date in YYYY-MM-DD format")
+		}
+	}
+	from = to.AddDate(0, 0, -(constants.AnalyticsDefaultDays - 1))
+	if fromDate != "" {
+		if from, err = time.ParseInLocation(constants.AnalyticsDateLayout, fromDate, time.Local); err != nil {
+			return from, to, errors.NewValidationError("from must be a date in YYYY-MM-DD format")
+		}
+	}
+	if from.After(to) {
+		return from, to, errors.NewValidationError("from must not be after to")
+	}
+	if to.Sub(from) >= constants.AnalyticsMaxDays*24*time.Hour {
+		return from, to, errors.NewValidationError("The date range cannot exceed 366 days")
+	}
+	return from, to, nil
+}
+
+// startOfDay returns midnight of t's day in the local time zone
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+// startOfPeriod returns the first day of the day, ISO week (starting Monday) or month containing day
+func startOfPeriod(day time.Time, granularity string) time.Time {
+	switch granularity {
+	case constants.AnalyticsGranularityWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case constants.AnalyticsGranularityMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+	}
+	return day
+}
+
+// nextPeriod returns the first day of the period after the one starting at period
+func nextPeriod(period time.Time, granularity string) time.Time {
+	switch granularity {
+	case constants.AnalyticsGranularityWeek:
+		return period.AddDate(0, 0, 7)
+	case constants.AnalyticsGranularityMonth:
+		return period.AddDate(0, 1, 0)
+	}
+	return period.AddDate(0, 0, 1)
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"errors"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/utils"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BrandLogo is a logo file uploaded with a brand application.
+type BrandLogo struct {
+	Filename string
+	Data     []byte
+}
+
+// BrandApplicationService defines business logic for brand onboarding: applications submitted by users
+// and brand members, and their review by admins.
+type BrandApplicationService interface {
+	SubmitApplication(userID uint64, dto *dtos.BrandApplicationRequestDTO, logo *BrandLogo) (*models.BrandApplication, error)
+	GetMyApplications(userID uint64) ([]models.BrandApplication, error)
+
+	// Admin
+	AdminGetApplications(query *dtos.BrandApplicationQueryDTO) (*dtos.BrandApplicationListResponseDTO, error)
+	AdminGetApplication(id uint64) (*models.BrandApplication, error)
+	AdminApproveApplication(id, adminID uint64, notes string) (*models.BrandApplication, error)
+	AdminRejectApplication(id, adminID uint64, notes string) (*models.BrandApplication, error)
+}
+
+// brandApplicationService implements BrandApplicationService interface
+type brandApplicationService struct {
+	applicationRepo     repositories.BrandApplicationRepository
+	brandRepo           repositories.BrandRepository
+	memberRepo          repositories.BrandMemberRepository
+	notificationService NotificationService
+	storageService      SupabaseStorageService
+}
+
+// NewBrandApplicationService creates a new brand application service.
+// storageService may be nil, in which case logo uploads are refused but logo URLs are still accepted.
+func NewBrandApplicationService(
+	applicationRepo repositories.BrandApplicationRepository,
+	brandRepo repositories.BrandRepository,
+	memberRepo repositories.BrandMemberRepository,
+	notificationService NotificationService,
+	storageService SupabaseStorageService,
+) BrandApplicationService {
+	return &brandApplicationService{
+		applicationRepo:     applicationRepo,
+		brandRepo:           brandRepo,
+		memberRepo:          memberRepo,
+		notificationService: notificationService,
+		storageService:      storageService,
+	}
+}
+
+// SubmitApplication validates and stores a brand application, uploading its logo when one is sent.
+// Members of an existing brand apply with its BrandID to get it verified.
+func (s *brandApplicationService) SubmitApplication(userID uint64, dto *dtos.BrandApplicationRequestDTO, logo *BrandLogo) (*models.BrandApplication, error) {
+	if err := validateBrandLinks(dto.WhatsAppNumber, dto.InstagramURL, dto.TokopediaURL, dto.ShopeeURL); err != nil {
+		return nil, err
+	}
+	if dto.BrandID != nil {
+		if err := s.checkBrandApplicant(*dto.BrandID, userID); err != nil {
+			return nil, err
+		}
+	}
+	pending, err := s.applicationRepo.HasPendingApplication(userID, dto.BrandID, dto.Name)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get brand applications", err)
+	}
+	if pending {
+		return nil, apperrors.New(apperrors.ErrorTypeConflict, http.StatusConflict, "You already have a pending application for this brand")
+	}
+
+	application := &models.BrandApplication{
+		UserID:         userID,
+		BrandID:        dto.BrandID,
+		Name:           strings.TrimSpace(dto.Name),
+		Description:    strings.TrimSpace(dto.Description),
+		LogoURL:        dto.LogoURL,
+		WebsiteURL:     dto.WebsiteURL,
+		WhatsAppNumber: dto.WhatsAppNumber,
+		InstagramURL:   dto.InstagramURL,
+		TokopediaURL:   dto.TokopediaURL,
+		ShopeeURL:      dto.ShopeeURL,
+		Status:         constants.BrandApplicationStatusPending,
+	}
+
+	var logoPath string
+	if logo != nil {
+		if logoPath, application.LogoURL, err = s.uploadLogo(logo); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.applicationRepo.CreateApplication(application); err != nil {
+		if logoPath != "" {
+			if removeErr := s.storageService.DeleteFile(logoPath); removeErr != nil {
+				utils.GetLogger().WithError(removeErr).Warn("Failed to remove orphaned brand logo")
+			}
+		}
+		return nil, apperrors.NewDatabaseError("create brand application", err)
+	}
+	return application, nil
+}
+
+// checkBrandApplicant allows only members of an existing, not yet verified brand to apply for it
+func (s *brandApplicationService) checkBrandApplicant(brandID, userID uint64) error {
+	brand, err := s.brandRepo.GetBrandByID(brandID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NewNotFoundError("Brand")
+		}
+		return apperrors.NewDatabaseError("get brand", err)
+	}
+	if err := requireBrandMember(s.memberRepo, brandID, userID); err != nil {
+		return err
+	}
+	if brand.Verified {
+		return apperrors.New(apperrors.ErrorTypeConflict, http.StatusConflict, "Brand is already verified")
+	}
+	return nil
+}
+
+// uploadLogo checks the logo file and uploads it to public storage, returning its storage path and URL
+func (s *brandApplicationService) uploadLogo(logo *BrandLogo) (string, string, error) {
+	if s.storageService == nil {
+		return "", "", apperrors.New(apperrors.ErrorTypeExternal, http.StatusServiceUnavailable, "Logo storage is not configured")
+	}
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(logo.Filename), "."))
+	contentType := http.DetectContentType(logo.Data)
+	if !containsExt(constants.AllowedImageExts, ext) || !strings.HasPrefix(contentType, "image/") {
+		return "", "", apperrors.NewValidationError(fmt.Sprintf("logo must be an image (%s)", constants.AllowedImageExts))
+	}
+	if len(logo.Data) > constants.MaxBrandLogoSize {
+		return "", "", apperrors.NewValidationError(fmt.Sprintf("logo must not exceed %d MB", constants.MaxBrandLogoSize>>20))
+	}
+
+	path := fmt.Sprintf("brands/applications/%s.%s", uuid.New().String(), ext)
+	url, err := s.storageService.UploadPublicFile(path, logo.Data, contentType)
+	if err != nil {
+		return "", "", apperrors.NewWithInternalError(apperrors.ErrorTypeExternal, http.StatusBadGateway, "Failed to upload logo", err)
+	}
+	return path, url, nil
+}
+
+// validateBrandLinks checks the WhatsApp number format and that marketplace links point to their marketplace
+func validateBrandLinks(whatsAppNumber, instagramURL, tokopediaURL, shopeeURL string) error {
+	var problems []string
+	if whatsAppNumber != "" {
+		if err := utils.ValidateWhatsAppNumber(whatsAppNumber); err != nil {
+			problems = append(problems, "whatsapp_number "+err.Error())
+		}
+	}
+	links := []struct {
+		field, url, domains string
+	}{
+		{"instagram_url", instagramURL, constants.InstagramDomains},
+		{"tokopedia_url", tokopediaURL, constants.TokopediaDomains},
+		{"shopee_url", shopeeURL, constants.ShopeeDomains},
+	}
+	for _, link := range links {
+		if link.url == "" {
+			continue
+		}
+		if err := utils.ValidateURLDomain(link.url, link.domains); err != nil {
+			problems = append(problems, link.field+" "+err.Error())
+		}
+	}
+	if len(problems) > 0 {
+		return apperrors.NewValidationError(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// GetMyApplications retrieves a user's applications, newest first
+func (s *brandApplicationService) GetMyApplications(userID uint64) ([]models.BrandApplication, error) {
+	applications, err := s.applicationRepo.GetApplicationsByUserID(userID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get brand applications", err)
+	}
+	return applications, nil
+}
+
+// AdminGetApplications retrieves a page of the review queue, oldest first
+func (s *brandApplicationService) AdminGetApplications(query *dtos.BrandApplicationQueryDTO) (*dtos.BrandApplicationListResponseDTO, error) {
+	page, limit := query.Page, query.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > constants.MaxPageSize {
+		limit = constants.DefaultPageSize
+	}
+
+	applications, total, err := s.applicationRepo.GetApplications(query.Status, limit, (page-1)*limit)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get brand applications", err)
+	}
+	return &dtos.BrandApplicationListResponseDTO{
+		Applications: dtos.ToBrandApplicationResponseDTOs(applications),
+		Pagination:   dtos.PaginationDTO{Page: page, Limit: limit, Total: int(total)},
+	}, nil
+}
+
+// AdminGetApplication retrieves a single application
+func (s *brandApplicationService) AdminGetApplication(id uint64) (*models.BrandApplication, error) {
+	application, err := s.applicationRepo.GetApplicationByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Brand application")
+		}
+		return nil, apperrors.NewDatabaseError("get brand application", err)
+	}
+	return application, nil
+}
+
+// AdminApproveApplication verifies the brand of a pending application. A new brand is created with the
+// applicant as its owner; an existing brand takes over the submitted details. The links are checked
+// again so applications submitted under older rules cannot be approved with invalid links.
+func (s *brandApplicationService) AdminApproveApplication(id, adminID uint64, notes string) (*models.BrandApplication, error) {
+	application, err := s.getPendingApplication(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateBrandLinks(application.WhatsAppNumber, application.InstagramURL, application.TokopediaURL, application.ShopeeURL); err != nil {
+		return nil, err
+	}
+
+	brand := &models.Brand{}
+	if application.BrandID != nil {
+		if brand, err = s.brandRepo.GetBrandByID(*application.BrandID); err != nil {
+			return nil, apperrors.NewNotFoundError("Brand")
+		}
+	}
+	brand.Name = application.Name
+	brand.Description = application.Description
+	if application.LogoURL != "" {
+		brand.LogoURL = application.LogoURL
+	}
+	if application.WebsiteURL != "" {
+		brand.WebsiteURL = application.WebsiteURL
+	}
+	brand.WhatsAppNumber = application.WhatsAppNumber
+	brand.InstagramURL = application.InstagramURL
+	brand.TokopediaURL = application.TokopediaURL
+	brand.ShopeeURL = application.ShopeeURL
+
+	now := time.Now()
+	brand.Verified = true
+	brand.VerifiedAt = &now
+	markReviewed(application, constants.BrandApplicationStatusApproved, adminID, notes, now)
+
+	if err := s.applicationRepo.ApproveApplication(application, brand); err != nil {
+		return nil, apperrors.NewDatabaseError("approve brand application", err)
+	}
+	application.Brand = brand
+
+	message := "Your brand is now verified."
+	if application.ReviewNotes != "" {
+		message += " " + application.ReviewNotes
+	}
+	s.notifyApplicant(application, constants.NotificationTypeBrandApproved, fmt.Sprintf("%s has been approved", application.Name), message)
+	return application, nil
+}
+
+// AdminRejectApplication rejects a pending application; the notes tell the applicant what to fix
+func (s *brandApplicationService) AdminRejectApplication(id, adminID uint64, notes string) (*models.BrandApplication, error) {
+	if strings.TrimSpace(notes) == "" {
+		return nil, apperrors.NewValidationError("notes are required when rejecting an application")
+	}
+	application, err := s.getPendingApplication(id)
+	if err != nil {
+		return nil, err
+	}
+
+	markReviewed(application, constants.BrandApplicationStatusRejected, adminID, notes, time.Now())
+	if err := s.applicationRepo.UpdateApplication(application); err != nil {
+		return nil, apperrors.NewDatabaseError("reject brand application", err)
+	}
+
+	s.notifyApplicant(application, constants.NotificationTypeBrandRejected, fmt.Sprintf("%s was not approved", application.Name), application.ReviewNotes)
+	return application, nil
+}
+
+// getPendingApplication retrieves an application that still waits for review
+func (s *brandApplicationService) getPendingApplication(id uint64) (*models.BrandApplication, error) {
+	application, err := s.AdminGetApplication(id)
+	if err != nil {
+		return nil, err
+	}
+	if application.Status != constants.BrandApplicationStatusPending {
+		return nil, apperrors.New(apperrors.ErrorTypeConflict, http.StatusConflict, "Application has already been reviewed")
+	}
+	return application, nil
+}
+
+// markReviewed records the admin's decision on an application
+func markReviewed(application *models.BrandApplication, status string, adminID uint64, notes string, at time.Time) {
+	application.Status = status
+	application.ReviewNotes = strings.TrimSpace(notes)
+	application.ReviewedBy = &adminID
+	application.ReviewedAt = &at
+}
+
+// notifyApplicant tells the applicant about the review. The review is already saved, so a failed
+// notification is only logged.
+func (s *brandApplicationService) notifyApplicant(application *models.BrandApplication, notificationType, title, message string) {
+	notification := models.Notification{Type: notificationType, Title: title, Message: message}
+	if err := s.notificationService.Notify([]uint64{application.UserID}, notification); err != nil {
+		utils.GetLogger().WithError(err).WithField("application_id", application.ID).Warn("Failed to notify brand applicant")
+	}
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	stderrors "errors"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+
+	"gorm.io/gorm"
+)
+
+// BrandMemberService defines business logic for managing the users of a brand.
+type BrandMemberService interface {
+	GetBrandMembers(brandID uint64) ([]models.BrandMember, error)
+	AddBrandMember(brandID uint64, dto *dtos.BrandMemberRequestDTO) (*models.BrandMember, error)
+	RemoveBrandMember(brandID, userID uint64) error
+}
+
+// brandMemberService implements BrandMemberService interface
+type brandMemberService struct {
+	memberRepo repositories.BrandMemberRepository
+	brandRepo  repositories.BrandRepository
+	userRepo   repositories.UserRepository
+}
+
+// NewBrandMemberService creates a new brand member service
+func NewBrandMemberService(memberRepo repositories.BrandMemberRepository, brandRepo repositories.BrandRepository, userRepo repositories.UserRepository) BrandMemberService {
+	return &brandMemberService{
+		memberRepo: memberRepo,
+		brandRepo:  brandRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// GetBrandMembers retrieves all members of a brand
+func (s *brandMemberService) GetBrandMembers(brandID uint64) ([]models.BrandMember, error) {
+	if _, err := s.brandRepo.GetBrandByID(brandID); err != nil {
+		return nil, errors.NewNotFoundError("Brand")
+	}
+	members, err := s.memberRepo.GetMembersByBrandID(brandID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get brand members", err)
+	}
+	return members, nil
+}
+
+// AddBrandMember adds a user to a brand or updates their role
+func (s *brandMemberService) AddBrandMember(brandID uint64, dto *dtos.BrandMemberRequestDTO) (*models.BrandMember, error) {
+	if _, err := s.brandRepo.GetBrandByID(brandID); err != nil {
+		return nil, errors.NewNotFoundError("Brand")
+	}
+	if _, err := s.userRepo.GetUserByID(dto.UserID); err != nil {
+		return nil, errors.NewNotFoundError("User")
+	}
+
+	role := dto.Role
+	if role == "" {
+		role = constants.BrandMemberRoleStaff
+	}
+	member := &models.BrandMember{
+		BrandID: brandID,
+		UserID:  dto.UserID,
+		Role:    role,
+	}
+	if err := s.memberRepo.AddMember(member); err != nil {
+		return nil, errors.NewDatabaseError("add brand member", err)
+	}
+
+	saved, err := s.memberRepo.GetMember(brandID, dto.UserID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get brand member", err)
+	}
+	return saved, nil
+}
+
+// RemoveBrandMember removes a user from a brand
+func (s *brandMemberService) RemoveBrandMember(brandID, userID uint64) error {
+	if _, err := s.memberRepo.GetMember(brandID, userID); err != nil {
+		return errors.NewNotFoundError("Brand member")
+	}
+	if err := s.memberRepo.RemoveMember(brandID, userID); err != nil {
+		return errors.NewDatabaseError("remove brand member", err)
+	}
+	return nil
+}
+
+// requireBrandMember refuses users who do not manage the brand
+func requireBrandMember(memberRepo repositories.BrandMemberRepository, brandID, userID uint64) error {
+	if _, err := memberRepo.GetMember(brandID, userID); err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.NewAuthorizationError("You are not a member of this brand")
+		}
+		return errors.NewDatabaseError("get brand member", err)
+	}
+	return nil
+}
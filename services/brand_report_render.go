@@ -0,0 +1,257 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/xuri/excelize/v2"
+)
+
+// reportSection is one table of a report. Every format renders the same sections: CSV one after another,
+// XLSX as sheets and PDF as tables.
+type reportSection struct {
+	Title   string
+	Header  []string
+	Rows    [][]interface{} // string, int64 or float64 cells
+	Weights []float64       // Relative column widths in the PDF
+}
+
+// reportSections lays a report out as tables
+func reportSections(report *dtos.BrandReportDTO) []reportSection {
+	averageRating := interface{}("-")
+	if report.AverageRating != nil {
+		averageRating = round(*report.AverageRating, 2)
+	}
+	summary := reportSection{
+		Title:  "Summary",
+		Header: []string{"Metric", "Value"},
+		Rows: [][]interface{}{
+			{"Brand", report.BrandName},
+			{"Period", report.From + " to " + report.To},
+			{"Clicks", report.TotalClicks},
+			{"Signed-in clicks", report.SignedInClicks},
+			{"Anonymous clicks", report.AnonymousClicks},
+			{"Unique users", report.UniqueUsers},
+			{"Product views", report.ProductViews},
+			{"Favorites added", report.FavoritesAdded},
+			{"Reviews", report.Reviews},
+			{"Average rating", averageRating},
+			{"Generated at", report.GeneratedAt.Format(time.RFC3339)},
+		},
+		Weights: []float64{1, 2},
+	}
+
+	daily := reportSection{
+		Title:   "Daily clicks",
+		Header:  []string{"Date", "Clicks", "Signed-in clicks", "Anonymous clicks", "Unique users"},
+		Weights: []float64{1, 1, 1, 1, 1},
+	}
+	for _, point := range report.DailyClicks {
+		daily.Rows = append(daily.Rows, []interface{}{point.Period, point.Clicks, point.SignedInClicks, point.AnonymousClicks, point.UniqueUsers})
+	}
+
+	products := reportSection{
+		Title:   "Top products",
+		Header:  []string{"Product ID", "Product", "Clicks", "Unique users"},
+		Weights: []float64{1, 3, 1, 1},
+	}
+	for _, product := range report.TopProducts {
+		products.Rows = append(products.Rows, []interface{}{int64(product.ProductID), product.ProductName, product.Clicks, product.UniqueUsers})
+	}
+
+	channels := reportSection{
+		Title:   "Channels",
+		Header:  []string{"Channel", "Clicks", "Share (%)"},
+		Weights: []float64{2, 1, 1},
+	}
+	for _, channel := range report.Channels {
+		channels.Rows = append(channels.Rows, []interface{}{channel.Key, channel.Clicks, round(channel.Share, 1)})
+	}
+
+	ratings := reportSection{
+		Title:   "Ratings",
+		Header:  []string{"Rating", "Reviews"},
+		Weights: []float64{1, 1},
+	}
+	for _, rating := range report.Ratings {
+		ratings.Rows = append(ratings.Rows, []interface{}{fmt.Sprintf("%d stars", rating.Rating), rating.Reviews})
+	}
+
+	return []reportSection{summary, products, channels, ratings, daily}
+}
+
+// renderReport renders a report as a CSV, XLSX or PDF file
+func renderReport(report *dtos.BrandReportDTO, format string) (*ReportFile, error) {
+	sections := reportSections(report)
+	file := &ReportFile{FileName: fmt.Sprintf("flicknfit-report-%d-%s-%s.%s", report.BrandID, report.From, report.To, format)}
+
+	var err error
+	switch format {
+	case constants.ReportFormatXLSX:
+		file.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		file.Content, err = renderReportXLSX(sections)
+	case constants.ReportFormatPDF:
+		file.ContentType = "application/pdf"
+		file.Content, err = renderReportPDF(report, sections)
+	default:
+		file.ContentType = "text/csv; charset=utf-8"
+		file.Content, err = renderReportCSV(sections)
+	}
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to render the report", err)
+	}
+	return file, nil
+}
+
+// renderReportCSV writes the sections one after another, each starting with its title and separated by an
+// empty line
+func renderReportCSV(sections []reportSection) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	for i, section := range sections {
+		if i > 0 {
+			writer.Write([]string{})
+		}
+		writer.Write([]string{section.Title})
+		writer.Write(section.Header)
+		for _, row := range section.Rows {
+			record := make([]string, len(row))
+			for j, cell := range row {
+				record[j] = cellText(cell)
+			}
+			writer.Write(record)
+		}
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// renderReportXLSX writes each section to its own sheet with a bold header row
+func renderReportXLSX(sections []reportSection) ([]byte, error) {
+	workbook := excelize.NewFile()
+	defer workbook.Close()
+
+	bold, err := workbook.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return nil, err
+	}
+	for i, section := range sections {
+		if i == 0 {
+			if err := workbook.SetSheetName(workbook.GetSheetName(0), section.Title); err != nil {
+				return nil, err
+			}
+		} else if _, err := workbook.NewSheet(section.Title); err != nil {
+			return nil, err
+		}
+
+		header := make([]interface{}, len(section.Header))
+		for j, title := range section.Header {
+			header[j] = title
+		}
+		if err := workbook.SetSheetRow(section.Title, "A1", &header); err != nil {
+			return nil, err
+		}
+		lastColumn, _ := excelize.ColumnNumberToName(len(section.Header))
+		if err := workbook.SetCellStyle(section.Title, "A1", lastColumn+"1", bold); err != nil {
+			return nil, err
+		}
+		if err := workbook.SetColWidth(section.Title, "A", lastColumn, 18); err != nil {
+			return nil, err
+		}
+		for j, row := range section.Rows {
+			cell, _ := excelize.CoordinatesToCellName(1, j+2)
+			if err := workbook.SetSheetRow(section.Title, cell, &row); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	buf, err := workbook.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderReportPDF writes a title followed by each section as a table
+func renderReportPDF(report *dtos.BrandReportDTO, sections []reportSection) ([]byte, error) {
+	const rowHeight = 6.0
+	pdf := fpdf.New("P", "mm", "A4", "")
+	translate := pdf.UnicodeTranslatorFromDescriptor("") // Core fonts only cover Latin-1
+	pdf.SetTitle("FlickNFit report "+report.BrandName, true)
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 10, translate("FlickNFit performance report: "+report.BrandName), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, rowHeight, report.From+" to "+report.To, "", 1, "L", false, 0, "")
+
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	tableWidth := pageWidth - left - right
+	for _, section := range sections {
+		pdf.Ln(4)
+		pdf.SetFont("Helvetica", "B", 12)
+		pdf.CellFormat(0, 8, section.Title, "", 1, "L", false, 0, "")
+
+		var totalWeight float64
+		for _, weight := range section.Weights {
+			totalWeight += weight
+		}
+		widths := make([]float64, len(section.Weights))
+		for i, weight := range section.Weights {
+			widths[i] = tableWidth * weight / totalWeight
+		}
+
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetFillColor(235, 235, 235)
+		for i, title := range section.Header {
+			pdf.CellFormat(widths[i], rowHeight, title, "1", 0, "L", true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Helvetica", "", 9)
+		if len(section.Rows) == 0 {
+			pdf.CellFormat(tableWidth, rowHeight, "No data", "1", 1, "L", false, 0, "")
+			continue
+		}
+		for _, row := range section.Rows {
+			for i, cell := range row {
+				align := "L"
+				if _, isText := cell.(string); !isText {
+					align = "R"
+				}
+				pdf.CellFormat(widths[i], rowHeight, truncateRunes(translate(cellText(cell)), 60), "1", 0, align, false, 0, "")
+			}
+			pdf.Ln(-1)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// cellText formats a report cell for CSV and PDF
+func cellText(cell interface{}) string {
+	switch value := cell.(type) {
+	case float64:
+		return fmt.Sprintf("%g", value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// round rounds value to the given number of decimals
+func round(value float64, decimals int) float64 {
+	factor := math.Pow10(decimals)
+	return math.Round(value*factor) / factor
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"errors"
+	"flicknfit_backend/utils"
+	"time"
+)
+
+// NewBrandReportScheduler creates a job that checks for due monthly reports at the given interval and emails
+// the previous month's report to brands that have not received it.
+func NewBrandReportScheduler(reportService BrandReportService, interval time.Duration) *PeriodicJob {
+	return NewPeriodicJob(interval, func() {
+		sent, err := reportService.SendMonthlyReports(time.Now())
+		if errors.Is(err, ErrMailerNotConfigured) {
+			utils.GetLogger().Debug("Mailer is not configured, skipping monthly brand reports")
+			return
+		}
+		if err != nil {
+			utils.GetLogger().WithError(err).Error("Failed to send monthly brand reports")
+		}
+		if sent > 0 {
+			utils.GetLogger().WithField("brands", sent).Info("Sent monthly brand reports")
+		}
+	})
+}
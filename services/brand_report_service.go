@@ -0,0 +1,256 @@
+package services
+
+import (
+	"errors"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/utils"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// BrandReportService defines business logic for exporting brand performance reports and emailing them monthly.
+type BrandReportService interface {
+	GetBrandReport(brandID, userID uint64, query *dtos.BrandReportQueryDTO) (*ReportFile, error)
+	AdminGetBrandReport(brandID uint64, query *dtos.BrandReportQueryDTO) (*ReportFile, error)
+	SendMonthlyReports(now time.Time) (int, error)
+}
+
+// ReportFile is a rendered report.
+type ReportFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// brandReportService implements BrandReportService interface
+type brandReportService struct {
+	clickAnalytics ClickAnalyticsService
+	reportRepo     repositories.BrandReportRepository
+	brandRepo      repositories.BrandRepository
+	memberRepo     repositories.BrandMemberRepository
+	mailer         Mailer
+}
+
+// NewBrandReportService creates a new brand report service
+func NewBrandReportService(
+	clickAnalytics ClickAnalyticsService,
+	reportRepo repositories.BrandReportRepository,
+	brandRepo repositories.BrandRepository,
+	memberRepo repositories.BrandMemberRepository,
+	mailer Mailer,
+) BrandReportService {
+	return &brandReportService{
+		clickAnalytics: clickAnalytics,
+		reportRepo:     reportRepo,
+		brandRepo:      brandRepo,
+		memberRepo:     memberRepo,
+		mailer:         mailer,
+	}
+}
+
+// GetBrandReport renders the report of a brand for one of its members
+func (s *brandReportService) GetBrandReport(brandID, userID uint64, query *dtos.BrandReportQueryDTO) (*ReportFile, error) {
+	if err := requireBrandMember(s.memberRepo, brandID, userID); err != nil {
+		return nil, err
+	}
+	return s.AdminGetBrandReport(brandID, query)
+}
+
+// AdminGetBrandReport renders the report of any brand
+func (s *brandReportService) AdminGetBrandReport(brandID uint64, query *dtos.BrandReportQueryDTO) (*ReportFile, error) {
+	format := query.Format
+	if format == "" {
+		format = constants.ReportFormatCSV
+	}
+	if format != constants.ReportFormatCSV && format != constants.ReportFormatXLSX && format != constants.ReportFormatPDF {
+		return nil, apperrors.NewValidationError("format must be csv, xlsx or pdf")
+	}
+	brand, err := s.brandRepo.GetBrandByID(brandID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Brand")
+	}
+
+	from, to := query.From, query.To
+	if from == "" && to == "" {
+		from, to = previousMonth(time.Now())
+	}
+	report, err := s.buildReport(brand, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return renderReport(report, format)
+}
+
+// SendMonthlyReports emails the previous month's report, as PDF and XLSX, to the members of every brand that
+// has not received it yet, and returns the number of brands it was sent to. Each report is sent once: a
+// report that failed for some members is not sent again.
+func (s *brandReportService) SendMonthlyReports(now time.Time) (int, error) {
+	from, to := previousMonth(now)
+	period := now.AddDate(0, 0, -now.Day()).Format(constants.ReportPeriodLayout)
+
+	brands, err := s.brandRepo.GetAllBrands()
+	if err != nil {
+		return 0, apperrors.NewDatabaseError("get brands", err)
+	}
+	sent := 0
+	for i := range brands {
+		brand := &brands[i]
+		delivered, err := s.reportRepo.HasDelivery(brand.ID, period)
+		if err != nil {
+			return sent, apperrors.NewDatabaseError("get report delivery", err)
+		}
+		if delivered {
+			continue
+		}
+		members, err := s.memberRepo.GetMembersByBrandID(brand.ID)
+		if err != nil {
+			return sent, apperrors.NewDatabaseError("get brand members", err)
+		}
+		if len(members) == 0 {
+			continue
+		}
+
+		recipients, err := s.sendMonthlyReport(brand, members, from, to, period)
+		if errors.Is(err, ErrMailerNotConfigured) {
+			return sent, err
+		}
+		if err != nil {
+			utils.GetLogger().WithError(err).WithField("brand_id", brand.ID).Error("Failed to send the monthly report")
+			continue
+		}
+		if err := s.reportRepo.RecordDelivery(&models.BrandReportDelivery{
+			BrandID:    brand.ID,
+			Period:     period,
+			Recipients: recipients,
+			SentAt:     now,
+		}); err != nil {
+			return sent, apperrors.NewDatabaseError("record report delivery", err)
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// sendMonthlyReport renders a brand's report and emails it to each member, returning the number of members
+// it reached. It fails only when the report cannot be built or no member could be reached.
+func (s *brandReportService) sendMonthlyReport(brand *models.Brand, members []models.BrandMember, from, to, period string) (int, error) {
+	report, err := s.buildReport(brand, from, to)
+	if err != nil {
+		return 0, err
+	}
+	var attachments []Attachment
+	for _, format := range []string{constants.ReportFormatPDF, constants.ReportFormatXLSX} {
+		file, err := renderReport(report, format)
+		if err != nil {
+			return 0, err
+		}
+		attachments = append(attachments, Attachment{FileName: file.FileName, ContentType: file.ContentType, Content: file.Content})
+	}
+
+	subject := fmt.Sprintf("FlickNFit report %s: %s", period, brand.Name)
+	body := fmt.Sprintf("Hi,\n\nAttached is the FlickNFit performance report of %s for %s to %s: %d clicks, %d favorites and %d reviews.\n\nThe FlickNFit team",
+		brand.Name, from, to, report.TotalClicks, report.FavoritesAdded, report.Reviews)
+
+	recipients := 0
+	var lastErr error
+	for _, member := range members {
+		if member.User.Email == "" {
+			continue
+		}
+		if err := s.mailer.SendWithAttachments(member.User.Email, subject, body, attachments); err != nil {
+			if errors.Is(err, ErrMailerNotConfigured) {
+				return 0, err
+			}
+			utils.GetLogger().WithError(err).WithFields(logrus.Fields{
+				"brand_id": brand.ID,
+				"user_id":  member.UserID,
+			}).Warn("Failed to email the monthly report")
+			lastErr = err
+			continue
+		}
+		recipients++
+	}
+	if recipients == 0 && lastErr != nil {
+		return 0, lastErr
+	}
+	return recipients, nil
+}
+
+// buildReport collects the figures of a brand between from and to (YYYY-MM-DD, both included)
+func (s *brandReportService) buildReport(brand *models.Brand, from, to string) (*dtos.BrandReportDTO, error) {
+	fromDay, toDay, err := parseDateRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	analytics, err := s.clickAnalytics.GetClickAnalytics(&dtos.ClickAnalyticsQueryDTO{
+		From:        from,
+		To:          to,
+		Granularity: constants.AnalyticsGranularityDay,
+		BrandID:     brand.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	scope := repositories.ClickScope{BrandID: brand.ID, From: fromDay, To: toDay.AddDate(0, 0, 1)}
+
+	reviews, err := s.reportRepo.GetReviewSummary(scope)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get review summary", err)
+	}
+	ratingCounts, err := s.reportRepo.GetRatingCounts(scope)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get rating counts", err)
+	}
+
+	report := &dtos.BrandReportDTO{
+		BrandID:         brand.ID,
+		BrandName:       brand.Name,
+		From:            analytics.From,
+		To:              analytics.To,
+		GeneratedAt:     time.Now(),
+		TotalClicks:     analytics.TotalClicks,
+		SignedInClicks:  analytics.SignedInClicks,
+		AnonymousClicks: analytics.AnonymousClicks,
+		UniqueUsers:     analytics.UniqueUsers,
+		Reviews:         reviews.Reviews,
+		DailyClicks:     analytics.Series,
+		TopProducts:     analytics.ByProduct,
+		Channels:        analytics.ByChannel,
+	}
+	for _, stage := range analytics.Funnel {
+		switch stage.Stage {
+		case constants.FunnelStageView:
+			report.ProductViews = stage.Count
+		case constants.FunnelStageFavorite:
+			report.FavoritesAdded = stage.Count
+		}
+	}
+	if len(report.TopProducts) > constants.ReportTopProducts {
+		report.TopProducts = report.TopProducts[:constants.ReportTopProducts]
+	}
+	if reviews.Reviews > 0 {
+		average := reviews.AverageRating
+		report.AverageRating = &average
+	}
+	counts := make(map[int]int64)
+	for _, count := range ratingCounts {
+		counts[count.Rating] = count.Reviews
+	}
+	for rating := 5; rating >= 1; rating-- {
+		report.Ratings = append(report.Ratings, models.RatingCount{Rating: rating, Reviews: counts[rating]})
+	}
+	return report, nil
+}
+
+// previousMonth returns the first and last day of the calendar month before now's
+func previousMonth(now time.Time) (from, to string) {
+	firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	return firstOfMonth.AddDate(0, -1, 0).Format(constants.AnalyticsDateLayout),
+		firstOfMonth.AddDate(0, 0, -1).Format(constants.AnalyticsDateLayout)
+}
@@ -2,9 +2,14 @@ package services
 
 import (
 	"errors"
+	"flicknfit_backend/constants"
 	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
 	"flicknfit_backend/models"
 	"flicknfit_backend/repositories"
+	"flicknfit_backend/utils"
+
+	"gorm.io/gorm"
 )
 
 // BrandService defines the interface for business logic related to brands.
@@ -15,29 +20,45 @@ type BrandService interface {
 	AdminDeleteBrand(id uint64) error
 
 	// Public
-	GetAllBrands() ([]dtos.BrandResponseDTO, error)
-	GetBrandByID(id uint64) (*dtos.BrandResponseDTO, error)
+	GetAllBrands(query *dtos.BrandListQueryDTO) (*dtos.BrandListResponseDTO, error)
+	GetBrandByID(id, userID uint64) (*dtos.BrandDetailResponseDTO, error)
+
+	// Follows
+	FollowBrand(brandID, userID uint64) (*dtos.BrandFollowResponseDTO, error)
+	UnfollowBrand(brandID, userID uint64) (*dtos.BrandFollowResponseDTO, error)
+	GetFollowedBrands(userID uint64) ([]dtos.BrandResponseDTO, error)
 }
 
 // brandService is the implementation of BrandService.
 type brandService struct {
 	brandRepository repositories.BrandRepository
+	productService  ProductService
 }
 
 // NewBrandService creates and returns a new instance of BrandService.
-func NewBrandService(brandRepository repositories.BrandRepository) BrandService {
+// The product service provides the brand's top products with campaign prices applied.
+func NewBrandService(brandRepository repositories.BrandRepository, productService ProductService) BrandService {
 	return &brandService{
 		brandRepository: brandRepository,
+		productService:  productService,
 	}
 }
 
 // AdminCreateBrand handles the creation of a new brand by an admin.
 func (s *brandService) AdminCreateBrand(dto *dtos.BrandCreateRequestDTO) (*models.Brand, error) {
+	if err := validateBrandLinks(dto.WhatsAppNumber, dto.InstagramURL, dto.TokopediaURL, dto.ShopeeURL); err != nil {
+		return nil, err
+	}
+
 	brand := models.Brand{
-		Name:        dto.Name,
-		Description: dto.Description,
-		LogoURL:     dto.LogoURL,
-		WebsiteURL:  dto.WebsiteURL,
+		Name:           dto.Name,
+		Description:    dto.Description,
+		LogoURL:        dto.LogoURL,
+		WebsiteURL:     dto.WebsiteURL,
+		WhatsAppNumber: dto.WhatsAppNumber,
+		InstagramURL:   dto.InstagramURL,
+		TokopediaURL:   dto.TokopediaURL,
+		ShopeeURL:      dto.ShopeeURL,
 	}
 
 	if err := s.brandRepository.CreateBrand(&brand); err != nil {
@@ -66,6 +87,21 @@ func (s *brandService) AdminUpdateBrand(id uint64, dto *dtos.BrandUpdateRequestD
 	if dto.WebsiteURL != "" {
 		brand.WebsiteURL = dto.WebsiteURL
 	}
+	if dto.WhatsAppNumber != "" {
+		brand.WhatsAppNumber = dto.WhatsAppNumber
+	}
+	if dto.InstagramURL != "" {
+		brand.InstagramURL = dto.InstagramURL
+	}
+	if dto.TokopediaURL != "" {
+		brand.TokopediaURL = dto.TokopediaURL
+	}
+	if dto.ShopeeURL != "" {
+		brand.ShopeeURL = dto.ShopeeURL
+	}
+	if err := validateBrandLinks(brand.WhatsAppNumber, brand.InstagramURL, brand.TokopediaURL, brand.ShopeeURL); err != nil {
+		return nil, err
+	}
 
 	if err := s.brandRepository.UpdateBrand(brand); err != nil {
 		return nil, errors.New("failed to update brand")
@@ -88,23 +124,125 @@ func (s *brandService) AdminDeleteBrand(id uint64) error {
 	return nil
 }
 
-// GetAllBrands retrieves all brands for public display.
-func (s *brandService) GetAllBrands() ([]dtos.BrandResponseDTO, error) {
-	brands, err := s.brandRepository.GetAllBrands()
+// GetAllBrands retrieves a page of the brand directory, optionally filtered by name and sorted.
+func (s *brandService) GetAllBrands(query *dtos.BrandListQueryDTO) (*dtos.BrandListResponseDTO, error) {
+	page, limit := query.Page, query.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > constants.MaxPageSize {
+		limit = constants.DefaultPageSize
+	}
+
+	brands, total, err := s.brandRepository.SearchBrands(query.Search, query.Sort, limit, (page-1)*limit)
 	if err != nil {
-		return nil, errors.New("failed to retrieve brands")
+		return nil, apperrors.NewDatabaseError("get brands", err)
+	}
+	return &dtos.BrandListResponseDTO{
+		Brands: dtos.ToBrandResponseDTOs(brands),
+		Pagination: dtos.PaginationDTO{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}, nil
+}
+
+// GetBrandByID retrieves a brand's detail page: its best-selling products, the categories it sells in
+// and its marketplace links. userID is 0 for anonymous visitors, who never follow the brand.
+func (s *brandService) GetBrandByID(id, userID uint64) (*dtos.BrandDetailResponseDTO, error) {
+	brand, err := s.getBrand(id)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.brandRepository.GetBrandCategories(id)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get brand categories", err)
+	}
+	products, err := s.productService.GetAllProductsPublicWithFilter(&dtos.ProductFilterRequestDTO{
+		BrandID: id,
+		Sort:    constants.ProductSortBestSelling,
+		Limit:   constants.BrandTopProductsLimit,
+	})
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get brand products", err)
+	}
+
+	following := false
+	if userID != 0 {
+		if following, err = s.brandRepository.IsFollowingBrand(id, userID); err != nil {
+			return nil, apperrors.NewDatabaseError("get brand follow", err)
+		}
 	}
 
+	if categories == nil {
+		categories = []string{}
+	}
+	return &dtos.BrandDetailResponseDTO{
+		BrandResponseDTO: dtos.ToBrandResponseDTO(*brand),
+		Categories:       categories,
+		TopProducts:      dtos.ToProductResponseDTOs(products),
+		Links:            brandLinks(brand),
+		IsFollowing:      following,
+	}, nil
+}
+
+// brandLinks collects the marketplace links a brand has set.
+func brandLinks(brand *models.Brand) dtos.BrandMarketplaceLinksDTO {
+	links := dtos.BrandMarketplaceLinksDTO{
+		Instagram: brand.InstagramURL,
+		Tokopedia: brand.TokopediaURL,
+		Shopee:    brand.ShopeeURL,
+		Website:   brand.WebsiteURL,
+	}
+	if brand.WhatsAppNumber != "" {
+		links.WhatsApp = utils.WhatsAppLink(brand.WhatsAppNumber, "")
+	}
+	return links
+}
+
+// FollowBrand makes a user follow a brand. Following a brand twice keeps a single follow.
+func (s *brandService) FollowBrand(brandID, userID uint64) (*dtos.BrandFollowResponseDTO, error) {
+	if _, err := s.getBrand(brandID); err != nil {
+		return nil, err
+	}
+	followers, err := s.brandRepository.FollowBrand(brandID, userID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("follow brand", err)
+	}
+	return &dtos.BrandFollowResponseDTO{BrandID: brandID, Following: true, Followers: followers}, nil
+}
+
+// UnfollowBrand removes a user's follow of a brand. Unfollowing a brand that is not followed is a no-op.
+func (s *brandService) UnfollowBrand(brandID, userID uint64) (*dtos.BrandFollowResponseDTO, error) {
+	if _, err := s.getBrand(brandID); err != nil {
+		return nil, err
+	}
+	followers, err := s.brandRepository.UnfollowBrand(brandID, userID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("unfollow brand", err)
+	}
+	return &dtos.BrandFollowResponseDTO{BrandID: brandID, Following: false, Followers: followers}, nil
+}
+
+// GetFollowedBrands retrieves the brands a user follows, most recently followed first.
+func (s *brandService) GetFollowedBrands(userID uint64) ([]dtos.BrandResponseDTO, error) {
+	brands, err := s.brandRepository.GetFollowedBrands(userID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get followed brands", err)
+	}
 	return dtos.ToBrandResponseDTOs(brands), nil
 }
 
-// GetBrandByID retrieves a single brand by its ID for public display.
-func (s *brandService) GetBrandByID(id uint64) (*dtos.BrandResponseDTO, error) {
+// getBrand retrieves a brand, reporting a missing brand as not found.
+func (s *brandService) getBrand(id uint64) (*models.Brand, error) {
 	brand, err := s.brandRepository.GetBrandByID(id)
 	if err != nil {
-		return nil, errors.New("brand not found")
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Brand")
+		}
+		return nil, apperrors.NewDatabaseError("get brand", err)
 	}
-
-	response := dtos.ToBrandResponseDTO(*brand)
-	return &response, nil
+	return brand, nil
 }
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"math"
+	"strings"
+	"time"
+)
+
+// CampaignService defines business logic for discount campaigns.
+type CampaignService interface {
+	GetAllCampaigns() ([]models.Campaign, error)
+	GetCampaignByID(id uint64) (*models.Campaign, error)
+	CreateCampaign(dto *dtos.CampaignRequestDTO) (*models.Campaign, error)
+	UpdateCampaign(id uint64, dto *dtos.CampaignRequestDTO) (*models.Campaign, error)
+	DeleteCampaign(id uint64) error
+}
+
+// campaignService implements CampaignService interface
+type campaignService struct {
+	campaignRepo repositories.CampaignRepository
+}
+
+// NewCampaignService creates a new campaign service
+func NewCampaignService(campaignRepo repositories.CampaignRepository) CampaignService {
+	return &campaignService{
+		campaignRepo: campaignRepo,
+	}
+}
+
+// GetAllCampaigns retrieves all campaigns with their targets
+func (s *campaignService) GetAllCampaigns() ([]models.Campaign, error) {
+	campaigns, err := s.campaignRepo.GetAllCampaigns()
+	if err != nil {
+		return nil, errors.NewDatabaseError("get campaigns", err)
+	}
+	return campaigns, nil
+}
+
+// GetCampaignByID retrieves a campaign with its targets
+func (s *campaignService) GetCampaignByID(id uint64) (*models.Campaign, error) {
+	campaign, err := s.campaignRepo.GetCampaignByID(id)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Campaign")
+	}
+	return campaign, nil
+}
+
+// CreateCampaign creates a new discount campaign
+func (s *campaignService) CreateCampaign(dto *dtos.CampaignRequestDTO) (*models.Campaign, error) {
+	campaign := &models.Campaign{IsActive: true}
+	if err := applyCampaignDTO(campaign, dto); err != nil {
+		return nil, err
+	}
+	if err := s.campaignRepo.CreateCampaign(campaign); err != nil {
+		return nil, errors.NewDatabaseError("create campaign", err)
+	}
+	return campaign, nil
+}
+
+// UpdateCampaign replaces a campaign's settings and targets
+func (s *campaignService) UpdateCampaign(id uint64, dto *dtos.CampaignRequestDTO) (*models.Campaign, error) {
+	campaign, err := s.campaignRepo.GetCampaignByID(id)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Campaign")
+	}
+	if err := applyCampaignDTO(campaign, dto); err != nil {
+		return nil, err
+	}
+	if err := s.campaignRepo.UpdateCampaign(campaign); err != nil {
+		return nil, errors.NewDatabaseError("update campaign", err)
+	}
+	return campaign, nil
+}
+
+// DeleteCampaign deletes a campaign and its targets
+func (s *campaignService) DeleteCampaign(id uint64) error {
+	if _, err := s.campaignRepo.GetCampaignByID(id); err != nil {
+		return errors.NewNotFoundError("Campaign")
+	}
+	if err := s.campaignRepo.DeleteCampaign(id); err != nil {
+		return errors.NewDatabaseError("delete campaign", err)
+	}
+	return nil
+}
+
+// applyCampaignDTO validates the request and copies it onto the campaign model
+func applyCampaignDTO(campaign *models.Campaign, dto *dtos.CampaignRequestDTO) error {
+	if dto.DiscountType == constants.CampaignDiscountPercentage && dto.DiscountValue > 100 {
+		return errors.NewValidationError("percentage discount must be between 0 and 100")
+	}
+	if !dto.EndAt.After(dto.StartAt) {
+		return errors.NewValidationError("end_at must be after start_at")
+	}
+
+	targets := make([]models.CampaignTarget, 0, len(dto.Targets))
+	for _, target := range dto.Targets {
+		t := models.CampaignTarget{TargetType: target.TargetType}
+		if target.TargetType == constants.CampaignTargetCategory {
+			t.Category = strings.TrimSpace(target.Category)
+		} else {
+			t.TargetID = target.TargetID
+		}
+		targets = append(targets, t)
+	}
+
+	campaign.Name = strings.TrimSpace(dto.Name)
+	campaign.Description = dto.Description
+	campaign.DiscountType = dto.DiscountType
+	campaign.DiscountValue = dto.DiscountValue
+	campaign.StartAt = dto.StartAt
+	campaign.EndAt = dto.EndAt
+	if dto.IsActive != nil {
+		campaign.IsActive = *dto.IsActive
+	}
+	campaign.Targets = targets
+	return nil
+}
+
+// applyEffectivePrices computes the effective price of every item of the given products.
+// The product's own discount and every matching campaign are evaluated separately and the
+// lowest resulting price wins; discounts never stack.
+func applyEffectivePrices(products []*models.Product, campaigns []models.Campaign, now time.Time) {
+	for _, product := range products {
+		matching := make([]*models.Campaign, 0, len(campaigns))
+		for i := range campaigns {
+			if campaignMatchesProduct(&campaigns[i], product, now) {
+				matching = append(matching, &campaigns[i])
+			}
+		}
+
+		for i := range product.ProductItems {
+			item := &product.ProductItems[i]
+			effective := discountedPrice(item.Price, product.Discount)
+			var applied *models.Campaign
+			for _, campaign := range matching {
+				if price := campaignPrice(item.Price, campaign); price < effective {
+					effective = price
+					applied = campaign
+				}
+			}
+			item.EffectivePrice = &effective
+			item.AppliedCampaign = applied
+		}
+	}
+}
+
+// campaignMatchesProduct reports whether a campaign is running and targets the product.
+// A campaign without targets applies to every product.
+func campaignMatchesProduct(campaign *models.Campaign, product *models.Product, now time.Time) bool {
+	if !campaign.IsActive || campaign.StartAt.After(now) || !campaign.EndAt.After(now) {
+		return false
+	}
+	if len(campaign.Targets) == 0 {
+		return true
+	}
+	for _, target := range campaign.Targets {
+		switch target.TargetType {
+		case constants.CampaignTargetBrand:
+			if target.TargetID == product.BrandID {
+				return true
+			}
+		case constants.CampaignTargetProduct:
+			if target.TargetID == product.ID {
+				return true
+			}
+		case constants.CampaignTargetCategory:
+			for _, category := range product.ProductCategories {
+				if strings.EqualFold(category.Category, target.Category) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// discountedPrice applies the product-level discount (0-1) to a price.
+func discountedPrice(price int, discount float64) int {
+	if discount <= 0 {
+		return price
+	}
+	return clampPrice(math.Round(float64(price) * (1 - discount)))
+}
+
+// campaignPrice applies a campaign discount to a price.
+func campaignPrice(price int, campaign *models.Campaign) int {
+	switch campaign.DiscountType {
+	case constants.CampaignDiscountPercentage:
+		return clampPrice(math.Round(float64(price) * (1 - campaign.DiscountValue/100)))
+	case constants.CampaignDiscountFixed:
+		return clampPrice(math.Round(float64(price) - campaign.DiscountValue))
+	default:
+		return price
+	}
+}
+
+// clampPrice converts a computed price to int, never going below zero.
+func clampPrice(price float64) int {
+	if price < 0 {
+		return 0
+	}
+	return int(price)
+}
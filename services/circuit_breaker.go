@@ -0,0 +1,118 @@
+package services
+
+import (
+	"errors"
+	"flicknfit_backend/constants"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by CircuitBreaker.Allow while calls are being refused.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreakerState is a snapshot of a circuit breaker.
+type CircuitBreakerState struct {
+	State    string    // closed, open or half_open
+	Failures int       // Failures in a row while closed
+	OpenedAt time.Time // When the breaker last opened; zero if it never did
+}
+
+// CircuitBreaker stops calls to a failing dependency. It opens after a number of failures in a row, refuses
+// calls while open and, once the open duration passed, lets a single probe through: the probe's success closes
+// the breaker, its failure opens it again. Every allowed call must report its outcome with Success, Failure or
+// Skip.
+type CircuitBreaker struct {
+	threshold    int
+	openDuration time.Duration
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool // A half-open probe is in flight
+}
+
+// NewCircuitBreaker creates a closed circuit breaker.
+func NewCircuitBreaker(threshold int, openDuration time.Duration) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &CircuitBreaker{
+		threshold:    threshold,
+		openDuration: openDuration,
+		state:        constants.CircuitClosed,
+	}
+}
+
+// Allow reports whether a call may go ahead, returning ErrCircuitOpen when it may not.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case constants.CircuitOpen:
+		if time.Since(b.openedAt) < b.openDuration {
+			return ErrCircuitOpen
+		}
+		b.state = constants.CircuitHalfOpen
+		b.probing = true
+		return nil
+	case constants.CircuitHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Success records a successful call and closes the breaker.
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = constants.CircuitClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// Failure records a failed call, opening the breaker once the threshold is reached or when a probe failed.
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == constants.CircuitClosed {
+		b.failures++
+		if b.failures < b.threshold {
+			return
+		}
+	}
+	b.state = constants.CircuitOpen
+	b.openedAt = time.Now()
+	b.probing = false
+}
+
+// Skip records a call whose outcome says nothing about the dependency, such as one cancelled by its caller,
+// so a half-open breaker lets the next probe through.
+func (b *CircuitBreaker) Skip() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// MarkHealthy lets an open breaker probe the dependency on the next call without waiting for the open
+// duration to pass, after an out-of-band check found it healthy.
+func (b *CircuitBreaker) MarkHealthy() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == constants.CircuitOpen {
+		b.state = constants.CircuitHalfOpen
+		b.probing = false
+	}
+}
+
+// State returns a snapshot of the breaker.
+func (b *CircuitBreaker) State() CircuitBreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return CircuitBreakerState{State: b.state, Failures: b.failures, OpenedAt: b.openedAt}
+}
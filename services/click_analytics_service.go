@@ -0,0 +1,224 @@
+package services
+
+import (
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/utils"
+	"sort"
+	"time"
+)
+
+// ClickAnalyticsService defines business logic for analysing outbound clicks, for admins across brands and for brand members.
+type ClickAnalyticsService interface {
+	GetClickAnalytics(query *dtos.ClickAnalyticsQueryDTO) (*dtos.ClickAnalyticsDTO, error)
+	GetBrandClickAnalytics(brandID, userID uint64, query *dtos.ClickAnalyticsQueryDTO) (*dtos.ClickAnalyticsDTO, error)
+}
+
+// clickAnalyticsService implements ClickAnalyticsService interface
+type clickAnalyticsService struct {
+	analyticsRepo repositories.ClickAnalyticsRepository
+	memberRepo    repositories.BrandMemberRepository
+}
+
+// NewClickAnalyticsService creates a new click analytics service
+func NewClickAnalyticsService(analyticsRepo repositories.ClickAnalyticsRepository, memberRepo repositories.BrandMemberRepository) ClickAnalyticsService {
+	return &clickAnalyticsService{
+		analyticsRepo: analyticsRepo,
+		memberRepo:    memberRepo,
+	}
+}
+
+// GetClickAnalytics analyses the clicks of all brands, or of the brand in the query
+func (s *clickAnalyticsService) GetClickAnalytics(query *dtos.ClickAnalyticsQueryDTO) (*dtos.ClickAnalyticsDTO, error) {
+	return s.analyse(query.BrandID, query)
+}
+
+// GetBrandClickAnalytics analyses the clicks of a brand for one of its members
+func (s *clickAnalyticsService) GetBrandClickAnalytics(brandID, userID uint64, query *dtos.ClickAnalyticsQueryDTO) (*dtos.ClickAnalyticsDTO, error) {
+	if err := requireBrandMember(s.memberRepo, brandID, userID); err != nil {
+		return nil, err
+	}
+	return s.analyse(brandID, query)
+}
+
+// analyse builds the click analytics of a brand, or of all brands when brandID is 0
+func (s *clickAnalyticsService) analyse(brandID uint64, query *dtos.ClickAnalyticsQueryDTO) (*dtos.ClickAnalyticsDTO, error) {
+	granularity, err := parseGranularity(query.Granularity)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := parseDateRange(query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+	scope := repositories.ClickScope{BrandID: brandID, From: from, To: to.AddDate(0, 0, 1)}
+
+	totals, err := s.analyticsRepo.GetClickTotals(scope)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get click totals", err)
+	}
+	series, err := s.series(scope, granularity, from, to)
+	if err != nil {
+		return nil, err
+	}
+	byProduct, err := s.analyticsRepo.GetClicksByProduct(scope, constants.ClickAnalyticsTopProducts)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get clicks by product", err)
+	}
+	byCategory, err := s.analyticsRepo.GetClicksByCategory(scope)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get clicks by category", err)
+	}
+	byUserAgent, err := s.analyticsRepo.GetClicksByUserAgent(scope)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get clicks by user agent", err)
+	}
+	byRegion, err := s.analyticsRepo.GetClicksByRegion(scope)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get clicks by region", err)
+	}
+	byChannel, err := s.analyticsRepo.GetClicksByChannel(scope)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get clicks by channel", err)
+	}
+	suspicious, err := s.analyticsRepo.GetSuspiciousClicks(scope)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get suspicious clicks", err)
+	}
+	var excluded int64
+	for _, reason := range suspicious {
+		excluded += reason.Clicks
+	}
+	funnel, err := s.funnel(scope, totals.Clicks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtos.ClickAnalyticsDTO{
+		BrandID:         brandID,
+		From:            from.Format(constants.AnalyticsDateLayout),
+		To:              to.Format(constants.AnalyticsDateLayout),
+		Granularity:     granularity,
+		TotalClicks:     totals.Clicks,
+		SignedInClicks:  totals.Clicks - totals.AnonymousClicks,
+		AnonymousClicks: totals.AnonymousClicks,
+		UniqueUsers:     totals.UniqueUsers,
+		ExcludedClicks:  excluded,
+		ExcludedReasons: suspicious,
+		Series:          series,
+		ByProduct:       byProduct,
+		ByCategory:      breakdown(byCategory, totals.Clicks, func(key string) string { return key }),
+		ByDevice:        breakdown(byUserAgent, totals.Clicks, utils.DeviceClass),
+		ByRegion:        breakdown(byRegion, totals.Clicks, regionKey),
+		ByChannel:       breakdown(byChannel, totals.Clicks, channelKey),
+		Funnel:          funnel,
+	}, nil
+}
+
+// series counts the clicks per period between from and to (both included). Unique users are counted per
+// period, so a user clicking on several days of a week counts once for that week.
+func (s *clickAnalyticsService) series(scope repositories.ClickScope, granularity string, from, to time.Time) ([]dtos.ClickSeriesPointDTO, error) {
+	daily, err := s.analyticsRepo.GetDailyClicks(scope)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get daily clicks", err)
+	}
+	dailyUsers, err := s.analyticsRepo.GetDailyClickUsers(scope)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get daily click users", err)
+	}
+
+	labels, index := periodLabels(from, to, granularity)
+	points := make([]dtos.ClickSeriesPointDTO, len(labels))
+	for i, label := range labels {
+		points[i].Period = label
+	}
+	for _, day := range daily {
+		if i, ok := periodOf(day.Day, granularity, index); ok {
+			points[i].Clicks += day.Clicks
+			points[i].AnonymousClicks += day.AnonymousClicks
+			points[i].SignedInClicks += day.Clicks - day.AnonymousClicks
+		}
+	}
+	users := make([]map[uint64]bool, len(points))
+	for _, user := range dailyUsers {
+		i, ok := periodOf(user.Day, granularity, index)
+		if !ok {
+			continue
+		}
+		if users[i] == nil {
+			users[i] = make(map[uint64]bool)
+		}
+		if !users[i][user.UserID] {
+			users[i][user.UserID] = true
+			points[i].UniqueUsers++
+		}
+	}
+	return points, nil
+}
+
+// funnel builds the view → favorite → click funnel
+func (s *clickAnalyticsService) funnel(scope repositories.ClickScope, clicks int64) ([]dtos.FunnelStageDTO, error) {
+	views, err := s.analyticsRepo.CountViews(scope)
+	if err != nil {
+		return nil, errors.NewDatabaseError("count views", err)
+	}
+	favorites, err := s.analyticsRepo.CountFavorites(scope)
+	if err != nil {
+		return nil, errors.NewDatabaseError("count favorites", err)
+	}
+	stages := []dtos.FunnelStageDTO{
+		{Stage: constants.FunnelStageView, Count: views},
+		{Stage: constants.FunnelStageFavorite, Count: favorites},
+		{Stage: constants.FunnelStageClick, Count: clicks},
+	}
+	for i := 1; i < len(stages); i++ {
+		if previous := stages[i-1].Count; previous > 0 {
+			rate := float64(stages[i].Count) / float64(previous) * 100
+			stages[i].Rate = &rate
+		}
+	}
+	return stages, nil
+}
+
+// breakdown merges click counts under the key classify maps them to and adds each key's share of all clicks,
+// largest first
+func breakdown(counts []models.ClickCount, total int64, classify func(string) string) []dtos.ClickBreakdownDTO {
+	merged := make(map[string]int64)
+	for _, count := range counts {
+		merged[classify(count.Key)] += count.Clicks
+	}
+	result := make([]dtos.ClickBreakdownDTO, 0, len(merged))
+	for key, clicks := range merged {
+		item := dtos.ClickBreakdownDTO{Key: key, Clicks: clicks}
+		if total > 0 {
+			item.Share = float64(clicks) / float64(total) * 100
+		}
+		result = append(result, item)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Clicks != result[j].Clicks {
+			return result[i].Clicks > result[j].Clicks
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// regionKey reports clicks without a region as unknown
+func regionKey(region string) string {
+	if region == "" {
+		return constants.ClickRegionUnknown
+	}
+	return region
+}
+
+// channelKey reports clicks recorded without a channel as unknown
+func channelKey(channel string) string {
+	if channel == "" {
+		return constants.RedirectChannelUnknown
+	}
+	return channel
+}
@@ -0,0 +1,20 @@
+package services
+
+import (
+	"flicknfit_backend/utils"
+	"time"
+)
+
+// NewClickRetentionScheduler creates a job that anonymises or deletes the clicks older than the retention
+// period at the given interval.
+func NewClickRetentionScheduler(trackingService TrackingService, interval time.Duration) *PeriodicJob {
+	return NewPeriodicJob(interval, func() {
+		processed, err := trackingService.ApplyClickRetention(time.Now())
+		if err != nil {
+			utils.GetLogger().WithError(err).Error("Failed to apply the click retention policy")
+		}
+		if processed > 0 {
+			utils.GetLogger().WithField("clicks", processed).Info("Applied the click retention policy")
+		}
+	})
+}
@@ -0,0 +1,287 @@
+package services
+
+import (
+	"errors"
+	"flicknfit_backend/config"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/utils"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ConversionService defines business logic for sales confirmed by brands and the sold/total-product counters.
+type ConversionService interface {
+	RecordConversion(brandID, userID uint64, dto *dtos.ConversionRequestDTO) (*models.Conversion, error)
+	GetBrandConversions(brandID, userID uint64, limit int) ([]models.Conversion, error)
+	ReconcileCounters() (*models.CounterReconciliation, error)
+	RotatePostbackSecret(brandID, userID uint64) (*dtos.PostbackSecretResponseDTO, error)
+	VerifyPostback(brandID uint64, timestamp, signature string, body []byte) error
+	RecordPostback(brandID uint64, idempotencyKey string, dto *dtos.ConversionPostbackDTO) (*dtos.ConversionPostbackResponseDTO, error)
+}
+
+// conversionService implements ConversionService interface
+type conversionService struct {
+	conversionRepo repositories.ConversionRepository
+	productRepo    repositories.ProductRepository
+	memberRepo     repositories.BrandMemberRepository
+	clickRepo      repositories.ProductClickRepository
+	clickIDSecret  string
+}
+
+// NewConversionService creates a new conversion service
+func NewConversionService(conversionRepo repositories.ConversionRepository, productRepo repositories.ProductRepository, memberRepo repositories.BrandMemberRepository, clickRepo repositories.ProductClickRepository, cfg *config.Config) ConversionService {
+	return &conversionService{
+		conversionRepo: conversionRepo,
+		productRepo:    productRepo,
+		memberRepo:     memberRepo,
+		clickRepo:      clickRepo,
+		clickIDSecret:  cfg.ClickIDSecret,
+	}
+}
+
+// RecordConversion records a sale reported by a member of the brand and adds it to the item and product Sold counters
+func (s *conversionService) RecordConversion(brandID, userID uint64, dto *dtos.ConversionRequestDTO) (*models.Conversion, error) {
+	if err := requireBrandMember(s.memberRepo, brandID, userID); err != nil {
+		return nil, err
+	}
+
+	conversion, err := s.newConversion(brandID, dto)
+	if err != nil {
+		return nil, err
+	}
+	conversion.Source = constants.ConversionSourceAPI
+	conversion.RecordedBy = &userID
+
+	if err := s.conversionRepo.RecordConversion(conversion); err != nil {
+		if errors.Is(err, repositories.ErrDuplicateConversion) {
+			return nil, apperrors.New(apperrors.ErrorTypeConflict, http.StatusConflict, "This order line has already been recorded")
+		}
+		return nil, apperrors.NewDatabaseError("record conversion", err)
+	}
+	return conversion, nil
+}
+
+// newConversion resolves the SKU to an item of the brand and builds the conversion
+func (s *conversionService) newConversion(brandID uint64, dto *dtos.ConversionRequestDTO) (*models.Conversion, error) {
+	items, err := s.productRepo.GetProductItemsBySKUs([]string{dto.SKU})
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get product item", err)
+	}
+	if len(items) == 0 {
+		return nil, apperrors.NewNotFoundError("Product item")
+	}
+	item := items[0]
+	product, err := s.productRepo.GetProductByID(item.ProductID)
+	if err != nil || product.BrandID != brandID {
+		return nil, apperrors.NewNotFoundError("Product item")
+	}
+
+	convertedAt := time.Now()
+	if dto.ConvertedAt != nil {
+		if dto.ConvertedAt.After(convertedAt) {
+			return nil, apperrors.NewValidationError("converted_at cannot be in the future")
+		}
+		convertedAt = *dto.ConvertedAt
+	}
+	revenue := item.Price * dto.Quantity
+	if dto.Amount != nil {
+		revenue = *dto.Amount
+	}
+	conversion := &models.Conversion{
+		BrandID:       brandID,
+		ProductID:     product.ID,
+		ProductItemID: item.ID,
+		Quantity:      dto.Quantity,
+		Revenue:       revenue,
+		OrderRef:      dto.OrderRef,
+		Channel:       dto.Channel,
+		ConvertedAt:   convertedAt,
+		Product:       *product,
+		ProductItem:   item,
+	}
+	if dto.ClickID != "" {
+		if err := s.attributeClick(conversion, dto.ClickID); err != nil {
+			return nil, err
+		}
+	}
+	return conversion, nil
+}
+
+// attributeClick links a conversion to the click a brand reported back and the user who clicked. The click
+// must belong to the brand; its channel is used when the brand did not report one.
+func (s *conversionService) attributeClick(conversion *models.Conversion, token string) error {
+	ref, err := utils.VerifyClickRef(token, s.clickIDSecret)
+	if err != nil {
+		return apperrors.NewValidationError("Invalid click_id")
+	}
+	// Click IDs handed out before clicks were given references sign the database ID
+	var click *models.ProductClick
+	if clickID, parseErr := strconv.ParseUint(ref, 10, 64); parseErr == nil {
+		click, err = s.clickRepo.GetByID(clickID)
+	} else {
+		click, err = s.clickRepo.GetByRef(ref)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NewValidationError("Invalid click_id")
+		}
+		return apperrors.NewDatabaseError("get product click", err)
+	}
+	if click.BrandID != conversion.BrandID {
+		return apperrors.NewValidationError("click_id belongs to another brand")
+	}
+	conversion.ClickID = &click.ID
+	conversion.UserID = click.UserID
+	if conversion.Channel == "" {
+		conversion.Channel = click.Channel
+	}
+	return nil
+}
+
+// GetBrandConversions retrieves the latest conversions of a brand for one of its members
+func (s *conversionService) GetBrandConversions(brandID, userID uint64, limit int) ([]models.Conversion, error) {
+	if err := requireBrandMember(s.memberRepo, brandID, userID); err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > constants.ConversionsPageSize {
+		limit = constants.ConversionsPageSize
+	}
+	conversions, err := s.conversionRepo.GetConversionsByBrandID(brandID, limit)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get conversions", err)
+	}
+	return conversions, nil
+}
+
+// ReconcileCounters recomputes all sold and total-product counters and reports how many were corrected
+func (s *conversionService) ReconcileCounters() (*models.CounterReconciliation, error) {
+	result, err := s.conversionRepo.ReconcileCounters()
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("reconcile counters", err)
+	}
+	return result, nil
+}
+
+// RotatePostbackSecret generates a new secret for signing the brand's postbacks; the previous one stops working
+func (s *conversionService) RotatePostbackSecret(brandID, userID uint64) (*dtos.PostbackSecretResponseDTO, error) {
+	if err := requireBrandMember(s.memberRepo, brandID, userID); err != nil {
+		return nil, err
+	}
+	secret, err := utils.GenerateSecret()
+	if err != nil {
+		return nil, apperrors.NewInternalError("Failed to generate postback secret", err)
+	}
+	if err := s.conversionRepo.SetPostbackSecret(brandID, secret); err != nil {
+		return nil, apperrors.NewDatabaseError("update postback secret", err)
+	}
+	return &dtos.PostbackSecretResponseDTO{
+		BrandID:     brandID,
+		Secret:      secret,
+		PostbackURL: fmt.Sprintf("%s/webhooks/brands/%d/conversions", constants.APIPrefix, brandID),
+	}, nil
+}
+
+// VerifyPostback checks that a postback body was signed with the brand's secret within the allowed clock skew
+func (s *conversionService) VerifyPostback(brandID uint64, timestamp, signature string, body []byte) error {
+	secret, err := s.conversionRepo.GetPostbackSecret(brandID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NewNotFoundError("Brand")
+		}
+		return apperrors.NewDatabaseError("get postback secret", err)
+	}
+	if secret == "" {
+		return apperrors.NewAuthorizationError("Postbacks are not enabled for this brand")
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return apperrors.NewAuthenticationError("Missing or invalid postback timestamp")
+	}
+	if skew := time.Since(time.Unix(unix, 0)); skew > constants.PostbackMaxClockSkew || skew < -constants.PostbackMaxClockSkew {
+		return apperrors.NewAuthenticationError("Postback timestamp is outside the allowed window")
+	}
+	if !utils.VerifyPostbackSignature(secret, timestamp, signature, body) {
+		return apperrors.NewAuthenticationError("Invalid postback signature")
+	}
+	return nil
+}
+
+// RecordPostback records every line of a verified postback in one transaction. Lines already recorded for the
+// order are counted as duplicates, and a repeated idempotency key returns the first result without recording anything.
+func (s *conversionService) RecordPostback(brandID uint64, idempotencyKey string, dto *dtos.ConversionPostbackDTO) (*dtos.ConversionPostbackResponseDTO, error) {
+	if idempotencyKey != "" {
+		previous, err := s.conversionRepo.GetPostback(brandID, idempotencyKey)
+		if err == nil {
+			return replayedPostback(previous), nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewDatabaseError("get postback", err)
+		}
+	}
+
+	// Resolve every line before recording, so an unknown SKU rejects the whole order
+	conversions := make([]*models.Conversion, len(dto.Items))
+	for i, item := range dto.Items {
+		conversion, err := s.newConversion(brandID, &dtos.ConversionRequestDTO{
+			SKU:         item.SKU,
+			Quantity:    item.Quantity,
+			Amount:      item.Amount,
+			OrderRef:    dto.OrderRef,
+			ConvertedAt: dto.ConvertedAt,
+			Channel:     dto.Channel,
+			ClickID:     dto.ClickID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		conversion.Source = constants.ConversionSourceWebhook
+		conversions[i] = conversion
+	}
+
+	postback := &models.ConversionPostback{
+		BrandID:        brandID,
+		IdempotencyKey: idempotencyKey,
+		OrderRef:       dto.OrderRef,
+	}
+	recorded, err := s.conversionRepo.RecordPostback(postback, conversions)
+	if err != nil {
+		if errors.Is(err, repositories.ErrDuplicatePostback) {
+			// A concurrent delivery with the same key was recorded first
+			previous, getErr := s.conversionRepo.GetPostback(brandID, idempotencyKey)
+			if getErr != nil {
+				return nil, apperrors.NewDatabaseError("get postback", getErr)
+			}
+			return replayedPostback(previous), nil
+		}
+		return nil, apperrors.NewDatabaseError("record postback", err)
+	}
+
+	response := &dtos.ConversionPostbackResponseDTO{
+		OrderRef:   dto.OrderRef,
+		Recorded:   postback.Recorded,
+		Duplicates: postback.Duplicates,
+	}
+	for _, conversion := range recorded {
+		response.Conversions = append(response.Conversions, dtos.ToConversionResponseDTO(*conversion))
+	}
+	return response, nil
+}
+
+// replayedPostback answers a repeated postback with the result of the first delivery
+func replayedPostback(previous *models.ConversionPostback) *dtos.ConversionPostbackResponseDTO {
+	return &dtos.ConversionPostbackResponseDTO{
+		OrderRef:   previous.OrderRef,
+		Recorded:   previous.Recorded,
+		Duplicates: previous.Duplicates,
+		Replayed:   true,
+	}
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"flicknfit_backend/utils"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NewCounterReconcileScheduler creates a job that reconciles the sold and total-product counters with their
+// sources at the given interval, correcting drift left by failed writes or direct database edits.
+func NewCounterReconcileScheduler(conversionService ConversionService, interval time.Duration) *PeriodicJob {
+	return NewPeriodicJob(interval, func() {
+		result, err := conversionService.ReconcileCounters()
+		if err != nil {
+			utils.GetLogger().WithError(err).Error("Failed to reconcile counters")
+			return
+		}
+		if result.ItemsCorrected+result.ProductsCorrected+result.BrandsCorrected > 0 {
+			utils.GetLogger().WithFields(logrus.Fields{
+				"items":    result.ItemsCorrected,
+				"products": result.ProductsCorrected,
+				"brands":   result.BrandsCorrected,
+			}).Warn("Corrected drifted counters")
+		}
+	})
+}
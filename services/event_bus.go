@@ -0,0 +1,212 @@
+package services
+
+import (
+	"errors"
+	"flicknfit_backend/utils"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrEventBusFull is returned when an event could not be queued before the publish timeout.
+var ErrEventBusFull = errors.New("event bus is full")
+
+// ErrEventBusClosed is returned when an event is published after the bus was stopped.
+var ErrEventBusClosed = errors.New("event bus is closed")
+
+// Event is a message published on the event bus. Payload is handed to the topic's handler unchanged.
+// Events with the same Key are handled in the order they were published; events without one may be handled
+// in any order.
+type Event struct {
+	Topic       string
+	Key         string
+	Payload     interface{}
+	PublishedAt time.Time
+}
+
+// EventHandler processes a batch of events of one topic.
+type EventHandler func(events []Event) error
+
+// EventBus decouples producers on hot paths from the writes their events cause. The in-process bus below can
+// be swapped for one backed by an external broker without changing publishers or handlers.
+type EventBus interface {
+	// Subscribe registers the handler of a topic. It must be called before Start.
+	Subscribe(topic string, handler EventHandler)
+	// Publish queues an event, waiting for room at most the publish timeout.
+	Publish(event Event) error
+	Start()
+	// Stop stops accepting events and returns once every queued event was handled.
+	Stop()
+}
+
+// EventBusOptions configures the in-process event bus.
+type EventBusOptions struct {
+	QueueSize      int           // Events that can wait for the workers, split evenly between them
+	Workers        int           // Goroutines handling events
+	BatchSize      int           // A worker hands a topic's events over once this many are waiting
+	FlushInterval  time.Duration // ... or once this much time has passed
+	PublishTimeout time.Duration // How long Publish waits for room in a full queue
+}
+
+// memoryEventBus is the in-process implementation of EventBus: a pool of workers, each draining its own
+// bounded channel and batching events per topic. Events are assigned to a worker by key.
+type memoryEventBus struct {
+	options  EventBusOptions
+	queues   []chan Event
+	handlers map[string]EventHandler
+	next     atomic.Uint64 // Spreads events without a key over the workers
+	wg       sync.WaitGroup
+
+	mu     sync.RWMutex // Guards closed; held for reading while publishing so Stop never closes a queue under a sender
+	closed bool
+}
+
+// NewEventBus creates an in-process event bus.
+func NewEventBus(options EventBusOptions) EventBus {
+	if options.Workers < 1 {
+		options.Workers = 1
+	}
+	if options.BatchSize < 1 {
+		options.BatchSize = 1
+	}
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = time.Second
+	}
+	queues := make([]chan Event, options.Workers)
+	for i := range queues {
+		queues[i] = make(chan Event, max(options.QueueSize/options.Workers, 1))
+	}
+	return &memoryEventBus{
+		options:  options,
+		queues:   queues,
+		handlers: make(map[string]EventHandler),
+	}
+}
+
+// Subscribe registers the handler of a topic, replacing an earlier one.
+func (b *memoryEventBus) Subscribe(topic string, handler EventHandler) {
+	b.handlers[topic] = handler
+}
+
+// Publish queues an event. When the queue is full it waits up to the publish timeout, slowing producers down
+// before events are refused.
+func (b *memoryEventBus) Publish(event Event) error {
+	if event.PublishedAt.IsZero() {
+		event.PublishedAt = time.Now()
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return ErrEventBusClosed
+	}
+
+	queue := b.queueFor(event.Key)
+	select {
+	case queue <- event:
+		return nil
+	default:
+	}
+	timer := time.NewTimer(b.options.PublishTimeout)
+	defer timer.Stop()
+	select {
+	case queue <- event:
+		return nil
+	case <-timer.C:
+		utils.GetLogger().WithField("topic", event.Topic).Warn("Event bus is full, dropping event")
+		return ErrEventBusFull
+	}
+}
+
+// queueFor returns the queue of the worker handling events with the given key.
+func (b *memoryEventBus) queueFor(key string) chan Event {
+	if key == "" {
+		return b.queues[b.next.Add(1)%uint64(len(b.queues))]
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return b.queues[hash.Sum32()%uint32(len(b.queues))]
+}
+
+// Start runs the workers.
+func (b *memoryEventBus) Start() {
+	for _, queue := range b.queues {
+		b.wg.Add(1)
+		go b.work(queue)
+	}
+}
+
+// Stop closes the queues and waits for the workers to handle the events still in them.
+func (b *memoryEventBus) Stop() {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		for _, queue := range b.queues {
+			close(queue)
+		}
+	}
+	b.mu.Unlock()
+	b.wg.Wait()
+}
+
+// work collects events per topic until a batch is full or the flush interval passes, and flushes what is
+// left once the queue is closed and empty.
+func (b *memoryEventBus) work(queue chan Event) {
+	defer b.wg.Done()
+	ticker := time.NewTicker(b.options.FlushInterval)
+	defer ticker.Stop()
+
+	batches := make(map[string][]Event)
+	for {
+		select {
+		case event, ok := <-queue:
+			if !ok {
+				for topic, batch := range batches {
+					b.handle(topic, batch)
+				}
+				return
+			}
+			batches[event.Topic] = append(batches[event.Topic], event)
+			if len(batches[event.Topic]) >= b.options.BatchSize {
+				b.handle(event.Topic, batches[event.Topic])
+				delete(batches, event.Topic)
+			}
+		case <-ticker.C:
+			for topic, batch := range batches {
+				b.handle(topic, batch)
+				delete(batches, topic)
+			}
+		}
+	}
+}
+
+// handle hands a batch to its topic's handler. Failed batches, including ones whose handler panics, are
+// logged and dropped.
+func (b *memoryEventBus) handle(topic string, batch []Event) {
+	handler, ok := b.handlers[topic]
+	if !ok {
+		utils.GetLogger().WithField("topic", topic).Warn("No handler for events, dropping them")
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			b.logFailure(topic, len(batch), fmt.Errorf("handler panicked: %v", r))
+		}
+	}()
+	if err := handler(batch); err != nil {
+		b.logFailure(topic, len(batch), err)
+	}
+}
+
+// logFailure logs a batch that could not be handled.
+func (b *memoryEventBus) logFailure(topic string, events int, err error) {
+	utils.GetLogger().WithError(err).WithFields(logrus.Fields{
+		"topic":  topic,
+		"events": events,
+	}).Error("Failed to handle events")
+}
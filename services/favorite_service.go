@@ -41,7 +41,7 @@ func (s *favoriteService) GetUserFavorites(userID uint64) ([]models.Favorite, er
 // AddFavorite adds a product to user's favorites
 func (s *favoriteService) AddFavorite(userID uint64, dto *dtos.AddFavoriteDTO) error {
 	// Check if product item exists
-	_, err := s.productRepo.GetProductItemByID(dto.ProductItemID)
+	_, err := s.productRepo.GetPublishedProductItemByID(dto.ProductItemID)
 	if err != nil {
 		return errors.NewNotFoundError("Product item")
 	}
@@ -89,7 +89,7 @@ func (s *favoriteService) RemoveFavorite(userID, productItemID uint64) error {
 // ToggleFavorite toggles favorite status for a product
 func (s *favoriteService) ToggleFavorite(userID uint64, dto *dtos.AddFavoriteDTO) (*dtos.FavoriteToggleResponseDTO, error) {
 	// Check if product item exists
-	_, err := s.productRepo.GetProductItemByID(dto.ProductItemID)
+	_, err := s.productRepo.GetPublishedProductItemByID(dto.ProductItemID)
 	if err != nil {
 		return nil, errors.NewNotFoundError("Product item")
 	}
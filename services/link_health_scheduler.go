@@ -0,0 +1,30 @@
+package services
+
+import (
+	"flicknfit_backend/utils"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NewLinkHealthScheduler creates a job that checks stored product and brand links at the given interval, so
+// redirects can skip dead ones.
+func NewLinkHealthScheduler(linkHealthService LinkHealthService, interval time.Duration) *PeriodicJob {
+	return NewPeriodicJob(interval, func() {
+		summary, err := linkHealthService.CheckAllLinks()
+		if err != nil {
+			utils.GetLogger().WithError(err).Error("Failed to check links")
+			return
+		}
+		entry := utils.GetLogger().WithFields(logrus.Fields{
+			"links":  summary.LinksChecked,
+			"urls":   summary.URLsChecked,
+			"broken": summary.Broken,
+		})
+		if summary.Broken > 0 {
+			entry.Warn("Found broken links")
+			return
+		}
+		entry.Info("Checked links")
+	})
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/utils"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LinkHealthService defines business logic for checking stored product and brand links and reporting
+// the broken ones.
+type LinkHealthService interface {
+	CheckAllLinks() (*models.LinkCheckSummary, error)
+	GetBrokenLinks(page, limit int) (*dtos.BrokenLinkListResponseDTO, error)
+}
+
+// linkHealthService implements LinkHealthService interface
+type linkHealthService struct {
+	linkCheckRepo repositories.LinkCheckRepository
+	client        *http.Client
+}
+
+// NewLinkHealthService creates a new link health service that checks links with the given HTTP client.
+// A nil client uses a client with LinkCheckTimeout that only connects to public addresses, since the
+// links are supplied by brands and must not reach internal services.
+func NewLinkHealthService(linkCheckRepo repositories.LinkCheckRepository, client *http.Client) LinkHealthService {
+	if client == nil {
+		client = utils.NewPublicHTTPClient(constants.LinkCheckTimeout)
+	}
+	return &linkHealthService{
+		linkCheckRepo: linkCheckRepo,
+		client:        client,
+	}
+}
+
+// linkResult is the outcome of requesting one URL
+type linkResult struct {
+	statusCode int
+	err        error
+}
+
+// CheckAllLinks requests every stored link once, records its status and marks links broken after
+// LinkBrokenAfterFailures failed checks in a row. Links shared by several products are requested once.
+func (s *linkHealthService) CheckAllLinks() (*models.LinkCheckSummary, error) {
+	runStartedAt := time.Now()
+	targets, err := s.linkCheckRepo.GetLinkTargets()
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get link targets", err)
+	}
+	previous, err := s.linkCheckRepo.GetLinkChecks()
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get link checks", err)
+	}
+	previousByKey := make(map[string]models.LinkCheck, len(previous))
+	for _, check := range previous {
+		previousByKey[linkCheckKey(check)] = check
+	}
+
+	var urls []string
+	seen := make(map[string]bool)
+	for _, target := range targets {
+		if !seen[target.URL] {
+			seen[target.URL] = true
+			urls = append(urls, target.URL)
+		}
+	}
+	results := s.checkURLs(urls)
+
+	summary := &models.LinkCheckSummary{LinksChecked: len(targets), URLsChecked: len(urls)}
+	checks := make([]models.LinkCheck, 0, len(targets))
+	for _, target := range targets {
+		check := target
+		result := results[target.URL]
+		checkedAt := time.Now()
+		check.StatusCode = result.statusCode
+		check.CheckedAt = checkedAt
+
+		// A changed URL starts with a clean record
+		failures, failingSince := 0, (*time.Time)(nil)
+		if prev, ok := previousByKey[linkCheckKey(target)]; ok && prev.URL == target.URL {
+			failures, failingSince = prev.ConsecutiveFailures, prev.FailingSince
+		}
+		if result.err != nil || isBrokenStatus(result.statusCode) {
+			check.Error = linkErrorMessage(result)
+			check.ConsecutiveFailures = failures + 1
+			check.FailingSince = failingSince
+			if check.FailingSince == nil {
+				check.FailingSince = &checkedAt
+			}
+		}
+		check.Broken = check.ConsecutiveFailures >= constants.LinkBrokenAfterFailures
+		if check.Broken {
+			summary.Broken++
+		}
+		checks = append(checks, check)
+	}
+
+	if err := s.linkCheckRepo.SaveLinkChecks(checks, runStartedAt); err != nil {
+		return nil, apperrors.NewDatabaseError("save link checks", err)
+	}
+	return summary, nil
+}
+
+// checkURLs requests the URLs with at most LinkCheckConcurrency requests in flight
+func (s *linkHealthService) checkURLs(urls []string) map[string]linkResult {
+	results := make(map[string]linkResult, len(urls))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	queue := make(chan string)
+	for i := 0; i < constants.LinkCheckConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url := range queue {
+				statusCode, err := s.checkURL(url)
+				mu.Lock()
+				results[url] = linkResult{statusCode: statusCode, err: err}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, url := range urls {
+		queue <- url
+	}
+	close(queue)
+	wg.Wait()
+	return results
+}
+
+// checkURL requests a URL with HEAD and falls back to GET when HEAD fails or is refused,
+// since many stores do not answer HEAD requests properly
+func (s *linkHealthService) checkURL(url string) (int, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return 0, fmt.Errorf("not an http or https URL")
+	}
+	statusCode, err := s.request(http.MethodHead, url)
+	if err == nil && statusCode < http.StatusBadRequest {
+		return statusCode, nil
+	}
+	return s.request(http.MethodGet, url)
+}
+
+// request sends a single request and returns the final status code after redirects
+func (s *linkHealthService) request(method, url string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), constants.LinkCheckTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set(constants.HeaderUserAgent, constants.LinkCheckUserAgent)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
+
+// isBrokenStatus reports whether a status means the page is gone or the store is down.
+// Other 4xx answers such as 403 and 429 are what marketplaces send to bots, so the page is assumed to exist.
+func isBrokenStatus(statusCode int) bool {
+	return statusCode == http.StatusNotFound || statusCode == http.StatusGone || statusCode >= http.StatusInternalServerError
+}
+
+// linkErrorMessage describes a failed check for the admin report
+func linkErrorMessage(result linkResult) string {
+	message := fmt.Sprintf("HTTP %d", result.statusCode)
+	if result.err != nil {
+		message = result.err.Error()
+	}
+	if len(message) > 255 {
+		message = message[:255]
+	}
+	return message
+}
+
+// linkCheckKey identifies the link of a check on its product or brand
+func linkCheckKey(check models.LinkCheck) string {
+	return fmt.Sprintf("%s:%d:%s", check.EntityType, check.EntityID, check.Field)
+}
+
+// GetBrokenLinks retrieves a page of broken links, longest failing first
+func (s *linkHealthService) GetBrokenLinks(page, limit int) (*dtos.BrokenLinkListResponseDTO, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > constants.MaxPageSize {
+		limit = constants.DefaultPageSize
+	}
+
+	links, total, err := s.linkCheckRepo.GetBrokenLinks(limit, (page-1)*limit)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get broken links", err)
+	}
+	return &dtos.BrokenLinkListResponseDTO{
+		Links:      links,
+		Pagination: dtos.PaginationDTO{Page: page, Limit: limit, Total: int(total)},
+	}, nil
+}
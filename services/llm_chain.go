@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"log"
 )
@@ -17,14 +18,17 @@ func NewLLMChain(providers ...LLMProvider) *LLMChain {
 	}
 }
 
-// GenerateColorRecommendations tries each provider until success
-func (c *LLMChain) GenerateColorRecommendations(skinTone string) ([]string, error) {
+// GenerateColorRecommendations tries each provider until success or until ctx is done
+func (c *LLMChain) GenerateColorRecommendations(ctx context.Context, skinTone string) ([]string, error) {
 	var lastErr error
 
 	for _, provider := range c.providers {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("stopped before trying %s: %w", provider.GetName(), err)
+		}
 		log.Printf("[LLMChain] Trying %s for color recommendations (skinTone: %s)", provider.GetName(), skinTone)
 
-		colors, err := provider.GenerateColorRecommendations(skinTone)
+		colors, err := provider.GenerateColorRecommendations(ctx, skinTone)
 		if err != nil {
 			log.Printf("[LLMChain] %s failed: %v", provider.GetName(), err)
 			lastErr = err
@@ -38,14 +42,17 @@ func (c *LLMChain) GenerateColorRecommendations(skinTone string) ([]string, erro
 	return nil, fmt.Errorf("all providers failed, last error: %w", lastErr)
 }
 
-// GenerateStyleRecommendations tries each provider until success
-func (c *LLMChain) GenerateStyleRecommendations(bodyType string) ([]string, error) {
+// GenerateStyleRecommendations tries each provider until success or until ctx is done
+func (c *LLMChain) GenerateStyleRecommendations(ctx context.Context, bodyType string) ([]string, error) {
 	var lastErr error
 
 	for _, provider := range c.providers {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("stopped before trying %s: %w", provider.GetName(), err)
+		}
 		log.Printf("[LLMChain] Trying %s for style recommendations (bodyType: %s)", provider.GetName(), bodyType)
 
-		styles, err := provider.GenerateStyleRecommendations(bodyType)
+		styles, err := provider.GenerateStyleRecommendations(ctx, bodyType)
 		if err != nil {
 			log.Printf("[LLMChain] %s failed: %v", provider.GetName(), err)
 			lastErr = err
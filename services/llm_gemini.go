@@ -30,8 +30,8 @@ func (g *GeminiProvider) GetName() string {
 }
 
 // invoke calls Gemini API
-func (g *GeminiProvider) invoke(systemPrompt, userPrompt string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+func (g *GeminiProvider) invoke(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
 	client, err := genai.NewClient(ctx, option.WithAPIKey(g.APIKey))
@@ -82,9 +82,9 @@ func (g *GeminiProvider) invoke(systemPrompt, userPrompt string) (string, error)
 }
 
 // GenerateColorRecommendations generates color recommendations based on skin tone
-func (g *GeminiProvider) GenerateColorRecommendations(skinTone string) ([]string, error) {
+func (g *GeminiProvider) GenerateColorRecommendations(ctx context.Context, skinTone string) ([]string, error) {
 	prompt := GetColorPrompt(skinTone)
-	content, err := g.invoke("You are a fashion color expert.", prompt)
+	content, err := g.invoke(ctx, "You are a fashion color expert.", prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -102,9 +102,9 @@ func (g *GeminiProvider) GenerateColorRecommendations(skinTone string) ([]string
 }
 
 // GenerateStyleRecommendations generates style recommendations based on body type
-func (g *GeminiProvider) GenerateStyleRecommendations(bodyType string) ([]string, error) {
+func (g *GeminiProvider) GenerateStyleRecommendations(ctx context.Context, bodyType string) ([]string, error) {
 	prompt := GetStylePrompt(bodyType)
-	content, err := g.invoke("You are a fashion style expert.", prompt)
+	content, err := g.invoke(ctx, "You are a fashion style expert.", prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -52,7 +53,7 @@ type groqResponse struct {
 }
 
 // invoke calls Groq API
-func (g *GroqProvider) invoke(systemPrompt, userPrompt string) (string, error) {
+func (g *GroqProvider) invoke(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
 	messages := []LLMMessage{
 		{
 			Role:    "system",
@@ -80,7 +81,7 @@ func (g *GroqProvider) invoke(systemPrompt, userPrompt string) (string, error) {
 		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", "https://api.groq.com/openai/v1/chat/completions", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", "https://api.groq.com/openai/v1/chat/completions", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
@@ -128,9 +129,9 @@ func (g *GroqProvider) invoke(systemPrompt, userPrompt string) (string, error) {
 }
 
 // GenerateColorRecommendations generates color recommendations based on skin tone
-func (g *GroqProvider) GenerateColorRecommendations(skinTone string) ([]string, error) {
+func (g *GroqProvider) GenerateColorRecommendations(ctx context.Context, skinTone string) ([]string, error) {
 	prompt := GetColorPrompt(skinTone)
-	content, err := g.invoke("You are a fashion color expert.", prompt)
+	content, err := g.invoke(ctx, "You are a fashion color expert.", prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -148,9 +149,9 @@ func (g *GroqProvider) GenerateColorRecommendations(skinTone string) ([]string,
 }
 
 // GenerateStyleRecommendations generates style recommendations based on body type
-func (g *GroqProvider) GenerateStyleRecommendations(bodyType string) ([]string, error) {
+func (g *GroqProvider) GenerateStyleRecommendations(ctx context.Context, bodyType string) ([]string, error) {
 	prompt := GetStylePrompt(bodyType)
-	content, err := g.invoke("You are a fashion style expert.", prompt)
+	content, err := g.invoke(ctx, "You are a fashion style expert.", prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -1,14 +1,15 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
 
 // LLMProvider adalah interface untuk semua LLM provider
 type LLMProvider interface {
-	GenerateColorRecommendations(skinTone string) ([]string, error)
-	GenerateStyleRecommendations(bodyType string) ([]string, error)
+	GenerateColorRecommendations(ctx context.Context, skinTone string) ([]string, error)
+	GenerateStyleRecommendations(ctx context.Context, bodyType string) ([]string, error)
 	GetName() string
 }
 
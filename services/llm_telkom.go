@@ -2,6 +2,7 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -55,7 +56,7 @@ type telkomResponse struct {
 }
 
 // invoke calls Telkom LLM API
-func (t *TelkomLLMProvider) invoke(systemPrompt, userPrompt string) (string, error) {
+func (t *TelkomLLMProvider) invoke(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
 	messages := []LLMMessage{
 		{
 			Role:    "system",
@@ -84,7 +85,7 @@ func (t *TelkomLLMProvider) invoke(systemPrompt, userPrompt string) (string, err
 		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", t.APIURL, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", t.APIURL, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
@@ -133,9 +134,9 @@ func (t *TelkomLLMProvider) invoke(systemPrompt, userPrompt string) (string, err
 }
 
 // GenerateColorRecommendations generates color recommendations based on skin tone
-func (t *TelkomLLMProvider) GenerateColorRecommendations(skinTone string) ([]string, error) {
+func (t *TelkomLLMProvider) GenerateColorRecommendations(ctx context.Context, skinTone string) ([]string, error) {
 	prompt := GetColorPrompt(skinTone)
-	content, err := t.invoke("You are a fashion color expert.", prompt)
+	content, err := t.invoke(ctx, "You are a fashion color expert.", prompt)
 	if err != nil {
 		return nil, err
 	}
@@ -153,9 +154,9 @@ func (t *TelkomLLMProvider) GenerateColorRecommendations(skinTone string) ([]str
 }
 
 // GenerateStyleRecommendations generates style recommendations based on body type
-func (t *TelkomLLMProvider) GenerateStyleRecommendations(bodyType string) ([]string, error) {
+func (t *TelkomLLMProvider) GenerateStyleRecommendations(ctx context.Context, bodyType string) ([]string, error) {
 	prompt := GetStylePrompt(bodyType)
-	content, err := t.invoke("You are a fashion style expert.", prompt)
+	content, err := t.invoke(ctx, "You are a fashion style expert.", prompt)
 	if err != nil {
 		return nil, err
 	}
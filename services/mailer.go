@@ -0,0 +1,123 @@
+package services
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"flicknfit_backend/config"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+)
+
+// ErrMailerNotConfigured is returned when SMTP settings are missing.
+var ErrMailerNotConfigured = errors.New("SMTP is not configured")
+
+// Mailer sends plain-text emails.
+type Mailer interface {
+	Send(to, subject, body string) error
+	SendWithAttachments(to, subject, body string, attachments []Attachment) error
+}
+
+// Attachment is a file attached to an email.
+type Attachment struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// smtpMailer sends emails through the SMTP server from the application config.
+type smtpMailer struct {
+	cfg *config.Config
+}
+
+// NewSMTPMailer creates a Mailer that uses the SMTP_* configuration.
+func NewSMTPMailer(cfg *config.Config) Mailer {
+	return &smtpMailer{cfg: cfg}
+}
+
+// Send sends a plain-text email to a single recipient.
+func (m *smtpMailer) Send(to, subject, body string) error {
+	headers := []string{
+		"From: " + m.cfg.SmtpUser,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	}
+	return m.send(to, []byte(strings.Join(headers, "\r\n")+"\r\n\r\n"+body))
+}
+
+// SendWithAttachments sends a plain-text email with files attached to a single recipient.
+func (m *smtpMailer) SendWithAttachments(to, subject, body string, attachments []Attachment) error {
+	var msg bytes.Buffer
+	writer := multipart.NewWriter(&msg)
+	headers := []string{
+		"From: " + m.cfg.SmtpUser,
+		"To: " + to,
+		"Subject: " + subject,
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/mixed; boundary=" + writer.Boundary(),
+	}
+	msg.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain; charset=UTF-8"}})
+	if err != nil {
+		return err
+	}
+	part.Write([]byte(body))
+	for _, attachment := range attachments {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {attachment.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})},
+		})
+		if err != nil {
+			return err
+		}
+		encoder := base64.NewEncoder(base64.StdEncoding, &lineWrapper{w: part})
+		encoder.Write(attachment.Content)
+		encoder.Close()
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return m.send(to, msg.Bytes())
+}
+
+// send delivers a complete message through the configured SMTP server.
+func (m *smtpMailer) send(to string, msg []byte) error {
+	if m.cfg.SmtpHost == "" || m.cfg.SmtpPort == "" {
+		return ErrMailerNotConfigured
+	}
+	auth := smtp.PlainAuth("", m.cfg.SmtpUser, m.cfg.SmtpPassword, m.cfg.SmtpHost)
+	return smtp.SendMail(m.cfg.SmtpHost+":"+m.cfg.SmtpPort, auth, m.cfg.SmtpUser, []string{to}, msg)
+}
+
+// lineWrapper breaks base64 output into lines of 76 characters, as MIME requires.
+type lineWrapper struct {
+	w       io.Writer
+	written int
+}
+
+func (l *lineWrapper) Write(p []byte) (int, error) {
+	total := len(p)
+	for len(p) > 0 {
+		n := min(76-l.written, len(p))
+		if _, err := l.w.Write(p[:n]); err != nil {
+			return 0, err
+		}
+		p = p[n:]
+		l.written += n
+		if l.written == 76 {
+			if _, err := l.w.Write([]byte("\r\n")); err != nil {
+				return 0, err
+			}
+			l.written = 0
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationService defines business logic for the in-app notification inbox.
+type NotificationService interface {
+	Notify(userIDs []uint64, notification models.Notification) error
+	NotifyOnce(userIDs []uint64, notification models.Notification, window time.Duration) ([]uint64, error)
+	GetUserNotifications(userID uint64, unreadOnly bool, page, limit int) (*dtos.NotificationListResponseDTO, error)
+	MarkAsRead(userID, notificationID uint64) error
+	MarkAllAsRead(userID uint64) error
+}
+
+// notificationService implements NotificationService interface
+type notificationService struct {
+	notificationRepo repositories.NotificationRepository
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(notificationRepo repositories.NotificationRepository) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// Notify delivers a copy of the notification to the inbox of every given user
+func (s *notificationService) Notify(userIDs []uint64, notification models.Notification) error {
+	notifications := make([]models.Notification, 0, len(userIDs))
+	seen := make(map[uint64]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		n := notification
+		n.UserID = userID
+		notifications = append(notifications, n)
+	}
+	if err := s.notificationRepo.CreateNotifications(notifications); err != nil {
+		return errors.NewDatabaseError("create notifications", err)
+	}
+	return nil
+}
+
+// NotifyOnce delivers the notification only to users who have not received one with the same
+// DedupKey within the window, and returns the users that were notified
+func (s *notificationService) NotifyOnce(userIDs []uint64, notification models.Notification, window time.Duration) ([]uint64, error) {
+	notified, err := s.notificationRepo.GetNotifiedUserIDs(userIDs, notification.DedupKey, time.Now().Add(-window))
+	if err != nil {
+		return nil, errors.NewDatabaseError("check notification history", err)
+	}
+	skip := make(map[uint64]bool, len(notified))
+	for _, userID := range notified {
+		skip[userID] = true
+	}
+
+	recipients := make([]uint64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if !skip[userID] {
+			skip[userID] = true
+			recipients = append(recipients, userID)
+		}
+	}
+	if len(recipients) == 0 {
+		return recipients, nil
+	}
+	if err := s.Notify(recipients, notification); err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
+
+// GetUserNotifications retrieves a page of the user's inbox with the unread count
+func (s *notificationService) GetUserNotifications(userID uint64, unreadOnly bool, page, limit int) (*dtos.NotificationListResponseDTO, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	notifications, total, err := s.notificationRepo.GetNotificationsByUserID(userID, unreadOnly, limit, (page-1)*limit)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get notifications", err)
+	}
+	unread, err := s.notificationRepo.CountUnread(userID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("count unread notifications", err)
+	}
+
+	return &dtos.NotificationListResponseDTO{
+		Notifications: dtos.ToNotificationResponseDTOs(notifications),
+		UnreadCount:   unread,
+		Pagination: dtos.PaginationDTO{
+			Page:  page,
+			Limit: limit,
+			Total: int(total),
+		},
+	}, nil
+}
+
+// MarkAsRead marks one of the user's notifications as read
+func (s *notificationService) MarkAsRead(userID, notificationID uint64) error {
+	if err := s.notificationRepo.MarkAsRead(userID, notificationID, time.Now()); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return errors.NewNotFoundError("Notification")
+		}
+		return errors.NewDatabaseError("mark notification as read", err)
+	}
+	return nil
+}
+
+// MarkAllAsRead marks all of the user's notifications as read
+func (s *notificationService) MarkAllAsRead(userID uint64) error {
+	if err := s.notificationRepo.MarkAllAsRead(userID, time.Now()); err != nil {
+		return errors.NewDatabaseError("mark notifications as read", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"sync"
+	"time"
+)
+
+// PeriodicJob runs a function in a separate goroutine immediately on Start and then every interval until
+// Stop is called. The schedulers of background jobs are PeriodicJobs with their own run function.
+type PeriodicJob struct {
+	interval time.Duration
+	run      func()
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewPeriodicJob creates a job that calls run at the given interval.
+func NewPeriodicJob(interval time.Duration, run func()) *PeriodicJob {
+	return &PeriodicJob{
+		interval: interval,
+		run:      run,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start runs the job immediately, then again every interval in a separate goroutine.
+func (j *PeriodicJob) Start() {
+	j.wg.Add(1)
+	go func() {
+		defer j.wg.Done()
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		j.run()
+		for {
+			select {
+			case <-ticker.C:
+				j.run()
+			case <-j.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the job and waits for a running pass to finish.
+func (j *PeriodicJob) Stop() {
+	close(j.stop)
+	j.wg.Wait()
+}
@@ -0,0 +1,634 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/utils"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// productImportColumns adalah urutan kolom CSV untuk impor dan ekspor produk.
+var productImportColumns = []string{
+	"sku", "product_name", "brand", "description", "discount", "categories", "styles", "variations",
+	"price", "stock", "photo_url", "brand_product_url", "instagram_product_url",
+	"tokopedia_product_url", "shopee_product_url", "whatsapp_template",
+}
+
+// productImportListSeparator memisahkan beberapa nilai dalam satu sel CSV (kategori, style, variasi).
+const productImportListSeparator = "|"
+
+// ProductImportService mendefinisikan antarmuka untuk impor dan ekspor produk massal.
+type ProductImportService interface {
+	// ImportProducts membaca file CSV/JSON, memvalidasi setiap baris, lalu melakukan upsert berdasarkan SKU
+	// dalam satu transaksi. Jika dryRun bernilai true atau ada baris yang tidak valid, tidak ada data yang disimpan.
+	// Perubahan stok dicatat ke ledger atas nama actorID (nil bila dijalankan dari command line).
+	ImportProducts(r io.Reader, format string, dryRun bool, actorID *uint64) (*dtos.ProductImportResultDTO, error)
+	// ExportProducts menulis semua item produk dengan format yang sama seperti impor.
+	ExportProducts(w io.Writer, format string) error
+}
+
+// productImportService adalah implementasi dari ProductImportService.
+type productImportService struct {
+	productRepository   repositories.ProductRepository
+	brandRepository     repositories.BrandRepository
+	variationRepository repositories.VariationRepository
+	watchService        WatchService
+	stockService        StockService
+	validator           *utils.Validator
+}
+
+// NewProductImportService membuat dan mengembalikan instance baru dari ProductImportService.
+func NewProductImportService(productRepository repositories.ProductRepository, brandRepository repositories.BrandRepository, variationRepository repositories.VariationRepository, watchService WatchService, stockService StockService) ProductImportService {
+	return &productImportService{
+		productRepository:   productRepository,
+		brandRepository:     brandRepository,
+		variationRepository: variationRepository,
+		watchService:        watchService,
+		stockService:        stockService,
+		validator:           utils.NewValidatorWrapper(),
+	}
+}
+
+// importRow adalah satu baris hasil parsing beserta nomor barisnya.
+type importRow struct {
+	number int
+	dto    dtos.ProductImportRowDTO
+}
+
+// importGroup adalah kumpulan baris yang membentuk satu produk.
+type importGroup struct {
+	brandID uint64
+	rows    []importRow
+	product *models.Product
+}
+
+// ImportProducts mengimplementasikan logika impor produk massal.
+func (s *productImportService) ImportProducts(r io.Reader, format string, dryRun bool, actorID *uint64) (*dtos.ProductImportResultDTO, error) {
+	result := &dtos.ProductImportResultDTO{DryRun: dryRun, Errors: []dtos.ProductImportRowErrorDTO{}}
+	addError := func(row importRow, format string, args ...interface{}) {
+		result.Errors = append(result.Errors, dtos.ProductImportRowErrorDTO{
+			Row:     row.number,
+			SKU:     row.dto.SKU,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	var rows []importRow
+	var parseErrors []dtos.ProductImportRowErrorDTO
+	var err error
+	switch format {
+	case dtos.ProductImportFormatCSV:
+		rows, parseErrors, err = parseProductImportCSV(r)
+	case dtos.ProductImportFormatJSON:
+		rows, err = parseProductImportJSON(r)
+	default:
+		return nil, apperrors.NewValidationError(fmt.Sprintf("unsupported import format %q", format))
+	}
+	if err != nil {
+		return nil, apperrors.NewValidationError(err.Error())
+	}
+	result.TotalRows = len(rows) + len(parseErrors)
+	result.Errors = append(result.Errors, parseErrors...)
+
+	brands, err := s.brandRepository.GetAllBrands()
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get brands", err)
+	}
+	brandsByName := make(map[string]models.Brand, len(brands))
+	for _, brand := range brands {
+		brandsByName[strings.ToLower(strings.TrimSpace(brand.Name))] = brand
+	}
+
+	variations, err := s.variationRepository.GetAllVariations()
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get variations", err)
+	}
+	optionsByVariation := make(map[string]map[string]models.ProductVariationOption, len(variations))
+	for _, variation := range variations {
+		options := make(map[string]models.ProductVariationOption, len(variation.Options))
+		for _, option := range variation.Options {
+			options[strings.ToLower(option.Value)] = option
+		}
+		optionsByVariation[strings.ToLower(variation.Name)] = options
+	}
+
+	// Validasi per baris dan kelompokkan baris per produk (brand + nama produk)
+	groups := make([]*importGroup, 0)
+	groupsByKey := make(map[string]*importGroup)
+	seenSKUs := make(map[string]int, len(rows))
+	skus := make([]string, 0, len(rows))
+	for _, row := range rows {
+		row.dto.SKU = strings.TrimSpace(row.dto.SKU)
+		row.dto.ProductName = strings.TrimSpace(row.dto.ProductName)
+		if err := s.validator.Struct(&row.dto); err != nil {
+			addError(row, "%s", err.Error())
+			continue
+		}
+		if firstRow, dup := seenSKUs[row.dto.SKU]; dup {
+			addError(row, "duplicate SKU, already used on row %d", firstRow)
+			continue
+		}
+		seenSKUs[row.dto.SKU] = row.number
+
+		brand, ok := brandsByName[strings.ToLower(strings.TrimSpace(row.dto.Brand))]
+		if !ok {
+			addError(row, "brand %q not found", row.dto.Brand)
+			continue
+		}
+		valid := true
+		for name, value := range row.dto.Variations {
+			options, ok := optionsByVariation[strings.ToLower(strings.TrimSpace(name))]
+			if !ok {
+				addError(row, "variation %q not found", name)
+				valid = false
+				continue
+			}
+			if _, ok := options[strings.ToLower(strings.TrimSpace(value))]; !ok {
+				addError(row, "option %q not found in variation %q", value, name)
+				valid = false
+			}
+		}
+		if !valid {
+			continue
+		}
+
+		key := fmt.Sprintf("%d|%s", brand.ID, strings.ToLower(row.dto.ProductName))
+		group, ok := groupsByKey[key]
+		if !ok {
+			group = &importGroup{brandID: brand.ID}
+			groupsByKey[key] = group
+			groups = append(groups, group)
+		}
+		group.rows = append(group.rows, row)
+		skus = append(skus, row.dto.SKU)
+	}
+
+	// Tentukan produk tujuan setiap kelompok: produk pemilik SKU, produk dengan nama yang sama, atau produk baru
+	skuOwners, err := s.productRepository.GetProductItemsBySKUs(skus)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("check SKUs", err)
+	}
+	ownerBySKU := make(map[string]uint64, len(skuOwners))
+	ownerItemsBySKU := make(map[string]models.ProductItem, len(skuOwners))
+	for _, owner := range skuOwners {
+		ownerBySKU[owner.SKU] = owner.ProductID
+		ownerItemsBySKU[owner.SKU] = owner
+	}
+	claimedProducts := make(map[uint64]string)
+	for _, group := range groups {
+		var productID uint64
+		for _, row := range group.rows {
+			ownerID, ok := ownerBySKU[row.dto.SKU]
+			if !ok {
+				continue
+			}
+			if productID == 0 {
+				productID = ownerID
+			} else if ownerID != productID {
+				addError(row, "SKU belongs to product %d, not product %d", ownerID, productID)
+			}
+		}
+		if productID == 0 {
+			if existing, err := s.productRepository.GetProductByBrandAndName(group.brandID, group.rows[0].dto.ProductName); err == nil {
+				productID = existing.ID
+			}
+		}
+		if productID == 0 {
+			group.product = &models.Product{}
+			continue
+		}
+		if other, claimed := claimedProducts[productID]; claimed {
+			addError(group.rows[0], "product %d is already imported as %q", productID, other)
+			continue
+		}
+		claimedProducts[productID] = group.rows[0].dto.ProductName
+
+		product, err := s.productRepository.GetProductDetailsByID(productID)
+		if err != nil {
+			addError(group.rows[0], "SKU belongs to product %d which has been deleted", productID)
+			continue
+		}
+		// Item yang pernah di-soft delete dipulihkan jika SKU-nya ada di file
+		activeSKUs := make(map[string]bool, len(product.ProductItems))
+		for _, item := range product.ProductItems {
+			activeSKUs[item.SKU] = true
+		}
+		for _, row := range group.rows {
+			owner, ok := ownerItemsBySKU[row.dto.SKU]
+			if ok && owner.ProductID == product.ID && !activeSKUs[owner.SKU] {
+				owner.DeletedAt = gorm.DeletedAt{}
+				product.ProductItems = append(product.ProductItems, owner)
+			}
+		}
+		group.product = product
+	}
+
+	// Stok dan harga item yang sudah ada dicatat sebelum digabung untuk notifikasi stok kembali dan penurunan harga
+	var before map[uint64]ItemSnapshot
+	if !dryRun && len(result.Errors) == 0 {
+		existing := make([]*models.Product, 0, len(groups))
+		for _, group := range groups {
+			if group.product != nil && group.product.ID != 0 {
+				existing = append(existing, group.product)
+			}
+		}
+		if before, err = s.watchService.SnapshotItems(existing); err != nil {
+			utils.GetLogger().Warn("Failed to snapshot imported product items: ", err)
+		}
+	}
+
+	products := make([]*models.Product, 0, len(groups))
+	for _, group := range groups {
+		if group.product == nil {
+			continue
+		}
+		created, updated, ok := s.mergeImportGroup(group, optionsByVariation, addError)
+		if !ok {
+			continue
+		}
+		if group.product.ID == 0 {
+			result.ProductsCreated++
+		} else {
+			result.ProductsUpdated++
+		}
+		result.ItemsCreated += created
+		result.ItemsUpdated += updated
+		products = append(products, group.product)
+	}
+
+	if len(result.Errors) > 0 {
+		sort.SliceStable(result.Errors, func(i, j int) bool { return result.Errors[i].Row < result.Errors[j].Row })
+		return result, nil
+	}
+	if dryRun {
+		return result, nil
+	}
+	movements, err := s.productRepository.SaveProductsWithDetails(products, actorID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("import products", err)
+	}
+	s.stockService.NotifyStockMovements(movements)
+	if err := s.watchService.NotifyItemChanges(before, products); err != nil {
+		utils.GetLogger().Warn("Failed to send watch alerts: ", err)
+	}
+	return result, nil
+}
+
+// mergeImportGroup menerapkan baris impor ke produk tujuan. Item yang sudah ada diperbarui berdasarkan SKU,
+// item baru ditambahkan, dan item lain milik produk dibiarkan apa adanya.
+func (s *productImportService) mergeImportGroup(group *importGroup, optionsByVariation map[string]map[string]models.ProductVariationOption, addError func(row importRow, format string, args ...interface{})) (created, updated int, ok bool) {
+	product := group.product
+	first := group.rows[0].dto
+
+	itemIndexBySKU := make(map[string]int, len(product.ProductItems))
+	for i := range product.ProductItems {
+		item := &product.ProductItems[i]
+		itemIndexBySKU[item.SKU] = i
+		// Bangun ulang konfigurasi tanpa relasi yang sudah dimuat
+		configurations := make([]models.ProductConfiguration, 0, len(item.Configurations))
+		for _, conf := range item.Configurations {
+			configurations = append(configurations, models.ProductConfiguration{ProductAttributeValueID: conf.ProductAttributeValueID})
+		}
+		item.Configurations = configurations
+		item.Product = models.Product{}
+	}
+
+	ok = true
+	for _, row := range group.rows {
+		configurations := make([]models.ProductConfiguration, 0, len(row.dto.Variations))
+		for name, value := range row.dto.Variations {
+			option := optionsByVariation[strings.ToLower(strings.TrimSpace(name))][strings.ToLower(strings.TrimSpace(value))]
+			configurations = append(configurations, models.ProductConfiguration{ProductAttributeValueID: option.ID})
+		}
+
+		index, exists := itemIndexBySKU[row.dto.SKU]
+		if !exists {
+			product.ProductItems = append(product.ProductItems, models.ProductItem{SKU: row.dto.SKU})
+			index = len(product.ProductItems) - 1
+			itemIndexBySKU[row.dto.SKU] = index
+			created++
+		} else {
+			updated++
+		}
+		item := &product.ProductItems[index]
+		item.Price = row.dto.Price
+		item.Stock = row.dto.Stock
+		item.PhotoURL = row.dto.PhotoURL
+		item.Configurations = configurations
+	}
+
+	// Kombinasi opsi variasi harus unik antar item produk (termasuk item yang tidak ada di file)
+	rowBySKU := make(map[string]importRow, len(group.rows))
+	for _, row := range group.rows {
+		rowBySKU[row.dto.SKU] = row
+	}
+	seenCombinations := make(map[string]string, len(product.ProductItems))
+	for _, item := range product.ProductItems {
+		optionIDs := make([]uint64, 0, len(item.Configurations))
+		for _, conf := range item.Configurations {
+			optionIDs = append(optionIDs, conf.ProductAttributeValueID)
+		}
+		key := variationCombinationKey(optionIDs)
+		if otherSKU, dup := seenCombinations[key]; dup {
+			row, inFile := rowBySKU[item.SKU]
+			if !inFile {
+				row = rowBySKU[otherSKU]
+			}
+			addError(row, "items %q and %q have the same variation combination", otherSKU, item.SKU)
+			ok = false
+			continue
+		}
+		seenCombinations[key] = item.SKU
+	}
+
+	if product.ID == 0 {
+		// Produk baru hasil impor dibuat sebagai draft agar dapat ditinjau sebelum dipublikasikan
+		product.Status = constants.ProductStatusDraft
+	}
+	product.BrandID = group.brandID
+	product.Name = first.ProductName
+	product.Description = first.Description
+	product.Discount = first.Discount
+	product.BrandProductURL = first.BrandProductURL
+	product.WhatsAppTemplate = first.WhatsAppTemplate
+	product.InstagramProductURL = first.InstagramProductURL
+	product.TokopediaProductURL = first.TokopediaProductURL
+	product.ShopeeProductURL = first.ShopeeProductURL
+	// Kategori dan style hanya diganti jika diisi pada file
+	if categories := uniqueTrimmed(first.Categories); len(categories) > 0 {
+		product.ProductCategories = make([]models.ProductCategory, 0, len(categories))
+		for _, category := range categories {
+			product.ProductCategories = append(product.ProductCategories, models.ProductCategory{Category: category})
+		}
+	} else {
+		product.ProductCategories = cloneProductCategories(product.ProductCategories)
+	}
+	if styles := uniqueTrimmed(first.Styles); len(styles) > 0 {
+		product.ProductStyles = make([]models.ProductStyle, 0, len(styles))
+		for _, style := range styles {
+			product.ProductStyles = append(product.ProductStyles, models.ProductStyle{Style: style})
+		}
+	} else {
+		product.ProductStyles = cloneProductStyles(product.ProductStyles)
+	}
+	product.Brand = models.Brand{}
+	product.Reviews = nil
+	return created, updated, ok
+}
+
+// cloneProductCategories menyalin kategori tanpa ID agar dapat dibuat ulang saat produk disimpan.
+func cloneProductCategories(categories []models.ProductCategory) []models.ProductCategory {
+	result := make([]models.ProductCategory, 0, len(categories))
+	for _, category := range categories {
+		result = append(result, models.ProductCategory{Category: category.Category})
+	}
+	return result
+}
+
+// cloneProductStyles menyalin style tanpa ID agar dapat dibuat ulang saat produk disimpan.
+func cloneProductStyles(styles []models.ProductStyle) []models.ProductStyle {
+	result := make([]models.ProductStyle, 0, len(styles))
+	for _, style := range styles {
+		result = append(result, models.ProductStyle{Style: style.Style})
+	}
+	return result
+}
+
+// ExportProducts mengimplementasikan logika ekspor produk massal.
+func (s *productImportService) ExportProducts(w io.Writer, format string) error {
+	if format != dtos.ProductImportFormatCSV && format != dtos.ProductImportFormatJSON {
+		return apperrors.NewValidationError(fmt.Sprintf("unsupported export format %q", format))
+	}
+
+	products, err := s.productRepository.GetAllProductDetails()
+	if err != nil {
+		return apperrors.NewDatabaseError("get products", err)
+	}
+
+	rows := make([]dtos.ProductImportRowDTO, 0)
+	for _, product := range products {
+		categories := make([]string, 0, len(product.ProductCategories))
+		for _, category := range product.ProductCategories {
+			categories = append(categories, category.Category)
+		}
+		styles := make([]string, 0, len(product.ProductStyles))
+		for _, style := range product.ProductStyles {
+			styles = append(styles, style.Style)
+		}
+		for _, item := range product.ProductItems {
+			variations := make(map[string]string, len(item.Configurations))
+			for _, conf := range item.Configurations {
+				option := conf.ProductVariationOption
+				variations[option.ProductVariation.Name] = option.Value
+			}
+			rows = append(rows, dtos.ProductImportRowDTO{
+				SKU:                 item.SKU,
+				ProductName:         product.Name,
+				Brand:               product.Brand.Name,
+				Description:         product.Description,
+				Discount:            product.Discount,
+				Categories:          categories,
+				Styles:              styles,
+				Variations:          variations,
+				Price:               item.Price,
+				Stock:               item.Stock,
+				PhotoURL:            item.PhotoURL,
+				BrandProductURL:     product.BrandProductURL,
+				InstagramProductURL: product.InstagramProductURL,
+				TokopediaProductURL: product.TokopediaProductURL,
+				ShopeeProductURL:    product.ShopeeProductURL,
+				WhatsAppTemplate:    product.WhatsAppTemplate,
+			})
+		}
+	}
+
+	if format == dtos.ProductImportFormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(rows)
+	}
+	return writeProductImportCSV(w, rows)
+}
+
+// parseProductImportJSON membaca array JSON berisi baris impor.
+func parseProductImportJSON(r io.Reader) ([]importRow, error) {
+	var dtoRows []dtos.ProductImportRowDTO
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&dtoRows); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	rows := make([]importRow, len(dtoRows))
+	for i, dto := range dtoRows {
+		rows[i] = importRow{number: i + 1, dto: dto}
+	}
+	return rows, nil
+}
+
+// parseProductImportCSV membaca file CSV dengan baris header. Kesalahan konversi angka atau format variasi
+// dilaporkan per baris, sedangkan header yang tidak valid menggagalkan seluruh file.
+func parseProductImportCSV(r io.Reader) ([]importRow, []dtos.ProductImportRowErrorDTO, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	known := make(map[string]bool, len(productImportColumns))
+	for _, column := range productImportColumns {
+		known[column] = true
+	}
+	columnIndex := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		if !known[column] {
+			return nil, nil, fmt.Errorf("unknown CSV column %q", column)
+		}
+		columnIndex[column] = i
+	}
+	for _, required := range []string{"sku", "product_name", "brand"} {
+		if _, ok := columnIndex[required]; !ok {
+			return nil, nil, fmt.Errorf("missing CSV column %q", required)
+		}
+	}
+
+	rows := make([]importRow, 0)
+	rowErrors := make([]dtos.ProductImportRowErrorDTO, 0)
+	for number := 1; ; number++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid CSV on row %d: %w", number, err)
+		}
+		get := func(column string) string {
+			if i, ok := columnIndex[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		dto := dtos.ProductImportRowDTO{
+			SKU:                 get("sku"),
+			ProductName:         get("product_name"),
+			Brand:               get("brand"),
+			Description:         get("description"),
+			Categories:          splitImportList(get("categories")),
+			Styles:              splitImportList(get("styles")),
+			PhotoURL:            get("photo_url"),
+			BrandProductURL:     get("brand_product_url"),
+			InstagramProductURL: get("instagram_product_url"),
+			TokopediaProductURL: get("tokopedia_product_url"),
+			ShopeeProductURL:    get("shopee_product_url"),
+			WhatsAppTemplate:    get("whatsapp_template"),
+		}
+		var rowErr error
+		if v := get("discount"); v != "" {
+			if dto.Discount, rowErr = strconv.ParseFloat(v, 64); rowErr != nil {
+				rowErr = fmt.Errorf("invalid discount %q", v)
+			}
+		}
+		if v := get("price"); v != "" && rowErr == nil {
+			if dto.Price, rowErr = strconv.Atoi(v); rowErr != nil {
+				rowErr = fmt.Errorf("invalid price %q", v)
+			}
+		}
+		if v := get("stock"); v != "" && rowErr == nil {
+			if dto.Stock, rowErr = strconv.Atoi(v); rowErr != nil {
+				rowErr = fmt.Errorf("invalid stock %q", v)
+			}
+		}
+		if rowErr == nil {
+			dto.Variations, rowErr = parseImportVariations(get("variations"))
+		}
+		if rowErr != nil {
+			rowErrors = append(rowErrors, dtos.ProductImportRowErrorDTO{Row: number, SKU: dto.SKU, Message: rowErr.Error()})
+			continue
+		}
+		rows = append(rows, importRow{number: number, dto: dto})
+	}
+	return rows, rowErrors, nil
+}
+
+// writeProductImportCSV menulis baris ekspor ke CSV dengan kolom yang sama seperti impor.
+func writeProductImportCSV(w io.Writer, rows []dtos.ProductImportRowDTO) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(productImportColumns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		names := make([]string, 0, len(row.Variations))
+		for name := range row.Variations {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		variations := make([]string, len(names))
+		for i, name := range names {
+			variations[i] = name + "=" + row.Variations[name]
+		}
+
+		record := []string{
+			row.SKU,
+			row.ProductName,
+			row.Brand,
+			row.Description,
+			strconv.FormatFloat(row.Discount, 'f', -1, 64),
+			strings.Join(row.Categories, productImportListSeparator),
+			strings.Join(row.Styles, productImportListSeparator),
+			strings.Join(variations, productImportListSeparator),
+			strconv.Itoa(row.Price),
+			strconv.Itoa(row.Stock),
+			row.PhotoURL,
+			row.BrandProductURL,
+			row.InstagramProductURL,
+			row.TokopediaProductURL,
+			row.ShopeeProductURL,
+			row.WhatsAppTemplate,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// splitImportList memecah sel CSV seperti "Casual|Street" menjadi daftar nilai.
+func splitImportList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return uniqueTrimmed(strings.Split(value, productImportListSeparator))
+}
+
+// parseImportVariations memecah sel CSV seperti "Ukuran=M|Warna=Hitam" menjadi peta variasi ke opsi.
+func parseImportVariations(value string) (map[string]string, error) {
+	variations := make(map[string]string)
+	for _, part := range splitImportList(value) {
+		name, option, found := strings.Cut(part, "=")
+		name, option = strings.TrimSpace(name), strings.TrimSpace(option)
+		if !found || name == "" || option == "" {
+			return nil, fmt.Errorf("invalid variation %q, expected Name=Value", part)
+		}
+		for existing := range variations {
+			if strings.EqualFold(existing, name) {
+				return nil, fmt.Errorf("variation %q is listed more than once", name)
+			}
+		}
+		variations[name] = option
+	}
+	return variations, nil
+}
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/utils"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ProductMediaService defines business logic for product and item galleries.
+type ProductMediaService interface {
+	GetProductMedia(productID uint64) ([]models.ProductMedia, error)
+	UploadMedia(productID uint64, dto *dtos.ProductMediaUploadRequestDTO, filename string, data []byte) (*models.ProductMedia, error)
+	UpdateMedia(mediaID uint64, dto *dtos.ProductMediaUpdateRequestDTO) (*models.ProductMedia, error)
+	DeleteMedia(mediaID uint64) error
+	ReorderMedia(productID uint64, dto *dtos.ProductMediaOrderRequestDTO) ([]models.ProductMedia, error)
+}
+
+// productMediaService implements ProductMediaService interface
+type productMediaService struct {
+	mediaRepo      repositories.ProductMediaRepository
+	productRepo    repositories.ProductRepository
+	storageService SupabaseStorageService
+}
+
+// NewProductMediaService creates a new product media service.
+// storageService may be nil when Supabase is not configured; uploads are then refused.
+func NewProductMediaService(mediaRepo repositories.ProductMediaRepository, productRepo repositories.ProductRepository, storageService SupabaseStorageService) ProductMediaService {
+	return &productMediaService{
+		mediaRepo:      mediaRepo,
+		productRepo:    productRepo,
+		storageService: storageService,
+	}
+}
+
+// GetProductMedia retrieves a product's gallery, including item media, in display order
+func (s *productMediaService) GetProductMedia(productID uint64) ([]models.ProductMedia, error) {
+	if _, err := s.productRepo.GetProductByID(productID); err != nil {
+		return nil, errors.NewNotFoundError("Product")
+	}
+	media, err := s.mediaRepo.GetMediaByProductID(productID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get product media", err)
+	}
+	return media, nil
+}
+
+// UploadMedia stores an image or video in the product gallery. Images get a generated thumbnail,
+// new media is appended to the end of the gallery, and the first media of a product or item becomes primary.
+func (s *productMediaService) UploadMedia(productID uint64, dto *dtos.ProductMediaUploadRequestDTO, filename string, data []byte) (*models.ProductMedia, error) {
+	if s.storageService == nil {
+		return nil, errors.New(errors.ErrorTypeExternal, http.StatusServiceUnavailable, "Media storage is not configured")
+	}
+
+	product, err := s.productRepo.GetProductDetailsByID(productID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Product")
+	}
+	if dto.ProductItemID != nil && !productHasItem(product, *dto.ProductItemID) {
+		return nil, errors.NewValidationError(fmt.Sprintf("product item %d does not belong to this product", *dto.ProductItemID))
+	}
+
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
+	mediaType, err := detectMediaType(ext, data)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.mediaRepo.GetMediaByProductID(productID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get product media", err)
+	}
+	media := &models.ProductMedia{
+		ProductID:     productID,
+		ProductItemID: dto.ProductItemID,
+		MediaType:     mediaType,
+		AltText:       strings.TrimSpace(dto.AltText),
+		SortOrder:     len(existing) + 1,
+		IsPrimary:     dto.IsPrimary || !hasMediaInScope(existing, dto.ProductItemID),
+	}
+
+	// Generate the thumbnail first so unreadable images are rejected before anything is uploaded
+	var thumbnail []byte
+	if mediaType == constants.ProductMediaTypeImage && ext != "webp" {
+		if thumbnail, err = utils.GenerateThumbnail(data, constants.ProductThumbnailWidth); err != nil {
+			return nil, errors.NewValidationError("file is not a valid image")
+		}
+	}
+
+	name := uuid.New().String()
+	media.StoragePath = fmt.Sprintf("products/%d/%s.%s", productID, name, ext)
+	if media.URL, err = s.storageService.UploadPublicFile(media.StoragePath, data, http.DetectContentType(data)); err != nil {
+		return nil, errors.NewWithInternalError(errors.ErrorTypeExternal, http.StatusBadGateway, "Failed to upload media", err)
+	}
+	switch {
+	case thumbnail != nil:
+		media.ThumbnailPath = fmt.Sprintf("products/%d/thumbnails/%s.jpg", productID, name)
+		if media.ThumbnailURL, err = s.storageService.UploadPublicFile(media.ThumbnailPath, thumbnail, "image/jpeg"); err != nil {
+			s.removeFiles(media)
+			return nil, errors.NewWithInternalError(errors.ErrorTypeExternal, http.StatusBadGateway, "Failed to upload thumbnail", err)
+		}
+	case mediaType == constants.ProductMediaTypeImage:
+		// WebP cannot be decoded by the standard library, so the original doubles as its thumbnail
+		media.ThumbnailURL = media.URL
+	}
+
+	if err := s.mediaRepo.CreateMedia(media); err != nil {
+		s.removeFiles(media)
+		return nil, errors.NewDatabaseError("create product media", err)
+	}
+	return media, nil
+}
+
+// UpdateMedia updates a media's alt text or makes it the primary media of its product or item
+func (s *productMediaService) UpdateMedia(mediaID uint64, dto *dtos.ProductMediaUpdateRequestDTO) (*models.ProductMedia, error) {
+	media, err := s.mediaRepo.GetMediaByID(mediaID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Media")
+	}
+	if dto.AltText != nil {
+		media.AltText = strings.TrimSpace(*dto.AltText)
+	}
+	if dto.IsPrimary != nil {
+		media.IsPrimary = *dto.IsPrimary
+	}
+	if err := s.mediaRepo.UpdateMedia(media); err != nil {
+		return nil, errors.NewDatabaseError("update product media", err)
+	}
+	return media, nil
+}
+
+// DeleteMedia removes a media record and its files. When the primary media is deleted,
+// the next media of the same product or item becomes primary.
+func (s *productMediaService) DeleteMedia(mediaID uint64) error {
+	media, err := s.mediaRepo.GetMediaByID(mediaID)
+	if err != nil {
+		return errors.NewNotFoundError("Media")
+	}
+	if err := s.mediaRepo.DeleteMedia(mediaID); err != nil {
+		return errors.NewDatabaseError("delete product media", err)
+	}
+	s.removeFiles(media)
+
+	if media.IsPrimary {
+		remaining, err := s.mediaRepo.GetMediaByProductID(media.ProductID)
+		if err != nil {
+			return errors.NewDatabaseError("get product media", err)
+		}
+		for i := range remaining {
+			if sameMediaScope(remaining[i].ProductItemID, media.ProductItemID) {
+				remaining[i].IsPrimary = true
+				if err := s.mediaRepo.UpdateMedia(&remaining[i]); err != nil {
+					return errors.NewDatabaseError("update product media", err)
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// ReorderMedia sets the display order of a product's gallery; every media must be listed exactly once
+func (s *productMediaService) ReorderMedia(productID uint64, dto *dtos.ProductMediaOrderRequestDTO) ([]models.ProductMedia, error) {
+	media, err := s.GetProductMedia(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dto.MediaIDs) != len(media) {
+		return nil, errors.NewValidationError("media_ids must list every media of the product exactly once")
+	}
+	belongs := make(map[uint64]bool, len(media))
+	for _, m := range media {
+		belongs[m.ID] = true
+	}
+	for _, id := range dto.MediaIDs {
+		if !belongs[id] {
+			return nil, errors.NewValidationError(fmt.Sprintf("media %d does not belong to this product or is listed twice", id))
+		}
+		delete(belongs, id)
+	}
+
+	if err := s.mediaRepo.UpdateMediaOrder(productID, dto.MediaIDs); err != nil {
+		return nil, errors.NewDatabaseError("reorder product media", err)
+	}
+	return s.GetProductMedia(productID)
+}
+
+// removeFiles deletes a media's files from storage; failures are only logged
+func (s *productMediaService) removeFiles(media *models.ProductMedia) {
+	if s.storageService == nil {
+		return
+	}
+	for _, path := range []string{media.StoragePath, media.ThumbnailPath} {
+		if path == "" {
+			continue
+		}
+		if err := s.storageService.DeleteFile(path); err != nil {
+			utils.GetLogger().Warn("Failed to delete product media file: ", err)
+		}
+	}
+}
+
+// detectMediaType validates the file extension and size and returns image or video
+func detectMediaType(ext string, data []byte) (string, error) {
+	switch {
+	case containsExt(constants.AllowedImageExts, ext):
+		if len(data) > constants.MaxFileSize {
+			return "", errors.NewValidationError(fmt.Sprintf("images must not exceed %d MB", constants.MaxFileSize>>20))
+		}
+		return constants.ProductMediaTypeImage, nil
+	case containsExt(constants.AllowedVideoExts, ext):
+		if len(data) > constants.MaxProductVideoSize {
+			return "", errors.NewValidationError(fmt.Sprintf("videos must not exceed %d MB", constants.MaxProductVideoSize>>20))
+		}
+		return constants.ProductMediaTypeVideo, nil
+	default:
+		return "", errors.NewValidationError(fmt.Sprintf("unsupported file type; allowed: %s,%s", constants.AllowedImageExts, constants.AllowedVideoExts))
+	}
+}
+
+// containsExt reports whether ext is in a comma-separated extension list
+func containsExt(list, ext string) bool {
+	if ext == "" {
+		return false
+	}
+	for _, allowed := range strings.Split(list, ",") {
+		if allowed == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// productHasItem reports whether the item belongs to the product
+func productHasItem(product *models.Product, itemID uint64) bool {
+	for _, item := range product.ProductItems {
+		if item.ID == itemID {
+			return true
+		}
+	}
+	return false
+}
+
+// hasMediaInScope reports whether any media belongs to the same product or item
+func hasMediaInScope(media []models.ProductMedia, productItemID *uint64) bool {
+	for _, m := range media {
+		if sameMediaScope(m.ProductItemID, productItemID) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameMediaScope reports whether two media item IDs refer to the same gallery (nil = product gallery)
+func sameMediaScope(a, b *uint64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"flicknfit_backend/utils"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NewProductPublishScheduler membuat job yang secara berkala mempublikasikan produk berstatus scheduled yang
+// jadwalnya sudah lewat.
+func NewProductPublishScheduler(productService ProductService, interval time.Duration) *PeriodicJob {
+	return NewPeriodicJob(interval, func() {
+		published, err := productService.PublishScheduledProducts()
+		if err != nil {
+			utils.GetLogger().WithError(err).Error("Failed to publish scheduled products")
+			return
+		}
+		if published > 0 {
+			utils.GetLogger().WithFields(logrus.Fields{"count": published}).Info("Published scheduled products")
+		}
+	})
+}
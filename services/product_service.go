@@ -2,9 +2,19 @@ package services
 
 import (
 	"errors"
+	"flicknfit_backend/constants"
 	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
 	"flicknfit_backend/models"
 	"flicknfit_backend/repositories"
+	"flicknfit_backend/utils"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 // ProductService mendefinisikan antarmuka untuk logika bisnis terkait produk.
@@ -14,29 +24,42 @@ type ProductService interface {
 	AdminUpdateProduct(id uint64, dto *dtos.AdminProductUpdateRequestDTO) (*models.Product, error)
 	AdminDeleteProduct(id uint64) error
 	AdminGetProductByID(id uint64) (*models.Product, error)
-	AdminGetAllProducts() ([]*models.Product, error)
+	AdminGetAllProducts(status string) ([]*models.Product, error)
+	AdminSetProductStatus(id uint64, dto *dtos.AdminProductStatusRequestDTO) (*models.Product, error)
+	PublishScheduledProducts() (int64, error)
 	AdminCreateReview(productID uint64, dto *dtos.AdminReviewCreateRequestDTO) (*models.Review, error)
 	AdminGetAllReviewsByProductID(productID uint64) ([]*models.Review, error)
 	AdminUpdateReview(reviewID uint64, dto *dtos.AdminReviewUpdateRequestDTO) (*models.Review, error)
 	AdminDeleteReview(reviewID uint64) error
+	AdminCreateProductFull(dto *dtos.AdminProductFullRequestDTO, actorID uint64) (*models.Product, error)
+	AdminUpdateProductFull(id uint64, dto *dtos.AdminProductFullRequestDTO, actorID uint64) (*models.Product, error)
+	AdminGetProductDetailsByID(id uint64) (*models.Product, error)
 
 	// Metode untuk user biasa
 	GetProductPublicByID(id uint64) (*models.Product, error)
 	GetAllProductsPublic() ([]*models.Product, error)
+	GetProductsPublicByIDs(ids []uint64) ([]*models.Product, error)
 	CreateReviewPublic(dto *dtos.ReviewCreateDTO) (*models.Review, error)
 	SearchProductsPublic(query string) ([]*models.Product, error)
 	GetAllProductsPublicWithFilter(filter *dtos.ProductFilterRequestDTO) ([]*models.Product, error)
+	GetProductPriceChart(productID uint64, days int) (*dtos.ProductPriceChartDTO, error)
 }
 
 // productService adalah implementasi dari ProductService.
 type productService struct {
-	productRepository repositories.ProductRepository
+	productRepository  repositories.ProductRepository
+	campaignRepository repositories.CampaignRepository
+	watchService       WatchService
+	stockService       StockService
 }
 
 // NewProductService membuat dan mengembalikan instance baru dari ProductService.
-func NewProductService(productRepository repositories.ProductRepository) ProductService {
+func NewProductService(productRepository repositories.ProductRepository, campaignRepository repositories.CampaignRepository, watchService WatchService, stockService StockService) ProductService {
 	return &productService{
-		productRepository: productRepository,
+		productRepository:  productRepository,
+		campaignRepository: campaignRepository,
+		watchService:       watchService,
+		stockService:       stockService,
 	}
 }
 
@@ -48,6 +71,13 @@ func (s *productService) AdminCreateProduct(dto *dtos.AdminProductCreateRequestD
 		Description: dto.Description,
 		Discount:    dto.Discount,
 	}
+	status := dto.Status
+	if status == "" {
+		status = constants.ProductStatusDraft
+	}
+	if err := applyProductStatus(product, status, dto.PublishAt, time.Now()); err != nil {
+		return nil, err
+	}
 	if err := s.productRepository.CreateProduct(product); err != nil {
 		return nil, err
 	}
@@ -70,19 +100,21 @@ func (s *productService) AdminUpdateProduct(id uint64, dto *dtos.AdminProductUpd
 	if dto.Description != "" {
 		product.Description = dto.Description
 	}
-	if dto.Discount != 0 {
+	// Perubahan diskon dapat menurunkan harga efektif, sehingga item dicatat dulu untuk notifikasi penurunan harga
+	var before map[uint64]ItemSnapshot
+	if dto.Discount != 0 && dto.Discount != product.Discount {
+		before = s.snapshotProductItems(id)
 		product.Discount = dto.Discount
 	}
-	if dto.Rating != 0 {
-		product.Rating = dto.Rating
-	}
-	if dto.Reviewer != 0 {
-		product.Reviewer = dto.Reviewer
-	}
 
 	if err := s.productRepository.UpdateProduct(product); err != nil {
 		return nil, errors.New("failed to update product")
 	}
+	if before != nil {
+		if updated, err := s.productRepository.GetProductDetailsByID(id); err == nil {
+			s.notifyItemChanges(before, updated)
+		}
+	}
 	return product, nil
 }
 
@@ -96,19 +128,98 @@ func (s *productService) AdminGetProductByID(id uint64) (*models.Product, error)
 	return s.productRepository.GetProductByID(id)
 }
 
-// AdminGetAllProducts mengimplementasikan logika untuk mendapatkan semua produk.
-func (s *productService) AdminGetAllProducts() ([]*models.Product, error) {
-	return s.productRepository.GetAllProducts()
+// AdminGetAllProducts mengimplementasikan logika untuk mendapatkan semua produk, opsional difilter berdasarkan status.
+func (s *productService) AdminGetAllProducts(status string) ([]*models.Product, error) {
+	return s.productRepository.GetAllProducts(status)
+}
+
+// AdminSetProductStatus mengubah status produk: publish, unpublish, draft, atau menjadwalkan publikasi.
+func (s *productService) AdminSetProductStatus(id uint64, dto *dtos.AdminProductStatusRequestDTO) (*models.Product, error) {
+	product, err := s.productRepository.GetProductByID(id)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Product")
+	}
+	if err := applyProductStatus(product, dto.Status, dto.PublishAt, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.productRepository.UpdateProductStatus(product); err != nil {
+		return nil, apperrors.NewDatabaseError("update product status", err)
+	}
+	return product, nil
+}
+
+// PublishScheduledProducts mengaktifkan produk scheduled yang jadwal publikasinya sudah lewat.
+// Dipanggil secara berkala oleh ProductPublishScheduler.
+func (s *productService) PublishScheduledProducts() (int64, error) {
+	return s.productRepository.PublishDueProducts(time.Now())
+}
+
+// applyProductStatus menerapkan perubahan status ke produk beserta stempel waktu publish/unpublish-nya.
+func applyProductStatus(product *models.Product, status string, publishAt *time.Time, now time.Time) error {
+	status = strings.ToLower(status)
+	wasPublished := product.ID != 0 && product.IsPublished(now)
+
+	switch status {
+	case constants.ProductStatusScheduled:
+		if publishAt == nil || !publishAt.After(now) {
+			return apperrors.NewValidationError("publish_at must be in the future for scheduled products")
+		}
+		if wasPublished {
+			product.UnpublishedAt = &now
+		}
+		scheduled := *publishAt
+		product.PublishAt = &scheduled
+	case constants.ProductStatusActive:
+		if !wasPublished {
+			product.PublishedAt = &now
+		}
+		product.PublishAt = nil
+	case constants.ProductStatusInactive, constants.ProductStatusDraft:
+		if wasPublished {
+			product.UnpublishedAt = &now
+		}
+		product.PublishAt = nil
+	default:
+		return apperrors.NewValidationError(fmt.Sprintf("invalid product status %q", status))
+	}
+	product.Status = status
+	return nil
 }
 
 // GetProductPublicByID mengimplementasikan logika untuk mendapatkan produk publik.
 func (s *productService) GetProductPublicByID(id uint64) (*models.Product, error) {
-	return s.productRepository.GetProductPublicByID(id)
+	product, err := s.productRepository.GetProductPublicByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyCampaignPrices([]*models.Product{product}); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// GetProductsPublicByIDs mengambil produk publik berdasarkan daftar ID dengan harga kampanye yang berlaku.
+func (s *productService) GetProductsPublicByIDs(ids []uint64) ([]*models.Product, error) {
+	products, err := s.productRepository.GetProductsPublicByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyCampaignPrices(products); err != nil {
+		return nil, err
+	}
+	return products, nil
 }
 
 // GetAllProductsPublic mengimplementasikan logika untuk mendapatkan semua produk publik.
 func (s *productService) GetAllProductsPublic() ([]*models.Product, error) {
-	return s.productRepository.GetAllProductsPublic()
+	products, err := s.productRepository.GetAllProductsPublic()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyCampaignPrices(products); err != nil {
+		return nil, err
+	}
+	return products, nil
 }
 
 // AdminCreateReview mengimplementasikan logika untuk membuat review baru.
@@ -175,10 +286,337 @@ func (s *productService) SearchProductsPublic(query string) ([]*models.Product,
 	if err != nil {
 		return nil, err
 	}
+	if err := s.applyCampaignPrices(products); err != nil {
+		return nil, err
+	}
 	return products, nil
 }
 
 // GetAllProductsPublicWithFilter mengimplementasikan logika bisnis untuk mendapatkan semua produk publik dengan filter.
 func (s *productService) GetAllProductsPublicWithFilter(filter *dtos.ProductFilterRequestDTO) ([]*models.Product, error) {
-	return s.productRepository.GetAllProductsPublicWithFilter(filter)
+	products, err := s.productRepository.GetAllProductsPublicWithFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyCampaignPrices(products); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// GetProductPriceChart mengambil riwayat harga item-item produk publik untuk grafik harga.
+// days dibatasi ke PriceChartMaxDays; nilai <= 0 memakai PriceChartDefaultDays.
+func (s *productService) GetProductPriceChart(productID uint64, days int) (*dtos.ProductPriceChartDTO, error) {
+	if days <= 0 {
+		days = constants.PriceChartDefaultDays
+	}
+	if days > constants.PriceChartMaxDays {
+		days = constants.PriceChartMaxDays
+	}
+
+	product, err := s.productRepository.GetProductPublicByID(productID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Product")
+	}
+	if err := s.applyCampaignPrices([]*models.Product{product}); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	from := now.AddDate(0, 0, -days)
+	history, err := s.productRepository.GetPriceHistory(productID, from)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get price history", err)
+	}
+
+	pointsByItem := make(map[uint64][]dtos.PricePointDTO)
+	for _, h := range history {
+		pointsByItem[h.ProductItemID] = append(pointsByItem[h.ProductItemID], dtos.PricePointDTO{
+			Price:     h.NewPrice,
+			ChangedAt: h.ChangedAt,
+		})
+	}
+
+	chart := &dtos.ProductPriceChartDTO{
+		ProductID: product.ID,
+		From:      from,
+		To:        now,
+		Items:     make([]dtos.ItemPriceChartDTO, 0, len(product.ProductItems)),
+	}
+	for _, item := range product.ProductItems {
+		points := pointsByItem[item.ID]
+		if points == nil {
+			points = []dtos.PricePointDTO{}
+		}
+		effective := item.Price
+		if item.EffectivePrice != nil {
+			effective = *item.EffectivePrice
+		}
+		chart.Items = append(chart.Items, dtos.ItemPriceChartDTO{
+			ProductItemID:  item.ID,
+			SKU:            item.SKU,
+			CurrentPrice:   item.Price,
+			EffectivePrice: effective,
+			Points:         points,
+		})
+	}
+	return chart, nil
+}
+
+// applyCampaignPrices menghitung harga efektif item berdasarkan diskon produk dan kampanye yang sedang berjalan.
+func (s *productService) applyCampaignPrices(products []*models.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+	now := time.Now()
+	campaigns, err := s.campaignRepository.GetActiveCampaigns(now)
+	if err != nil {
+		return apperrors.NewDatabaseError("get active campaigns", err)
+	}
+	applyEffectivePrices(products, campaigns, now)
+	return nil
+}
+
+// AdminCreateProductFull membuat produk lengkap beserta item, variasi, kategori, dan style dalam satu transaksi.
+// Stok awal item dicatat ke ledger atas nama actorID.
+func (s *productService) AdminCreateProductFull(dto *dtos.AdminProductFullRequestDTO, actorID uint64) (*models.Product, error) {
+	product := &models.Product{}
+	if err := s.applyProductFullDTO(product, dto); err != nil {
+		return nil, err
+	}
+	status := dto.Status
+	if status == "" {
+		status = constants.ProductStatusDraft
+	}
+	if err := applyProductStatus(product, status, dto.PublishAt, time.Now()); err != nil {
+		return nil, err
+	}
+	if _, err := s.productRepository.SaveProductWithDetails(product, &actorID); err != nil {
+		return nil, apperrors.NewDatabaseError("save product", err)
+	}
+	return s.productRepository.GetProductDetailsByID(product.ID)
+}
+
+// AdminUpdateProductFull mengganti data produk, item, variasi, kategori, dan style dalam satu transaksi.
+// Perubahan stok dicatat ke ledger atas nama actorID dan memicu peringatan stok menipis/habis.
+func (s *productService) AdminUpdateProductFull(id uint64, dto *dtos.AdminProductFullRequestDTO, actorID uint64) (*models.Product, error) {
+	product, err := s.productRepository.GetProductDetailsByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError("Product")
+		}
+		return nil, apperrors.NewDatabaseError("get product", err)
+	}
+	before, err := s.watchService.SnapshotItems([]*models.Product{product})
+	if err != nil {
+		utils.GetLogger().Warn("Failed to snapshot product items: ", err)
+	}
+	if err := s.applyProductFullDTO(product, dto); err != nil {
+		return nil, err
+	}
+	if dto.Status != "" {
+		if err := applyProductStatus(product, dto.Status, dto.PublishAt, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	movements, err := s.productRepository.SaveProductWithDetails(product, &actorID)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("save product", err)
+	}
+	s.stockService.NotifyStockMovements(movements)
+	updated, err := s.productRepository.GetProductDetailsByID(product.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.notifyItemChanges(before, updated)
+	return updated, nil
+}
+
+// snapshotProductItems mencatat stok dan harga efektif item produk sebelum diperbarui.
+// Kegagalan hanya dicatat di log agar pembaruan produk tetap berjalan.
+func (s *productService) snapshotProductItems(id uint64) map[uint64]ItemSnapshot {
+	product, err := s.productRepository.GetProductDetailsByID(id)
+	if err != nil {
+		return nil
+	}
+	before, err := s.watchService.SnapshotItems([]*models.Product{product})
+	if err != nil {
+		utils.GetLogger().Warn("Failed to snapshot product items: ", err)
+		return nil
+	}
+	return before
+}
+
+// notifyItemChanges mengirim notifikasi stok kembali dan penurunan harga ke pengguna yang memantau item produk.
+func (s *productService) notifyItemChanges(before map[uint64]ItemSnapshot, product *models.Product) {
+	if err := s.watchService.NotifyItemChanges(before, []*models.Product{product}); err != nil {
+		utils.GetLogger().Warn("Failed to send watch alerts: ", err)
+	}
+}
+
+// AdminGetProductDetailsByID mengambil produk lengkap untuk editor admin.
+func (s *productService) AdminGetProductDetailsByID(id uint64) (*models.Product, error) {
+	product, err := s.productRepository.GetProductDetailsByID(id)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Product")
+	}
+	if err := s.applyCampaignPrices([]*models.Product{product}); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// applyProductFullDTO memvalidasi DTO lalu menerapkannya ke model produk (baru atau yang sudah ada).
+// Validasi mencakup keunikan SKU dan keunikan kombinasi opsi variasi per item.
+func (s *productService) applyProductFullDTO(product *models.Product, dto *dtos.AdminProductFullRequestDTO) error {
+	existingItems := make(map[uint64]models.ProductItem, len(product.ProductItems))
+	for _, item := range product.ProductItems {
+		existingItems[item.ID] = item
+	}
+
+	// SKU harus unik di dalam request
+	skus := make([]string, 0, len(dto.Items))
+	seenSKUs := make(map[string]bool, len(dto.Items))
+	for _, item := range dto.Items {
+		sku := strings.TrimSpace(item.SKU)
+		if seenSKUs[sku] {
+			return apperrors.NewValidationError(fmt.Sprintf("duplicate SKU %q in request", sku))
+		}
+		seenSKUs[sku] = true
+		skus = append(skus, sku)
+	}
+
+	// SKU tidak boleh dipakai oleh produk lain; SKU milik produk ini dipetakan ke item yang sudah ada
+	skuOwners, err := s.productRepository.GetProductItemsBySKUs(skus)
+	if err != nil {
+		return apperrors.NewDatabaseError("check SKUs", err)
+	}
+	itemIDBySKU := make(map[string]uint64, len(skuOwners))
+	for _, owner := range skuOwners {
+		if product.ID == 0 || owner.ProductID != product.ID {
+			return apperrors.NewConflictError(fmt.Sprintf("SKU %q", owner.SKU))
+		}
+		itemIDBySKU[owner.SKU] = owner.ID
+		if _, ok := existingItems[owner.ID]; !ok {
+			existingItems[owner.ID] = owner
+		}
+	}
+
+	// Muat semua opsi variasi yang dirujuk
+	optionIDs := make([]uint64, 0)
+	for _, item := range dto.Items {
+		optionIDs = append(optionIDs, item.VariationOptionIDs...)
+	}
+	options, err := s.productRepository.GetVariationOptionsByIDs(optionIDs)
+	if err != nil {
+		return apperrors.NewDatabaseError("get variation options", err)
+	}
+	optionsByID := make(map[uint64]models.ProductVariationOption, len(options))
+	for _, opt := range options {
+		optionsByID[opt.ID] = opt
+	}
+
+	items := make([]models.ProductItem, 0, len(dto.Items))
+	seenCombinations := make(map[string]string, len(dto.Items))
+	for i, itemDTO := range dto.Items {
+		sku := skus[i]
+
+		// Tentukan item yang diperbarui: berdasarkan ID eksplisit atau SKU yang sudah terdaftar
+		itemID := itemDTO.ID
+		if ownerID, ok := itemIDBySKU[sku]; ok {
+			if itemID != 0 && itemID != ownerID {
+				return apperrors.NewConflictError(fmt.Sprintf("SKU %q", sku))
+			}
+			itemID = ownerID
+		}
+		item := models.ProductItem{}
+		if itemID != 0 {
+			existing, ok := existingItems[itemID]
+			if !ok {
+				return apperrors.NewValidationError(fmt.Sprintf("product item %d does not belong to this product", itemID))
+			}
+			item = existing
+			item.Configurations = nil
+			item.Product = models.Product{}
+			item.DeletedAt = gorm.DeletedAt{}
+		}
+		item.ID = itemID
+		item.SKU = sku
+		item.Price = itemDTO.Price
+		item.Stock = itemDTO.Stock
+		item.PhotoURL = itemDTO.PhotoURL
+
+		// Satu opsi per variasi, dan kombinasi opsi harus unik antar item
+		usedVariations := make(map[uint64]bool, len(itemDTO.VariationOptionIDs))
+		comboIDs := make([]uint64, 0, len(itemDTO.VariationOptionIDs))
+		for _, optionID := range itemDTO.VariationOptionIDs {
+			opt, ok := optionsByID[optionID]
+			if !ok {
+				return apperrors.NewValidationError(fmt.Sprintf("variation option %d not found", optionID))
+			}
+			if usedVariations[opt.ProductAttributeID] {
+				return apperrors.NewValidationError(fmt.Sprintf("item %q has more than one option for variation %q", sku, opt.ProductVariation.Name))
+			}
+			usedVariations[opt.ProductAttributeID] = true
+			comboIDs = append(comboIDs, optionID)
+			item.Configurations = append(item.Configurations, models.ProductConfiguration{ProductAttributeValueID: optionID})
+		}
+		key := variationCombinationKey(comboIDs)
+		if otherSKU, dup := seenCombinations[key]; dup {
+			return apperrors.NewValidationError(fmt.Sprintf("items %q and %q have the same variation combination", otherSKU, sku))
+		}
+		seenCombinations[key] = sku
+
+		items = append(items, item)
+	}
+
+	categories := make([]models.ProductCategory, 0, len(dto.Categories))
+	for _, category := range uniqueTrimmed(dto.Categories) {
+		categories = append(categories, models.ProductCategory{Category: category})
+	}
+	styles := make([]models.ProductStyle, 0, len(dto.Styles))
+	for _, style := range uniqueTrimmed(dto.Styles) {
+		styles = append(styles, models.ProductStyle{Style: style})
+	}
+
+	product.BrandID = dto.BrandID
+	product.Name = dto.Name
+	product.Description = dto.Description
+	product.Discount = dto.Discount
+	product.BrandProductURL = dto.BrandProductURL
+	product.WhatsAppTemplate = dto.WhatsAppTemplate
+	product.InstagramProductURL = dto.InstagramProductURL
+	product.TokopediaProductURL = dto.TokopediaProductURL
+	product.ShopeeProductURL = dto.ShopeeProductURL
+	product.ProductCategories = categories
+	product.ProductStyles = styles
+	product.ProductItems = items
+	product.Reviews = nil
+	return nil
+}
+
+// variationCombinationKey membuat kunci yang tidak bergantung urutan untuk satu kombinasi opsi variasi.
+func variationCombinationKey(optionIDs []uint64) string {
+	sorted := append([]uint64(nil), optionIDs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	parts := make([]string, len(sorted))
+	for i, id := range sorted {
+		parts[i] = strconv.FormatUint(id, 10)
+	}
+	return strings.Join(parts, ",")
+}
+
+// uniqueTrimmed menghapus spasi di awal/akhir dan nilai duplikat dengan tetap menjaga urutan.
+func uniqueTrimmed(values []string) []string {
+	result := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[strings.ToLower(v)] {
+			continue
+		}
+		seen[strings.ToLower(v)] = true
+		result = append(result, v)
+	}
+	return result
 }
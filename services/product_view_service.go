@@ -0,0 +1,169 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"flicknfit_backend/config"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/utils"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ProductViewService defines business logic for recording product detail views and the recently-viewed list.
+type ProductViewService interface {
+	RecordView(product *models.Product, userID uint64, sessionID, ipAddress, userAgent string)
+	GetRecentlyViewed(userID uint64, limit int) ([]dtos.RecentlyViewedProductDTO, error)
+}
+
+// productViewService implements ProductViewService interface. Debouncing is kept in memory, so each API
+// instance debounces on its own.
+type productViewService struct {
+	viewRepo       repositories.ProductViewRepository
+	productService ProductService
+	events         EventBus
+	hashKey        string
+
+	mu       sync.Mutex
+	lastSeen map[string]time.Time // Last published view per session and product
+}
+
+// NewProductViewService creates a new product view service that stores views published on the event bus.
+// Session keys are keyed with the IP hash key, so stored keys cannot be matched to an IP address by hashing guesses.
+func NewProductViewService(viewRepo repositories.ProductViewRepository, productService ProductService, events EventBus, cfg *config.Config) ProductViewService {
+	s := &productViewService{
+		viewRepo:       viewRepo,
+		productService: productService,
+		events:         events,
+		hashKey:        cfg.ClickIPHashKey,
+		lastSeen:       make(map[string]time.Time),
+	}
+	events.Subscribe(constants.EventTopicProductView, s.storeViews)
+	return s
+}
+
+// RecordView publishes a view of a product. Signed-in users are debounced per account, anonymous users per
+// app session, or per IP address and user agent when the app sends no session ID. Views by bots are ignored.
+func (s *productViewService) RecordView(product *models.Product, userID uint64, sessionID, ipAddress, userAgent string) {
+	if utils.DeviceClass(userAgent) == constants.DeviceClassBot {
+		return
+	}
+	view := models.ProductView{
+		ProductID:  product.ID,
+		BrandID:    product.BrandID,
+		SessionKey: viewSessionKey(s.hashKey, userID, sessionID, ipAddress, userAgent),
+		ViewedAt:   time.Now(),
+	}
+	if userID > 0 {
+		view.UserID = &userID
+	}
+
+	key := view.SessionKey + ":" + strconv.FormatUint(view.ProductID, 10)
+	s.mu.Lock()
+	if last, ok := s.lastSeen[key]; ok && view.ViewedAt.Sub(last) < constants.ViewDebounceWindow {
+		s.mu.Unlock()
+		return
+	}
+	s.lastSeen[key] = view.ViewedAt
+	s.mu.Unlock()
+
+	// A view the bus refused may be recorded on the next visit
+	if err := s.events.Publish(Event{Topic: constants.EventTopicProductView, Key: view.SessionKey, Payload: view}); err != nil {
+		s.mu.Lock()
+		delete(s.lastSeen, key)
+		s.mu.Unlock()
+	}
+}
+
+// storeViews handles a batch of view events and drops debounce entries that have expired.
+func (s *productViewService) storeViews(events []Event) error {
+	s.forgetExpired(time.Now())
+
+	views := make([]models.ProductView, 0, len(events))
+	for _, event := range events {
+		view, ok := event.Payload.(models.ProductView)
+		if !ok {
+			utils.GetLogger().WithField("payload", fmt.Sprintf("%T", event.Payload)).Error("Unexpected view event payload")
+			continue
+		}
+		views = append(views, view)
+	}
+	if len(views) == 0 {
+		return nil
+	}
+	return s.viewRepo.RecordViews(views)
+}
+
+// forgetExpired drops debounce entries older than the debounce window.
+func (s *productViewService) forgetExpired(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, last := range s.lastSeen {
+		if now.Sub(last) >= constants.ViewDebounceWindow {
+			delete(s.lastSeen, key)
+		}
+	}
+}
+
+// GetRecentlyViewed retrieves the products a user viewed most recently, newest first. Products that are no
+// longer published are left out.
+func (s *productViewService) GetRecentlyViewed(userID uint64, limit int) ([]dtos.RecentlyViewedProductDTO, error) {
+	if limit < 1 {
+		limit = constants.RecentlyViewedDefaultLimit
+	}
+	if limit > constants.RecentlyViewedMaxLimit {
+		limit = constants.RecentlyViewedMaxLimit
+	}
+	views, err := s.viewRepo.GetRecentViews(userID, limit)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get recent views", err)
+	}
+
+	ids := make([]uint64, len(views))
+	for i, view := range views {
+		ids[i] = view.ProductID
+	}
+	products, err := s.productService.GetProductsPublicByIDs(ids)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get products", err)
+	}
+	byID := make(map[uint64]*models.Product, len(products))
+	for _, product := range products {
+		byID[product.ID] = product
+	}
+
+	result := make([]dtos.RecentlyViewedProductDTO, 0, len(views))
+	for _, view := range views {
+		if product, ok := byID[view.ProductID]; ok {
+			result = append(result, dtos.RecentlyViewedProductDTO{
+				ProductResponseDTO: dtos.ToProductResponseDTO(product),
+				ViewedAt:           view.ViewedAt,
+			})
+		}
+	}
+	return result, nil
+}
+
+// viewSessionKey identifies who viewed a product for debouncing. It is an HMAC under hashKey so that no IP
+// address is stored.
+func viewSessionKey(hashKey string, userID uint64, sessionID, ipAddress, userAgent string) string {
+	var key string
+	switch {
+	case userID > 0:
+		key = "user:" + strconv.FormatUint(userID, 10)
+	case sessionID != "":
+		key = "session:" + sessionID
+	default:
+		key = "client:" + ipAddress + "|" + userAgent
+	}
+	mac := hmac.New(sha256.New, []byte(hashKey))
+	mac.Write([]byte(key))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	stderrors "errors"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RedirectChannelService defines business logic for how a brand's outbound clicks are routed and how each channel performs.
+type RedirectChannelService interface {
+	GetBrandChannels(brandID, userID uint64) (*dtos.RedirectChannelsResponseDTO, error)
+	UpdateBrandChannels(brandID, userID uint64, dto *dtos.RedirectChannelsRequestDTO) (*dtos.RedirectChannelsResponseDTO, error)
+	GetChannelReport(brandID, userID uint64, days int) (*dtos.ChannelReportResponseDTO, error)
+	GetLinkParams(brandID, userID uint64) (*models.BrandLinkParams, error)
+	UpdateLinkParams(brandID, userID uint64, dto *dtos.BrandLinkParamsDTO) (*models.BrandLinkParams, error)
+}
+
+// redirectChannelService implements RedirectChannelService interface
+type redirectChannelService struct {
+	channelRepo repositories.RedirectChannelRepository
+	memberRepo  repositories.BrandMemberRepository
+}
+
+// defaultRedirectChannels is the order used by brands without settings of their own
+var defaultRedirectChannels = []string{
+	constants.RedirectChannelWhatsApp,
+	constants.RedirectChannelTokopedia,
+	constants.RedirectChannelShopee,
+	constants.RedirectChannelInstagram,
+	constants.RedirectChannelWebsite,
+}
+
+// NewRedirectChannelService creates a new redirect channel service
+func NewRedirectChannelService(channelRepo repositories.RedirectChannelRepository, memberRepo repositories.BrandMemberRepository) RedirectChannelService {
+	return &redirectChannelService{
+		channelRepo: channelRepo,
+		memberRepo:  memberRepo,
+	}
+}
+
+// GetBrandChannels retrieves the redirect order of a brand for one of its members
+func (s *redirectChannelService) GetBrandChannels(brandID, userID uint64) (*dtos.RedirectChannelsResponseDTO, error) {
+	if err := requireBrandMember(s.memberRepo, brandID, userID); err != nil {
+		return nil, err
+	}
+	return s.brandChannels(brandID)
+}
+
+// UpdateBrandChannels replaces the redirect order and A/B weights of a brand
+func (s *redirectChannelService) UpdateBrandChannels(brandID, userID uint64, dto *dtos.RedirectChannelsRequestDTO) (*dtos.RedirectChannelsResponseDTO, error) {
+	if err := requireBrandMember(s.memberRepo, brandID, userID); err != nil {
+		return nil, err
+	}
+
+	channels := make([]models.BrandRedirectChannel, len(dto.Channels))
+	for i, channel := range dto.Channels {
+		channels[i] = models.BrandRedirectChannel{
+			BrandID:  brandID,
+			Channel:  channel.Channel,
+			Position: i,
+			Weight:   channel.Weight,
+		}
+	}
+	if err := s.channelRepo.ReplaceChannels(brandID, channels); err != nil {
+		return nil, errors.NewDatabaseError("update redirect channels", err)
+	}
+	return s.brandChannels(brandID)
+}
+
+// brandChannels builds the redirect order response, falling back to the default order
+func (s *redirectChannelService) brandChannels(brandID uint64) (*dtos.RedirectChannelsResponseDTO, error) {
+	settings, err := s.channelRepo.GetChannels(brandID)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get redirect channels", err)
+	}
+
+	response := &dtos.RedirectChannelsResponseDTO{BrandID: brandID, Default: len(settings) == 0}
+	if response.Default {
+		for _, channel := range defaultRedirectChannels {
+			response.Channels = append(response.Channels, dtos.RedirectChannelDTO{Channel: channel})
+		}
+		return response, nil
+	}
+	for _, setting := range settings {
+		response.Channels = append(response.Channels, dtos.RedirectChannelDTO{Channel: setting.Channel, Weight: setting.Weight})
+	}
+	return response, nil
+}
+
+// GetChannelReport reports clicks, conversions and conversion rate per channel over the last days
+func (s *redirectChannelService) GetChannelReport(brandID, userID uint64, days int) (*dtos.ChannelReportResponseDTO, error) {
+	if err := requireBrandMember(s.memberRepo, brandID, userID); err != nil {
+		return nil, err
+	}
+	if days < 1 || days > constants.ChannelReportMaxDays {
+		days = constants.ChannelReportDefaultDays
+	}
+
+	since := time.Now().AddDate(0, 0, -days)
+	stats, err := s.channelRepo.GetChannelReport(brandID, since)
+	if err != nil {
+		return nil, errors.NewDatabaseError("get channel report", err)
+	}
+
+	rows := make([]dtos.ChannelReportRowDTO, len(stats))
+	for i, stat := range stats {
+		rows[i] = dtos.ChannelReportRowDTO{ChannelStat: stat}
+		if stat.Clicks > 0 {
+			rows[i].ConversionRate = float64(stat.Conversions) / float64(stat.Clicks)
+		}
+	}
+	return &dtos.ChannelReportResponseDTO{BrandID: brandID, Days: days, Channels: rows}, nil
+}
+
+// GetLinkParams retrieves a brand's outbound link parameter overrides; brands without overrides get empty values
+func (s *redirectChannelService) GetLinkParams(brandID, userID uint64) (*models.BrandLinkParams, error) {
+	if err := requireBrandMember(s.memberRepo, brandID, userID); err != nil {
+		return nil, err
+	}
+	params, err := s.channelRepo.GetLinkParams(brandID)
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return &models.BrandLinkParams{BrandID: brandID}, nil
+		}
+		return nil, errors.NewDatabaseError("get link parameters", err)
+	}
+	return params, nil
+}
+
+// UpdateLinkParams replaces a brand's outbound link parameter overrides
+func (s *redirectChannelService) UpdateLinkParams(brandID, userID uint64, dto *dtos.BrandLinkParamsDTO) (*models.BrandLinkParams, error) {
+	if err := requireBrandMember(s.memberRepo, brandID, userID); err != nil {
+		return nil, err
+	}
+	params := &models.BrandLinkParams{
+		BrandID:              brandID,
+		UTMSource:            dto.UTMSource,
+		UTMMedium:            dto.UTMMedium,
+		UTMCampaign:          dto.UTMCampaign,
+		TokopediaAffiliateID: dto.TokopediaAffiliateID,
+		ShopeeAffiliateID:    dto.ShopeeAffiliateID,
+	}
+	if err := s.channelRepo.SaveLinkParams(params); err != nil {
+		return nil, errors.NewDatabaseError("update link parameters", err)
+	}
+	return params, nil
+}
@@ -16,6 +16,7 @@ type ReviewService interface {
 	DeleteReview(userID, reviewID uint64) error
 	GetUserReviews(userID uint64) ([]models.Review, error)
 	GetProductReviewStats(productID uint64) (map[string]interface{}, error)
+	RecalculateRatings(dryRun bool) (*dtos.RatingRecalculationResultDTO, error)
 }
 
 // reviewService implements ReviewService interface
@@ -35,7 +36,7 @@ func NewReviewService(reviewRepo repositories.ReviewRepository, productRepo repo
 // GetProductReviews retrieves reviews for a product with pagination
 func (s *reviewService) GetProductReviews(productID uint64, page, limit int) (*dtos.ReviewListResponseDTO, error) {
 	// Validate product exists
-	_, err := s.productRepo.GetProductByID(productID)
+	_, err := s.productRepo.GetPublishedProductByID(productID)
 	if err != nil {
 		return nil, errors.NewNotFoundError("Product")
 	}
@@ -75,10 +76,10 @@ func (s *reviewService) GetProductReviews(productID uint64, page, limit int) (*d
 	return response, nil
 }
 
-// CreateReview creates a new review
+// CreateReview creates a new review; the product and brand rating aggregates are updated in the same transaction
 func (s *reviewService) CreateReview(userID uint64, dto *dtos.CreateReviewDTO) error {
 	// Validate product exists
-	_, err := s.productRepo.GetProductByID(dto.ProductID)
+	_, err := s.productRepo.GetPublishedProductByID(dto.ProductID)
 	if err != nil {
 		return errors.NewNotFoundError("Product")
 	}
@@ -107,7 +108,7 @@ func (s *reviewService) CreateReview(userID uint64, dto *dtos.CreateReviewDTO) e
 	return nil
 }
 
-// UpdateReview updates an existing review
+// UpdateReview updates an existing review and recomputes the rating aggregates
 func (s *reviewService) UpdateReview(userID, reviewID uint64, dto *dtos.UpdateReviewDTO) error {
 	// Get existing review
 	review, err := s.reviewRepo.GetReviewByID(reviewID)
@@ -136,7 +137,7 @@ func (s *reviewService) UpdateReview(userID, reviewID uint64, dto *dtos.UpdateRe
 	return nil
 }
 
-// DeleteReview deletes a review
+// DeleteReview deletes a review and recomputes the rating aggregates
 func (s *reviewService) DeleteReview(userID, reviewID uint64) error {
 	// Get existing review
 	review, err := s.reviewRepo.GetReviewByID(reviewID)
@@ -169,7 +170,7 @@ func (s *reviewService) GetUserReviews(userID uint64) ([]models.Review, error) {
 // GetProductReviewStats gets review statistics for a product
 func (s *reviewService) GetProductReviewStats(productID uint64) (map[string]interface{}, error) {
 	// Validate product exists
-	_, err := s.productRepo.GetProductByID(productID)
+	_, err := s.productRepo.GetPublishedProductByID(productID)
 	if err != nil {
 		return nil, errors.NewNotFoundError("Product")
 	}
@@ -181,3 +182,25 @@ func (s *reviewService) GetProductReviewStats(productID uint64) (map[string]inte
 
 	return stats, nil
 }
+
+// RecalculateRatings recomputes the rating aggregates of every product and brand and reports the ones
+// that drifted from their reviews. With dryRun the drift is only reported.
+func (s *reviewService) RecalculateRatings(dryRun bool) (*dtos.RatingRecalculationResultDTO, error) {
+	drifts, err := s.reviewRepo.RecalculateAllRatings(dryRun)
+	if err != nil {
+		return nil, errors.NewDatabaseError("recalculate ratings", err)
+	}
+
+	result := &dtos.RatingRecalculationResultDTO{DryRun: dryRun, Drifts: drifts}
+	if result.Drifts == nil {
+		result.Drifts = []models.RatingDrift{}
+	}
+	for _, drift := range drifts {
+		if drift.Entity == models.RatingDriftEntityBrand {
+			result.BrandsDrifted++
+		} else {
+			result.ProductsDrifted++
+		}
+	}
+	return result, nil
+}
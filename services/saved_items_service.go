@@ -3,8 +3,11 @@ package services
 import (
 	"errors"
 	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
 	"flicknfit_backend/models"
 	"flicknfit_backend/repositories"
+	"fmt"
+	"net/http"
 
 	"gorm.io/gorm"
 )
@@ -20,15 +23,15 @@ type SavedItemsService interface {
 
 // SavedItemsService is the implementation of SavedItemsService.
 type savedItemsService struct {
-	savedItemsRepository    repositories.SavedItemsRepository
-	productRepository repositories.ProductRepository
+	savedItemsRepository repositories.SavedItemsRepository
+	productRepository    repositories.ProductRepository
 }
 
 // NewSavedItemsService creates and returns a new instance of SavedItemsService.
 func NewSavedItemsService(savedItemsRepo repositories.SavedItemsRepository, productRepo repositories.ProductRepository) SavedItemsService {
 	return &savedItemsService{
-		savedItemsRepository:    savedItemsRepo,
-		productRepository: productRepo,
+		savedItemsRepository: savedItemsRepo,
+		productRepository:    productRepo,
 	}
 }
 
@@ -67,7 +70,7 @@ func (s *savedItemsService) AddProductItemToSavedItems(userID uint64, dto *dtos.
 		return nil, err
 	}
 
-	productItem, err := s.productRepository.GetProductItemByID(dto.ProductItemID)
+	productItem, err := s.productRepository.GetPublishedProductItemByID(dto.ProductItemID)
 	if productItem == nil && err != nil {
 		return nil, errors.New("product item not found")
 	}
@@ -81,18 +84,27 @@ func (s *savedItemsService) AddProductItemToSavedItems(userID uint64, dto *dtos.
 		}
 	}
 
+	// The total quantity saved may not exceed the available stock.
+	quantity := dto.Quantity
+	if SavedItemsList != nil {
+		quantity += SavedItemsList.Quantity
+	}
+	if err := checkStockAvailability(productItem, quantity); err != nil {
+		return nil, err
+	}
+
 	if SavedItemsList != nil {
 		// Update quantity if item exists.
-		SavedItemsList.Quantity += dto.Quantity
+		SavedItemsList.Quantity = quantity
 		if err := s.savedItemsRepository.UpdateSavedItem(SavedItemsList); err != nil {
 			return nil, errors.New("failed to update savedItems item")
 		}
 	} else {
 		// Add new item to savedItems.
 		SavedItemsList = &models.SavedItemsList{
-			SavedItemsID: savedItems.ID,
-			ProductItemID:  dto.ProductItemID,
-			Quantity:       dto.Quantity,
+			SavedItemsID:  savedItems.ID,
+			ProductItemID: dto.ProductItemID,
+			Quantity:      dto.Quantity,
 		}
 		if err := s.savedItemsRepository.AddSavedItem(SavedItemsList); err != nil {
 			return nil, errors.New("failed to add item to savedItems")
@@ -120,6 +132,14 @@ func (s *savedItemsService) UpdateProductItemInSavedItems(userID uint64, savedIt
 		return nil, errors.New("savedItems item does not belong to this user")
 	}
 
+	productItem, err := s.productRepository.GetPublishedProductItemByID(SavedItemsList.ProductItemID)
+	if err != nil {
+		return nil, errors.New("product item not found")
+	}
+	if err := checkStockAvailability(productItem, dto.Quantity); err != nil {
+		return nil, err
+	}
+
 	SavedItemsList.Quantity = dto.Quantity
 	if err := s.savedItemsRepository.UpdateSavedItem(SavedItemsList); err != nil {
 		return nil, errors.New("failed to update savedItems item quantity")
@@ -147,3 +167,14 @@ func (s *savedItemsService) RemoveProductItemFromSavedItems(userID uint64, saved
 
 	return s.savedItemsRepository.DeleteSavedItem(savedItemID)
 }
+
+// checkStockAvailability rejects quantities above the item's available stock.
+func checkStockAvailability(productItem *models.ProductItem, quantity int) error {
+	if productItem.Stock <= 0 {
+		return apperrors.New(apperrors.ErrorTypeConflict, http.StatusConflict, "Product item is out of stock")
+	}
+	if quantity > productItem.Stock {
+		return apperrors.New(apperrors.ErrorTypeValidation, http.StatusBadRequest, fmt.Sprintf("Only %d left in stock", productItem.Stock))
+	}
+	return nil
+}
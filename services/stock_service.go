@@ -0,0 +1,166 @@
+package services
+
+import (
+	"errors"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/utils"
+	"fmt"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+// StockService defines business logic for product item stock, its ledger and low-stock alerts.
+type StockService interface {
+	AdjustStock(itemID uint64, actorID uint64, dto *dtos.StockAdjustmentRequestDTO) (*models.ProductItem, *models.StockMovement, error)
+	GetStockMovements(itemID uint64, limit int) ([]models.StockMovement, error)
+	SetLowStockThreshold(itemID uint64, dto *dtos.LowStockThresholdRequestDTO) (*models.ProductItem, error)
+	GetLowStockItems() ([]models.ProductItem, error)
+	// NotifyStockMovements sends low-stock and out-of-stock alerts for ledger entries recorded outside
+	// AdjustStock, such as product edits and imports.
+	NotifyStockMovements(movements []models.StockMovement)
+}
+
+// stockService implements StockService interface
+type stockService struct {
+	stockRepo           repositories.StockRepository
+	productRepo         repositories.ProductRepository
+	memberRepo          repositories.BrandMemberRepository
+	notificationService NotificationService
+	watchService        WatchService
+}
+
+// NewStockService creates a new stock service
+func NewStockService(stockRepo repositories.StockRepository, productRepo repositories.ProductRepository, memberRepo repositories.BrandMemberRepository, notificationService NotificationService, watchService WatchService) StockService {
+	return &stockService{
+		stockRepo:           stockRepo,
+		productRepo:         productRepo,
+		memberRepo:          memberRepo,
+		notificationService: notificationService,
+		watchService:        watchService,
+	}
+}
+
+// AdjustStock applies a stock delta, records it in the ledger and alerts brand members
+// when the item drops to its low-stock threshold or runs out of stock. Users watching
+// the item are alerted when it comes back in stock.
+func (s *stockService) AdjustStock(itemID uint64, actorID uint64, dto *dtos.StockAdjustmentRequestDTO) (*models.ProductItem, *models.StockMovement, error) {
+	movement := &models.StockMovement{
+		Reason:  dto.Reason,
+		Note:    dto.Note,
+		ActorID: &actorID,
+	}
+	item, err := s.stockRepo.AdjustStock(itemID, dto.Delta, movement)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, apperrors.NewNotFoundError("Product item")
+		}
+		if errors.Is(err, repositories.ErrInsufficientStock) {
+			return nil, nil, apperrors.New(apperrors.ErrorTypeConflict, http.StatusConflict, "Stock cannot go below zero")
+		}
+		return nil, nil, apperrors.NewDatabaseError("adjust stock", err)
+	}
+
+	// A failed alert does not undo the stock adjustment, which is already saved
+	if err := s.notifyStockLevel(item, movement.StockBefore); err != nil {
+		utils.GetLogger().Warn("Failed to send stock alert: ", err)
+	}
+	if err := s.watchService.NotifyBackInStock(item, movement.StockBefore); err != nil {
+		utils.GetLogger().Warn("Failed to send back-in-stock alert: ", err)
+	}
+	return item, movement, nil
+}
+
+// notifyStockLevel alerts the brand's members when an adjustment crosses the out-of-stock or low-stock boundary.
+func (s *stockService) notifyStockLevel(item *models.ProductItem, stockBefore int) error {
+	threshold := item.EffectiveLowStockThreshold(constants.DefaultLowStockThreshold)
+
+	var notification models.Notification
+	switch {
+	case item.Stock == 0 && stockBefore > 0:
+		notification = models.Notification{
+			Type:    constants.NotificationTypeOutOfStock,
+			Title:   fmt.Sprintf("%s (%s) is out of stock", item.Product.Name, item.SKU),
+			Message: fmt.Sprintf("Stock for SKU %s reached 0.", item.SKU),
+		}
+	case item.Stock <= threshold && stockBefore > threshold:
+		notification = models.Notification{
+			Type:    constants.NotificationTypeLowStock,
+			Title:   fmt.Sprintf("%s (%s) is low on stock", item.Product.Name, item.SKU),
+			Message: fmt.Sprintf("Stock for SKU %s dropped to %d (threshold %d).", item.SKU, item.Stock, threshold),
+		}
+	default:
+		return nil
+	}
+
+	userIDs, err := s.memberRepo.GetMemberUserIDs(item.Product.BrandID)
+	if err != nil {
+		return err
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	productID, itemID := item.ProductID, item.ID
+	notification.ProductID = &productID
+	notification.ProductItemID = &itemID
+	return s.notificationService.Notify(userIDs, notification)
+}
+
+// NotifyStockMovements alerts brand members about movements that took an item below its low-stock threshold or
+// out of stock. The movements are already saved, so failures are only logged.
+func (s *stockService) NotifyStockMovements(movements []models.StockMovement) {
+	for _, movement := range movements {
+		if movement.StockAfter >= movement.StockBefore {
+			continue
+		}
+		item, err := s.productRepo.GetProductItemByID(movement.ProductItemID)
+		if err != nil {
+			utils.GetLogger().Warn("Failed to load product item for stock alert: ", err)
+			continue
+		}
+		if err := s.notifyStockLevel(item, movement.StockBefore); err != nil {
+			utils.GetLogger().Warn("Failed to send stock alert: ", err)
+		}
+	}
+}
+
+// GetStockMovements retrieves the latest ledger entries of an item
+func (s *stockService) GetStockMovements(itemID uint64, limit int) ([]models.StockMovement, error) {
+	if _, err := s.productRepo.GetProductItemByID(itemID); err != nil {
+		return nil, apperrors.NewNotFoundError("Product item")
+	}
+	if limit < 1 || limit > constants.StockMovementsPageSize {
+		limit = constants.StockMovementsPageSize
+	}
+	movements, err := s.stockRepo.GetMovementsByItemID(itemID, limit)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get stock movements", err)
+	}
+	return movements, nil
+}
+
+// SetLowStockThreshold sets or resets an item's low-stock threshold
+func (s *stockService) SetLowStockThreshold(itemID uint64, dto *dtos.LowStockThresholdRequestDTO) (*models.ProductItem, error) {
+	item, err := s.productRepo.GetProductItemByID(itemID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Product item")
+	}
+	if err := s.stockRepo.SetLowStockThreshold(itemID, dto.Threshold); err != nil {
+		return nil, apperrors.NewDatabaseError("set low stock threshold", err)
+	}
+	item.LowStockThreshold = dto.Threshold
+	return item, nil
+}
+
+// GetLowStockItems retrieves all items at or below their low-stock threshold
+func (s *stockService) GetLowStockItems() ([]models.ProductItem, error) {
+	items, err := s.stockRepo.GetLowStockItems(constants.DefaultLowStockThreshold)
+	if err != nil {
+		return nil, apperrors.NewDatabaseError("get low stock items", err)
+	}
+	return items, nil
+}
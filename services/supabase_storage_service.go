@@ -17,6 +17,7 @@ import (
 // SupabaseStorageService handles file uploads to Supabase Storage
 type SupabaseStorageService interface {
 	UploadScanImage(userID uint64, file multipart.File, filename, scanType string) (string, error)
+	UploadPublicFile(filePath string, data []byte, contentType string) (string, error)
 	GetSignedURL(filePath string, expiresIn int) (string, error)
 	DeleteFile(filePath string) error
 	DownloadAndDecryptFile(filePath string) ([]byte, error)
@@ -87,6 +88,16 @@ func (s *supabaseStorageService) UploadScanImage(userID uint64, file multipart.F
 	return filePath, nil
 }
 
+// UploadPublicFile uploads an unencrypted file meant to be served publicly (e.g. product media)
+// and returns its public URL. The bucket must allow public reads for the URL to be accessible.
+func (s *supabaseStorageService) UploadPublicFile(filePath string, data []byte, contentType string) (string, error) {
+	_, err := s.client.UploadFile(s.bucket, filePath, bytes.NewReader(data), storage_go.FileOptions{ContentType: &contentType})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload to Supabase: %w", err)
+	}
+	return s.client.GetPublicUrl(s.bucket, filePath).SignedURL, nil
+}
+
 // GetSignedURL generates a signed URL for temporary access
 func (s *supabaseStorageService) GetSignedURL(filePath string, expiresIn int) (string, error) {
 	if expiresIn == 0 {
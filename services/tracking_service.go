@@ -1,26 +1,43 @@
 package services
 
 import (
+	"errors"
+	"flicknfit_backend/config"
+	"flicknfit_backend/constants"
 	"flicknfit_backend/models"
 	"flicknfit_backend/repositories"
+	"flicknfit_backend/utils"
 	"fmt"
+	"math/rand"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 // TrackingService handles product click tracking and redirect logic
 type TrackingService interface {
-	TrackClick(userID, productID uint64, ipAddress, userAgent string) error
-	GetRedirectURL(product *models.Product, brand *models.Brand) string
+	TrackClick(userID, productID uint64, ipAddress, userAgent, channel, region string) (*models.ProductClick, error)
+	GetRedirectURL(product *models.Product, brand *models.Brand) (url string, channel string)
+	BuildOutboundURL(redirectURL, channel string, product *models.Product, brand *models.Brand, click *models.ProductClick) string
 	GenerateWhatsAppLink(phoneNumber, message string) string
 	GetClickStats(productID uint64) (int64, error)
 	GetBrandClickStats(brandID uint64) (int64, error)
+	ApplyClickRetention(now time.Time) (int64, error)
 }
 
 type trackingService struct {
-	clickRepo   repositories.ProductClickRepository
-	productRepo repositories.ProductRepository
-	brandRepo   repositories.BrandRepository
+	clickRepo     repositories.ProductClickRepository
+	productRepo   repositories.ProductRepository
+	brandRepo     repositories.BrandRepository
+	userRepo      repositories.UserRepository
+	linkCheckRepo repositories.LinkCheckRepository
+	channelRepo   repositories.RedirectChannelRepository
+	events        EventBus
+	cfg           *config.Config
 }
 
 // NewTrackingService creates a new tracking service
@@ -28,20 +45,38 @@ func NewTrackingService(
 	clickRepo repositories.ProductClickRepository,
 	productRepo repositories.ProductRepository,
 	brandRepo repositories.BrandRepository,
+	userRepo repositories.UserRepository,
+	linkCheckRepo repositories.LinkCheckRepository,
+	channelRepo repositories.RedirectChannelRepository,
+	events EventBus,
+	cfg *config.Config,
 ) TrackingService {
-	return &trackingService{
-		clickRepo:   clickRepo,
-		productRepo: productRepo,
-		brandRepo:   brandRepo,
+	s := &trackingService{
+		clickRepo:     clickRepo,
+		productRepo:   productRepo,
+		brandRepo:     brandRepo,
+		userRepo:      userRepo,
+		linkCheckRepo: linkCheckRepo,
+		channelRepo:   channelRepo,
+		events:        events,
+		cfg:           cfg,
 	}
+	events.Subscribe(constants.EventTopicProductClick, s.storeClicks)
+	return s
 }
 
-// TrackClick records a product click event
-func (s *trackingService) TrackClick(userID, productID uint64, ipAddress, userAgent string) error {
+// TrackClick publishes a product click event with the channel it was redirected to and the region it came
+// from, and returns the click before it is stored: it has a reference for the click ID but no database ID.
+// Clicks are stored in batches in the background, see storeClicks.
+func (s *trackingService) TrackClick(userID, productID uint64, ipAddress, userAgent, channel, region string) (*models.ProductClick, error) {
 	// Get product to obtain brand_id
 	product, err := s.productRepo.GetProductByID(productID)
 	if err != nil {
-		return fmt.Errorf("product not found: %w", err)
+		return nil, fmt.Errorf("product not found: %w", err)
+	}
+	ref, err := utils.NewClickRef()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate click reference: %w", err)
 	}
 
 	// Handle anonymous users (userID = 0) by setting nil
@@ -51,35 +86,217 @@ func (s *trackingService) TrackClick(userID, productID uint64, ipAddress, userAg
 	}
 
 	click := &models.ProductClick{
+		Ref:       &ref,
 		UserID:    userIDPtr,
 		ProductID: productID,
 		BrandID:   product.BrandID,
-		IPAddress: ipAddress,
+		ClickedAt: time.Now(),
+		IPAddress: utils.AnonymizeIP(ipAddress, s.cfg.ClickIPMode, s.cfg.ClickIPHashKey),
 		UserAgent: userAgent,
+		Channel:   channel,
+		Region:    truncateRunes(strings.TrimSpace(region), constants.ClickRegionMaxLength),
+	}
+
+	// Clicks of one user, or of one IP address for anonymous users, go to the same worker so the
+	// duplicate check sees the clicks still waiting to be stored
+	key := click.IPAddress
+	if userIDPtr != nil {
+		key = "user:" + strconv.FormatUint(userID, 10)
+	}
+	if err := s.events.Publish(Event{Topic: constants.EventTopicProductClick, Key: key, Payload: *click}); err != nil {
+		return nil, err
+	}
+	return click, nil
+}
+
+// storeClicks handles a batch of click events. Users who opted out of tracking are recorded like anonymous
+// users, without their IP address. Clicks from bots, repeated clicks and bursts from one IP address are
+// still stored but flagged as suspicious, so analytics can leave them out.
+func (s *trackingService) storeClicks(events []Event) error {
+	clicks := make([]models.ProductClick, 0, len(events))
+	doNotTrack := make(map[uint64]bool)
+	for _, event := range events {
+		click, ok := event.Payload.(models.ProductClick)
+		if !ok {
+			utils.GetLogger().WithField("payload", fmt.Sprintf("%T", event.Payload)).Error("Unexpected click event payload")
+			continue
+		}
+
+		if click.UserID != nil {
+			optedOut, seen := doNotTrack[*click.UserID]
+			if !seen {
+				optedOut = s.doNotTrack(*click.UserID)
+				doNotTrack[*click.UserID] = optedOut
+			}
+			if optedOut {
+				click.UserID = nil
+				click.IPAddress = ""
+			}
+		}
+
+		if reason := s.suspicionReason(&click, clicks); reason != "" {
+			click.Suspicious = true
+			click.SuspicionReason = reason
+		}
+		clicks = append(clicks, click)
+	}
+	return s.clickRepo.CreateBatch(clicks)
+}
+
+// suspicionReason returns why a click should be left out of analytics, or an empty string for a genuine
+// click. Stored clicks are counted together with the earlier clicks of the batch, which are not stored yet.
+// A failed check counts the click as genuine.
+func (s *trackingService) suspicionReason(click *models.ProductClick, pending []models.ProductClick) string {
+	if strings.TrimSpace(click.UserAgent) == "" || utils.DeviceClass(click.UserAgent) == constants.DeviceClassBot {
+		return constants.ClickFlagBot
+	}
+
+	// Anonymous clicks without an IP address cannot be told apart, so they are never duplicates
+	if click.UserID != nil || click.IPAddress != "" {
+		since := click.ClickedAt.Add(-constants.ClickDedupWindow)
+		duplicates, err := s.clickRepo.CountRecentDuplicates(click, since)
+		if err != nil {
+			utils.GetLogger().WithError(err).Warn("Failed to check for duplicate clicks")
+		}
+		for _, earlier := range pending {
+			if isDuplicateClick(click, &earlier, since) {
+				duplicates++
+			}
+		}
+		if duplicates > 0 {
+			return constants.ClickFlagDuplicate
+		}
+	}
+
+	if click.IPAddress != "" {
+		since := click.ClickedAt.Add(-constants.ClickRateWindow)
+		recent, err := s.clickRepo.CountRecentByIP(click.IPAddress, since)
+		if err != nil {
+			utils.GetLogger().WithError(err).Warn("Failed to check the click rate")
+		}
+		for _, earlier := range pending {
+			if earlier.IPAddress == click.IPAddress && !earlier.ClickedAt.Before(since) {
+				recent++
+			}
+		}
+		if recent >= constants.ClickRateLimit {
+			return constants.ClickFlagRate
+		}
+	}
+	return ""
+}
+
+// isDuplicateClick reports whether an earlier click since the given time was on the same product by the same
+// user, or for anonymous clicks from the same IP address and user agent. It mirrors CountRecentDuplicates.
+func isDuplicateClick(click, earlier *models.ProductClick, since time.Time) bool {
+	if earlier.ProductID != click.ProductID || earlier.ClickedAt.Before(since) {
+		return false
+	}
+	if click.UserID != nil {
+		return earlier.UserID != nil && *earlier.UserID == *click.UserID
+	}
+	return earlier.UserID == nil && earlier.IPAddress == click.IPAddress && earlier.UserAgent == click.UserAgent
+}
+
+// doNotTrack reports whether a user opted out of tracking. When the user cannot be loaded the click is
+// recorded anonymously.
+func (s *trackingService) doNotTrack(userID uint64) bool {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		utils.GetLogger().WithError(err).Warn("Failed to get the tracking preference, recording the click anonymously")
+		return true
+	}
+	return user.DoNotTrack
+}
+
+// ApplyClickRetention anonymises or deletes, depending on the configured action, the clicks older than the
+// retention period and returns how many were processed. Nothing happens without a retention period.
+func (s *trackingService) ApplyClickRetention(now time.Time) (int64, error) {
+	if s.cfg.ClickRetentionDays <= 0 {
+		return 0, nil
+	}
+	cutoff := now.AddDate(0, 0, -s.cfg.ClickRetentionDays)
+	process := s.clickRepo.AnonymizeClicksBefore
+	if s.cfg.ClickRetentionAction == constants.ClickRetentionDelete {
+		process = s.clickRepo.DeleteClicksBefore
+	}
+
+	var total int64
+	for {
+		processed, err := process(cutoff, constants.ClickRetentionBatchSize)
+		total += processed
+		if err != nil {
+			return total, err
+		}
+		if processed < constants.ClickRetentionBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// GetRedirectURL determines the best redirect URL based on available platform links and returns it with
+// its channel. Links the link checker found broken are skipped. Brands may reorder the channels and
+// split traffic between weighted channels; without settings the default order below applies.
+func (s *trackingService) GetRedirectURL(product *models.Product, brand *models.Brand) (string, string) {
+	candidates := s.redirectCandidates(product, brand)
+	if len(candidates) == 0 {
+		// Last resort: FlickNFit homepage
+		return "https://flicknfit.com", constants.RedirectChannelFallback
 	}
 
-	return s.clickRepo.Create(click)
+	settings, err := s.channelRepo.GetChannels(brand.ID)
+	if err != nil {
+		utils.GetLogger().WithError(err).Warn("Failed to get redirect channels, using default order")
+		settings = nil
+	}
+	if len(settings) > 0 {
+		rank := make(map[string]int, len(settings))
+		for _, setting := range settings {
+			rank[setting.Channel] = setting.Position
+		}
+		position := func(channel string) int {
+			if p, ok := rank[channel]; ok {
+				return p
+			}
+			return len(settings) // Channels the brand did not list keep their default order at the end
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return position(candidates[i].channel) < position(candidates[j].channel)
+		})
+		if picked, ok := s.pickWeighted(candidates, settings); ok {
+			return picked.url, picked.channel
+		}
+	}
+	return candidates[0].url, candidates[0].channel
 }
 
-// GetRedirectURL determines the best redirect URL based on available platform links
-func (s *trackingService) GetRedirectURL(product *models.Product, brand *models.Brand) string {
+// redirectCandidate is a usable outbound link and the channel it belongs to
+type redirectCandidate struct {
+	channel string
+	url     string
+}
+
+// redirectCandidates lists the usable links of a product and its brand in the default order
+func (s *trackingService) redirectCandidates(product *models.Product, brand *models.Brand) []redirectCandidate {
+	usable := s.usableLink(product.ID, brand.ID)
+	var candidates []redirectCandidate
+	add := func(channel, url string) {
+		if usable(url) {
+			candidates = append(candidates, redirectCandidate{channel: channel, url: url})
+		}
+	}
+
 	// Priority 1: Product-specific WhatsApp (if template exists)
 	if product.WhatsAppTemplate != "" && brand.WhatsAppNumber != "" {
 		message := strings.ReplaceAll(product.WhatsAppTemplate, "{product_name}", product.Name)
 		message = strings.ReplaceAll(message, "{brand_name}", brand.Name)
-		return s.GenerateWhatsAppLink(brand.WhatsAppNumber, message)
+		add(constants.RedirectChannelWhatsApp, s.GenerateWhatsAppLink(brand.WhatsAppNumber, message))
 	}
 
 	// Priority 2: Product-specific Tokopedia/Shopee/Instagram
-	if product.TokopediaProductURL != "" {
-		return product.TokopediaProductURL
-	}
-	if product.ShopeeProductURL != "" {
-		return product.ShopeeProductURL
-	}
-	if product.InstagramProductURL != "" {
-		return product.InstagramProductURL
-	}
+	add(constants.RedirectChannelTokopedia, product.TokopediaProductURL)
+	add(constants.RedirectChannelShopee, product.ShopeeProductURL)
+	add(constants.RedirectChannelInstagram, product.InstagramProductURL)
 
 	// Priority 3: Brand-level WhatsApp (with default message)
 	if brand.WhatsAppNumber != "" {
@@ -87,55 +304,143 @@ func (s *trackingService) GetRedirectURL(product *models.Product, brand *models.
 			"Halo! Saya tertarik dengan produk %s dari FlickNFit. Apakah masih tersedia?",
 			product.Name,
 		)
-		return s.GenerateWhatsAppLink(brand.WhatsAppNumber, defaultMessage)
+		add(constants.RedirectChannelWhatsApp, s.GenerateWhatsAppLink(brand.WhatsAppNumber, defaultMessage))
 	}
 
 	// Priority 4: Brand-level Tokopedia/Shopee/Instagram
-	if brand.TokopediaURL != "" {
-		return brand.TokopediaURL
+	add(constants.RedirectChannelTokopedia, brand.TokopediaURL)
+	add(constants.RedirectChannelShopee, brand.ShopeeURL)
+	add(constants.RedirectChannelInstagram, brand.InstagramURL)
+
+	// Priority 5: Generic product URL, then the brand website
+	add(constants.RedirectChannelWebsite, product.BrandProductURL)
+	add(constants.RedirectChannelWebsite, brand.WebsiteURL)
+
+	return candidates
+}
+
+// pickWeighted chooses between the weighted channels that have a usable link, in proportion to their
+// weights. It reports false when no weighted channel can be used.
+func (s *trackingService) pickWeighted(candidates []redirectCandidate, settings []models.BrandRedirectChannel) (redirectCandidate, bool) {
+	best := make(map[string]redirectCandidate)
+	for _, candidate := range candidates {
+		if _, ok := best[candidate.channel]; !ok {
+			best[candidate.channel] = candidate
+		}
 	}
-	if brand.ShopeeURL != "" {
-		return brand.ShopeeURL
+
+	var pool []redirectCandidate
+	var weights []int
+	total := 0
+	for _, setting := range settings {
+		candidate, ok := best[setting.Channel]
+		if !ok || setting.Weight <= 0 {
+			continue
+		}
+		pool = append(pool, candidate)
+		weights = append(weights, setting.Weight)
+		total += setting.Weight
 	}
-	if brand.InstagramURL != "" {
-		return brand.InstagramURL
+	if total == 0 {
+		return redirectCandidate{}, false
+	}
+
+	n := rand.Intn(total)
+	for i, weight := range weights {
+		if n < weight {
+			return pool[i], true
+		}
+		n -= weight
 	}
+	return pool[len(pool)-1], true
+}
 
-	// Priority 5: Generic product URL
-	if product.BrandProductURL != "" {
-		return product.BrandProductURL
+// BuildOutboundURL adds UTM tags, the marketplace affiliate ID and the signed click ID to a redirect URL,
+// keeping any parameters the link already has. WhatsApp links get the click ID as a line appended to the
+// message instead, since the brand only sees the text. The FlickNFit homepage fallback is left untouched.
+func (s *trackingService) BuildOutboundURL(redirectURL, channel string, product *models.Product, brand *models.Brand, click *models.ProductClick) string {
+	if channel == constants.RedirectChannelFallback {
+		return redirectURL
 	}
 
-	// Fallback: Brand website
-	if brand.WebsiteURL != "" {
-		return brand.WebsiteURL
+	var clickID string
+	if click != nil && click.Ref != nil {
+		clickID = utils.SignClickRef(*click.Ref, s.cfg.ClickIDSecret)
+	} else if click != nil && click.ID != 0 {
+		clickID = utils.SignClickID(click.ID, s.cfg.ClickIDSecret)
+	}
+	if channel == constants.RedirectChannelWhatsApp {
+		if clickID == "" {
+			return redirectURL
+		}
+		return utils.AppendWhatsAppText(redirectURL, "Ref: "+clickID)
 	}
 
-	// Last resort: FlickNFit homepage
-	return "https://flicknfit.com"
+	params := s.linkParams(brand.ID)
+	values := url.Values{}
+	values.Set("utm_source", params.UTMSource)
+	values.Set("utm_medium", params.UTMMedium)
+	values.Set("utm_campaign", params.UTMCampaign)
+	values.Set("utm_content", strconv.FormatUint(product.ID, 10))
+	switch channel {
+	case constants.RedirectChannelTokopedia:
+		values.Set(constants.TokopediaAffiliateParam, params.TokopediaAffiliateID)
+	case constants.RedirectChannelShopee:
+		values.Set(constants.ShopeeAffiliateParam, params.ShopeeAffiliateID)
+	}
+	values.Set(constants.ClickIDParam, clickID)
+	return utils.AppendQueryParams(redirectURL, values)
 }
 
-// GenerateWhatsAppLink creates a WhatsApp deep link with pre-filled message
-func (s *trackingService) GenerateWhatsAppLink(phoneNumber, message string) string {
-	// Remove all non-numeric characters from phone number
-	cleanPhone := strings.Map(func(r rune) rune {
-		if r >= '0' && r <= '9' {
-			return r
+// linkParams merges a brand's link parameter overrides over the application defaults
+func (s *trackingService) linkParams(brandID uint64) models.BrandLinkParams {
+	params := models.BrandLinkParams{
+		BrandID:              brandID,
+		UTMSource:            s.cfg.UTMSource,
+		UTMMedium:            s.cfg.UTMMedium,
+		UTMCampaign:          s.cfg.UTMCampaign,
+		TokopediaAffiliateID: s.cfg.TokopediaAffiliateID,
+		ShopeeAffiliateID:    s.cfg.ShopeeAffiliateID,
+	}
+	overrides, err := s.channelRepo.GetLinkParams(brandID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.GetLogger().WithError(err).Warn("Failed to get brand link parameters, using defaults")
+		}
+		return params
+	}
+	override := func(value *string, brandValue string) {
+		if brandValue != "" {
+			*value = brandValue
 		}
-		return -1
-	}, phoneNumber)
-
-	// Ensure phone number starts with country code (62 for Indonesia)
-	if !strings.HasPrefix(cleanPhone, "62") {
-		// Remove leading 0 if exists
-		cleanPhone = strings.TrimPrefix(cleanPhone, "0")
-		cleanPhone = "62" + cleanPhone
 	}
+	override(&params.UTMSource, overrides.UTMSource)
+	override(&params.UTMMedium, overrides.UTMMedium)
+	override(&params.UTMCampaign, overrides.UTMCampaign)
+	override(&params.TokopediaAffiliateID, overrides.TokopediaAffiliateID)
+	override(&params.ShopeeAffiliateID, overrides.ShopeeAffiliateID)
+	return params
+}
 
-	// URL encode the message
-	encodedMessage := url.QueryEscape(message)
+// usableLink returns a check that accepts non-empty links not known to be broken. When the link checks
+// cannot be read every link is accepted, so redirects keep working.
+func (s *trackingService) usableLink(productID, brandID uint64) func(url string) bool {
+	urls, err := s.linkCheckRepo.GetBrokenURLs(productID, brandID)
+	if err != nil {
+		utils.GetLogger().WithError(err).Warn("Failed to get broken links, redirecting without link health")
+	}
+	broken := make(map[string]bool, len(urls))
+	for _, url := range urls {
+		broken[url] = true
+	}
+	return func(url string) bool {
+		return url != "" && !broken[url]
+	}
+}
 
-	return fmt.Sprintf("https://wa.me/%s?text=%s", cleanPhone, encodedMessage)
+// GenerateWhatsAppLink creates a WhatsApp deep link with pre-filled message
+func (s *trackingService) GenerateWhatsAppLink(phoneNumber, message string) string {
+	return utils.WhatsAppLink(phoneNumber, message)
 }
 
 // GetClickStats returns total clicks for a product
@@ -147,3 +452,12 @@ func (s *trackingService) GetClickStats(productID uint64) (int64, error) {
 func (s *trackingService) GetBrandClickStats(brandID uint64) (int64, error) {
 	return s.clickRepo.CountByBrandID(brandID)
 }
+
+// truncateRunes shortens s to at most max characters
+func truncateRunes(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
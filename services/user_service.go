@@ -392,6 +392,9 @@ func (s *userService) EditProfile(id uint64, dto *dtos.UserEditProfileRequestDTO
 	if dto.Region != "" {
 		user.Region = dto.Region
 	}
+	if dto.DoNotTrack != nil {
+		user.DoNotTrack = *dto.DoNotTrack
+	}
 
 	if err := s.userRepository.UpdateUser(user); err != nil {
 		return nil, errors.New("failed to update user")
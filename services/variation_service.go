@@ -0,0 +1,313 @@
+package services
+
+import (
+	stderrors "errors"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// VariationService defines business logic for managing product variations and their options.
+type VariationService interface {
+	GetAllVariations() ([]models.ProductVariation, error)
+	GetVariationByID(id uint64) (*models.ProductVariation, error)
+	CreateVariation(dto *dtos.VariationCreateRequestDTO) (*models.ProductVariation, error)
+	UpdateVariation(id uint64, dto *dtos.VariationUpdateRequestDTO) (*models.ProductVariation, error)
+	DeleteVariation(id uint64) error
+
+	CreateOption(variationID uint64, dto *dtos.VariationOptionCreateRequestDTO) (*models.ProductVariationOption, error)
+	UpdateOption(optionID uint64, dto *dtos.VariationOptionUpdateRequestDTO) (*models.ProductVariationOption, error)
+	DeleteOption(optionID uint64) error
+	ReorderOptions(variationID uint64, dto *dtos.VariationOptionOrderRequestDTO) (*models.ProductVariation, error)
+}
+
+// variationService implements VariationService interface
+type variationService struct {
+	variationRepo repositories.VariationRepository
+}
+
+// NewVariationService creates a new variation service
+func NewVariationService(variationRepo repositories.VariationRepository) VariationService {
+	return &variationService{
+		variationRepo: variationRepo,
+	}
+}
+
+// GetAllVariations retrieves all variations with ordered options
+func (s *variationService) GetAllVariations() ([]models.ProductVariation, error) {
+	variations, err := s.variationRepo.GetAllVariations()
+	if err != nil {
+		return nil, errors.NewDatabaseError("get variations", err)
+	}
+	return variations, nil
+}
+
+// GetVariationByID retrieves a variation with ordered options
+func (s *variationService) GetVariationByID(id uint64) (*models.ProductVariation, error) {
+	variation, err := s.variationRepo.GetVariationByID(id)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Variation")
+	}
+	return variation, nil
+}
+
+// CreateVariation creates a variation and its initial options
+func (s *variationService) CreateVariation(dto *dtos.VariationCreateRequestDTO) (*models.ProductVariation, error) {
+	name := strings.TrimSpace(dto.Name)
+	if err := s.ensureVariationNameFree(name); err != nil {
+		return nil, err
+	}
+
+	variation := &models.ProductVariation{
+		Name:    name,
+		IsColor: dto.IsColor,
+	}
+
+	seen := make(map[string]bool, len(dto.Options))
+	for i, optionDTO := range dto.Options {
+		option, err := buildVariationOption(variation, optionDTO)
+		if err != nil {
+			return nil, err
+		}
+		key := strings.ToLower(option.Value)
+		if seen[key] {
+			return nil, errors.NewValidationError(fmt.Sprintf("duplicate option %q", option.Value))
+		}
+		seen[key] = true
+		if option.SortOrder == 0 {
+			option.SortOrder = i + 1
+		}
+		variation.Options = append(variation.Options, *option)
+	}
+
+	if err := s.variationRepo.CreateVariation(variation); err != nil {
+		if stderrors.Is(err, repositories.ErrDuplicateVariation) {
+			return nil, errors.NewConflictError(fmt.Sprintf("Variation %q", name))
+		}
+		return nil, errors.NewDatabaseError("create variation", err)
+	}
+	return s.GetVariationByID(variation.ID)
+}
+
+// UpdateVariation updates a variation's name or colour flag
+func (s *variationService) UpdateVariation(id uint64, dto *dtos.VariationUpdateRequestDTO) (*models.ProductVariation, error) {
+	variation, err := s.variationRepo.GetVariationByID(id)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Variation")
+	}
+
+	if name := strings.TrimSpace(dto.Name); name != "" && !strings.EqualFold(name, variation.Name) {
+		if err := s.ensureVariationNameFree(name); err != nil {
+			return nil, err
+		}
+		variation.Name = name
+	}
+	if dto.IsColor != nil {
+		if *dto.IsColor {
+			// Semua opsi yang ada harus sudah memiliki kode warna sebelum variasi ditandai sebagai warna
+			for _, option := range variation.Options {
+				if option.HexValue == "" {
+					return nil, errors.NewValidationError(fmt.Sprintf("option %q has no hex value", option.Value))
+				}
+			}
+		}
+		variation.IsColor = *dto.IsColor
+	}
+
+	if err := s.variationRepo.UpdateVariation(variation); err != nil {
+		if stderrors.Is(err, repositories.ErrDuplicateVariation) {
+			return nil, errors.NewConflictError(fmt.Sprintf("Variation %q", variation.Name))
+		}
+		return nil, errors.NewDatabaseError("update variation", err)
+	}
+	return s.GetVariationByID(id)
+}
+
+// DeleteVariation deletes a variation and its options, refusing if any option is still used by product items
+func (s *variationService) DeleteVariation(id uint64) error {
+	variation, err := s.variationRepo.GetVariationByID(id)
+	if err != nil {
+		return errors.NewNotFoundError("Variation")
+	}
+
+	optionIDs := make([]uint64, 0, len(variation.Options))
+	for _, option := range variation.Options {
+		optionIDs = append(optionIDs, option.ID)
+	}
+	inUse, err := s.variationRepo.CountConfigurationsByOptionIDs(optionIDs)
+	if err != nil {
+		return errors.NewDatabaseError("check variation usage", err)
+	}
+	if inUse > 0 {
+		return errors.New(errors.ErrorTypeConflict, http.StatusConflict,
+			fmt.Sprintf("Variation is still used by %d product item configurations", inUse))
+	}
+
+	if err := s.variationRepo.DeleteVariation(id); err != nil {
+		return errors.NewDatabaseError("delete variation", err)
+	}
+	return nil
+}
+
+// CreateOption adds an option to a variation; new options are appended to the end of the order by default
+func (s *variationService) CreateOption(variationID uint64, dto *dtos.VariationOptionCreateRequestDTO) (*models.ProductVariationOption, error) {
+	variation, err := s.variationRepo.GetVariationByID(variationID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Variation")
+	}
+
+	option, err := buildVariationOption(variation, *dto)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureOptionValueFree(variationID, option.Value); err != nil {
+		return nil, err
+	}
+	if option.SortOrder == 0 {
+		option.SortOrder = len(variation.Options) + 1
+	}
+	option.ProductAttributeID = variationID
+
+	if err := s.variationRepo.CreateOption(option); err != nil {
+		if stderrors.Is(err, repositories.ErrDuplicateVariationOption) {
+			return nil, errors.NewConflictError(fmt.Sprintf("Option %q", option.Value))
+		}
+		return nil, errors.NewDatabaseError("create variation option", err)
+	}
+	return option, nil
+}
+
+// UpdateOption updates an option's value, hex value or sort order
+func (s *variationService) UpdateOption(optionID uint64, dto *dtos.VariationOptionUpdateRequestDTO) (*models.ProductVariationOption, error) {
+	option, err := s.variationRepo.GetOptionByID(optionID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Variation option")
+	}
+
+	if value := strings.TrimSpace(dto.Value); value != "" && !strings.EqualFold(value, option.Value) {
+		if err := s.ensureOptionValueFree(option.ProductAttributeID, value); err != nil {
+			return nil, err
+		}
+		option.Value = value
+	}
+	if dto.HexValue != "" {
+		option.HexValue = normalizeHexColor(dto.HexValue)
+	}
+	if dto.SortOrder != nil {
+		option.SortOrder = *dto.SortOrder
+	}
+
+	if err := s.variationRepo.UpdateOption(option); err != nil {
+		if stderrors.Is(err, repositories.ErrDuplicateVariationOption) {
+			return nil, errors.NewConflictError(fmt.Sprintf("Option %q", option.Value))
+		}
+		return nil, errors.NewDatabaseError("update variation option", err)
+	}
+	return option, nil
+}
+
+// DeleteOption deletes an option, refusing if it is still used by product item configurations
+func (s *variationService) DeleteOption(optionID uint64) error {
+	if _, err := s.variationRepo.GetOptionByID(optionID); err != nil {
+		return errors.NewNotFoundError("Variation option")
+	}
+
+	inUse, err := s.variationRepo.CountConfigurationsByOptionIDs([]uint64{optionID})
+	if err != nil {
+		return errors.NewDatabaseError("check option usage", err)
+	}
+	if inUse > 0 {
+		return errors.New(errors.ErrorTypeConflict, http.StatusConflict,
+			fmt.Sprintf("Option is still used by %d product item configurations", inUse))
+	}
+
+	if err := s.variationRepo.DeleteOption(optionID); err != nil {
+		return errors.NewDatabaseError("delete variation option", err)
+	}
+	return nil
+}
+
+// ReorderOptions sets the display order of a variation's options; every option must be listed exactly once
+func (s *variationService) ReorderOptions(variationID uint64, dto *dtos.VariationOptionOrderRequestDTO) (*models.ProductVariation, error) {
+	variation, err := s.variationRepo.GetVariationByID(variationID)
+	if err != nil {
+		return nil, errors.NewNotFoundError("Variation")
+	}
+
+	if len(dto.OptionIDs) != len(variation.Options) {
+		return nil, errors.NewValidationError("option_ids must list every option of the variation exactly once")
+	}
+	belongs := make(map[uint64]bool, len(variation.Options))
+	for _, option := range variation.Options {
+		belongs[option.ID] = true
+	}
+	for _, id := range dto.OptionIDs {
+		if !belongs[id] {
+			return nil, errors.NewValidationError(fmt.Sprintf("option %d does not belong to this variation or is listed twice", id))
+		}
+		delete(belongs, id)
+	}
+
+	if err := s.variationRepo.UpdateOptionOrder(variationID, dto.OptionIDs); err != nil {
+		return nil, errors.NewDatabaseError("reorder variation options", err)
+	}
+	return s.GetVariationByID(variationID)
+}
+
+// ensureVariationNameFree returns a conflict if another variation already uses the name
+func (s *variationService) ensureVariationNameFree(name string) error {
+	_, err := s.variationRepo.GetVariationByName(name)
+	if err == nil {
+		return errors.NewConflictError(fmt.Sprintf("Variation %q", name))
+	}
+	if !stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.NewDatabaseError("check variation name", err)
+	}
+	return nil
+}
+
+// ensureOptionValueFree returns a conflict if the variation already has an option with the value
+func (s *variationService) ensureOptionValueFree(variationID uint64, value string) error {
+	_, err := s.variationRepo.GetOptionByValue(variationID, value)
+	if err == nil {
+		return errors.NewConflictError(fmt.Sprintf("Option %q", value))
+	}
+	if !stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.NewDatabaseError("check variation option value", err)
+	}
+	return nil
+}
+
+// buildVariationOption validates an option DTO against its variation and returns the option model
+func buildVariationOption(variation *models.ProductVariation, dto dtos.VariationOptionCreateRequestDTO) (*models.ProductVariationOption, error) {
+	value := strings.TrimSpace(dto.Value)
+	if value == "" {
+		return nil, errors.NewValidationError("option value is required")
+	}
+	if variation.IsColor && dto.HexValue == "" {
+		return nil, errors.NewValidationError(fmt.Sprintf("colour option %q requires a hex value", value))
+	}
+	return &models.ProductVariationOption{
+		Value:     value,
+		HexValue:  normalizeHexColor(dto.HexValue),
+		SortOrder: dto.SortOrder,
+	}, nil
+}
+
+// normalizeHexColor converts #abc and #aabbcc into the upper-case #AABBCC form used by colour recommendations
+func normalizeHexColor(hex string) string {
+	hex = strings.TrimPrefix(strings.TrimSpace(hex), "#")
+	if hex == "" {
+		return ""
+	}
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	return "#" + strings.ToUpper(hex)
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"errors"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/utils"
+	"fmt"
+	"time"
+)
+
+// ItemSnapshot is the state of a product item before an update, used to detect
+// back-in-stock and price-drop changes.
+type ItemSnapshot struct {
+	Stock          int
+	EffectivePrice int
+}
+
+// WatchService defines business logic for back-in-stock and price-drop alerts on favorites and saved items.
+type WatchService interface {
+	SnapshotItems(products []*models.Product) (map[uint64]ItemSnapshot, error)
+	NotifyItemChanges(before map[uint64]ItemSnapshot, products []*models.Product) error
+	NotifyBackInStock(item *models.ProductItem, previousStock int) error
+	UpdateFavoriteWatch(userID, productItemID uint64, dto *dtos.WatchPreferencesRequestDTO) (*models.Favorite, error)
+	UpdateSavedItemWatch(userID, savedItemID uint64, dto *dtos.WatchPreferencesRequestDTO) (*models.SavedItemsList, error)
+}
+
+// watchService implements WatchService interface
+type watchService struct {
+	watchRepo           repositories.WatchRepository
+	campaignRepo        repositories.CampaignRepository
+	notificationService NotificationService
+	mailer              Mailer
+}
+
+// NewWatchService creates a new watch service
+func NewWatchService(watchRepo repositories.WatchRepository, campaignRepo repositories.CampaignRepository, notificationService NotificationService, mailer Mailer) WatchService {
+	return &watchService{
+		watchRepo:           watchRepo,
+		campaignRepo:        campaignRepo,
+		notificationService: notificationService,
+		mailer:              mailer,
+	}
+}
+
+// itemChange describes a watched change of one product item
+type itemChange struct {
+	product     *models.Product
+	item        *models.ProductItem
+	backInStock bool
+	priceDrop   bool
+	oldPrice    int
+	newPrice    int
+}
+
+// SnapshotItems records the stock and effective price of the existing items of the given products.
+// New items (without ID) are ignored since nobody can watch them yet.
+func (s *watchService) SnapshotItems(products []*models.Product) (map[uint64]ItemSnapshot, error) {
+	snapshot := make(map[uint64]ItemSnapshot)
+	existing := make([]*models.Product, 0, len(products))
+	for _, product := range products {
+		for _, item := range product.ProductItems {
+			if item.ID != 0 {
+				existing = append(existing, product)
+				break
+			}
+		}
+	}
+	if len(existing) == 0 {
+		return snapshot, nil
+	}
+
+	if err := s.applyEffectivePrices(existing); err != nil {
+		return nil, err
+	}
+	for _, product := range existing {
+		for _, item := range product.ProductItems {
+			if item.ID != 0 {
+				snapshot[item.ID] = ItemSnapshot{Stock: item.Stock, EffectivePrice: *item.EffectivePrice}
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+// NotifyItemChanges compares saved products against a snapshot taken before the update and alerts
+// watchers of items that came back in stock or whose effective price dropped.
+func (s *watchService) NotifyItemChanges(before map[uint64]ItemSnapshot, products []*models.Product) error {
+	if len(before) == 0 {
+		return nil
+	}
+	if err := s.applyEffectivePrices(products); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	changes := make([]itemChange, 0)
+	for _, product := range products {
+		if !product.IsPublished(now) {
+			continue
+		}
+		for i := range product.ProductItems {
+			item := &product.ProductItems[i]
+			old, ok := before[item.ID]
+			if !ok {
+				continue
+			}
+			change := itemChange{
+				product:     product,
+				item:        item,
+				backInStock: old.Stock <= 0 && item.Stock > 0,
+				priceDrop:   *item.EffectivePrice < old.EffectivePrice,
+				oldPrice:    old.EffectivePrice,
+				newPrice:    *item.EffectivePrice,
+			}
+			if change.backInStock || change.priceDrop {
+				changes = append(changes, change)
+			}
+		}
+	}
+	return s.notifyWatchers(changes)
+}
+
+// NotifyBackInStock alerts watchers when a stock adjustment brings an item back in stock.
+// The item must have its product preloaded.
+func (s *watchService) NotifyBackInStock(item *models.ProductItem, previousStock int) error {
+	if previousStock > 0 || item.Stock <= 0 || !item.Product.IsPublished(time.Now()) {
+		return nil
+	}
+	return s.notifyWatchers([]itemChange{{product: &item.Product, item: item, backInStock: true}})
+}
+
+// notifyWatchers sends de-duplicated in-app (and optional email) alerts for the given changes
+func (s *watchService) notifyWatchers(changes []itemChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	itemIDs := make([]uint64, len(changes))
+	for i, change := range changes {
+		itemIDs[i] = change.item.ID
+	}
+	watchers, err := s.watchRepo.GetItemWatchers(itemIDs)
+	if err != nil {
+		return apperrors.NewDatabaseError("get item watchers", err)
+	}
+	watchersByItem := make(map[uint64][]models.ItemWatcher)
+	for _, watcher := range watchers {
+		watchersByItem[watcher.ProductItemID] = append(watchersByItem[watcher.ProductItemID], watcher)
+	}
+
+	for _, change := range changes {
+		productID, itemID := change.product.ID, change.item.ID
+		if change.backInStock {
+			notification := models.Notification{
+				Type:          constants.NotificationTypeBackInStock,
+				Title:         fmt.Sprintf("%s is back in stock", change.product.Name),
+				Message:       fmt.Sprintf("SKU %s is available again (%d in stock).", change.item.SKU, change.item.Stock),
+				ProductID:     &productID,
+				ProductItemID: &itemID,
+				DedupKey:      fmt.Sprintf("%s:%d", constants.NotificationTypeBackInStock, itemID),
+			}
+			if err := s.deliver(watchersByItem[itemID], notification, func(w models.ItemWatcher) bool {
+				return w.NotifyBackInStock
+			}); err != nil {
+				return err
+			}
+		}
+		if change.priceDrop {
+			notification := models.Notification{
+				Type:          constants.NotificationTypePriceDrop,
+				Title:         fmt.Sprintf("Price drop on %s", change.product.Name),
+				Message:       fmt.Sprintf("SKU %s dropped from Rp%d to Rp%d.", change.item.SKU, change.oldPrice, change.newPrice),
+				ProductID:     &productID,
+				ProductItemID: &itemID,
+				DedupKey:      fmt.Sprintf("%s:%d", constants.NotificationTypePriceDrop, itemID),
+			}
+			newPrice := change.newPrice
+			if err := s.deliver(watchersByItem[itemID], notification, func(w models.ItemWatcher) bool {
+				return w.NotifyPriceDrop && (w.TargetPrice == nil || newPrice <= *w.TargetPrice)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deliver notifies the watchers accepted by the filter, at most once per cooldown window,
+// and emails those who asked for email alerts.
+func (s *watchService) deliver(watchers []models.ItemWatcher, notification models.Notification, accept func(models.ItemWatcher) bool) error {
+	userIDs := make([]uint64, 0, len(watchers))
+	emails := make(map[uint64]string)
+	for _, watcher := range watchers {
+		if !accept(watcher) {
+			continue
+		}
+		// A user may watch the same item as a favorite and as a saved item; NotifyOnce de-duplicates them
+		userIDs = append(userIDs, watcher.UserID)
+		if watcher.NotifyByEmail && watcher.Email != "" {
+			emails[watcher.UserID] = watcher.Email
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	notified, err := s.notificationService.NotifyOnce(userIDs, notification, constants.WatchNotificationCooldown)
+	if err != nil {
+		return err
+	}
+
+	recipients := make([]string, 0, len(emails))
+	for _, userID := range notified {
+		if email, ok := emails[userID]; ok {
+			recipients = append(recipients, email)
+		}
+	}
+	if len(recipients) > 0 {
+		go s.sendEmails(recipients, notification.Title, notification.Message)
+	}
+	return nil
+}
+
+// sendEmails emails an alert to each recipient individually
+func (s *watchService) sendEmails(recipients []string, subject, body string) {
+	for _, to := range recipients {
+		if err := s.mailer.Send(to, subject, body); err != nil {
+			if errors.Is(err, ErrMailerNotConfigured) {
+				return
+			}
+			utils.GetLogger().Warn("Failed to send watch alert email: ", err)
+		}
+	}
+}
+
+// applyEffectivePrices computes effective item prices with the currently running campaigns
+func (s *watchService) applyEffectivePrices(products []*models.Product) error {
+	now := time.Now()
+	campaigns, err := s.campaignRepo.GetActiveCampaigns(now)
+	if err != nil {
+		return apperrors.NewDatabaseError("get active campaigns", err)
+	}
+	applyEffectivePrices(products, campaigns, now)
+	return nil
+}
+
+// UpdateFavoriteWatch replaces the alert settings of the user's favorite for a product item
+func (s *watchService) UpdateFavoriteWatch(userID, productItemID uint64, dto *dtos.WatchPreferencesRequestDTO) (*models.Favorite, error) {
+	favorite, err := s.watchRepo.GetUserFavoriteByItemID(userID, productItemID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Favorite")
+	}
+	favorite.WatchPreferences = watchPreferencesFromDTO(dto)
+	if err := s.watchRepo.UpdateFavoriteWatch(favorite); err != nil {
+		return nil, apperrors.NewDatabaseError("update favorite watch", err)
+	}
+	return favorite, nil
+}
+
+// UpdateSavedItemWatch replaces the alert settings of one of the user's saved items
+func (s *watchService) UpdateSavedItemWatch(userID, savedItemID uint64, dto *dtos.WatchPreferencesRequestDTO) (*models.SavedItemsList, error) {
+	item, err := s.watchRepo.GetUserSavedItem(userID, savedItemID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("Saved item")
+	}
+	item.WatchPreferences = watchPreferencesFromDTO(dto)
+	if err := s.watchRepo.UpdateSavedItemWatch(item); err != nil {
+		return nil, apperrors.NewDatabaseError("update saved item watch", err)
+	}
+	return item, nil
+}
+
+// watchPreferencesFromDTO converts a watch request into WatchPreferences
+func watchPreferencesFromDTO(dto *dtos.WatchPreferencesRequestDTO) models.WatchPreferences {
+	return models.WatchPreferences{
+		NotifyBackInStock: dto.NotifyBackInStock,
+		NotifyPriceDrop:   dto.NotifyPriceDrop,
+		NotifyByEmail:     dto.NotifyByEmail,
+		TargetPrice:       dto.TargetPrice,
+	}
+}
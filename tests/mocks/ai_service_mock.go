@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+	"flicknfit_backend/dtos"
+	"io"
+	"mime/multipart"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAIService is a mock implementation of AIService. The file is read and passed to the expectations as
+// its content.
+type MockAIService struct {
+	mock.Mock
+}
+
+func (m *MockAIService) PredictSkinColorTone(ctx context.Context, file multipart.File, filename string) (*dtos.SkinColorTonePredictionResponseDTO, error) {
+	content, _ := io.ReadAll(file)
+	args := m.Called(string(content), filename)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dtos.SkinColorTonePredictionResponseDTO), args.Error(1)
+}
+
+func (m *MockAIService) PredictWomanBodyScan(ctx context.Context, file multipart.File, filename string) (*dtos.WomanBodyScanPredictionResponseDTO, error) {
+	content, _ := io.ReadAll(file)
+	args := m.Called(string(content), filename)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dtos.WomanBodyScanPredictionResponseDTO), args.Error(1)
+}
+
+func (m *MockAIService) PredictMenBodyScan(ctx context.Context, file multipart.File, filename string) (*dtos.MenBodyScanPredictionResponseDTO, error) {
+	content, _ := io.ReadAll(file)
+	args := m.Called(string(content), filename)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dtos.MenBodyScanPredictionResponseDTO), args.Error(1)
+}
@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAnalyticsRepository is a mock implementation of AnalyticsRepository
+type MockAnalyticsRepository struct {
+	mock.Mock
+}
+
+func (m *MockAnalyticsRepository) LogAIRequest(log *models.AIRequestLog) error {
+	args := m.Called(log)
+	return args.Error(0)
+}
+
+func (m *MockAnalyticsRepository) GetMetricTotal(metric string, from, to time.Time) (float64, error) {
+	args := m.Called(metric, from, to)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) GetDailySeries(metric string, from, to time.Time) ([]models.DailyValue, error) {
+	args := m.Called(metric, from, to)
+	return args.Get(0).([]models.DailyValue), args.Error(1)
+}
+
+func (m *MockAnalyticsRepository) GetRevenueByCategory(from, to time.Time) ([]models.CategoryRevenue, error) {
+	args := m.Called(from, to)
+	return args.Get(0).([]models.CategoryRevenue), args.Error(1)
+}
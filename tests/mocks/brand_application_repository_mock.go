@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBrandApplicationRepository is a mock implementation of BrandApplicationRepository
+type MockBrandApplicationRepository struct {
+	mock.Mock
+}
+
+func (m *MockBrandApplicationRepository) CreateApplication(application *models.BrandApplication) error {
+	args := m.Called(application)
+	return args.Error(0)
+}
+
+func (m *MockBrandApplicationRepository) GetApplicationByID(id uint64) (*models.BrandApplication, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BrandApplication), args.Error(1)
+}
+
+func (m *MockBrandApplicationRepository) GetApplications(status string, limit, offset int) ([]models.BrandApplication, int64, error) {
+	args := m.Called(status, limit, offset)
+	return args.Get(0).([]models.BrandApplication), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockBrandApplicationRepository) GetApplicationsByUserID(userID uint64) ([]models.BrandApplication, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]models.BrandApplication), args.Error(1)
+}
+
+func (m *MockBrandApplicationRepository) HasPendingApplication(userID uint64, brandID *uint64, name string) (bool, error) {
+	args := m.Called(userID, brandID, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBrandApplicationRepository) ApproveApplication(application *models.BrandApplication, brand *models.Brand) error {
+	args := m.Called(application, brand)
+	return args.Error(0)
+}
+
+func (m *MockBrandApplicationRepository) UpdateApplication(application *models.BrandApplication) error {
+	args := m.Called(application)
+	return args.Error(0)
+}
@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBrandMemberRepository is a mock implementation of BrandMemberRepository
+type MockBrandMemberRepository struct {
+	mock.Mock
+}
+
+func (m *MockBrandMemberRepository) GetMembersByBrandID(brandID uint64) ([]models.BrandMember, error) {
+	args := m.Called(brandID)
+	return args.Get(0).([]models.BrandMember), args.Error(1)
+}
+
+func (m *MockBrandMemberRepository) GetMember(brandID, userID uint64) (*models.BrandMember, error) {
+	args := m.Called(brandID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BrandMember), args.Error(1)
+}
+
+func (m *MockBrandMemberRepository) AddMember(member *models.BrandMember) error {
+	args := m.Called(member)
+	return args.Error(0)
+}
+
+func (m *MockBrandMemberRepository) RemoveMember(brandID, userID uint64) error {
+	args := m.Called(brandID, userID)
+	return args.Error(0)
+}
+
+func (m *MockBrandMemberRepository) GetMemberUserIDs(brandID uint64) ([]uint64, error) {
+	args := m.Called(brandID)
+	return args.Get(0).([]uint64), args.Error(1)
+}
@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBrandReportRepository is a mock implementation of BrandReportRepository
+type MockBrandReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockBrandReportRepository) GetReviewSummary(scope repositories.ClickScope) (*models.ReviewSummary, error) {
+	args := m.Called(scope)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReviewSummary), args.Error(1)
+}
+
+func (m *MockBrandReportRepository) GetRatingCounts(scope repositories.ClickScope) ([]models.RatingCount, error) {
+	args := m.Called(scope)
+	return args.Get(0).([]models.RatingCount), args.Error(1)
+}
+
+func (m *MockBrandReportRepository) HasDelivery(brandID uint64, period string) (bool, error) {
+	args := m.Called(brandID, period)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBrandReportRepository) RecordDelivery(delivery *models.BrandReportDelivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
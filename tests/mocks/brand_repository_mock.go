@@ -0,0 +1,70 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBrandRepository is a mock implementation of BrandRepository
+type MockBrandRepository struct {
+	mock.Mock
+}
+
+func (m *MockBrandRepository) CreateBrand(brand *models.Brand) error {
+	args := m.Called(brand)
+	return args.Error(0)
+}
+
+func (m *MockBrandRepository) GetAllBrands() ([]models.Brand, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Brand), args.Error(1)
+}
+
+func (m *MockBrandRepository) GetBrandByID(id uint64) (*models.Brand, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Brand), args.Error(1)
+}
+
+func (m *MockBrandRepository) UpdateBrand(brand *models.Brand) error {
+	args := m.Called(brand)
+	return args.Error(0)
+}
+
+func (m *MockBrandRepository) DeleteBrand(brand *models.Brand) error {
+	args := m.Called(brand)
+	return args.Error(0)
+}
+
+func (m *MockBrandRepository) SearchBrands(search, sort string, limit, offset int) ([]models.Brand, int64, error) {
+	args := m.Called(search, sort, limit, offset)
+	return args.Get(0).([]models.Brand), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockBrandRepository) GetBrandCategories(brandID uint64) ([]string, error) {
+	args := m.Called(brandID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockBrandRepository) FollowBrand(brandID, userID uint64) (uint, error) {
+	args := m.Called(brandID, userID)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockBrandRepository) UnfollowBrand(brandID, userID uint64) (uint, error) {
+	args := m.Called(brandID, userID)
+	return args.Get(0).(uint), args.Error(1)
+}
+
+func (m *MockBrandRepository) IsFollowingBrand(brandID, userID uint64) (bool, error) {
+	args := m.Called(brandID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBrandRepository) GetFollowedBrands(userID uint64) ([]models.Brand, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]models.Brand), args.Error(1)
+}
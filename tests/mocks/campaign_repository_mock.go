@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCampaignRepository is a mock implementation of CampaignRepository
+type MockCampaignRepository struct {
+	mock.Mock
+}
+
+func (m *MockCampaignRepository) GetAllCampaigns() ([]models.Campaign, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Campaign), args.Error(1)
+}
+
+func (m *MockCampaignRepository) GetCampaignByID(id uint64) (*models.Campaign, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Campaign), args.Error(1)
+}
+
+func (m *MockCampaignRepository) GetActiveCampaigns(now time.Time) ([]models.Campaign, error) {
+	args := m.Called(now)
+	return args.Get(0).([]models.Campaign), args.Error(1)
+}
+
+func (m *MockCampaignRepository) CreateCampaign(campaign *models.Campaign) error {
+	args := m.Called(campaign)
+	return args.Error(0)
+}
+
+func (m *MockCampaignRepository) UpdateCampaign(campaign *models.Campaign) error {
+	args := m.Called(campaign)
+	return args.Error(0)
+}
+
+func (m *MockCampaignRepository) DeleteCampaign(id uint64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,71 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockClickAnalyticsRepository is a mock implementation of ClickAnalyticsRepository
+type MockClickAnalyticsRepository struct {
+	mock.Mock
+}
+
+func (m *MockClickAnalyticsRepository) GetClickTotals(scope repositories.ClickScope) (*models.ClickTotals, error) {
+	args := m.Called(scope)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ClickTotals), args.Error(1)
+}
+
+func (m *MockClickAnalyticsRepository) GetDailyClicks(scope repositories.ClickScope) ([]models.DailyClicks, error) {
+	args := m.Called(scope)
+	return args.Get(0).([]models.DailyClicks), args.Error(1)
+}
+
+func (m *MockClickAnalyticsRepository) GetDailyClickUsers(scope repositories.ClickScope) ([]models.DailyUser, error) {
+	args := m.Called(scope)
+	return args.Get(0).([]models.DailyUser), args.Error(1)
+}
+
+func (m *MockClickAnalyticsRepository) GetClicksByProduct(scope repositories.ClickScope, limit int) ([]models.ProductClickCount, error) {
+	args := m.Called(scope, limit)
+	return args.Get(0).([]models.ProductClickCount), args.Error(1)
+}
+
+func (m *MockClickAnalyticsRepository) GetClicksByCategory(scope repositories.ClickScope) ([]models.ClickCount, error) {
+	args := m.Called(scope)
+	return args.Get(0).([]models.ClickCount), args.Error(1)
+}
+
+func (m *MockClickAnalyticsRepository) GetClicksByUserAgent(scope repositories.ClickScope) ([]models.ClickCount, error) {
+	args := m.Called(scope)
+	return args.Get(0).([]models.ClickCount), args.Error(1)
+}
+
+func (m *MockClickAnalyticsRepository) GetClicksByRegion(scope repositories.ClickScope) ([]models.ClickCount, error) {
+	args := m.Called(scope)
+	return args.Get(0).([]models.ClickCount), args.Error(1)
+}
+
+func (m *MockClickAnalyticsRepository) GetClicksByChannel(scope repositories.ClickScope) ([]models.ClickCount, error) {
+	args := m.Called(scope)
+	return args.Get(0).([]models.ClickCount), args.Error(1)
+}
+
+func (m *MockClickAnalyticsRepository) GetSuspiciousClicks(scope repositories.ClickScope) ([]models.ClickCount, error) {
+	args := m.Called(scope)
+	return args.Get(0).([]models.ClickCount), args.Error(1)
+}
+
+func (m *MockClickAnalyticsRepository) CountViews(scope repositories.ClickScope) (int64, error) {
+	args := m.Called(scope)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockClickAnalyticsRepository) CountFavorites(scope repositories.ClickScope) (int64, error) {
+	args := m.Called(scope)
+	return args.Get(0).(int64), args.Error(1)
+}
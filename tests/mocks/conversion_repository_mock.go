@@ -0,0 +1,56 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockConversionRepository is a mock implementation of ConversionRepository
+type MockConversionRepository struct {
+	mock.Mock
+}
+
+func (m *MockConversionRepository) RecordConversion(conversion *models.Conversion) error {
+	args := m.Called(conversion)
+	return args.Error(0)
+}
+
+func (m *MockConversionRepository) GetConversionsByBrandID(brandID uint64, limit int) ([]models.Conversion, error) {
+	args := m.Called(brandID, limit)
+	return args.Get(0).([]models.Conversion), args.Error(1)
+}
+
+func (m *MockConversionRepository) ReconcileCounters() (*models.CounterReconciliation, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CounterReconciliation), args.Error(1)
+}
+
+func (m *MockConversionRepository) GetPostbackSecret(brandID uint64) (string, error) {
+	args := m.Called(brandID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockConversionRepository) SetPostbackSecret(brandID uint64, secret string) error {
+	args := m.Called(brandID, secret)
+	return args.Error(0)
+}
+
+func (m *MockConversionRepository) GetPostback(brandID uint64, idempotencyKey string) (*models.ConversionPostback, error) {
+	args := m.Called(brandID, idempotencyKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ConversionPostback), args.Error(1)
+}
+
+func (m *MockConversionRepository) RecordPostback(postback *models.ConversionPostback, conversions []*models.Conversion) ([]*models.Conversion, error) {
+	args := m.Called(postback, conversions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Conversion), args.Error(1)
+}
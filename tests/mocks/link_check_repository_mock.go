@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLinkCheckRepository is a mock implementation of LinkCheckRepository
+type MockLinkCheckRepository struct {
+	mock.Mock
+}
+
+func (m *MockLinkCheckRepository) GetLinkTargets() ([]models.LinkCheck, error) {
+	args := m.Called()
+	return args.Get(0).([]models.LinkCheck), args.Error(1)
+}
+
+func (m *MockLinkCheckRepository) GetLinkChecks() ([]models.LinkCheck, error) {
+	args := m.Called()
+	return args.Get(0).([]models.LinkCheck), args.Error(1)
+}
+
+func (m *MockLinkCheckRepository) SaveLinkChecks(checks []models.LinkCheck, runStartedAt time.Time) error {
+	args := m.Called(checks, runStartedAt)
+	return args.Error(0)
+}
+
+func (m *MockLinkCheckRepository) GetBrokenURLs(productID, brandID uint64) ([]string, error) {
+	args := m.Called(productID, brandID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockLinkCheckRepository) GetBrokenLinks(limit, offset int) ([]models.LinkCheck, int64, error) {
+	args := m.Called(limit, offset)
+	return args.Get(0).([]models.LinkCheck), args.Get(1).(int64), args.Error(2)
+}
@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockNotificationRepository is a mock implementation of NotificationRepository
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) CreateNotifications(notifications []models.Notification) error {
+	args := m.Called(notifications)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) GetNotificationsByUserID(userID uint64, unreadOnly bool, limit, offset int) ([]models.Notification, int64, error) {
+	args := m.Called(userID, unreadOnly, limit, offset)
+	return args.Get(0).([]models.Notification), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockNotificationRepository) CountUnread(userID uint64) (int64, error) {
+	args := m.Called(userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkAsRead(userID, notificationID uint64, readAt time.Time) error {
+	args := m.Called(userID, notificationID, readAt)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) MarkAllAsRead(userID uint64, readAt time.Time) error {
+	args := m.Called(userID, readAt)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepository) GetNotifiedUserIDs(userIDs []uint64, dedupKey string, since time.Time) ([]uint64, error) {
+	args := m.Called(userIDs, dedupKey, since)
+	return args.Get(0).([]uint64), args.Error(1)
+}
@@ -0,0 +1,100 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProductClickRepository is a mock implementation of ProductClickRepository
+type MockProductClickRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductClickRepository) Create(click *models.ProductClick) error {
+	args := m.Called(click)
+	return args.Error(0)
+}
+
+// CreateBatch records a copy of the batch, which the caller may reuse.
+func (m *MockProductClickRepository) CreateBatch(clicks []models.ProductClick) error {
+	args := m.Called(append([]models.ProductClick(nil), clicks...))
+	return args.Error(0)
+}
+
+func (m *MockProductClickRepository) GetByRef(ref string) (*models.ProductClick, error) {
+	args := m.Called(ref)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductClick), args.Error(1)
+}
+
+func (m *MockProductClickRepository) GetByID(id uint64) (*models.ProductClick, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductClick), args.Error(1)
+}
+
+func (m *MockProductClickRepository) GetByUserID(userID uint64, limit int) ([]models.ProductClick, error) {
+	args := m.Called(userID, limit)
+	return args.Get(0).([]models.ProductClick), args.Error(1)
+}
+
+func (m *MockProductClickRepository) GetByProductID(productID uint64, limit int) ([]models.ProductClick, error) {
+	args := m.Called(productID, limit)
+	return args.Get(0).([]models.ProductClick), args.Error(1)
+}
+
+func (m *MockProductClickRepository) GetByBrandID(brandID uint64, limit int) ([]models.ProductClick, error) {
+	args := m.Called(brandID, limit)
+	return args.Get(0).([]models.ProductClick), args.Error(1)
+}
+
+func (m *MockProductClickRepository) CountByProductID(productID uint64) (int64, error) {
+	args := m.Called(productID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductClickRepository) CountByBrandID(brandID uint64) (int64, error) {
+	args := m.Called(brandID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductClickRepository) CountByBrandIDAndDateRange(brandID uint64, startDate, endDate time.Time) (int64, error) {
+	args := m.Called(brandID, startDate, endDate)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductClickRepository) GetTopClickedProducts(limit int) ([]map[string]interface{}, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]map[string]interface{}), args.Error(1)
+}
+
+func (m *MockProductClickRepository) GetClickStatsByBrand(brandID uint64, startDate, endDate time.Time) ([]map[string]interface{}, error) {
+	args := m.Called(brandID, startDate, endDate)
+	return args.Get(0).([]map[string]interface{}), args.Error(1)
+}
+
+func (m *MockProductClickRepository) CountRecentDuplicates(click *models.ProductClick, since time.Time) (int64, error) {
+	args := m.Called(click, since)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductClickRepository) CountRecentByIP(ipAddress string, since time.Time) (int64, error) {
+	args := m.Called(ipAddress, since)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductClickRepository) AnonymizeClicksBefore(cutoff time.Time, limit int) (int64, error) {
+	args := m.Called(cutoff, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductClickRepository) DeleteClicksBefore(cutoff time.Time, limit int) (int64, error) {
+	args := m.Called(cutoff, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
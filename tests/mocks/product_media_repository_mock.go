@@ -0,0 +1,79 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+	"mime/multipart"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProductMediaRepository is a mock implementation of ProductMediaRepository
+type MockProductMediaRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductMediaRepository) GetMediaByProductID(productID uint64) ([]models.ProductMedia, error) {
+	args := m.Called(productID)
+	return args.Get(0).([]models.ProductMedia), args.Error(1)
+}
+
+func (m *MockProductMediaRepository) GetMediaByID(id uint64) (*models.ProductMedia, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductMedia), args.Error(1)
+}
+
+func (m *MockProductMediaRepository) CreateMedia(media *models.ProductMedia) error {
+	args := m.Called(media)
+	return args.Error(0)
+}
+
+func (m *MockProductMediaRepository) UpdateMedia(media *models.ProductMedia) error {
+	args := m.Called(media)
+	return args.Error(0)
+}
+
+func (m *MockProductMediaRepository) DeleteMedia(id uint64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockProductMediaRepository) UpdateMediaOrder(productID uint64, orderedMediaIDs []uint64) error {
+	args := m.Called(productID, orderedMediaIDs)
+	return args.Error(0)
+}
+
+// MockStorageService is a mock implementation of SupabaseStorageService
+type MockStorageService struct {
+	mock.Mock
+}
+
+func (m *MockStorageService) UploadScanImage(userID uint64, file multipart.File, filename, scanType string) (string, error) {
+	args := m.Called(userID, file, filename, scanType)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockStorageService) UploadPublicFile(filePath string, data []byte, contentType string) (string, error) {
+	args := m.Called(filePath, data, contentType)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockStorageService) GetSignedURL(filePath string, expiresIn int) (string, error) {
+	args := m.Called(filePath, expiresIn)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockStorageService) DeleteFile(filePath string) error {
+	args := m.Called(filePath)
+	return args.Error(0)
+}
+
+func (m *MockStorageService) DownloadAndDecryptFile(filePath string) ([]byte, error) {
+	args := m.Called(filePath)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
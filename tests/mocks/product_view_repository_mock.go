@@ -0,0 +1,23 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockProductViewRepository is a mock implementation of ProductViewRepository
+type MockProductViewRepository struct {
+	mock.Mock
+}
+
+func (m *MockProductViewRepository) RecordViews(views []models.ProductView) error {
+	// Copy the batch; the recorder reuses its buffer after the call
+	args := m.Called(append([]models.ProductView(nil), views...))
+	return args.Error(0)
+}
+
+func (m *MockProductViewRepository) GetRecentViews(userID uint64, limit int) ([]models.RecentView, error) {
+	args := m.Called(userID, limit)
+	return args.Get(0).([]models.RecentView), args.Error(1)
+}
@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRedirectChannelRepository is a mock implementation of RedirectChannelRepository
+type MockRedirectChannelRepository struct {
+	mock.Mock
+}
+
+func (m *MockRedirectChannelRepository) GetChannels(brandID uint64) ([]models.BrandRedirectChannel, error) {
+	args := m.Called(brandID)
+	return args.Get(0).([]models.BrandRedirectChannel), args.Error(1)
+}
+
+func (m *MockRedirectChannelRepository) ReplaceChannels(brandID uint64, channels []models.BrandRedirectChannel) error {
+	args := m.Called(brandID, channels)
+	return args.Error(0)
+}
+
+func (m *MockRedirectChannelRepository) GetChannelReport(brandID uint64, since time.Time) ([]models.ChannelStat, error) {
+	args := m.Called(brandID, since)
+	return args.Get(0).([]models.ChannelStat), args.Error(1)
+}
+
+func (m *MockRedirectChannelRepository) GetLinkParams(brandID uint64) (*models.BrandLinkParams, error) {
+	args := m.Called(brandID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BrandLinkParams), args.Error(1)
+}
+
+func (m *MockRedirectChannelRepository) SaveLinkParams(params *models.BrandLinkParams) error {
+	args := m.Called(params)
+	return args.Error(0)
+}
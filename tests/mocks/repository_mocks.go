@@ -3,6 +3,7 @@ package mocks
 import (
 	"flicknfit_backend/dtos"
 	"flicknfit_backend/models"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -73,11 +74,26 @@ func (m *MockProductRepository) DeleteProduct(id uint64) error {
 	return args.Error(0)
 }
 
-func (m *MockProductRepository) GetAllProducts() ([]*models.Product, error) {
-	args := m.Called()
+func (m *MockProductRepository) GetAllProducts(status string) ([]*models.Product, error) {
+	args := m.Called(status)
 	return args.Get(0).([]*models.Product), args.Error(1)
 }
 
+func (m *MockProductRepository) UpdateProductStatus(product *models.Product) error {
+	args := m.Called(product)
+	return args.Error(0)
+}
+
+func (m *MockProductRepository) PublishDueProducts(now time.Time) (int64, error) {
+	args := m.Called(now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockProductRepository) GetPriceHistory(productID uint64, since time.Time) ([]models.PriceHistory, error) {
+	args := m.Called(productID, since)
+	return args.Get(0).([]models.PriceHistory), args.Error(1)
+}
+
 func (m *MockProductRepository) CreateReview(review *models.Review) error {
 	args := m.Called(review)
 	return args.Error(0)
@@ -114,11 +130,32 @@ func (m *MockProductRepository) GetProductPublicByID(id uint64) (*models.Product
 	return args.Get(0).(*models.Product), args.Error(1)
 }
 
+func (m *MockProductRepository) GetPublishedProductByID(id uint64) (*models.Product, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) GetPublishedProductItemByID(id uint64) (*models.ProductItem, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductItem), args.Error(1)
+}
+
 func (m *MockProductRepository) GetAllProductsPublic() ([]*models.Product, error) {
 	args := m.Called()
 	return args.Get(0).([]*models.Product), args.Error(1)
 }
 
+func (m *MockProductRepository) GetProductsPublicByIDs(ids []uint64) ([]*models.Product, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
 func (m *MockProductRepository) SearchProducts(query string) ([]*models.Product, error) {
 	args := m.Called(query)
 	return args.Get(0).([]*models.Product), args.Error(1)
@@ -128,3 +165,50 @@ func (m *MockProductRepository) GetAllProductsPublicWithFilter(filter *dtos.Prod
 	args := m.Called(filter)
 	return args.Get(0).([]*models.Product), args.Error(1)
 }
+
+func (m *MockProductRepository) GetProductDetailsByID(id uint64) (*models.Product, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) SaveProductWithDetails(product *models.Product, actorID *uint64) ([]models.StockMovement, error) {
+	args := m.Called(product, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StockMovement), args.Error(1)
+}
+
+func (m *MockProductRepository) GetProductItemsBySKUs(skus []string) ([]models.ProductItem, error) {
+	args := m.Called(skus)
+	return args.Get(0).([]models.ProductItem), args.Error(1)
+}
+
+func (m *MockProductRepository) GetVariationOptionsByIDs(ids []uint64) ([]models.ProductVariationOption, error) {
+	args := m.Called(ids)
+	return args.Get(0).([]models.ProductVariationOption), args.Error(1)
+}
+
+func (m *MockProductRepository) GetAllProductDetails() ([]*models.Product, error) {
+	args := m.Called()
+	return args.Get(0).([]*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) GetProductByBrandAndName(brandID uint64, name string) (*models.Product, error) {
+	args := m.Called(brandID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductRepository) SaveProductsWithDetails(products []*models.Product, actorID *uint64) ([]models.StockMovement, error) {
+	args := m.Called(products, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StockMovement), args.Error(1)
+}
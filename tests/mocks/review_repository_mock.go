@@ -56,3 +56,11 @@ func (m *MockReviewRepository) HasUserReviewedProduct(userID, productID uint64)
 	args := m.Called(userID, productID)
 	return args.Bool(0), args.Error(1)
 }
+
+func (m *MockReviewRepository) RecalculateAllRatings(dryRun bool) ([]models.RatingDrift, error) {
+	args := m.Called(dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.RatingDrift), args.Error(1)
+}
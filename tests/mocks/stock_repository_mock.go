@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStockRepository is a mock implementation of StockRepository
+type MockStockRepository struct {
+	mock.Mock
+}
+
+func (m *MockStockRepository) AdjustStock(itemID uint64, delta int, movement *models.StockMovement) (*models.ProductItem, error) {
+	args := m.Called(itemID, delta, movement)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductItem), args.Error(1)
+}
+
+func (m *MockStockRepository) GetMovementsByItemID(itemID uint64, limit int) ([]models.StockMovement, error) {
+	args := m.Called(itemID, limit)
+	return args.Get(0).([]models.StockMovement), args.Error(1)
+}
+
+func (m *MockStockRepository) SetLowStockThreshold(itemID uint64, threshold *int) error {
+	args := m.Called(itemID, threshold)
+	return args.Error(0)
+}
+
+func (m *MockStockRepository) GetLowStockItems(defaultThreshold int) ([]models.ProductItem, error) {
+	args := m.Called(defaultThreshold)
+	return args.Get(0).([]models.ProductItem), args.Error(1)
+}
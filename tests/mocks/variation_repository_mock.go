@@ -0,0 +1,89 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockVariationRepository is a mock implementation of VariationRepository
+type MockVariationRepository struct {
+	mock.Mock
+}
+
+func (m *MockVariationRepository) GetAllVariations() ([]models.ProductVariation, error) {
+	args := m.Called()
+	return args.Get(0).([]models.ProductVariation), args.Error(1)
+}
+
+func (m *MockVariationRepository) GetVariationByID(id uint64) (*models.ProductVariation, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductVariation), args.Error(1)
+}
+
+func (m *MockVariationRepository) GetVariationByName(name string) (*models.ProductVariation, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductVariation), args.Error(1)
+}
+
+func (m *MockVariationRepository) CreateVariation(variation *models.ProductVariation) error {
+	args := m.Called(variation)
+	return args.Error(0)
+}
+
+func (m *MockVariationRepository) UpdateVariation(variation *models.ProductVariation) error {
+	args := m.Called(variation)
+	return args.Error(0)
+}
+
+func (m *MockVariationRepository) DeleteVariation(id uint64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockVariationRepository) GetOptionByID(id uint64) (*models.ProductVariationOption, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductVariationOption), args.Error(1)
+}
+
+func (m *MockVariationRepository) GetOptionByValue(variationID uint64, value string) (*models.ProductVariationOption, error) {
+	args := m.Called(variationID, value)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductVariationOption), args.Error(1)
+}
+
+func (m *MockVariationRepository) CreateOption(option *models.ProductVariationOption) error {
+	args := m.Called(option)
+	return args.Error(0)
+}
+
+func (m *MockVariationRepository) UpdateOption(option *models.ProductVariationOption) error {
+	args := m.Called(option)
+	return args.Error(0)
+}
+
+func (m *MockVariationRepository) DeleteOption(id uint64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockVariationRepository) UpdateOptionOrder(variationID uint64, orderedOptionIDs []uint64) error {
+	args := m.Called(variationID, orderedOptionIDs)
+	return args.Error(0)
+}
+
+func (m *MockVariationRepository) CountConfigurationsByOptionIDs(optionIDs []uint64) (int64, error) {
+	args := m.Called(optionIDs)
+	return args.Get(0).(int64), args.Error(1)
+}
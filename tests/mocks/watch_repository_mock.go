@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"flicknfit_backend/models"
+	"flicknfit_backend/services"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWatchRepository is a mock implementation of WatchRepository
+type MockWatchRepository struct {
+	mock.Mock
+}
+
+func (m *MockWatchRepository) GetItemWatchers(itemIDs []uint64) ([]models.ItemWatcher, error) {
+	args := m.Called(itemIDs)
+	return args.Get(0).([]models.ItemWatcher), args.Error(1)
+}
+
+func (m *MockWatchRepository) GetUserFavoriteByItemID(userID, productItemID uint64) (*models.Favorite, error) {
+	args := m.Called(userID, productItemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Favorite), args.Error(1)
+}
+
+func (m *MockWatchRepository) GetUserSavedItem(userID, savedItemID uint64) (*models.SavedItemsList, error) {
+	args := m.Called(userID, savedItemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SavedItemsList), args.Error(1)
+}
+
+func (m *MockWatchRepository) UpdateFavoriteWatch(favorite *models.Favorite) error {
+	args := m.Called(favorite)
+	return args.Error(0)
+}
+
+func (m *MockWatchRepository) UpdateSavedItemWatch(item *models.SavedItemsList) error {
+	args := m.Called(item)
+	return args.Error(0)
+}
+
+// MockMailer is a mock implementation of Mailer
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(to, subject, body string) error {
+	args := m.Called(to, subject, body)
+	return args.Error(0)
+}
+
+func (m *MockMailer) SendWithAttachments(to, subject, body string, attachments []services.Attachment) error {
+	args := m.Called(to, subject, body, attachments)
+	return args.Error(0)
+}
@@ -0,0 +1,192 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// waitForJob follows a job of user 3 until it finished
+func waitForJob(t *testing.T, service services.AIJobService, jobID string) *dtos.AIJobDTO {
+	deadline := time.After(5 * time.Second)
+	for {
+		job, changed, err := service.WatchJob(jobID, 3)
+		require.NoError(t, err)
+		if job.Status == "succeeded" || job.Status == "failed" {
+			return job
+		}
+		select {
+		case <-changed:
+		case <-deadline:
+			t.Fatalf("job %s did not finish, last status %s", jobID, job.Status)
+		}
+	}
+}
+
+// blockingAIService is an AI service whose skin tone prediction runs until its context is done
+type blockingAIService struct {
+	*mocks.MockAIService
+	started chan struct{}
+}
+
+func (s *blockingAIService) PredictSkinColorTone(ctx context.Context, file multipart.File, filename string) (*dtos.SkinColorTonePredictionResponseDTO, error) {
+	close(s.started)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestAIJobService_SubmitJob(t *testing.T) {
+	options := services.AIJobOptions{Workers: 1, QueueSize: 2, Retention: time.Minute}
+
+	t.Run("should run the prediction in the background and keep its result", func(t *testing.T) {
+		// Arrange
+		aiService := new(mocks.MockAIService)
+		aiService.On("PredictSkinColorTone", "image", "face.jpg").Return(&dtos.SkinColorTonePredictionResponseDTO{SkinTone: "Light Spring"}, nil)
+		service := services.NewAIJobService(aiService, nil, options)
+
+		// Act
+		queued, err := service.SubmitJob(3, "skin_color_tone", []byte("image"), "face.jpg")
+		require.NoError(t, err)
+		service.Start()
+		defer service.Stop()
+		job := waitForJob(t, service, queued.ID)
+
+		// Assert
+		assert.Equal(t, "queued", queued.Status)
+		assert.Equal(t, 1, queued.QueuePosition)
+		assert.Equal(t, "succeeded", job.Status)
+		assert.Equal(t, &dtos.SkinColorTonePredictionResponseDTO{SkinTone: "Light Spring"}, job.Result)
+		assert.NotNil(t, job.StartedAt)
+		assert.NotNil(t, job.FinishedAt)
+	})
+
+	t.Run("should fail the job with the prediction's error", func(t *testing.T) {
+		// Arrange
+		aiService := new(mocks.MockAIService)
+		aiService.On("PredictMenBodyScan", "image", "body.jpg").Return(nil, errors.New("AI API error (status 500)"))
+		service := services.NewAIJobService(aiService, nil, options)
+		service.Start()
+		defer service.Stop()
+
+		// Act
+		queued, err := service.SubmitJob(3, "men_body_scan", []byte("image"), "body.jpg")
+		require.NoError(t, err)
+		job := waitForJob(t, service, queued.ID)
+
+		// Assert
+		assert.Equal(t, "failed", job.Status)
+		assert.Equal(t, "AI API error (status 500)", job.Error)
+		assert.Nil(t, job.Result)
+	})
+
+	t.Run("should fail a prediction that runs longer than the job timeout", func(t *testing.T) {
+		// Arrange
+		aiService := &blockingAIService{MockAIService: new(mocks.MockAIService), started: make(chan struct{})}
+		service := services.NewAIJobService(aiService, nil, services.AIJobOptions{Workers: 1, QueueSize: 1, Timeout: 20 * time.Millisecond})
+		service.Start()
+		defer service.Stop()
+
+		// Act
+		queued, err := service.SubmitJob(3, "skin_color_tone", []byte("image"), "face.jpg")
+		require.NoError(t, err)
+		job := waitForJob(t, service, queued.ID)
+
+		// Assert
+		assert.Equal(t, "failed", job.Status)
+		assert.Contains(t, job.Error, "took too long")
+	})
+
+	t.Run("should report the queue position and refuse jobs once the queue is full", func(t *testing.T) {
+		// Arrange
+		service := services.NewAIJobService(new(mocks.MockAIService), nil, options)
+
+		// Act
+		first, _ := service.SubmitJob(3, "woman_body_scan", []byte("a"), "a.jpg")
+		second, _ := service.SubmitJob(3, "woman_body_scan", []byte("b"), "b.jpg")
+		_, err := service.SubmitJob(3, "woman_body_scan", []byte("c"), "c.jpg")
+
+		// Assert
+		assert.Equal(t, 1, first.QueuePosition)
+		assert.Equal(t, 2, second.QueuePosition)
+		assertAppErrorCode(t, err, http.StatusServiceUnavailable)
+	})
+
+	t.Run("should reject an unknown kind", func(t *testing.T) {
+		// Arrange
+		service := services.NewAIJobService(new(mocks.MockAIService), nil, options)
+
+		// Act
+		_, err := service.SubmitJob(3, "face_swap", []byte("image"), "face.jpg")
+
+		// Assert
+		assertAppErrorCode(t, err, http.StatusBadRequest)
+	})
+}
+
+func TestAIJobService_GetJob(t *testing.T) {
+	t.Run("should hide jobs of other users", func(t *testing.T) {
+		// Arrange
+		service := services.NewAIJobService(new(mocks.MockAIService), nil, services.AIJobOptions{QueueSize: 1})
+		queued, _ := service.SubmitJob(3, "skin_color_tone", []byte("image"), "face.jpg")
+
+		// Act
+		_, err := service.GetJob(queued.ID, 4)
+
+		// Assert
+		assertAppErrorCode(t, err, http.StatusNotFound)
+	})
+}
+
+func TestAIJobService_Stop(t *testing.T) {
+	t.Run("should fail queued jobs and refuse new ones", func(t *testing.T) {
+		// Arrange
+		service := services.NewAIJobService(new(mocks.MockAIService), nil, services.AIJobOptions{QueueSize: 1})
+		queued, _ := service.SubmitJob(3, "skin_color_tone", []byte("image"), "face.jpg")
+		_, changed, _ := service.WatchJob(queued.ID, 3)
+
+		// Act
+		service.Stop()
+		_, err := service.SubmitJob(3, "skin_color_tone", []byte("image"), "face.jpg")
+
+		// Assert
+		<-changed
+		job, _ := service.GetJob(queued.ID, 3)
+		assert.Equal(t, "failed", job.Status)
+		assertAppErrorCode(t, err, http.StatusServiceUnavailable)
+	})
+
+	t.Run("should cancel running predictions", func(t *testing.T) {
+		// Arrange
+		aiService := &blockingAIService{MockAIService: new(mocks.MockAIService), started: make(chan struct{})}
+		service := services.NewAIJobService(aiService, nil, services.AIJobOptions{Workers: 1, QueueSize: 1, Timeout: time.Hour})
+		queued, _ := service.SubmitJob(3, "skin_color_tone", []byte("image"), "face.jpg")
+		service.Start()
+		<-aiService.started
+
+		// Act
+		stopped := make(chan struct{})
+		go func() {
+			service.Stop()
+			close(stopped)
+		}()
+
+		// Assert
+		select {
+		case <-stopped:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Stop did not cancel the running prediction")
+		}
+		job, _ := service.GetJob(queued.ID, 3)
+		assert.Equal(t, "failed", job.Status)
+		assert.Contains(t, job.Error, "server restarted")
+	})
+}
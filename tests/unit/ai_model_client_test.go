@@ -0,0 +1,194 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"flicknfit_backend/services"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newModelAPI starts a model API answering predictions with the given statuses in turn, repeating the last
+// one, and its health check with healthStatus. It returns the server and the number of predictions it got.
+func newModelAPI(t *testing.T, healthStatus int, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			w.WriteHeader(healthStatus)
+			return
+		}
+		call := int(calls.Add(1))
+		w.WriteHeader(statuses[min(call, len(statuses))-1])
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func modelClientOptions() services.AIModelClientOptions {
+	return services.AIModelClientOptions{
+		AttemptTimeout:     time.Second,
+		HealthCheckTimeout: time.Second,
+		MaxAttempts:        3,
+		RetryBaseDelay:     time.Millisecond,
+		RetryMaxDelay:      2 * time.Millisecond,
+		FailureThreshold:   2,
+		OpenDuration:       time.Hour,
+	}
+}
+
+func TestAIModelClient_Post(t *testing.T) {
+	t.Run("should retry an unavailable model API and return the first success", func(t *testing.T) {
+		// Arrange
+		server, calls := newModelAPI(t, http.StatusOK, http.StatusServiceUnavailable, http.StatusOK)
+		client := services.NewAIModelClient(server.URL, modelClientOptions())
+
+		// Act
+		resp, err := client.Post(context.Background(), "/predict/sct", []byte("form"), "multipart/form-data")
+
+		// Assert
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(2), calls.Load())
+		assert.Equal(t, "closed", client.Health().Breaker)
+	})
+
+	t.Run("should return client errors without retrying", func(t *testing.T) {
+		// Arrange
+		server, calls := newModelAPI(t, http.StatusOK, http.StatusBadRequest)
+		client := services.NewAIModelClient(server.URL, modelClientOptions())
+
+		// Act
+		resp, err := client.Post(context.Background(), "/predict/sct", []byte("form"), "multipart/form-data")
+
+		// Assert
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("should open the breaker after failures in a row and refuse calls without reaching the model API", func(t *testing.T) {
+		// Arrange
+		server, calls := newModelAPI(t, http.StatusOK, http.StatusInternalServerError)
+		client := services.NewAIModelClient(server.URL, modelClientOptions())
+
+		// Act
+		for i := 0; i < 2; i++ {
+			resp, err := client.Post(context.Background(), "/predict/sct", []byte("form"), "multipart/form-data")
+			require.NoError(t, err)
+			resp.Body.Close()
+		}
+		_, err := client.Post(context.Background(), "/predict/sct", []byte("form"), "multipart/form-data")
+
+		// Assert
+		assert.True(t, errors.Is(err, services.ErrAIModelUnavailable))
+		assert.Equal(t, int32(2), calls.Load())
+		health := client.Health()
+		assert.Equal(t, "open", health.Breaker)
+		assert.False(t, health.Healthy)
+		assert.NotNil(t, health.OpenedAt)
+	})
+
+	t.Run("should stop retrying once the caller gives up", func(t *testing.T) {
+		// Arrange
+		server, _ := newModelAPI(t, http.StatusOK, http.StatusServiceUnavailable)
+		options := modelClientOptions()
+		options.RetryBaseDelay, options.RetryMaxDelay = time.Hour, time.Hour
+		client := services.NewAIModelClient(server.URL, options)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		// Act
+		_, err := client.Post(ctx, "/predict/sct", []byte("form"), "multipart/form-data")
+
+		// Assert
+		assert.ErrorContains(t, err, "status 503")
+	})
+}
+
+func TestAIModelClient_CheckHealth(t *testing.T) {
+	t.Run("should let an open breaker probe once the model API is healthy again", func(t *testing.T) {
+		// Arrange
+		server, calls := newModelAPI(t, http.StatusNotFound, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK)
+		client := services.NewAIModelClient(server.URL, modelClientOptions())
+		for i := 0; i < 2; i++ {
+			resp, _ := client.Post(context.Background(), "/predict/sct", []byte("form"), "multipart/form-data")
+			resp.Body.Close()
+		}
+
+		// Act
+		err := client.CheckHealth(context.Background())
+		resp, postErr := client.Post(context.Background(), "/predict/sct", []byte("form"), "multipart/form-data")
+
+		// Assert
+		assert.NoError(t, err)
+		require.NoError(t, postErr)
+		resp.Body.Close()
+		assert.Equal(t, int32(3), calls.Load())
+		health := client.Health()
+		assert.Equal(t, "closed", health.Breaker)
+		assert.True(t, health.Healthy)
+		assert.NotNil(t, health.LastCheckAt)
+	})
+
+	t.Run("should report a failing health check", func(t *testing.T) {
+		// Arrange
+		server, _ := newModelAPI(t, http.StatusBadGateway, http.StatusOK)
+		client := services.NewAIModelClient(server.URL, modelClientOptions())
+
+		// Act
+		err := client.CheckHealth(context.Background())
+
+		// Assert
+		assert.Error(t, err)
+		health := client.Health()
+		assert.False(t, health.Healthy)
+		assert.Equal(t, 1, health.ConsecutiveFailures)
+		assert.Contains(t, health.LastCheckError, "status 502")
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Run("should let a single probe through once the open duration passed", func(t *testing.T) {
+		// Arrange
+		breaker := services.NewCircuitBreaker(1, 10*time.Millisecond)
+		breaker.Failure()
+
+		// Act
+		refused := breaker.Allow()
+		time.Sleep(20 * time.Millisecond)
+		probe := breaker.Allow()
+		second := breaker.Allow()
+		breaker.Failure()
+		afterFailedProbe := breaker.Allow()
+
+		// Assert
+		assert.ErrorIs(t, refused, services.ErrCircuitOpen)
+		assert.NoError(t, probe)
+		assert.ErrorIs(t, second, services.ErrCircuitOpen)
+		assert.ErrorIs(t, afterFailedProbe, services.ErrCircuitOpen)
+		assert.Equal(t, "open", breaker.State().State)
+	})
+
+	t.Run("should close after a successful probe", func(t *testing.T) {
+		// Arrange
+		breaker := services.NewCircuitBreaker(1, 0)
+		breaker.Failure()
+
+		// Act
+		probe := breaker.Allow()
+		breaker.Success()
+
+		// Assert
+		assert.NoError(t, probe)
+		assert.Equal(t, "closed", breaker.State().State)
+		assert.NoError(t, breaker.Allow())
+	})
+}
@@ -0,0 +1,136 @@
+package unit
+
+import (
+	"bytes"
+	"context"
+	"flicknfit_backend/config"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/models"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAnalyticsService_GetTimeSeries(t *testing.T) {
+	t.Run("should roll days up into weeks and fill empty weeks", func(t *testing.T) {
+		// Arrange
+		analyticsRepo := new(mocks.MockAnalyticsRepository)
+		service := services.NewAnalyticsService(analyticsRepo)
+
+		from := time.Date(2026, 9, 2, 0, 0, 0, 0, time.Local) // Wednesday
+		to := time.Date(2026, 9, 20, 0, 0, 0, 0, time.Local)  // Sunday
+		analyticsRepo.On("GetDailySeries", "gmv", from, to.AddDate(0, 0, 1)).Return([]models.DailyValue{
+			{Day: "2026-09-02", Value: 100000},
+			{Day: "2026-09-06", Value: 50000},
+			{Day: "2026-09-20", Value: 25000},
+		}, nil)
+
+		// Act
+		series, err := service.GetTimeSeries(&dtos.TimeSeriesQueryDTO{Metric: "gmv", From: "2026-09-02", To: "2026-09-20", Granularity: "week"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []dtos.TimeSeriesPointDTO{
+			{Period: "2026-08-31", Value: 150000},
+			{Period: "2026-09-07", Value: 0},
+			{Period: "2026-09-14", Value: 25000},
+		}, series.Points)
+		assert.Equal(t, float64(175000), series.Total)
+	})
+
+	t.Run("should default to daily values of the last 30 days", func(t *testing.T) {
+		// Arrange
+		analyticsRepo := new(mocks.MockAnalyticsRepository)
+		service := services.NewAnalyticsService(analyticsRepo)
+
+		analyticsRepo.On("GetDailySeries", "clicks", mock.Anything, mock.Anything).Return([]models.DailyValue{}, nil)
+
+		// Act
+		series, err := service.GetTimeSeries(&dtos.TimeSeriesQueryDTO{Metric: "clicks"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "day", series.Granularity)
+		assert.Len(t, series.Points, 30)
+		assert.Equal(t, time.Now().Format("2006-01-02"), series.To)
+	})
+
+	t.Run("should reject invalid parameters", func(t *testing.T) {
+		service := services.NewAnalyticsService(new(mocks.MockAnalyticsRepository))
+
+		for _, query := range []dtos.TimeSeriesQueryDTO{
+			{Metric: "revenue"},
+			{Metric: "gmv", Granularity: "year"},
+			{Metric: "gmv", From: "2026-10-02", To: "2026-10-01"},
+			{Metric: "gmv", From: "2024-01-01", To: "2026-01-01"},
+			{Metric: "gmv", From: "01/10/2026"},
+		} {
+			_, err := service.GetTimeSeries(&query)
+			assertAppErrorCode(t, err, http.StatusBadRequest)
+		}
+	})
+}
+
+func TestAnalyticsService_GetRevenueAnalytics(t *testing.T) {
+	t.Run("should compute growth of this month over last month", func(t *testing.T) {
+		// Arrange
+		analyticsRepo := new(mocks.MockAnalyticsRepository)
+		service := services.NewAnalyticsService(analyticsRepo)
+		now := time.Now()
+		thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+		lastMonth := thisMonth.AddDate(0, -1, 0)
+
+		analyticsRepo.On("GetDailySeries", "gmv", mock.Anything, mock.Anything).Return([]models.DailyValue{
+			{Day: lastMonth.AddDate(0, 0, 3).Format("2006-01-02"), Value: 200000},
+			{Day: thisMonth.Format("2006-01-02"), Value: 250000},
+		}, nil)
+		analyticsRepo.On("GetRevenueByCategory", mock.Anything, mock.Anything).Return([]models.CategoryRevenue{{Category: "shirt", Revenue: 450000}}, nil)
+		analyticsRepo.On("GetMetricTotal", "gmv", time.Time{}, mock.Anything).Return(float64(900000), nil)
+
+		// Act
+		analytics, err := service.GetRevenueAnalytics()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, analytics.MonthlyRevenue, 6)
+		assert.Equal(t, float64(250000), analytics.MonthlyRevenue[5].Value)
+		assert.InDelta(t, 25.0, *analytics.RevenueGrowth, 0.001)
+		assert.Equal(t, float64(900000), analytics.TotalRevenue)
+	})
+}
+
+func TestAIService_RequestLog(t *testing.T) {
+	t.Run("should log failed predictions", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		analyticsRepo := new(mocks.MockAnalyticsRepository)
+		service := services.NewAIService(&config.Config{AIApiURL: server.URL}, services.NewAIModelClient(server.URL, services.AIModelClientOptions{}), analyticsRepo)
+
+		analyticsRepo.On("LogAIRequest", mock.MatchedBy(func(log *models.AIRequestLog) bool {
+			return log.Kind == "skin_color_tone" && !log.Success && log.Error != ""
+		})).Return(nil)
+
+		// Act
+		_, err := service.PredictSkinColorTone(context.Background(), nopFile{bytes.NewReader([]byte("image"))}, "face.png")
+
+		// Assert
+		assert.Error(t, err)
+		analyticsRepo.AssertExpectations(t)
+	})
+}
+
+// nopFile adapts a bytes.Reader to multipart.File
+type nopFile struct {
+	*bytes.Reader
+}
+
+func (nopFile) Close() error { return nil }
@@ -0,0 +1,213 @@
+package unit
+
+import (
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type brandApplicationMocks struct {
+	applicationRepo  *mocks.MockBrandApplicationRepository
+	brandRepo        *mocks.MockBrandRepository
+	memberRepo       *mocks.MockBrandMemberRepository
+	notificationRepo *mocks.MockNotificationRepository
+	storage          *mocks.MockStorageService
+}
+
+func newBrandApplicationService() (services.BrandApplicationService, *brandApplicationMocks) {
+	m := &brandApplicationMocks{
+		applicationRepo:  new(mocks.MockBrandApplicationRepository),
+		brandRepo:        new(mocks.MockBrandRepository),
+		memberRepo:       new(mocks.MockBrandMemberRepository),
+		notificationRepo: new(mocks.MockNotificationRepository),
+		storage:          new(mocks.MockStorageService),
+	}
+	service := services.NewBrandApplicationService(m.applicationRepo, m.brandRepo, m.memberRepo,
+		services.NewNotificationService(m.notificationRepo), m.storage)
+	return service, m
+}
+
+func assertAppErrorCode(t *testing.T, err error, code int) {
+	appErr, ok := err.(*apperrors.AppError)
+	if assert.True(t, ok, "expected an AppError, got %v", err) {
+		assert.Equal(t, code, appErr.Code)
+	}
+}
+
+func TestBrandApplicationService_SubmitApplication(t *testing.T) {
+	validDTO := func() *dtos.BrandApplicationRequestDTO {
+		return &dtos.BrandApplicationRequestDTO{
+			Name:           "Batik Sari",
+			Description:    "Handmade batik from Solo",
+			WhatsAppNumber: "0812-3456-7890",
+			InstagramURL:   "https://www.instagram.com/batiksari",
+			TokopediaURL:   "https://tokopedia.com/batiksari",
+			ShopeeURL:      "https://shopee.co.id/batiksari",
+		}
+	}
+
+	t.Run("should store a pending application with the uploaded logo", func(t *testing.T) {
+		// Arrange
+		service, m := newBrandApplicationService()
+		logo := &services.BrandLogo{Filename: "logo.png", Data: testPNG(t, 64, 64)}
+
+		m.applicationRepo.On("HasPendingApplication", uint64(3), (*uint64)(nil), "Batik Sari").Return(false, nil)
+		m.storage.On("UploadPublicFile", mock.MatchedBy(func(path string) bool {
+			return strings.HasPrefix(path, "brands/applications/") && strings.HasSuffix(path, ".png")
+		}), logo.Data, "image/png").Return("https://cdn.test/logo.png", nil)
+		m.applicationRepo.On("CreateApplication", mock.MatchedBy(func(a *models.BrandApplication) bool {
+			return a.UserID == 3 && a.Status == constants.BrandApplicationStatusPending && a.LogoURL == "https://cdn.test/logo.png"
+		})).Return(nil)
+
+		// Act
+		application, err := service.SubmitApplication(3, validDTO(), logo)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Batik Sari", application.Name)
+		m.applicationRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject invalid WhatsApp numbers and marketplace links", func(t *testing.T) {
+		// Arrange
+		service, m := newBrandApplicationService()
+		dto := validDTO()
+		dto.WhatsAppNumber = "12345"
+		dto.TokopediaURL = "https://tokopedia.evil.com/batiksari"
+
+		// Act
+		_, err := service.SubmitApplication(3, dto, nil)
+
+		// Assert
+		assertAppErrorCode(t, err, http.StatusBadRequest)
+		assert.Contains(t, err.Error(), "whatsapp_number")
+		assert.Contains(t, err.Error(), "tokopedia_url")
+		assert.NotContains(t, err.Error(), "shopee_url")
+		m.applicationRepo.AssertNotCalled(t, "CreateApplication", mock.Anything)
+	})
+
+	t.Run("should refuse to verify an already verified brand", func(t *testing.T) {
+		// Arrange
+		service, m := newBrandApplicationService()
+		brandID := uint64(7)
+		dto := validDTO()
+		dto.BrandID = &brandID
+
+		m.brandRepo.On("GetBrandByID", brandID).Return(&models.Brand{ID: brandID, Verified: true}, nil)
+		m.memberRepo.On("GetMember", brandID, uint64(3)).Return(&models.BrandMember{BrandID: brandID, UserID: 3}, nil)
+
+		// Act
+		_, err := service.SubmitApplication(3, dto, nil)
+
+		// Assert
+		assertAppErrorCode(t, err, http.StatusConflict)
+	})
+
+	t.Run("should refuse a second pending application", func(t *testing.T) {
+		// Arrange
+		service, m := newBrandApplicationService()
+
+		m.applicationRepo.On("HasPendingApplication", uint64(3), (*uint64)(nil), "Batik Sari").Return(true, nil)
+
+		// Act
+		_, err := service.SubmitApplication(3, validDTO(), nil)
+
+		// Assert
+		assertAppErrorCode(t, err, http.StatusConflict)
+		m.applicationRepo.AssertNotCalled(t, "CreateApplication", mock.Anything)
+	})
+}
+
+func TestBrandApplicationService_AdminReview(t *testing.T) {
+	pending := func() *models.BrandApplication {
+		return &models.BrandApplication{
+			ID:             5,
+			UserID:         3,
+			Name:           "Batik Sari",
+			Description:    "Handmade batik from Solo",
+			WhatsAppNumber: "+62 812 3456 7890",
+			Status:         constants.BrandApplicationStatusPending,
+		}
+	}
+
+	t.Run("should create a verified brand and notify the applicant", func(t *testing.T) {
+		// Arrange
+		service, m := newBrandApplicationService()
+
+		m.applicationRepo.On("GetApplicationByID", uint64(5)).Return(pending(), nil)
+		m.applicationRepo.On("ApproveApplication", mock.MatchedBy(func(a *models.BrandApplication) bool {
+			return a.Status == constants.BrandApplicationStatusApproved && *a.ReviewedBy == 1 && a.ReviewedAt != nil
+		}), mock.MatchedBy(func(b *models.Brand) bool {
+			return b.ID == 0 && b.Name == "Batik Sari" && b.Verified && b.VerifiedAt != nil
+		})).Return(nil)
+		m.notificationRepo.On("CreateNotifications", mock.MatchedBy(func(n []models.Notification) bool {
+			return len(n) == 1 && n[0].UserID == 3 && n[0].Type == constants.NotificationTypeBrandApproved
+		})).Return(nil)
+
+		// Act
+		application, err := service.AdminApproveApplication(5, 1, "")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, constants.BrandApplicationStatusApproved, application.Status)
+		m.notificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not review an application twice", func(t *testing.T) {
+		// Arrange
+		service, m := newBrandApplicationService()
+		rejected := pending()
+		rejected.Status = constants.BrandApplicationStatusRejected
+
+		m.applicationRepo.On("GetApplicationByID", uint64(5)).Return(rejected, nil)
+
+		// Act
+		_, err := service.AdminApproveApplication(5, 1, "")
+
+		// Assert
+		assertAppErrorCode(t, err, http.StatusConflict)
+		m.applicationRepo.AssertNotCalled(t, "ApproveApplication", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should require notes to reject", func(t *testing.T) {
+		// Arrange
+		service, m := newBrandApplicationService()
+
+		// Act
+		_, err := service.AdminRejectApplication(5, 1, "  ")
+
+		// Assert
+		assertAppErrorCode(t, err, http.StatusBadRequest)
+		m.applicationRepo.AssertNotCalled(t, "UpdateApplication", mock.Anything)
+	})
+
+	t.Run("should reject with notes and notify the applicant", func(t *testing.T) {
+		// Arrange
+		service, m := newBrandApplicationService()
+
+		m.applicationRepo.On("GetApplicationByID", uint64(5)).Return(pending(), nil)
+		m.applicationRepo.On("UpdateApplication", mock.MatchedBy(func(a *models.BrandApplication) bool {
+			return a.Status == constants.BrandApplicationStatusRejected && a.ReviewNotes == "Logo is blurry"
+		})).Return(nil)
+		m.notificationRepo.On("CreateNotifications", mock.MatchedBy(func(n []models.Notification) bool {
+			return len(n) == 1 && n[0].Type == constants.NotificationTypeBrandRejected && n[0].Message == "Logo is blurry"
+		})).Return(nil)
+
+		// Act
+		_, err := service.AdminRejectApplication(5, 1, " Logo is blurry ")
+
+		// Assert
+		assert.NoError(t, err)
+		m.applicationRepo.AssertExpectations(t)
+		m.notificationRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,198 @@
+package unit
+
+import (
+	"encoding/csv"
+	"errors"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// brandReportMocks holds the dependencies of a brand report service under test
+type brandReportMocks struct {
+	analyticsRepo *mocks.MockClickAnalyticsRepository
+	reportRepo    *mocks.MockBrandReportRepository
+	brandRepo     *mocks.MockBrandRepository
+	memberRepo    *mocks.MockBrandMemberRepository
+	mailer        *mocks.MockMailer
+}
+
+func newBrandReportService() (services.BrandReportService, *brandReportMocks) {
+	m := &brandReportMocks{
+		analyticsRepo: new(mocks.MockClickAnalyticsRepository),
+		reportRepo:    new(mocks.MockBrandReportRepository),
+		brandRepo:     new(mocks.MockBrandRepository),
+		memberRepo:    new(mocks.MockBrandMemberRepository),
+		mailer:        new(mocks.MockMailer),
+	}
+	clickAnalytics := services.NewClickAnalyticsService(m.analyticsRepo, m.memberRepo)
+	return services.NewBrandReportService(clickAnalytics, m.reportRepo, m.brandRepo, m.memberRepo, m.mailer), m
+}
+
+// stubBrandReport makes every query of brand 7's report in scope return sample figures
+func (m *brandReportMocks) stubBrandReport(scope repositories.ClickScope) {
+	stubClickAnalytics(m.analyticsRepo, scope, models.ClickTotals{Clicks: 12, AnonymousClicks: 3, UniqueUsers: 2},
+		[]models.ClickCount{{Key: "Dart/3.3 (dart:io)", Clicks: 12}}, []models.ClickCount{{Key: "Jawa Barat", Clicks: 12}}, 48, 6)
+	m.reportRepo.On("GetReviewSummary", scope).Return(&models.ReviewSummary{Reviews: 3, AverageRating: 4.333}, nil)
+	m.reportRepo.On("GetRatingCounts", scope).Return([]models.RatingCount{{Rating: 5, Reviews: 2}, {Rating: 3, Reviews: 1}}, nil)
+}
+
+func TestBrandReportService_AdminGetBrandReport(t *testing.T) {
+	from := time.Date(2026, 9, 7, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 9, 20, 0, 0, 0, 0, time.Local)
+	scope := repositories.ClickScope{BrandID: 7, From: from, To: to.AddDate(0, 0, 1)}
+
+	t.Run("should export the brand's figures as CSV by default", func(t *testing.T) {
+		// Arrange
+		service, m := newBrandReportService()
+		m.brandRepo.On("GetBrandByID", uint64(7)).Return(&models.Brand{ID: 7, Name: "Kain Kita"}, nil)
+		m.stubBrandReport(scope)
+
+		// Act
+		file, err := service.AdminGetBrandReport(7, &dtos.BrandReportQueryDTO{From: "2026-09-07", To: "2026-09-20"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "flicknfit-report-7-2026-09-07-2026-09-20.csv", file.FileName)
+		assert.Equal(t, "text/csv; charset=utf-8", file.ContentType)
+
+		reader := csv.NewReader(strings.NewReader(string(file.Content)))
+		reader.FieldsPerRecord = -1
+		records, err := reader.ReadAll()
+		assert.NoError(t, err)
+		content := make(map[string][]string)
+		for _, record := range records {
+			content[record[0]] = record
+		}
+		assert.Equal(t, []string{"Clicks", "12"}, content["Clicks"])
+		assert.Equal(t, []string{"Product views", "48"}, content["Product views"])
+		assert.Equal(t, []string{"Favorites added", "6"}, content["Favorites added"])
+		assert.Equal(t, []string{"Average rating", "4.33"}, content["Average rating"])
+		assert.Equal(t, []string{"5", "Shirt", "12", "2"}, content["5"])
+		assert.Equal(t, []string{"shopee", "10", "83.3"}, content["shopee"])
+		assert.Equal(t, []string{"4 stars", "0"}, content["4 stars"])
+		assert.Equal(t, []string{"2026-09-09", "6", "4", "2", "2"}, content["2026-09-09"])
+	})
+
+	t.Run("should render XLSX and PDF files", func(t *testing.T) {
+		// Arrange
+		service, m := newBrandReportService()
+		m.brandRepo.On("GetBrandByID", uint64(7)).Return(&models.Brand{ID: 7, Name: "Kain Kita"}, nil)
+		m.stubBrandReport(scope)
+
+		for format, signature := range map[string]string{"xlsx": "PK", "pdf": "%PDF"} {
+			// Act
+			file, err := service.AdminGetBrandReport(7, &dtos.BrandReportQueryDTO{From: "2026-09-07", To: "2026-09-20", Format: format})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.True(t, strings.HasSuffix(file.FileName, "."+format))
+			assert.True(t, strings.HasPrefix(string(file.Content), signature))
+		}
+	})
+
+	t.Run("should reject an unknown format", func(t *testing.T) {
+		// Arrange
+		service, m := newBrandReportService()
+
+		// Act
+		_, err := service.AdminGetBrandReport(7, &dtos.BrandReportQueryDTO{Format: "docx"})
+
+		// Assert
+		assertAppErrorCode(t, err, http.StatusBadRequest)
+		m.brandRepo.AssertNotCalled(t, "GetBrandByID", mock.Anything)
+	})
+
+	t.Run("should return not found for an unknown brand", func(t *testing.T) {
+		// Arrange
+		service, m := newBrandReportService()
+		m.brandRepo.On("GetBrandByID", uint64(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		// Act
+		_, err := service.AdminGetBrandReport(9, &dtos.BrandReportQueryDTO{})
+
+		// Assert
+		assertAppErrorCode(t, err, http.StatusNotFound)
+	})
+}
+
+func TestBrandReportService_GetBrandReport(t *testing.T) {
+	t.Run("should reject users who are not members of the brand", func(t *testing.T) {
+		// Arrange
+		service, m := newBrandReportService()
+		m.memberRepo.On("GetMember", uint64(7), uint64(3)).Return(nil, gorm.ErrRecordNotFound)
+
+		// Act
+		_, err := service.GetBrandReport(7, 3, &dtos.BrandReportQueryDTO{})
+
+		// Assert
+		assertAppErrorCode(t, err, http.StatusForbidden)
+		m.brandRepo.AssertNotCalled(t, "GetBrandByID", mock.Anything)
+	})
+}
+
+func TestBrandReportService_SendMonthlyReports(t *testing.T) {
+	now := time.Date(2026, 10, 1, 8, 0, 0, 0, time.Local)
+	scope := repositories.ClickScope{BrandID: 7, From: time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local), To: time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)}
+	members := []models.BrandMember{
+		{UserID: 3, User: models.User{Email: "owner@kainkita.id"}},
+		{UserID: 4, User: models.User{Email: "staff@kainkita.id"}},
+	}
+
+	t.Run("should email last month's report to every member once", func(t *testing.T) {
+		// Arrange
+		service, m := newBrandReportService()
+		m.brandRepo.On("GetAllBrands").Return([]models.Brand{{ID: 7, Name: "Kain Kita"}, {ID: 8, Name: "Sent Already"}}, nil)
+		m.reportRepo.On("HasDelivery", uint64(7), "2026-09").Return(false, nil)
+		m.reportRepo.On("HasDelivery", uint64(8), "2026-09").Return(true, nil)
+		m.memberRepo.On("GetMembersByBrandID", uint64(7)).Return(members, nil)
+		m.stubBrandReport(scope)
+		var attachments []services.Attachment
+		m.mailer.On("SendWithAttachments", mock.Anything, "FlickNFit report 2026-09: Kain Kita", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			attachments = args.Get(3).([]services.Attachment)
+		}).Return(nil)
+		m.reportRepo.On("RecordDelivery", mock.Anything).Return(nil)
+
+		// Act
+		sent, err := service.SendMonthlyReports(now)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		m.mailer.AssertNumberOfCalls(t, "SendWithAttachments", 2)
+		assert.Equal(t, "flicknfit-report-7-2026-09-01-2026-09-30.pdf", attachments[0].FileName)
+		assert.Equal(t, "flicknfit-report-7-2026-09-01-2026-09-30.xlsx", attachments[1].FileName)
+		m.reportRepo.AssertCalled(t, "RecordDelivery", mock.MatchedBy(func(delivery *models.BrandReportDelivery) bool {
+			return delivery.BrandID == 7 && delivery.Period == "2026-09" && delivery.Recipients == 2
+		}))
+		m.memberRepo.AssertNotCalled(t, "GetMembersByBrandID", uint64(8))
+	})
+
+	t.Run("should stop without recording deliveries when the mailer is not configured", func(t *testing.T) {
+		// Arrange
+		service, m := newBrandReportService()
+		m.brandRepo.On("GetAllBrands").Return([]models.Brand{{ID: 7, Name: "Kain Kita"}}, nil)
+		m.reportRepo.On("HasDelivery", uint64(7), "2026-09").Return(false, nil)
+		m.memberRepo.On("GetMembersByBrandID", uint64(7)).Return(members, nil)
+		m.stubBrandReport(scope)
+		m.mailer.On("SendWithAttachments", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(services.ErrMailerNotConfigured)
+
+		// Act
+		sent, err := service.SendMonthlyReports(now)
+
+		// Assert
+		assert.True(t, errors.Is(err, services.ErrMailerNotConfigured))
+		assert.Zero(t, sent)
+		m.reportRepo.AssertNotCalled(t, "RecordDelivery", mock.Anything)
+	})
+}
@@ -0,0 +1,215 @@
+package unit
+
+import (
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func newBrandService() (services.BrandService, *mocks.MockBrandRepository, *mocks.MockProductRepository) {
+	brandRepo := new(mocks.MockBrandRepository)
+	productRepo := new(mocks.MockProductRepository)
+	campaignRepo := new(mocks.MockCampaignRepository)
+	campaignRepo.On("GetActiveCampaigns", mock.AnythingOfType("time.Time")).Return([]models.Campaign{}, nil).Maybe()
+	return services.NewBrandService(brandRepo, newProductService(productRepo, campaignRepo)), brandRepo, productRepo
+}
+
+func TestBrandService_GetAllBrands(t *testing.T) {
+	t.Run("should fall back to the default page size", func(t *testing.T) {
+		// Arrange
+		service, brandRepo, _ := newBrandService()
+
+		brandRepo.On("SearchBrands", "linen", constants.BrandSortFollowers, constants.DefaultPageSize, 0).
+			Return([]models.Brand{{ID: 1, Name: "Linen Co"}}, int64(1), nil)
+
+		// Act
+		result, err := service.GetAllBrands(&dtos.BrandListQueryDTO{Page: 0, Limit: 500, Search: "linen", Sort: constants.BrandSortFollowers})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, result.Brands, 1)
+		assert.Equal(t, dtos.PaginationDTO{Page: 1, Limit: constants.DefaultPageSize, Total: 1}, result.Pagination)
+	})
+
+	t.Run("should skip the previous pages", func(t *testing.T) {
+		// Arrange
+		service, brandRepo, _ := newBrandService()
+
+		brandRepo.On("SearchBrands", "", "", 20, 40).Return([]models.Brand{}, int64(45), nil)
+
+		// Act
+		result, err := service.GetAllBrands(&dtos.BrandListQueryDTO{Page: 3, Limit: 20})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 45, result.Pagination.Total)
+		brandRepo.AssertExpectations(t)
+	})
+}
+
+func TestBrandService_GetBrandByID(t *testing.T) {
+	brand := &models.Brand{ID: 7, Name: "Linen Co", Rating: 4.5, WhatsAppNumber: "0812-3456", ShopeeURL: "https://shopee.co.id/linenco"}
+
+	t.Run("should return top products, categories and links", func(t *testing.T) {
+		// Arrange
+		service, brandRepo, productRepo := newBrandService()
+
+		products := make([]*models.Product, 0, constants.BrandTopProductsLimit)
+		for i := 0; i < constants.BrandTopProductsLimit; i++ {
+			products = append(products, &models.Product{ID: uint64(i + 1), BrandID: 7})
+		}
+		brandRepo.On("GetBrandByID", uint64(7)).Return(brand, nil)
+		brandRepo.On("GetBrandCategories", uint64(7)).Return([]string{"Pants", "Shirts"}, nil)
+		brandRepo.On("IsFollowingBrand", uint64(7), uint64(3)).Return(true, nil)
+		productRepo.On("GetAllProductsPublicWithFilter", mock.MatchedBy(func(f *dtos.ProductFilterRequestDTO) bool {
+			return f.BrandID == 7 && f.Sort == constants.ProductSortBestSelling && f.Limit == constants.BrandTopProductsLimit
+		})).Return(products, nil)
+
+		// Act
+		detail, err := service.GetBrandByID(7, 3)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 4.5, detail.Rating)
+		assert.Equal(t, []string{"Pants", "Shirts"}, detail.Categories)
+		assert.Len(t, detail.TopProducts, constants.BrandTopProductsLimit)
+		assert.Equal(t, "https://wa.me/628123456", detail.Links.WhatsApp)
+		assert.Equal(t, brand.ShopeeURL, detail.Links.Shopee)
+		assert.Empty(t, detail.Links.Tokopedia)
+		assert.True(t, detail.IsFollowing)
+	})
+
+	t.Run("should not look up follows of anonymous visitors", func(t *testing.T) {
+		// Arrange
+		service, brandRepo, productRepo := newBrandService()
+
+		brandRepo.On("GetBrandByID", uint64(7)).Return(brand, nil)
+		brandRepo.On("GetBrandCategories", uint64(7)).Return([]string(nil), nil)
+		productRepo.On("GetAllProductsPublicWithFilter", mock.Anything).Return([]*models.Product{}, nil)
+
+		// Act
+		detail, err := service.GetBrandByID(7, 0)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, detail.IsFollowing)
+		assert.NotNil(t, detail.Categories)
+		brandRepo.AssertNotCalled(t, "IsFollowingBrand", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should report a missing brand as not found", func(t *testing.T) {
+		// Arrange
+		service, brandRepo, _ := newBrandService()
+
+		brandRepo.On("GetBrandByID", uint64(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		// Act
+		_, err := service.GetBrandByID(9, 0)
+
+		// Assert
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+	})
+}
+
+func TestBrandService_AdminCreateBrand(t *testing.T) {
+	t.Run("should create a brand with valid links", func(t *testing.T) {
+		// Arrange
+		service, brandRepo, _ := newBrandService()
+
+		brandRepo.On("CreateBrand", mock.AnythingOfType("*models.Brand")).Return(nil)
+
+		// Act
+		brand, err := service.AdminCreateBrand(&dtos.BrandCreateRequestDTO{
+			Name:           "Linen Co",
+			WhatsAppNumber: "0812-3456-7890",
+			ShopeeURL:      "https://shopee.co.id/linenco",
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Linen Co", brand.Name)
+		brandRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject an invalid WhatsApp number and an off-marketplace link", func(t *testing.T) {
+		// Arrange
+		service, brandRepo, _ := newBrandService()
+
+		// Act
+		_, err := service.AdminCreateBrand(&dtos.BrandCreateRequestDTO{
+			Name:           "Linen Co",
+			WhatsAppNumber: "12345",
+			TokopediaURL:   "https://evil.example.com/linenco",
+		})
+
+		// Assert
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		assert.Contains(t, err.Error(), "whatsapp_number")
+		assert.Contains(t, err.Error(), "tokopedia_url")
+		brandRepo.AssertNotCalled(t, "CreateBrand", mock.Anything)
+	})
+}
+
+func TestBrandService_AdminUpdateBrand(t *testing.T) {
+	t.Run("should reject a marketplace link pointing elsewhere", func(t *testing.T) {
+		// Arrange
+		service, brandRepo, _ := newBrandService()
+
+		brandRepo.On("GetBrandByID", uint64(7)).Return(&models.Brand{ID: 7, Name: "Linen Co"}, nil)
+
+		// Act
+		_, err := service.AdminUpdateBrand(7, &dtos.BrandUpdateRequestDTO{ShopeeURL: "https://shopee.evil.example.com/linenco"})
+
+		// Assert
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		brandRepo.AssertNotCalled(t, "UpdateBrand", mock.Anything)
+	})
+}
+
+func TestBrandService_FollowBrand(t *testing.T) {
+	t.Run("should return the updated followers count", func(t *testing.T) {
+		// Arrange
+		service, brandRepo, _ := newBrandService()
+
+		brandRepo.On("GetBrandByID", uint64(7)).Return(&models.Brand{ID: 7}, nil)
+		brandRepo.On("FollowBrand", uint64(7), uint64(3)).Return(uint(12), nil)
+
+		// Act
+		follow, err := service.FollowBrand(7, 3)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, dtos.BrandFollowResponseDTO{BrandID: 7, Following: true, Followers: 12}, *follow)
+	})
+
+	t.Run("should not follow a missing brand", func(t *testing.T) {
+		// Arrange
+		service, brandRepo, _ := newBrandService()
+
+		brandRepo.On("GetBrandByID", uint64(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		// Act
+		_, err := service.FollowBrand(9, 3)
+
+		// Assert
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+		brandRepo.AssertNotCalled(t, "FollowBrand", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,133 @@
+package unit
+
+import (
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// stubClickAnalytics makes every click analytics query of scope return the given values
+func stubClickAnalytics(repo *mocks.MockClickAnalyticsRepository, scope repositories.ClickScope, totals models.ClickTotals, userAgents []models.ClickCount, regions []models.ClickCount, views, favorites int64) {
+	repo.On("GetClickTotals", scope).Return(&totals, nil)
+	repo.On("GetDailyClicks", scope).Return([]models.DailyClicks{
+		{Day: "2026-09-07", Clicks: 4, AnonymousClicks: 1},
+		{Day: "2026-09-09", Clicks: 6, AnonymousClicks: 2},
+		{Day: "2026-09-14", Clicks: 2, AnonymousClicks: 0},
+	}, nil)
+	repo.On("GetDailyClickUsers", scope).Return([]models.DailyUser{
+		{Day: "2026-09-07", UserID: 1},
+		{Day: "2026-09-09", UserID: 1},
+		{Day: "2026-09-09", UserID: 2},
+		{Day: "2026-09-14", UserID: 1},
+	}, nil)
+	repo.On("GetClicksByProduct", scope, 20).Return([]models.ProductClickCount{{ProductID: 5, ProductName: "Shirt", Clicks: 12, UniqueUsers: 2}}, nil)
+	repo.On("GetClicksByCategory", scope).Return([]models.ClickCount{{Key: "tops", Clicks: 12}}, nil)
+	repo.On("GetClicksByUserAgent", scope).Return(userAgents, nil)
+	repo.On("GetClicksByRegion", scope).Return(regions, nil)
+	repo.On("GetClicksByChannel", scope).Return([]models.ClickCount{{Key: "shopee", Clicks: 10}, {Key: "", Clicks: 2}}, nil)
+	repo.On("GetSuspiciousClicks", scope).Return([]models.ClickCount{{Key: "bot", Clicks: 4}, {Key: "duplicate", Clicks: 1}}, nil)
+	repo.On("CountViews", scope).Return(views, nil)
+	repo.On("CountFavorites", scope).Return(favorites, nil)
+}
+
+func TestClickAnalyticsService_GetClickAnalytics(t *testing.T) {
+	from := time.Date(2026, 9, 7, 0, 0, 0, 0, time.Local) // Monday
+	to := time.Date(2026, 9, 20, 0, 0, 0, 0, time.Local)  // Sunday
+	query := &dtos.ClickAnalyticsQueryDTO{From: "2026-09-07", To: "2026-09-20", Granularity: "week"}
+
+	t.Run("should count unique users per week and classify devices and regions", func(t *testing.T) {
+		// Arrange
+		analyticsRepo := new(mocks.MockClickAnalyticsRepository)
+		service := services.NewClickAnalyticsService(analyticsRepo, new(mocks.MockBrandMemberRepository))
+
+		scope := repositories.ClickScope{From: from, To: to.AddDate(0, 0, 1)}
+		stubClickAnalytics(analyticsRepo, scope, models.ClickTotals{Clicks: 12, AnonymousClicks: 3, UniqueUsers: 2},
+			[]models.ClickCount{
+				{Key: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)", Clicks: 5},
+				{Key: "Dart/3.3 (dart:io)", Clicks: 4},
+				{Key: "Mozilla/5.0 (Windows NT 10.0; Win64; x64)", Clicks: 2},
+				{Key: "", Clicks: 1},
+			},
+			[]models.ClickCount{{Key: "Jawa Barat", Clicks: 9}, {Key: "", Clicks: 3}},
+			48, 6)
+
+		// Act
+		analytics, err := service.GetClickAnalytics(query)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(9), analytics.SignedInClicks)
+		assert.Equal(t, int64(5), analytics.ExcludedClicks)
+		assert.Equal(t, []dtos.ClickSeriesPointDTO{
+			{Period: "2026-09-07", Clicks: 10, SignedInClicks: 7, AnonymousClicks: 3, UniqueUsers: 2},
+			{Period: "2026-09-14", Clicks: 2, SignedInClicks: 2, AnonymousClicks: 0, UniqueUsers: 1},
+		}, analytics.Series)
+		assert.Equal(t, []dtos.ClickBreakdownDTO{
+			{Key: "mobile", Clicks: 9, Share: 75},
+			{Key: "desktop", Clicks: 2, Share: float64(2) / 12 * 100},
+			{Key: "unknown", Clicks: 1, Share: float64(1) / 12 * 100},
+		}, analytics.ByDevice)
+		assert.Equal(t, "unknown", analytics.ByRegion[1].Key)
+		assert.Equal(t, "unknown", analytics.ByChannel[1].Key)
+		assert.Equal(t, []string{"view", "favorite", "click"}, []string{analytics.Funnel[0].Stage, analytics.Funnel[1].Stage, analytics.Funnel[2].Stage})
+		assert.Nil(t, analytics.Funnel[0].Rate)
+		assert.Equal(t, float64(12.5), *analytics.Funnel[1].Rate)
+		assert.Equal(t, float64(200), *analytics.Funnel[2].Rate)
+	})
+
+	t.Run("should scope the admin analytics to the requested brand", func(t *testing.T) {
+		// Arrange
+		analyticsRepo := new(mocks.MockClickAnalyticsRepository)
+		service := services.NewClickAnalyticsService(analyticsRepo, new(mocks.MockBrandMemberRepository))
+
+		scope := repositories.ClickScope{BrandID: 7, From: from, To: to.AddDate(0, 0, 1)}
+		stubClickAnalytics(analyticsRepo, scope, models.ClickTotals{}, []models.ClickCount{}, []models.ClickCount{}, 0, 0)
+
+		// Act
+		analytics, err := service.GetClickAnalytics(&dtos.ClickAnalyticsQueryDTO{From: query.From, To: query.To, Granularity: query.Granularity, BrandID: 7})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(7), analytics.BrandID)
+		assert.Nil(t, analytics.Funnel[1].Rate)
+		assert.Nil(t, analytics.Funnel[2].Rate)
+		analyticsRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject an invalid granularity", func(t *testing.T) {
+		// Arrange
+		service := services.NewClickAnalyticsService(new(mocks.MockClickAnalyticsRepository), new(mocks.MockBrandMemberRepository))
+
+		// Act
+		_, err := service.GetClickAnalytics(&dtos.ClickAnalyticsQueryDTO{Granularity: "hour"})
+
+		// Assert
+		assertAppErrorCode(t, err, http.StatusBadRequest)
+	})
+}
+
+func TestClickAnalyticsService_GetBrandClickAnalytics(t *testing.T) {
+	t.Run("should reject users who are not members of the brand", func(t *testing.T) {
+		// Arrange
+		memberRepo := new(mocks.MockBrandMemberRepository)
+		analyticsRepo := new(mocks.MockClickAnalyticsRepository)
+		service := services.NewClickAnalyticsService(analyticsRepo, memberRepo)
+
+		memberRepo.On("GetMember", uint64(7), uint64(3)).Return(nil, gorm.ErrRecordNotFound)
+
+		// Act
+		_, err := service.GetBrandClickAnalytics(7, 3, &dtos.ClickAnalyticsQueryDTO{})
+
+		// Assert
+		assertAppErrorCode(t, err, http.StatusForbidden)
+		analyticsRepo.AssertNotCalled(t, "GetClickTotals")
+	})
+}
@@ -0,0 +1,405 @@
+package unit
+
+import (
+	"flicknfit_backend/config"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"flicknfit_backend/tests/testhelpers"
+	"flicknfit_backend/utils"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestConversionService_RecordConversion(t *testing.T) {
+	newService := func() (services.ConversionService, *mocks.MockConversionRepository, *mocks.MockProductRepository, *mocks.MockBrandMemberRepository) {
+		conversionRepo := new(mocks.MockConversionRepository)
+		productRepo := new(mocks.MockProductRepository)
+		memberRepo := new(mocks.MockBrandMemberRepository)
+		return services.NewConversionService(conversionRepo, productRepo, memberRepo, new(mocks.MockProductClickRepository), &config.Config{}), conversionRepo, productRepo, memberRepo
+	}
+	dto := &dtos.ConversionRequestDTO{SKU: "LS-M", Quantity: 2, OrderRef: "INV-001"}
+
+	t.Run("should record a sale of the member's brand", func(t *testing.T) {
+		// Arrange
+		service, conversionRepo, productRepo, memberRepo := newService()
+
+		memberRepo.On("GetMember", uint64(7), uint64(3)).Return(&models.BrandMember{BrandID: 7, UserID: 3}, nil)
+		productRepo.On("GetProductItemsBySKUs", []string{"LS-M"}).Return([]models.ProductItem{{ID: 11, ProductID: 1, SKU: "LS-M"}}, nil)
+		productRepo.On("GetProductByID", uint64(1)).Return(&models.Product{ID: 1, BrandID: 7, Name: "Linen Shirt"}, nil)
+		conversionRepo.On("RecordConversion", mock.MatchedBy(func(c *models.Conversion) bool {
+			return c.BrandID == 7 && c.ProductID == 1 && c.ProductItemID == 11 && c.Quantity == 2 &&
+				c.OrderRef == "INV-001" && c.Source == constants.ConversionSourceAPI && *c.RecordedBy == 3 &&
+				time.Since(c.ConvertedAt) < time.Minute
+		})).Return(nil)
+
+		// Act
+		conversion, err := service.RecordConversion(7, 3, dto)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "Linen Shirt", conversion.Product.Name)
+		conversionRepo.AssertExpectations(t)
+	})
+
+	t.Run("should refuse users outside the brand", func(t *testing.T) {
+		// Arrange
+		service, conversionRepo, _, memberRepo := newService()
+
+		memberRepo.On("GetMember", uint64(7), uint64(3)).Return(nil, gorm.ErrRecordNotFound)
+
+		// Act
+		_, err := service.RecordConversion(7, 3, dto)
+
+		// Assert
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusForbidden, appErr.Code)
+		conversionRepo.AssertNotCalled(t, "RecordConversion", mock.Anything)
+	})
+
+	t.Run("should not accept items of another brand", func(t *testing.T) {
+		// Arrange
+		service, conversionRepo, productRepo, memberRepo := newService()
+
+		memberRepo.On("GetMember", uint64(7), uint64(3)).Return(&models.BrandMember{BrandID: 7, UserID: 3}, nil)
+		productRepo.On("GetProductItemsBySKUs", []string{"LS-M"}).Return([]models.ProductItem{{ID: 11, ProductID: 1}}, nil)
+		productRepo.On("GetProductByID", uint64(1)).Return(&models.Product{ID: 1, BrandID: 8}, nil)
+
+		// Act
+		_, err := service.RecordConversion(7, 3, dto)
+
+		// Assert
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+		conversionRepo.AssertNotCalled(t, "RecordConversion", mock.Anything)
+	})
+
+	t.Run("should report duplicate order lines as a conflict", func(t *testing.T) {
+		// Arrange
+		service, conversionRepo, productRepo, memberRepo := newService()
+
+		memberRepo.On("GetMember", uint64(7), uint64(3)).Return(&models.BrandMember{BrandID: 7, UserID: 3}, nil)
+		productRepo.On("GetProductItemsBySKUs", []string{"LS-M"}).Return([]models.ProductItem{{ID: 11, ProductID: 1}}, nil)
+		productRepo.On("GetProductByID", uint64(1)).Return(&models.Product{ID: 1, BrandID: 7}, nil)
+		conversionRepo.On("RecordConversion", mock.Anything).Return(repositories.ErrDuplicateConversion)
+
+		// Act
+		_, err := service.RecordConversion(7, 3, dto)
+
+		// Assert
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, appErr.Code)
+	})
+}
+
+func TestConversionService_ReconcileCounters(t *testing.T) {
+	t.Run("should return the number of corrected counters", func(t *testing.T) {
+		// Arrange
+		conversionRepo := new(mocks.MockConversionRepository)
+		service := services.NewConversionService(conversionRepo, new(mocks.MockProductRepository), new(mocks.MockBrandMemberRepository), new(mocks.MockProductClickRepository), &config.Config{})
+
+		conversionRepo.On("ReconcileCounters").Return(&models.CounterReconciliation{ItemsCorrected: 2, BrandsCorrected: 1}, nil)
+
+		// Act
+		result, err := service.ReconcileCounters()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), result.ItemsCorrected)
+		assert.Equal(t, int64(1), result.BrandsCorrected)
+	})
+}
+
+func TestConversionService_ClickAttribution(t *testing.T) {
+	newService := func() (services.ConversionService, *mocks.MockConversionRepository, *mocks.MockProductClickRepository) {
+		conversionRepo := new(mocks.MockConversionRepository)
+		productRepo := new(mocks.MockProductRepository)
+		memberRepo := new(mocks.MockBrandMemberRepository)
+		clickRepo := new(mocks.MockProductClickRepository)
+		memberRepo.On("GetMember", uint64(7), uint64(3)).Return(&models.BrandMember{BrandID: 7, UserID: 3}, nil)
+		productRepo.On("GetProductItemsBySKUs", []string{"LS-M"}).Return([]models.ProductItem{{ID: 11, ProductID: 1}}, nil)
+		productRepo.On("GetProductByID", uint64(1)).Return(&models.Product{ID: 1, BrandID: 7}, nil)
+		service := services.NewConversionService(conversionRepo, productRepo, memberRepo, clickRepo, &config.Config{ClickIDSecret: "secret"})
+		return service, conversionRepo, clickRepo
+	}
+
+	t.Run("should link the reported click and take its channel", func(t *testing.T) {
+		// Arrange
+		service, conversionRepo, clickRepo := newService()
+		dto := &dtos.ConversionRequestDTO{SKU: "LS-M", Quantity: 1, OrderRef: "INV-002", ClickID: utils.SignClickID(99, "secret")}
+
+		clickRepo.On("GetByID", uint64(99)).Return(&models.ProductClick{ID: 99, BrandID: 7, Channel: "shopee"}, nil)
+		conversionRepo.On("RecordConversion", mock.MatchedBy(func(c *models.Conversion) bool {
+			return c.ClickID != nil && *c.ClickID == 99 && c.Channel == "shopee"
+		})).Return(nil)
+
+		// Act
+		_, err := service.RecordConversion(7, 3, dto)
+
+		// Assert
+		assert.NoError(t, err)
+		conversionRepo.AssertExpectations(t)
+	})
+
+	t.Run("should link a click by the reference in its click ID", func(t *testing.T) {
+		// Arrange
+		service, conversionRepo, clickRepo := newService()
+		ref := "c0123456789abcdef0123456789abcd"
+		dto := &dtos.ConversionRequestDTO{SKU: "LS-M", Quantity: 1, OrderRef: "INV-003", ClickID: utils.SignClickRef(ref, "secret")}
+
+		clickRepo.On("GetByRef", ref).Return(&models.ProductClick{ID: 120, BrandID: 7, Channel: "tokopedia"}, nil)
+		conversionRepo.On("RecordConversion", mock.MatchedBy(func(c *models.Conversion) bool {
+			return c.ClickID != nil && *c.ClickID == 120 && c.Channel == "tokopedia"
+		})).Return(nil)
+
+		// Act
+		_, err := service.RecordConversion(7, 3, dto)
+
+		// Assert
+		assert.NoError(t, err)
+		clickRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+	})
+
+	t.Run("should reject forged click IDs", func(t *testing.T) {
+		// Arrange
+		service, conversionRepo, _ := newService()
+		dto := &dtos.ConversionRequestDTO{SKU: "LS-M", Quantity: 1, OrderRef: "INV-002", ClickID: utils.SignClickID(99, "other")}
+
+		// Act
+		_, err := service.RecordConversion(7, 3, dto)
+
+		// Assert
+		assertAppErrorCode(t, err, http.StatusBadRequest)
+		conversionRepo.AssertNotCalled(t, "RecordConversion", mock.Anything)
+	})
+
+	t.Run("should reject clicks of another brand", func(t *testing.T) {
+		// Arrange
+		service, conversionRepo, clickRepo := newService()
+		dto := &dtos.ConversionRequestDTO{SKU: "LS-M", Quantity: 1, OrderRef: "INV-002", ClickID: utils.SignClickID(99, "secret")}
+
+		clickRepo.On("GetByID", uint64(99)).Return(&models.ProductClick{ID: 99, BrandID: 8}, nil)
+
+		// Act
+		_, err := service.RecordConversion(7, 3, dto)
+
+		// Assert
+		assertAppErrorCode(t, err, http.StatusBadRequest)
+		conversionRepo.AssertNotCalled(t, "RecordConversion", mock.Anything)
+	})
+}
+
+func TestConversionService_VerifyPostback(t *testing.T) {
+	body := []byte(`{"order_ref":"INV-003","items":[{"sku":"LS-M","quantity":1}]}`)
+	newService := func(secret string) services.ConversionService {
+		conversionRepo := new(mocks.MockConversionRepository)
+		conversionRepo.On("GetPostbackSecret", uint64(7)).Return(secret, nil)
+		return services.NewConversionService(conversionRepo, new(mocks.MockProductRepository), new(mocks.MockBrandMemberRepository), new(mocks.MockProductClickRepository), &config.Config{})
+	}
+
+	t.Run("should accept a fresh signed postback", func(t *testing.T) {
+		service := newService("brand-secret")
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+		err := service.VerifyPostback(7, timestamp, "sha256="+utils.SignPostback("brand-secret", timestamp, body), body)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should reject a wrong signature", func(t *testing.T) {
+		service := newService("brand-secret")
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+		err := service.VerifyPostback(7, timestamp, utils.SignPostback("other-secret", timestamp, body), body)
+
+		assertAppErrorCode(t, err, http.StatusUnauthorized)
+	})
+
+	t.Run("should reject an old timestamp", func(t *testing.T) {
+		service := newService("brand-secret")
+		timestamp := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+
+		err := service.VerifyPostback(7, timestamp, utils.SignPostback("brand-secret", timestamp, body), body)
+
+		assertAppErrorCode(t, err, http.StatusUnauthorized)
+	})
+
+	t.Run("should refuse brands without a secret", func(t *testing.T) {
+		service := newService("")
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+		err := service.VerifyPostback(7, timestamp, utils.SignPostback("", timestamp, body), body)
+
+		assertAppErrorCode(t, err, http.StatusForbidden)
+	})
+}
+
+func TestConversionService_RecordPostback(t *testing.T) {
+	newService := func() (services.ConversionService, *mocks.MockConversionRepository) {
+		conversionRepo := new(mocks.MockConversionRepository)
+		productRepo := new(mocks.MockProductRepository)
+		productRepo.On("GetProductItemsBySKUs", []string{"LS-M"}).Return([]models.ProductItem{{ID: 11, ProductID: 1, Price: 150000}}, nil)
+		productRepo.On("GetProductItemsBySKUs", []string{"LS-L"}).Return([]models.ProductItem{{ID: 12, ProductID: 1, Price: 160000}}, nil)
+		productRepo.On("GetProductItemsBySKUs", []string{"NOPE"}).Return([]models.ProductItem{}, nil)
+		productRepo.On("GetProductByID", uint64(1)).Return(&models.Product{ID: 1, BrandID: 7}, nil)
+		service := services.NewConversionService(conversionRepo, productRepo, new(mocks.MockBrandMemberRepository), new(mocks.MockProductClickRepository), &config.Config{})
+		return service, conversionRepo
+	}
+	amount := 250000
+	dto := &dtos.ConversionPostbackDTO{OrderRef: "INV-003", Items: []dtos.ConversionPostbackItemDTO{
+		{SKU: "LS-M", Quantity: 2},
+		{SKU: "LS-L", Quantity: 2, Amount: &amount},
+	}}
+
+	t.Run("should record new lines and skip recorded ones", func(t *testing.T) {
+		// Arrange
+		service, conversionRepo := newService()
+
+		conversionRepo.On("GetPostback", uint64(7), "delivery-1").Return(nil, gorm.ErrRecordNotFound)
+		conversionRepo.On("RecordPostback", mock.MatchedBy(func(p *models.ConversionPostback) bool {
+			return p.BrandID == 7 && p.IdempotencyKey == "delivery-1" && p.OrderRef == "INV-003"
+		}), mock.MatchedBy(func(conversions []*models.Conversion) bool {
+			return len(conversions) == 2 &&
+				conversions[0].ProductItemID == 11 && conversions[0].Revenue == 300000 &&
+				conversions[0].Source == constants.ConversionSourceWebhook && conversions[0].RecordedBy == nil &&
+				conversions[1].ProductItemID == 12 && conversions[1].Revenue == 250000
+		})).Run(func(args mock.Arguments) {
+			postback := args.Get(0).(*models.ConversionPostback)
+			postback.Recorded, postback.Duplicates = 1, 1
+		}).Return([]*models.Conversion{{ID: 21, ProductItemID: 11}}, nil)
+
+		// Act
+		result, err := service.RecordPostback(7, "delivery-1", dto)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Recorded)
+		assert.Equal(t, 1, result.Duplicates)
+		assert.Len(t, result.Conversions, 1)
+		assert.False(t, result.Replayed)
+		conversionRepo.AssertExpectations(t)
+	})
+
+	t.Run("should replay when a concurrent delivery used the key first", func(t *testing.T) {
+		// Arrange
+		service, conversionRepo := newService()
+
+		conversionRepo.On("GetPostback", uint64(7), "delivery-1").Return(nil, gorm.ErrRecordNotFound).Once()
+		conversionRepo.On("RecordPostback", mock.Anything, mock.Anything).Return(nil, repositories.ErrDuplicatePostback)
+		conversionRepo.On("GetPostback", uint64(7), "delivery-1").Return(&models.ConversionPostback{OrderRef: "INV-003", Recorded: 2}, nil).Once()
+
+		// Act
+		result, err := service.RecordPostback(7, "delivery-1", dto)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, result.Replayed)
+		assert.Equal(t, 2, result.Recorded)
+		conversionRepo.AssertExpectations(t)
+	})
+
+	t.Run("should replay a repeated idempotency key", func(t *testing.T) {
+		// Arrange
+		service, conversionRepo := newService()
+
+		conversionRepo.On("GetPostback", uint64(7), "delivery-1").Return(&models.ConversionPostback{OrderRef: "INV-003", Recorded: 2}, nil)
+
+		// Act
+		result, err := service.RecordPostback(7, "delivery-1", dto)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, result.Replayed)
+		assert.Equal(t, 2, result.Recorded)
+		conversionRepo.AssertNotCalled(t, "RecordPostback", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject the whole order when a SKU is unknown", func(t *testing.T) {
+		// Arrange
+		service, conversionRepo := newService()
+		unknown := &dtos.ConversionPostbackDTO{OrderRef: "INV-004", Items: []dtos.ConversionPostbackItemDTO{{SKU: "LS-M", Quantity: 1}, {SKU: "NOPE", Quantity: 1}}}
+
+		// Act
+		_, err := service.RecordPostback(7, "", unknown)
+
+		// Assert
+		assertAppErrorCode(t, err, http.StatusNotFound)
+		conversionRepo.AssertNotCalled(t, "RecordPostback", mock.Anything, mock.Anything)
+	})
+}
+
+func TestConversionRepository_RecordConversion(t *testing.T) {
+	t.Run("should reject an order line recorded twice through the unique index", func(t *testing.T) {
+		// Arrange
+		db := testhelpers.NewTestDB(t, &models.Product{}, &models.ProductItem{}, &models.Conversion{})
+		product := models.Product{BrandID: 7, Name: "Linen Shirt", Description: "Linen", ProductItems: []models.ProductItem{{SKU: "LS-M", Price: 100000, Stock: 5}}}
+		assert.NoError(t, db.Create(&product).Error)
+		repo := repositories.NewConversionRepository(db)
+		newConversion := func() *models.Conversion {
+			return &models.Conversion{BrandID: 7, ProductID: product.ID, ProductItemID: product.ProductItems[0].ID, Quantity: 2, OrderRef: "INV-1", Source: constants.ConversionSourceAPI, ConvertedAt: time.Now()}
+		}
+
+		// Act
+		first := repo.RecordConversion(newConversion())
+		second := repo.RecordConversion(newConversion())
+
+		// Assert
+		assert.NoError(t, first)
+		assert.ErrorIs(t, second, repositories.ErrDuplicateConversion)
+		var item models.ProductItem
+		assert.NoError(t, db.First(&item, product.ProductItems[0].ID).Error)
+		assert.Equal(t, 2, item.Sold)
+	})
+}
+
+func TestConversionRepository_RecordPostback(t *testing.T) {
+	t.Run("should record the lines and the key together and roll back a repeated key", func(t *testing.T) {
+		// Arrange
+		db := testhelpers.NewTestDB(t, &models.Product{}, &models.ProductItem{}, &models.Conversion{}, &models.ConversionPostback{})
+		product := models.Product{BrandID: 7, Name: "Linen Shirt", Description: "Linen", ProductItems: []models.ProductItem{
+			{SKU: "LS-M", Price: 100000, Stock: 5},
+			{SKU: "LS-L", Price: 100000, Stock: 5},
+		}}
+		assert.NoError(t, db.Create(&product).Error)
+		repo := repositories.NewConversionRepository(db)
+		newConversions := func(orderRef string) []*models.Conversion {
+			conversions := make([]*models.Conversion, len(product.ProductItems))
+			for i, item := range product.ProductItems {
+				conversions[i] = &models.Conversion{BrandID: 7, ProductID: product.ID, ProductItemID: item.ID, Quantity: 1, OrderRef: orderRef, Source: constants.ConversionSourceWebhook, ConvertedAt: time.Now()}
+			}
+			return conversions
+		}
+		assert.NoError(t, repo.RecordConversion(newConversions("INV-1")[0]))
+
+		// Act
+		first := &models.ConversionPostback{BrandID: 7, IdempotencyKey: "delivery-1", OrderRef: "INV-1"}
+		recorded, err := repo.RecordPostback(first, newConversions("INV-1"))
+		_, replayErr := repo.RecordPostback(&models.ConversionPostback{BrandID: 7, IdempotencyKey: "delivery-1", OrderRef: "INV-2"}, newConversions("INV-2"))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, recorded, 1)
+		assert.Equal(t, 1, first.Recorded)
+		assert.Equal(t, 1, first.Duplicates)
+		assert.ErrorIs(t, replayErr, repositories.ErrDuplicatePostback)
+		var count int64
+		assert.NoError(t, db.Model(&models.Conversion{}).Where("order_ref = ?", "INV-2").Count(&count).Error)
+		assert.Zero(t, count)
+		var item models.ProductItem
+		assert.NoError(t, db.First(&item, product.ProductItems[0].ID).Error)
+		assert.Equal(t, 1, item.Sold)
+	})
+}
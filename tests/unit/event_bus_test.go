@@ -0,0 +1,105 @@
+package unit
+
+import (
+	"errors"
+	"flicknfit_backend/services"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventBus(t *testing.T) {
+	t.Run("should hand events over in batches and flush the rest when stopped", func(t *testing.T) {
+		// Arrange
+		bus := services.NewEventBus(services.EventBusOptions{QueueSize: 100, Workers: 2, BatchSize: 3, FlushInterval: time.Hour, PublishTimeout: time.Second})
+		var mu sync.Mutex
+		var batches [][]int
+		bus.Subscribe("test", func(events []services.Event) error {
+			batch := make([]int, 0, len(events))
+			for _, event := range events {
+				batch = append(batch, event.Payload.(int))
+			}
+			mu.Lock()
+			batches = append(batches, batch)
+			mu.Unlock()
+			return nil
+		})
+		bus.Start()
+
+		// Act: one key keeps all events on one worker, in order
+		for i := 1; i <= 7; i++ {
+			assert.NoError(t, bus.Publish(services.Event{Topic: "test", Key: "a", Payload: i}))
+		}
+		bus.Stop()
+
+		// Assert
+		assert.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}, {7}}, batches)
+	})
+
+	t.Run("should flush waiting events every interval", func(t *testing.T) {
+		// Arrange
+		bus := services.NewEventBus(services.EventBusOptions{QueueSize: 10, Workers: 1, BatchSize: 100, FlushInterval: 10 * time.Millisecond, PublishTimeout: time.Second})
+		handled := make(chan int, 1)
+		bus.Subscribe("test", func(events []services.Event) error {
+			handled <- len(events)
+			return nil
+		})
+		bus.Start()
+		defer bus.Stop()
+
+		// Act
+		bus.Publish(services.Event{Topic: "test", Payload: 1})
+
+		// Assert
+		select {
+		case size := <-handled:
+			assert.Equal(t, 1, size)
+		case <-time.After(time.Second):
+			t.Fatal("events were not flushed")
+		}
+	})
+
+	t.Run("should refuse events once the queue stays full", func(t *testing.T) {
+		// Arrange: the bus is not started, so nothing drains the queue
+		bus := services.NewEventBus(services.EventBusOptions{QueueSize: 1, Workers: 1, BatchSize: 10, FlushInterval: time.Hour, PublishTimeout: 10 * time.Millisecond})
+
+		// Act
+		first := bus.Publish(services.Event{Topic: "test"})
+		second := bus.Publish(services.Event{Topic: "test"})
+
+		// Assert
+		assert.NoError(t, first)
+		assert.ErrorIs(t, second, services.ErrEventBusFull)
+	})
+
+	t.Run("should keep handling events after a failed batch and refuse events once stopped", func(t *testing.T) {
+		// Arrange
+		bus := services.NewEventBus(services.EventBusOptions{QueueSize: 10, Workers: 1, BatchSize: 1, FlushInterval: time.Hour, PublishTimeout: time.Second})
+		var handled []int
+		bus.Subscribe("test", func(events []services.Event) error {
+			value := events[0].Payload.(int)
+			if value == 1 {
+				panic("boom")
+			}
+			handled = append(handled, value)
+			if value == 2 {
+				return errors.New("write failed")
+			}
+			return nil
+		})
+		bus.Start()
+
+		// Act
+		for i := 1; i <= 3; i++ {
+			bus.Publish(services.Event{Topic: "test", Payload: i})
+		}
+		bus.Stop()
+		err := bus.Publish(services.Event{Topic: "test", Payload: 4})
+
+		// Assert
+		assert.Equal(t, []int{2, 3}, handled)
+		assert.ErrorIs(t, err, services.ErrEventBusClosed)
+	})
+}
@@ -2,8 +2,11 @@ package unit
 
 import (
 	"errors"
+	"flicknfit_backend/constants"
 	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
 	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
 	"flicknfit_backend/services"
 	"flicknfit_backend/tests/mocks"
 	"flicknfit_backend/tests/testhelpers"
@@ -66,7 +69,7 @@ func TestFavoriteService_AddFavorite(t *testing.T) {
 		productItem := testhelpers.CreateTestProductItem()
 		dto := &dtos.AddFavoriteDTO{ProductItemID: productItem.ID}
 
-		mockProductRepo.On("GetProductItemByID", dto.ProductItemID).Return(&productItem, nil)
+		mockProductRepo.On("GetPublishedProductItemByID", dto.ProductItemID).Return(&productItem, nil)
 		mockFavoriteRepo.On("IsFavorite", userID, dto.ProductItemID).Return(false, nil)
 		mockFavoriteRepo.On("AddFavorite", mock.AnythingOfType("*models.Favorite")).Return(nil)
 
@@ -88,7 +91,7 @@ func TestFavoriteService_AddFavorite(t *testing.T) {
 		userID := uint64(1)
 		dto := &dtos.AddFavoriteDTO{ProductItemID: 999}
 
-		mockProductRepo.On("GetProductItemByID", dto.ProductItemID).Return(nil, errors.New("not found"))
+		mockProductRepo.On("GetPublishedProductItemByID", dto.ProductItemID).Return(nil, errors.New("not found"))
 
 		// Act
 		err := service.AddFavorite(userID, dto)
@@ -98,6 +101,31 @@ func TestFavoriteService_AddFavorite(t *testing.T) {
 		mockProductRepo.AssertExpectations(t)
 	})
 
+	t.Run("should return not found when product is a draft", func(t *testing.T) {
+		// Arrange
+		db := testhelpers.NewTestDB(t, &models.Product{}, &models.ProductItem{})
+		product := models.Product{
+			BrandID:      1,
+			Name:         "Draft Jacket",
+			Description:  "Not published yet",
+			Status:       constants.ProductStatusDraft,
+			ProductItems: []models.ProductItem{{SKU: "DJ-1", Price: 100000, Stock: 1}},
+		}
+		assert.NoError(t, db.Create(&product).Error)
+
+		mockFavoriteRepo := new(mocks.MockFavoriteRepository)
+		service := services.NewFavoriteService(mockFavoriteRepo, repositories.NewProductRepository(db))
+
+		// Act
+		err := service.AddFavorite(1, &dtos.AddFavoriteDTO{ProductItemID: product.ProductItems[0].ID})
+
+		// Assert
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, apperrors.ErrorTypeNotFound, appErr.Type)
+		mockFavoriteRepo.AssertNotCalled(t, "AddFavorite", mock.Anything)
+	})
+
 	t.Run("should return error when product already favorited", func(t *testing.T) {
 		// Arrange
 		mockFavoriteRepo := new(mocks.MockFavoriteRepository)
@@ -108,7 +136,7 @@ func TestFavoriteService_AddFavorite(t *testing.T) {
 		productItem := testhelpers.CreateTestProductItem()
 		dto := &dtos.AddFavoriteDTO{ProductItemID: productItem.ID}
 
-		mockProductRepo.On("GetProductItemByID", dto.ProductItemID).Return(&productItem, nil)
+		mockProductRepo.On("GetPublishedProductItemByID", dto.ProductItemID).Return(&productItem, nil)
 		mockFavoriteRepo.On("IsFavorite", userID, dto.ProductItemID).Return(true, nil)
 
 		// Act
@@ -173,7 +201,7 @@ func TestFavoriteService_ToggleFavorite(t *testing.T) {
 		productItem := testhelpers.CreateTestProductItem()
 		dto := &dtos.AddFavoriteDTO{ProductItemID: productItem.ID}
 
-		mockProductRepo.On("GetProductItemByID", dto.ProductItemID).Return(&productItem, nil)
+		mockProductRepo.On("GetPublishedProductItemByID", dto.ProductItemID).Return(&productItem, nil)
 		mockFavoriteRepo.On("IsFavorite", userID, dto.ProductItemID).Return(false, nil)
 		mockFavoriteRepo.On("AddFavorite", mock.AnythingOfType("*models.Favorite")).Return(nil)
 
@@ -199,7 +227,7 @@ func TestFavoriteService_ToggleFavorite(t *testing.T) {
 		productItem := testhelpers.CreateTestProductItem()
 		dto := &dtos.AddFavoriteDTO{ProductItemID: productItem.ID}
 
-		mockProductRepo.On("GetProductItemByID", dto.ProductItemID).Return(&productItem, nil)
+		mockProductRepo.On("GetPublishedProductItemByID", dto.ProductItemID).Return(&productItem, nil)
 		mockFavoriteRepo.On("IsFavorite", userID, dto.ProductItemID).Return(true, nil)
 		mockFavoriteRepo.On("RemoveFavorite", userID, dto.ProductItemID).Return(nil)
 
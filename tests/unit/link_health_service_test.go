@@ -0,0 +1,169 @@
+package unit
+
+import (
+	"flicknfit_backend/config"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/models"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newLinkServer serves /ok, /gone, /no-head (405 on HEAD) and /blocked (403, as marketplaces answer bots).
+func newLinkServer(t *testing.T, requests *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/blocked":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLinkHealthService_CheckAllLinks(t *testing.T) {
+	t.Run("should record status codes and mark links broken after repeated failures", func(t *testing.T) {
+		// Arrange
+		var requests int32
+		server := newLinkServer(t, &requests)
+		linkRepo := new(mocks.MockLinkCheckRepository)
+		service := services.NewLinkHealthService(linkRepo, server.Client())
+		failingSince := time.Now().Add(-6 * time.Hour)
+
+		linkRepo.On("GetLinkTargets").Return([]models.LinkCheck{
+			{EntityType: constants.LinkEntityProduct, EntityID: 1, Field: "tokopedia_product_url", URL: server.URL + "/gone"},
+			{EntityType: constants.LinkEntityProduct, EntityID: 2, Field: "tokopedia_product_url", URL: server.URL + "/gone"},
+			{EntityType: constants.LinkEntityProduct, EntityID: 1, Field: "shopee_product_url", URL: server.URL + "/no-head"},
+			{EntityType: constants.LinkEntityBrand, EntityID: 7, Field: "instagram_url", URL: server.URL + "/blocked"},
+			{EntityType: constants.LinkEntityBrand, EntityID: 7, Field: "website_url", URL: server.URL + "/ok"},
+		}, nil)
+		linkRepo.On("GetLinkChecks").Return([]models.LinkCheck{
+			{EntityType: constants.LinkEntityProduct, EntityID: 1, Field: "tokopedia_product_url", URL: server.URL + "/gone",
+				ConsecutiveFailures: 1, FailingSince: &failingSince},
+		}, nil)
+
+		var saved []models.LinkCheck
+		linkRepo.On("SaveLinkChecks", mock.Anything, mock.AnythingOfType("time.Time")).
+			Run(func(args mock.Arguments) { saved = args.Get(0).([]models.LinkCheck) }).
+			Return(nil)
+
+		// Act
+		summary, err := service.CheckAllLinks()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, models.LinkCheckSummary{LinksChecked: 5, URLsChecked: 4, Broken: 1}, *summary)
+		assert.Len(t, saved, 5)
+		// Each URL once; HEAD answers of 400 and above are retried with GET
+		assert.Equal(t, int32(7), atomic.LoadInt32(&requests))
+
+		// Failed before: second failure in a row makes it broken, keeping the first failure time
+		assert.True(t, saved[0].Broken)
+		assert.Equal(t, http.StatusNotFound, saved[0].StatusCode)
+		assert.Equal(t, failingSince, *saved[0].FailingSince)
+		// Same URL on another product: first failure only
+		assert.False(t, saved[1].Broken)
+		assert.Equal(t, 1, saved[1].ConsecutiveFailures)
+		// HEAD refused, GET succeeds
+		assert.Equal(t, http.StatusOK, saved[2].StatusCode)
+		assert.Zero(t, saved[2].ConsecutiveFailures)
+		// Bot protection is not a dead link
+		assert.False(t, saved[3].Broken)
+		assert.Empty(t, saved[3].Error)
+		assert.Nil(t, saved[4].FailingSince)
+	})
+
+	t.Run("should start over when a failing link was replaced", func(t *testing.T) {
+		// Arrange
+		var requests int32
+		server := newLinkServer(t, &requests)
+		linkRepo := new(mocks.MockLinkCheckRepository)
+		service := services.NewLinkHealthService(linkRepo, server.Client())
+
+		linkRepo.On("GetLinkTargets").Return([]models.LinkCheck{
+			{EntityType: constants.LinkEntityBrand, EntityID: 7, Field: "shopee_url", URL: server.URL + "/moved"},
+		}, nil)
+		linkRepo.On("GetLinkChecks").Return([]models.LinkCheck{
+			{EntityType: constants.LinkEntityBrand, EntityID: 7, Field: "shopee_url", URL: server.URL + "/old", ConsecutiveFailures: 4, Broken: true},
+		}, nil)
+		linkRepo.On("SaveLinkChecks", mock.MatchedBy(func(checks []models.LinkCheck) bool {
+			return len(checks) == 1 && checks[0].ConsecutiveFailures == 1 && !checks[0].Broken
+		}), mock.AnythingOfType("time.Time")).Return(nil)
+
+		// Act
+		summary, err := service.CheckAllLinks()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Zero(t, summary.Broken)
+		linkRepo.AssertExpectations(t)
+	})
+
+	t.Run("should refuse links to internal addresses without requesting them", func(t *testing.T) {
+		// Arrange
+		var requests int32
+		server := newLinkServer(t, &requests)
+		linkRepo := new(mocks.MockLinkCheckRepository)
+		service := services.NewLinkHealthService(linkRepo, nil)
+
+		linkRepo.On("GetLinkTargets").Return([]models.LinkCheck{
+			{EntityType: constants.LinkEntityBrand, EntityID: 7, Field: "website_url", URL: server.URL + "/ok"},
+			{EntityType: constants.LinkEntityBrand, EntityID: 7, Field: "shopee_url", URL: "http://169.254.169.254/latest/meta-data"},
+		}, nil)
+		linkRepo.On("GetLinkChecks").Return([]models.LinkCheck{}, nil)
+
+		var saved []models.LinkCheck
+		linkRepo.On("SaveLinkChecks", mock.Anything, mock.AnythingOfType("time.Time")).
+			Run(func(args mock.Arguments) { saved = args.Get(0).([]models.LinkCheck) }).
+			Return(nil)
+
+		// Act
+		_, err := service.CheckAllLinks()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Zero(t, atomic.LoadInt32(&requests))
+		assert.Len(t, saved, 2)
+		assert.Contains(t, saved[0].Error, "not a public address")
+		assert.Contains(t, saved[1].Error, "not a public address")
+	})
+}
+
+func TestTrackingService_GetRedirectURL(t *testing.T) {
+	t.Run("should skip broken links", func(t *testing.T) {
+		// Arrange
+		linkRepo := new(mocks.MockLinkCheckRepository)
+		channelRepo := new(mocks.MockRedirectChannelRepository)
+		service := services.NewTrackingService(nil, new(mocks.MockProductRepository), new(mocks.MockBrandRepository), new(mocks.MockUserRepository), linkRepo, channelRepo, services.NewEventBus(services.EventBusOptions{}), &config.Config{})
+		product := &models.Product{ID: 1, TokopediaProductURL: "https://tokopedia.com/shop/dead", ShopeeProductURL: "https://shopee.co.id/shop/item"}
+		brand := &models.Brand{ID: 7}
+
+		linkRepo.On("GetBrokenURLs", uint64(1), uint64(7)).Return([]string{"https://tokopedia.com/shop/dead"}, nil)
+		channelRepo.On("GetChannels", uint64(7)).Return([]models.BrandRedirectChannel{}, nil)
+
+		// Act
+		url, channel := service.GetRedirectURL(product, brand)
+
+		// Assert
+		assert.Equal(t, "https://shopee.co.id/shop/item", url)
+		assert.Equal(t, "shopee", channel)
+	})
+}
@@ -0,0 +1,65 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"flicknfit_backend/services"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeLLMProvider answers with fixed styles or fails, and counts its calls
+type fakeLLMProvider struct {
+	name   string
+	styles []string
+	err    error
+	calls  int
+}
+
+func (p *fakeLLMProvider) GenerateColorRecommendations(ctx context.Context, skinTone string) ([]string, error) {
+	return nil, errors.New("not used")
+}
+
+func (p *fakeLLMProvider) GenerateStyleRecommendations(ctx context.Context, bodyType string) ([]string, error) {
+	p.calls++
+	return p.styles, p.err
+}
+
+func (p *fakeLLMProvider) GetName() string {
+	return p.name
+}
+
+func TestLLMChain_GenerateStyleRecommendations(t *testing.T) {
+	t.Run("should fall back to the next provider", func(t *testing.T) {
+		// Arrange
+		failing := &fakeLLMProvider{name: "first", err: errors.New("status 503")}
+		working := &fakeLLMProvider{name: "second", styles: []string{"Jeans", "Shirts"}}
+		chain := services.NewLLMChain(failing, working)
+
+		// Act
+		styles, err := chain.GenerateStyleRecommendations(context.Background(), "Hourglass")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Jeans", "Shirts"}, styles)
+		assert.Equal(t, 1, failing.calls)
+	})
+
+	t.Run("should stop trying providers once the context is done", func(t *testing.T) {
+		// Arrange
+		first := &fakeLLMProvider{name: "first", err: context.DeadlineExceeded}
+		second := &fakeLLMProvider{name: "second", styles: []string{"Jeans"}}
+		chain := services.NewLLMChain(first, second)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// Act
+		_, err := chain.GenerateStyleRecommendations(ctx, "Hourglass")
+
+		// Assert
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Zero(t, first.calls)
+		assert.Zero(t, second.calls)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"flicknfit_backend/middlewares"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
@@ -124,3 +125,26 @@ func TestRecoverHandler(t *testing.T) {
 		assert.Equal(t, 500, resp.StatusCode)
 	})
 }
+
+func TestRequestTimeout(t *testing.T) {
+	t.Run("should give the handler a context with a deadline", func(t *testing.T) {
+		// Arrange
+		app := fiber.New()
+		app.Use(middlewares.RequestTimeout(time.Minute))
+		var deadline time.Time
+		var hasDeadline bool
+		app.Get("/test", func(c *fiber.Ctx) error {
+			deadline, hasDeadline = c.UserContext().Deadline()
+			return c.SendStatus(200)
+		})
+
+		// Act
+		resp, err := app.Test(httptest.NewRequest("GET", "/test", nil))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.True(t, hasDeadline)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+	})
+}
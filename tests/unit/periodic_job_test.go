@@ -0,0 +1,42 @@
+package unit
+
+import (
+	"flicknfit_backend/services"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeriodicJob(t *testing.T) {
+	t.Run("should run immediately and then every interval until stopped", func(t *testing.T) {
+		// Arrange
+		var runs int32
+		ran := make(chan struct{}, 10)
+		job := services.NewPeriodicJob(10*time.Millisecond, func() {
+			atomic.AddInt32(&runs, 1)
+			select {
+			case ran <- struct{}{}:
+			default:
+			}
+		})
+
+		// Act
+		job.Start()
+		for i := 0; i < 3; i++ {
+			select {
+			case <-ran:
+			case <-time.After(time.Second):
+				t.Fatal("job did not run")
+			}
+		}
+		job.Stop()
+		stopped := atomic.LoadInt32(&runs)
+		time.Sleep(30 * time.Millisecond)
+
+		// Assert
+		assert.GreaterOrEqual(t, stopped, int32(3))
+		assert.Equal(t, stopped, atomic.LoadInt32(&runs))
+	})
+}
@@ -0,0 +1,186 @@
+package unit
+
+import (
+	"bytes"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/models"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newProductImportMocks() (*mocks.MockProductRepository, *mocks.MockBrandRepository, *mocks.MockVariationRepository) {
+	productRepo := new(mocks.MockProductRepository)
+	brandRepo := new(mocks.MockBrandRepository)
+	variationRepo := new(mocks.MockVariationRepository)
+
+	brandRepo.On("GetAllBrands").Return([]models.Brand{{ID: 1, Name: "Uniqlo"}}, nil)
+	variationRepo.On("GetAllVariations").Return([]models.ProductVariation{
+		{ID: 1, Name: "Ukuran", Options: []models.ProductVariationOption{{ID: 10, ProductAttributeID: 1, Value: "M"}, {ID: 11, ProductAttributeID: 1, Value: "L"}}},
+	}, nil)
+	return productRepo, brandRepo, variationRepo
+}
+
+func newProductImportService(productRepo *mocks.MockProductRepository, brandRepo *mocks.MockBrandRepository, variationRepo *mocks.MockVariationRepository) services.ProductImportService {
+	watchService, _, _ := newWatchService(new(mocks.MockCampaignRepository))
+	stockService := services.NewStockService(new(mocks.MockStockRepository), productRepo, new(mocks.MockBrandMemberRepository), services.NewNotificationService(new(mocks.MockNotificationRepository)), watchService)
+	return services.NewProductImportService(productRepo, brandRepo, variationRepo, watchService, stockService)
+}
+
+func TestProductImportService_ImportProducts(t *testing.T) {
+	header := "sku,product_name,brand,description,discount,categories,styles,variations,price,stock\n"
+
+	t.Run("should report row-level errors without saving", func(t *testing.T) {
+		// Arrange
+		productRepo, brandRepo, variationRepo := newProductImportMocks()
+		service := newProductImportService(productRepo, brandRepo, variationRepo)
+		productRepo.On("GetProductItemsBySKUs", mock.Anything).Return([]models.ProductItem{}, nil)
+		productRepo.On("GetProductByBrandAndName", uint64(1), "Basic Tee").Return(nil, assert.AnError)
+
+		csv := header +
+			"TS-M,Basic Tee,Uniqlo,Cotton tee,0,T-Shirts,Casual,Ukuran=M,99000,5\n" +
+			"TS-L,Basic Tee,Unknown,Cotton tee,0,T-Shirts,Casual,Ukuran=L,99000,5\n" +
+			"TS-XL,Basic Tee,Uniqlo,Cotton tee,0,T-Shirts,Casual,Ukuran=XL,99000,5\n" +
+			"TS-M,Basic Tee,Uniqlo,Cotton tee,0,T-Shirts,Casual,Ukuran=L,99000,5\n" +
+			"TS-S,Basic Tee,Uniqlo,Cotton tee,0,T-Shirts,Casual,Ukuran=M,abc,5\n"
+
+		// Act
+		result, err := service.ImportProducts(strings.NewReader(csv), dtos.ProductImportFormatCSV, false, nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 5, result.TotalRows)
+		rows := make([]int, 0, len(result.Errors))
+		for _, rowErr := range result.Errors {
+			rows = append(rows, rowErr.Row)
+		}
+		assert.Equal(t, []int{2, 3, 4, 5}, rows)
+		productRepo.AssertNotCalled(t, "SaveProductsWithDetails", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not save on dry run", func(t *testing.T) {
+		// Arrange
+		productRepo, brandRepo, variationRepo := newProductImportMocks()
+		service := newProductImportService(productRepo, brandRepo, variationRepo)
+		productRepo.On("GetProductItemsBySKUs", mock.Anything).Return([]models.ProductItem{}, nil)
+		productRepo.On("GetProductByBrandAndName", uint64(1), "Basic Tee").Return(nil, assert.AnError)
+
+		csv := header +
+			"TS-M,Basic Tee,Uniqlo,Cotton tee,0,T-Shirts,Casual,Ukuran=M,99000,5\n" +
+			"TS-L,Basic Tee,Uniqlo,Cotton tee,0,T-Shirts,Casual,Ukuran=L,99000,5\n"
+
+		// Act
+		result, err := service.ImportProducts(strings.NewReader(csv), dtos.ProductImportFormatCSV, true, nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, 1, result.ProductsCreated)
+		assert.Equal(t, 2, result.ItemsCreated)
+		productRepo.AssertNotCalled(t, "SaveProductsWithDetails", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should upsert existing item by SKU and keep other items", func(t *testing.T) {
+		// Arrange
+		productRepo, brandRepo, variationRepo := newProductImportMocks()
+		service := newProductImportService(productRepo, brandRepo, variationRepo)
+
+		existing := &models.Product{
+			ID:      7,
+			BrandID: 1,
+			Name:    "Basic Tee",
+			ProductItems: []models.ProductItem{
+				{ID: 70, ProductID: 7, SKU: "TS-M", Price: 50000, Stock: 1, Configurations: []models.ProductConfiguration{{ProductAttributeValueID: 10}}},
+				{ID: 71, ProductID: 7, SKU: "TS-L", Price: 50000, Stock: 1, Configurations: []models.ProductConfiguration{{ProductAttributeValueID: 11}}},
+			},
+		}
+		productRepo.On("GetProductItemsBySKUs", []string{"TS-M"}).Return([]models.ProductItem{{ID: 70, ProductID: 7, SKU: "TS-M"}}, nil)
+		productRepo.On("GetProductDetailsByID", uint64(7)).Return(existing, nil)
+		productRepo.On("SaveProductsWithDetails", mock.Anything, mock.Anything).Return(nil, nil)
+
+		json := `[{"sku":"TS-M","product_name":"Basic Tee","brand":"uniqlo","description":"Cotton tee","variations":{"Ukuran":"M"},"price":99000,"stock":20}]`
+
+		// Act
+		result, err := service.ImportProducts(strings.NewReader(json), dtos.ProductImportFormatJSON, false, nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, 1, result.ProductsUpdated)
+		assert.Equal(t, 1, result.ItemsUpdated)
+		saved := productRepo.Calls[len(productRepo.Calls)-1].Arguments.Get(0).([]*models.Product)
+		assert.Len(t, saved, 1)
+		assert.Len(t, saved[0].ProductItems, 2)
+		assert.Equal(t, uint64(70), saved[0].ProductItems[0].ID)
+		assert.Equal(t, 99000, saved[0].ProductItems[0].Price)
+		assert.Equal(t, 20, saved[0].ProductItems[0].Stock)
+	})
+
+	t.Run("should reject duplicate variation combination with an existing item", func(t *testing.T) {
+		// Arrange
+		productRepo, brandRepo, variationRepo := newProductImportMocks()
+		service := newProductImportService(productRepo, brandRepo, variationRepo)
+
+		existing := &models.Product{
+			ID:      7,
+			BrandID: 1,
+			Name:    "Basic Tee",
+			ProductItems: []models.ProductItem{
+				{ID: 70, ProductID: 7, SKU: "TS-M", Configurations: []models.ProductConfiguration{{ProductAttributeValueID: 10}}},
+			},
+		}
+		productRepo.On("GetProductItemsBySKUs", []string{"TS-M2"}).Return([]models.ProductItem{}, nil)
+		productRepo.On("GetProductByBrandAndName", uint64(1), "Basic Tee").Return(existing, nil)
+		productRepo.On("GetProductDetailsByID", uint64(7)).Return(existing, nil)
+
+		csv := header + "TS-M2,Basic Tee,Uniqlo,Cotton tee,0,,,Ukuran=M,99000,5\n"
+
+		// Act
+		result, err := service.ImportProducts(strings.NewReader(csv), dtos.ProductImportFormatCSV, false, nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, result.Errors, 1)
+		assert.Equal(t, 1, result.Errors[0].Row)
+		productRepo.AssertNotCalled(t, "SaveProductsWithDetails", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProductImportService_ExportProducts(t *testing.T) {
+	t.Run("should export one CSV row per item", func(t *testing.T) {
+		// Arrange
+		productRepo, brandRepo, variationRepo := newProductImportMocks()
+		service := newProductImportService(productRepo, brandRepo, variationRepo)
+
+		productRepo.On("GetAllProductDetails").Return([]*models.Product{{
+			ID:                7,
+			Name:              "Basic Tee",
+			Description:       "Cotton tee",
+			Brand:             models.Brand{Name: "Uniqlo"},
+			ProductCategories: []models.ProductCategory{{Category: "T-Shirts"}},
+			ProductStyles:     []models.ProductStyle{{Style: "Casual"}, {Style: "Minimalist"}},
+			ProductItems: []models.ProductItem{{
+				SKU:   "TS-M",
+				Price: 99000,
+				Stock: 5,
+				Configurations: []models.ProductConfiguration{{
+					ProductVariationOption: models.ProductVariationOption{Value: "M", ProductVariation: models.ProductVariation{Name: "Ukuran"}},
+				}},
+			}},
+		}}, nil)
+
+		// Act
+		var buf bytes.Buffer
+		err := service.ExportProducts(&buf, dtos.ProductImportFormatCSV)
+
+		// Assert
+		assert.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Len(t, lines, 2)
+		assert.True(t, strings.HasPrefix(lines[1], "TS-M,Basic Tee,Uniqlo,Cotton tee,0,T-Shirts,Casual|Minimalist,Ukuran=M,99000,5,"))
+	})
+}
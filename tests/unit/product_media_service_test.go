@@ -0,0 +1,156 @@
+package unit
+
+import (
+	"bytes"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testPNG encodes a solid PNG of the given size.
+func testPNG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 40, B: 40, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestProductMediaService_UploadMedia(t *testing.T) {
+	newService := func() (services.ProductMediaService, *mocks.MockProductMediaRepository, *mocks.MockProductRepository, *mocks.MockStorageService) {
+		mediaRepo := new(mocks.MockProductMediaRepository)
+		productRepo := new(mocks.MockProductRepository)
+		storage := new(mocks.MockStorageService)
+		productRepo.On("GetProductDetailsByID", uint64(1)).
+			Return(&models.Product{ID: 1, ProductItems: []models.ProductItem{{ID: 11}}}, nil)
+		return services.NewProductMediaService(mediaRepo, productRepo, storage), mediaRepo, productRepo, storage
+	}
+
+	t.Run("should upload image with thumbnail and make the first item media primary", func(t *testing.T) {
+		// Arrange
+		service, mediaRepo, _, storage := newService()
+		itemID := uint64(11)
+		data := testPNG(t, 800, 400)
+
+		mediaRepo.On("GetMediaByProductID", uint64(1)).Return([]models.ProductMedia{{ID: 5, ProductID: 1, IsPrimary: true}}, nil)
+		storage.On("UploadPublicFile", mock.MatchedBy(func(path string) bool {
+			return strings.HasPrefix(path, "products/1/") && strings.HasSuffix(path, ".png")
+		}), data, "image/png").Return("https://cdn.test/full.png", nil)
+		storage.On("UploadPublicFile", mock.MatchedBy(func(path string) bool {
+			return strings.HasPrefix(path, "products/1/thumbnails/")
+		}), mock.MatchedBy(func(thumb []byte) bool {
+			img, err := jpeg.Decode(bytes.NewReader(thumb))
+			return err == nil && img.Bounds().Dx() == constants.ProductThumbnailWidth && img.Bounds().Dy() == 200
+		}), "image/jpeg").Return("https://cdn.test/thumb.jpg", nil)
+		mediaRepo.On("CreateMedia", mock.AnythingOfType("*models.ProductMedia")).Return(nil)
+
+		// Act
+		media, err := service.UploadMedia(1, &dtos.ProductMediaUploadRequestDTO{ProductItemID: &itemID, AltText: " Red shirt "}, "Photo.PNG", data)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, constants.ProductMediaTypeImage, media.MediaType)
+		assert.Equal(t, "https://cdn.test/thumb.jpg", media.ThumbnailURL)
+		assert.Equal(t, "Red shirt", media.AltText)
+		assert.Equal(t, 2, media.SortOrder)
+		assert.True(t, media.IsPrimary)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("should reject unsupported file types", func(t *testing.T) {
+		// Arrange
+		service, mediaRepo, _, storage := newService()
+
+		// Act
+		_, err := service.UploadMedia(1, &dtos.ProductMediaUploadRequestDTO{}, "manual.pdf", []byte("%PDF"))
+
+		// Assert
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		mediaRepo.AssertNotCalled(t, "CreateMedia", mock.Anything)
+		storage.AssertNotCalled(t, "UploadPublicFile", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject items of another product", func(t *testing.T) {
+		// Arrange
+		service, _, _, storage := newService()
+		otherItemID := uint64(99)
+
+		// Act
+		_, err := service.UploadMedia(1, &dtos.ProductMediaUploadRequestDTO{ProductItemID: &otherItemID}, "photo.png", testPNG(t, 10, 10))
+
+		// Assert
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		storage.AssertNotCalled(t, "UploadPublicFile", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestProductMediaService_DeleteMedia(t *testing.T) {
+	t.Run("should remove files and promote the next media of the same item", func(t *testing.T) {
+		// Arrange
+		mediaRepo := new(mocks.MockProductMediaRepository)
+		storage := new(mocks.MockStorageService)
+		service := services.NewProductMediaService(mediaRepo, new(mocks.MockProductRepository), storage)
+		itemID := uint64(11)
+
+		deleted := &models.ProductMedia{ID: 5, ProductID: 1, ProductItemID: &itemID, IsPrimary: true, StoragePath: "products/1/a.png", ThumbnailPath: "products/1/thumbnails/a.jpg"}
+		mediaRepo.On("GetMediaByID", uint64(5)).Return(deleted, nil)
+		mediaRepo.On("DeleteMedia", uint64(5)).Return(nil)
+		storage.On("DeleteFile", "products/1/a.png").Return(nil)
+		storage.On("DeleteFile", "products/1/thumbnails/a.jpg").Return(nil)
+		mediaRepo.On("GetMediaByProductID", uint64(1)).Return([]models.ProductMedia{
+			{ID: 6, ProductID: 1},
+			{ID: 7, ProductID: 1, ProductItemID: &itemID},
+		}, nil)
+		mediaRepo.On("UpdateMedia", mock.MatchedBy(func(m *models.ProductMedia) bool {
+			return m.ID == 7 && m.IsPrimary
+		})).Return(nil)
+
+		// Act
+		err := service.DeleteMedia(5)
+
+		// Assert
+		assert.NoError(t, err)
+		storage.AssertExpectations(t)
+		mediaRepo.AssertExpectations(t)
+	})
+}
+
+func TestProductMediaService_ReorderMedia(t *testing.T) {
+	t.Run("should require every media exactly once", func(t *testing.T) {
+		// Arrange
+		mediaRepo := new(mocks.MockProductMediaRepository)
+		productRepo := new(mocks.MockProductRepository)
+		service := services.NewProductMediaService(mediaRepo, productRepo, nil)
+
+		productRepo.On("GetProductByID", uint64(1)).Return(&models.Product{ID: 1}, nil)
+		mediaRepo.On("GetMediaByProductID", uint64(1)).Return([]models.ProductMedia{{ID: 5}, {ID: 6}}, nil)
+
+		// Act
+		_, err := service.ReorderMedia(1, &dtos.ProductMediaOrderRequestDTO{MediaIDs: []uint64{5, 5}})
+
+		// Assert
+		assert.Error(t, err)
+		mediaRepo.AssertNotCalled(t, "UpdateMediaOrder", mock.Anything, mock.Anything)
+	})
+}
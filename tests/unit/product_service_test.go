@@ -0,0 +1,421 @@
+package unit
+
+import (
+	"errors"
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"flicknfit_backend/tests/testhelpers"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func newProductService(productRepo repositories.ProductRepository, campaignRepo *mocks.MockCampaignRepository) services.ProductService {
+	watchService, _, _ := newWatchService(new(mocks.MockCampaignRepository))
+	stockService := services.NewStockService(new(mocks.MockStockRepository), productRepo, new(mocks.MockBrandMemberRepository), services.NewNotificationService(new(mocks.MockNotificationRepository)), watchService)
+	return services.NewProductService(productRepo, campaignRepo, watchService, stockService)
+}
+
+func newFullProductDTO(items ...dtos.AdminProductItemInputDTO) *dtos.AdminProductFullRequestDTO {
+	return &dtos.AdminProductFullRequestDTO{
+		BrandID:     1,
+		Name:        "Linen Shirt",
+		Description: "Breathable linen shirt",
+		Categories:  []string{"Shirts", "shirts"},
+		Styles:      []string{"Casual"},
+		Items:       items,
+	}
+}
+
+func testVariationOptions() []models.ProductVariationOption {
+	return []models.ProductVariationOption{
+		{ID: 1, ProductAttributeID: 10, Value: "Merah", ProductVariation: models.ProductVariation{ID: 10, Name: "Warna"}},
+		{ID: 2, ProductAttributeID: 20, Value: "M", ProductVariation: models.ProductVariation{ID: 20, Name: "Ukuran"}},
+		{ID: 3, ProductAttributeID: 20, Value: "L", ProductVariation: models.ProductVariation{ID: 20, Name: "Ukuran"}},
+	}
+}
+
+func TestProductService_AdminCreateProductFull(t *testing.T) {
+	t.Run("should save product with items, categories and styles", func(t *testing.T) {
+		// Arrange
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := newProductService(mockProductRepo, new(mocks.MockCampaignRepository))
+
+		dto := newFullProductDTO(
+			dtos.AdminProductItemInputDTO{SKU: "LS-RED-M", Price: 150000, Stock: 5, VariationOptionIDs: []uint64{1, 2}},
+			dtos.AdminProductItemInputDTO{SKU: "LS-RED-L", Price: 150000, Stock: 3, VariationOptionIDs: []uint64{3, 1}},
+		)
+
+		mockProductRepo.On("GetProductItemsBySKUs", []string{"LS-RED-M", "LS-RED-L"}).Return([]models.ProductItem{}, nil)
+		mockProductRepo.On("GetVariationOptionsByIDs", mock.Anything).Return(testVariationOptions(), nil)
+		mockProductRepo.On("SaveProductWithDetails", mock.MatchedBy(func(p *models.Product) bool {
+			return len(p.ProductItems) == 2 && len(p.ProductCategories) == 1 && len(p.ProductStyles) == 1 &&
+				len(p.ProductItems[0].Configurations) == 2
+		}), mock.Anything).Return(nil, nil)
+		mockProductRepo.On("GetProductDetailsByID", uint64(0)).Return(&models.Product{Name: dto.Name}, nil)
+
+		// Act
+		product, err := service.AdminCreateProductFull(dto, 1)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, dto.Name, product.Name)
+		mockProductRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject duplicate SKU in request", func(t *testing.T) {
+		// Arrange
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := newProductService(mockProductRepo, new(mocks.MockCampaignRepository))
+
+		dto := newFullProductDTO(
+			dtos.AdminProductItemInputDTO{SKU: "LS-RED-M", Price: 150000},
+			dtos.AdminProductItemInputDTO{SKU: "LS-RED-M", Price: 150000},
+		)
+
+		// Act
+		_, err := service.AdminCreateProductFull(dto, 1)
+
+		// Assert
+		assert.Error(t, err)
+		mockProductRepo.AssertNotCalled(t, "SaveProductWithDetails", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject SKU owned by another product", func(t *testing.T) {
+		// Arrange
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := newProductService(mockProductRepo, new(mocks.MockCampaignRepository))
+
+		dto := newFullProductDTO(dtos.AdminProductItemInputDTO{SKU: "TAKEN-1", Price: 1000})
+		mockProductRepo.On("GetProductItemsBySKUs", []string{"TAKEN-1"}).
+			Return([]models.ProductItem{{ID: 7, ProductID: 99, SKU: "TAKEN-1"}}, nil)
+
+		// Act
+		_, err := service.AdminCreateProductFull(dto, 1)
+
+		// Assert
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, appErr.Code)
+		mockProductRepo.AssertNotCalled(t, "SaveProductWithDetails", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject items sharing the same option combination", func(t *testing.T) {
+		// Arrange
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := newProductService(mockProductRepo, new(mocks.MockCampaignRepository))
+
+		dto := newFullProductDTO(
+			dtos.AdminProductItemInputDTO{SKU: "A", Price: 1000, VariationOptionIDs: []uint64{1, 2}},
+			dtos.AdminProductItemInputDTO{SKU: "B", Price: 1000, VariationOptionIDs: []uint64{2, 1}},
+		)
+		mockProductRepo.On("GetProductItemsBySKUs", mock.Anything).Return([]models.ProductItem{}, nil)
+		mockProductRepo.On("GetVariationOptionsByIDs", mock.Anything).Return(testVariationOptions(), nil)
+
+		// Act
+		_, err := service.AdminCreateProductFull(dto, 1)
+
+		// Assert
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		mockProductRepo.AssertNotCalled(t, "SaveProductWithDetails", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject two options of the same variation on one item", func(t *testing.T) {
+		// Arrange
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := newProductService(mockProductRepo, new(mocks.MockCampaignRepository))
+
+		dto := newFullProductDTO(dtos.AdminProductItemInputDTO{SKU: "A", Price: 1000, VariationOptionIDs: []uint64{2, 3}})
+		mockProductRepo.On("GetProductItemsBySKUs", mock.Anything).Return([]models.ProductItem{}, nil)
+		mockProductRepo.On("GetVariationOptionsByIDs", mock.Anything).Return(testVariationOptions(), nil)
+
+		// Act
+		_, err := service.AdminCreateProductFull(dto, 1)
+
+		// Assert
+		assert.Error(t, err)
+		mockProductRepo.AssertNotCalled(t, "SaveProductWithDetails", mock.Anything, mock.Anything)
+	})
+}
+
+func TestProductService_AdminUpdateProductFull(t *testing.T) {
+	t.Run("should restore a soft-deleted item when its SKU is added again", func(t *testing.T) {
+		// Arrange
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := newProductService(mockProductRepo, new(mocks.MockCampaignRepository))
+
+		deleted := models.ProductItem{ID: 7, ProductID: 5, SKU: "LS-RED-M", Price: 120000}
+		deleted.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		dto := newFullProductDTO(dtos.AdminProductItemInputDTO{SKU: "LS-RED-M", Price: 150000, Stock: 2})
+
+		mockProductRepo.On("GetProductDetailsByID", uint64(5)).Return(&models.Product{ID: 5, Name: dto.Name}, nil)
+		mockProductRepo.On("GetProductItemsBySKUs", []string{"LS-RED-M"}).Return([]models.ProductItem{deleted}, nil)
+		mockProductRepo.On("GetVariationOptionsByIDs", mock.Anything).Return(testVariationOptions(), nil)
+		mockProductRepo.On("SaveProductWithDetails", mock.MatchedBy(func(p *models.Product) bool {
+			return len(p.ProductItems) == 1 && p.ProductItems[0].ID == 7 && !p.ProductItems[0].DeletedAt.Valid &&
+				p.ProductItems[0].Price == 150000
+		}), mock.Anything).Return(nil, nil)
+
+		// Act
+		_, err := service.AdminUpdateProductFull(5, dto, 1)
+
+		// Assert
+		assert.NoError(t, err)
+		mockProductRepo.AssertExpectations(t)
+	})
+
+	t.Run("should record the admin on stock changes and alert brand members when stock runs low", func(t *testing.T) {
+		// Arrange
+		mockProductRepo := new(mocks.MockProductRepository)
+		memberRepo := new(mocks.MockBrandMemberRepository)
+		notificationRepo := new(mocks.MockNotificationRepository)
+		watchService, _, _ := newWatchService(new(mocks.MockCampaignRepository))
+		stockService := services.NewStockService(new(mocks.MockStockRepository), mockProductRepo, memberRepo, services.NewNotificationService(notificationRepo), watchService)
+		service := services.NewProductService(mockProductRepo, new(mocks.MockCampaignRepository), watchService, stockService)
+
+		actorID := uint64(1)
+		dto := newFullProductDTO(dtos.AdminProductItemInputDTO{SKU: "LS-RED-M", Price: 150000, Stock: 3})
+		mockProductRepo.On("GetProductDetailsByID", uint64(5)).Return(&models.Product{
+			ID: 5, BrandID: 9, Name: dto.Name,
+			ProductItems: []models.ProductItem{{ID: 7, ProductID: 5, SKU: "LS-RED-M", Price: 150000, Stock: 8}},
+		}, nil)
+		mockProductRepo.On("GetProductItemsBySKUs", []string{"LS-RED-M"}).Return([]models.ProductItem{{ID: 7, ProductID: 5, SKU: "LS-RED-M"}}, nil)
+		mockProductRepo.On("GetVariationOptionsByIDs", mock.Anything).Return(testVariationOptions(), nil)
+		mockProductRepo.On("SaveProductWithDetails", mock.AnythingOfType("*models.Product"), &actorID).Return([]models.StockMovement{
+			{ProductItemID: 7, Delta: -5, StockBefore: 8, StockAfter: 3, Reason: constants.StockReasonProductEdit, ActorID: &actorID},
+		}, nil)
+		mockProductRepo.On("GetProductItemByID", uint64(7)).Return(&models.ProductItem{
+			ID: 7, ProductID: 5, SKU: "LS-RED-M", Stock: 3, Product: models.Product{ID: 5, BrandID: 9, Name: dto.Name},
+		}, nil)
+		memberRepo.On("GetMemberUserIDs", uint64(9)).Return([]uint64{2}, nil)
+		notificationRepo.On("CreateNotifications", mock.MatchedBy(func(n []models.Notification) bool {
+			return len(n) == 1 && n[0].UserID == 2 && n[0].Type == constants.NotificationTypeLowStock
+		})).Return(nil)
+
+		// Act
+		_, err := service.AdminUpdateProductFull(5, dto, actorID)
+
+		// Assert
+		assert.NoError(t, err)
+		mockProductRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("should distinguish a missing product from a database failure", func(t *testing.T) {
+		// Arrange
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := newProductService(mockProductRepo, new(mocks.MockCampaignRepository))
+		dto := newFullProductDTO(dtos.AdminProductItemInputDTO{SKU: "LS-RED-M", Price: 150000, Stock: 2})
+
+		mockProductRepo.On("GetProductDetailsByID", uint64(5)).Return(nil, gorm.ErrRecordNotFound)
+		mockProductRepo.On("GetProductDetailsByID", uint64(6)).Return(nil, errors.New("connection refused"))
+
+		// Act
+		_, notFoundErr := service.AdminUpdateProductFull(5, dto, 1)
+		_, dbErr := service.AdminUpdateProductFull(6, dto, 1)
+
+		// Assert
+		assert.Equal(t, apperrors.ErrorTypeNotFound, notFoundErr.(*apperrors.AppError).Type)
+		assert.Equal(t, apperrors.ErrorTypeDatabase, dbErr.(*apperrors.AppError).Type)
+	})
+}
+
+func TestProductService_AdminSetProductStatus(t *testing.T) {
+	t.Run("should reject scheduling in the past", func(t *testing.T) {
+		// Arrange
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := newProductService(mockProductRepo, new(mocks.MockCampaignRepository))
+
+		past := time.Now().Add(-time.Hour)
+		mockProductRepo.On("GetProductByID", uint64(1)).Return(&models.Product{ID: 1, Status: constants.ProductStatusDraft}, nil)
+
+		// Act
+		_, err := service.AdminSetProductStatus(1, &dtos.AdminProductStatusRequestDTO{Status: constants.ProductStatusScheduled, PublishAt: &past})
+
+		// Assert
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+		mockProductRepo.AssertNotCalled(t, "UpdateProductStatus", mock.Anything)
+	})
+
+	t.Run("should schedule a future publish", func(t *testing.T) {
+		// Arrange
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := newProductService(mockProductRepo, new(mocks.MockCampaignRepository))
+
+		future := time.Now().Add(24 * time.Hour)
+		mockProductRepo.On("GetProductByID", uint64(1)).Return(&models.Product{ID: 1, Status: constants.ProductStatusDraft}, nil)
+		mockProductRepo.On("UpdateProductStatus", mock.AnythingOfType("*models.Product")).Return(nil)
+
+		// Act
+		product, err := service.AdminSetProductStatus(1, &dtos.AdminProductStatusRequestDTO{Status: constants.ProductStatusScheduled, PublishAt: &future})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, constants.ProductStatusScheduled, product.Status)
+		assert.False(t, product.IsPublished(time.Now()))
+		assert.True(t, product.IsPublished(future.Add(time.Minute)))
+	})
+
+	t.Run("should record unpublish time when deactivating an active product", func(t *testing.T) {
+		// Arrange
+		mockProductRepo := new(mocks.MockProductRepository)
+		service := newProductService(mockProductRepo, new(mocks.MockCampaignRepository))
+
+		mockProductRepo.On("GetProductByID", uint64(1)).Return(&models.Product{ID: 1, Status: constants.ProductStatusActive}, nil)
+		mockProductRepo.On("UpdateProductStatus", mock.AnythingOfType("*models.Product")).Return(nil)
+
+		// Act
+		product, err := service.AdminSetProductStatus(1, &dtos.AdminProductStatusRequestDTO{Status: constants.ProductStatusInactive})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, constants.ProductStatusInactive, product.Status)
+		assert.NotNil(t, product.UnpublishedAt)
+		assert.False(t, product.IsPublished(time.Now()))
+	})
+}
+
+func TestProductService_GetProductPublicByID_CampaignPricing(t *testing.T) {
+	newProduct := func() *models.Product {
+		return &models.Product{
+			ID:                1,
+			BrandID:           7,
+			Discount:          0.1,
+			ProductCategories: []models.ProductCategory{{Category: "Shirts"}},
+			ProductItems:      []models.ProductItem{{ID: 11, Price: 100000}},
+		}
+	}
+
+	t.Run("should apply the best running campaign without stacking", func(t *testing.T) {
+		// Arrange
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockCampaignRepo := new(mocks.MockCampaignRepository)
+		service := newProductService(mockProductRepo, mockCampaignRepo)
+
+		now := time.Now()
+		campaigns := []models.Campaign{
+			{ID: 1, Name: "Brand Week", DiscountType: constants.CampaignDiscountPercentage, DiscountValue: 20, StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour), IsActive: true,
+				Targets: []models.CampaignTarget{{TargetType: constants.CampaignTargetBrand, TargetID: 7}}},
+			{ID: 2, Name: "Shirt Flash Sale", DiscountType: constants.CampaignDiscountFixed, DiscountValue: 30000, StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour), IsActive: true,
+				Targets: []models.CampaignTarget{{TargetType: constants.CampaignTargetCategory, Category: "shirts"}}},
+			{ID: 3, Name: "Other Brand", DiscountType: constants.CampaignDiscountPercentage, DiscountValue: 90, StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour), IsActive: true,
+				Targets: []models.CampaignTarget{{TargetType: constants.CampaignTargetBrand, TargetID: 8}}},
+		}
+		mockProductRepo.On("GetProductPublicByID", uint64(1)).Return(newProduct(), nil)
+		mockCampaignRepo.On("GetActiveCampaigns", mock.AnythingOfType("time.Time")).Return(campaigns, nil)
+
+		// Act
+		product, err := service.GetProductPublicByID(1)
+
+		// Assert
+		assert.NoError(t, err)
+		item := product.ProductItems[0]
+		assert.Equal(t, 70000, *item.EffectivePrice)
+		assert.Equal(t, uint64(2), item.AppliedCampaign.ID)
+		assert.Equal(t, 100000, item.Price)
+	})
+
+	t.Run("should ignore campaigns outside their time window", func(t *testing.T) {
+		// Arrange
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockCampaignRepo := new(mocks.MockCampaignRepository)
+		service := newProductService(mockProductRepo, mockCampaignRepo)
+
+		now := time.Now()
+		campaigns := []models.Campaign{
+			{ID: 1, DiscountType: constants.CampaignDiscountPercentage, DiscountValue: 50, StartAt: now.Add(-2 * time.Hour), EndAt: now.Add(-time.Hour), IsActive: true},
+		}
+		mockProductRepo.On("GetProductPublicByID", uint64(1)).Return(newProduct(), nil)
+		mockCampaignRepo.On("GetActiveCampaigns", mock.AnythingOfType("time.Time")).Return(campaigns, nil)
+
+		// Act
+		product, err := service.GetProductPublicByID(1)
+
+		// Assert
+		assert.NoError(t, err)
+		item := product.ProductItems[0]
+		assert.Equal(t, 90000, *item.EffectivePrice)
+		assert.Nil(t, item.AppliedCampaign)
+	})
+}
+
+func TestProductService_GetProductsPublicByIDs_CategoryCampaign(t *testing.T) {
+	t.Run("should load categories so category campaigns apply", func(t *testing.T) {
+		// Arrange
+		db := testhelpers.NewTestDB(t, &models.Product{}, &models.ProductCategory{}, &models.ProductItem{})
+		product := models.Product{
+			BrandID:           7,
+			Name:              "Linen Shirt",
+			Description:       "Breathable linen shirt",
+			Status:            constants.ProductStatusActive,
+			ProductCategories: []models.ProductCategory{{Category: "Shirts"}},
+			ProductItems:      []models.ProductItem{{SKU: "LS-1", Price: 100000, Stock: 1}},
+		}
+		assert.NoError(t, db.Create(&product).Error)
+
+		mockCampaignRepo := new(mocks.MockCampaignRepository)
+		service := newProductService(repositories.NewProductRepository(db), mockCampaignRepo)
+
+		now := time.Now()
+		campaigns := []models.Campaign{
+			{ID: 2, Name: "Shirt Flash Sale", DiscountType: constants.CampaignDiscountFixed, DiscountValue: 30000, StartAt: now.Add(-time.Hour), EndAt: now.Add(time.Hour), IsActive: true,
+				Targets: []models.CampaignTarget{{TargetType: constants.CampaignTargetCategory, Category: "shirts"}}},
+		}
+		mockCampaignRepo.On("GetActiveCampaigns", mock.AnythingOfType("time.Time")).Return(campaigns, nil)
+
+		// Act
+		products, err := service.GetProductsPublicByIDs([]uint64{product.ID})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, products, 1)
+		item := products[0].ProductItems[0]
+		assert.Equal(t, 70000, *item.EffectivePrice)
+		assert.Equal(t, uint64(2), item.AppliedCampaign.ID)
+	})
+}
+
+func TestProductService_GetProductPriceChart(t *testing.T) {
+	t.Run("should group price history per item and clamp days", func(t *testing.T) {
+		// Arrange
+		mockProductRepo := new(mocks.MockProductRepository)
+		mockCampaignRepo := new(mocks.MockCampaignRepository)
+		service := newProductService(mockProductRepo, mockCampaignRepo)
+
+		product := &models.Product{ID: 1, ProductItems: []models.ProductItem{{ID: 11, SKU: "A", Price: 90000}, {ID: 12, SKU: "B", Price: 50000}}}
+		changedAt := time.Now().Add(-48 * time.Hour)
+		history := []models.PriceHistory{
+			{ProductItemID: 11, ProductID: 1, OldPrice: 0, NewPrice: 100000, ChangedAt: changedAt},
+			{ProductItemID: 11, ProductID: 1, OldPrice: 100000, NewPrice: 90000, ChangedAt: changedAt.Add(time.Hour)},
+		}
+		mockProductRepo.On("GetProductPublicByID", uint64(1)).Return(product, nil)
+		mockCampaignRepo.On("GetActiveCampaigns", mock.AnythingOfType("time.Time")).Return([]models.Campaign{}, nil)
+		mockProductRepo.On("GetPriceHistory", uint64(1), mock.MatchedBy(func(since time.Time) bool {
+			return time.Since(since) > 364*24*time.Hour && time.Since(since) < 366*24*time.Hour
+		})).Return(history, nil)
+
+		// Act
+		chart, err := service.GetProductPriceChart(1, 1000)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, chart.Items, 2)
+		assert.Len(t, chart.Items[0].Points, 2)
+		assert.Equal(t, 90000, chart.Items[0].Points[1].Price)
+		assert.Empty(t, chart.Items[1].Points)
+		mockProductRepo.AssertExpectations(t)
+	})
+}
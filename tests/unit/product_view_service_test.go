@@ -0,0 +1,109 @@
+package unit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"flicknfit_backend/config"
+	"flicknfit_backend/models"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// recordViews runs record against a product view service backed by a started event bus, stops the bus and
+// returns the views it stored.
+func recordViews(t *testing.T, cfg *config.Config, record func(service services.ProductViewService)) []models.ProductView {
+	viewRepo := new(mocks.MockProductViewRepository)
+	var written []models.ProductView
+	viewRepo.On("RecordViews", mock.Anything).Run(func(args mock.Arguments) {
+		written = append(written, args.Get(0).([]models.ProductView)...)
+	}).Return(nil)
+
+	bus := services.NewEventBus(services.EventBusOptions{QueueSize: 100, Workers: 2, BatchSize: 100, FlushInterval: time.Hour, PublishTimeout: time.Second})
+	service := services.NewProductViewService(viewRepo, nil, bus, cfg)
+	bus.Start()
+	record(service)
+	bus.Stop()
+	return written
+}
+
+func TestProductViewService_RecordView(t *testing.T) {
+	const browser = "Mozilla/5.0"
+	shirt := &models.Product{ID: 1, BrandID: 7}
+	pants := &models.Product{ID: 2, BrandID: 7}
+
+	t.Run("should debounce repeated views and store the rest through the event bus", func(t *testing.T) {
+		// Act
+		written := recordViews(t, &config.Config{}, func(service services.ProductViewService) {
+			service.RecordView(shirt, 3, "", "203.0.113.77", browser)
+			service.RecordView(shirt, 3, "", "203.0.113.77", browser)
+			service.RecordView(pants, 3, "", "203.0.113.77", browser)
+			service.RecordView(shirt, 0, "session-a", "203.0.113.77", browser)
+			service.RecordView(shirt, 0, "session-a", "203.0.113.77", browser)
+			service.RecordView(shirt, 0, "", "203.0.113.77", "Googlebot/2.1")
+		})
+
+		// Assert
+		assert.Len(t, written, 3)
+		users := 0
+		for _, view := range written {
+			if view.UserID != nil {
+				users++
+				assert.Equal(t, uint64(3), *view.UserID)
+			}
+		}
+		assert.Equal(t, 2, users)
+	})
+
+	t.Run("should key anonymous sessions with the configured secret", func(t *testing.T) {
+		// Act
+		written := recordViews(t, &config.Config{ClickIPHashKey: "view-secret"}, func(service services.ProductViewService) {
+			service.RecordView(shirt, 0, "", "203.0.113.77", browser)
+		})
+
+		// Assert
+		mac := hmac.New(sha256.New, []byte("view-secret"))
+		mac.Write([]byte("client:203.0.113.77|" + browser))
+		plain := sha256.Sum256([]byte("client:203.0.113.77|" + browser))
+		assert.Len(t, written, 1)
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), written[0].SessionKey)
+		assert.NotEqual(t, hex.EncodeToString(plain[:]), written[0].SessionKey)
+	})
+}
+
+func TestProductViewService_GetRecentlyViewed(t *testing.T) {
+	t.Run("should keep the view order and skip unpublished products", func(t *testing.T) {
+		// Arrange
+		viewRepo := new(mocks.MockProductViewRepository)
+		productRepo := new(mocks.MockProductRepository)
+		campaignRepo := new(mocks.MockCampaignRepository)
+		service := services.NewProductViewService(viewRepo, newProductService(productRepo, campaignRepo), services.NewEventBus(services.EventBusOptions{}), &config.Config{})
+
+		now := time.Now()
+		viewRepo.On("GetRecentViews", uint64(3), 20).Return([]models.RecentView{
+			{ProductID: 9, ViewedAt: now},
+			{ProductID: 4, ViewedAt: now.Add(-time.Hour)},
+			{ProductID: 7, ViewedAt: now.Add(-2 * time.Hour)},
+		}, nil)
+		productRepo.On("GetProductsPublicByIDs", []uint64{9, 4, 7}).Return([]*models.Product{
+			{ID: 4, Name: "Pants"},
+			{ID: 9, Name: "Shirt"},
+		}, nil)
+		campaignRepo.On("GetActiveCampaigns", mock.AnythingOfType("time.Time")).Return([]models.Campaign{}, nil)
+
+		// Act
+		products, err := service.GetRecentlyViewed(3, 0)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, products, 2)
+		assert.Equal(t, "Shirt", products[0].Name)
+		assert.Equal(t, now, products[0].ViewedAt)
+		assert.Equal(t, "Pants", products[1].Name)
+	})
+}
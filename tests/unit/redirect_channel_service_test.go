@@ -0,0 +1,260 @@
+package unit
+
+import (
+	"flicknfit_backend/config"
+	"flicknfit_backend/dtos"
+	"flicknfit_backend/models"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"flicknfit_backend/utils"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func newRedirectTrackingService(channels []models.BrandRedirectChannel) services.TrackingService {
+	linkRepo := new(mocks.MockLinkCheckRepository)
+	linkRepo.On("GetBrokenURLs", mock.Anything, mock.Anything).Return([]string{}, nil)
+	channelRepo := new(mocks.MockRedirectChannelRepository)
+	channelRepo.On("GetChannels", uint64(7)).Return(channels, nil)
+	return services.NewTrackingService(nil, new(mocks.MockProductRepository), new(mocks.MockBrandRepository), new(mocks.MockUserRepository), linkRepo, channelRepo, services.NewEventBus(services.EventBusOptions{}), &config.Config{})
+}
+
+func TestTrackingService_RedirectChannels(t *testing.T) {
+	product := &models.Product{ID: 1, Name: "Linen Shirt", ShopeeProductURL: "https://shopee.co.id/shop/item"}
+	brand := &models.Brand{ID: 7, WhatsAppNumber: "628123456789", InstagramURL: "https://instagram.com/shop"}
+
+	t.Run("should keep the default order without settings", func(t *testing.T) {
+		// Arrange
+		service := newRedirectTrackingService([]models.BrandRedirectChannel{})
+
+		// Act
+		url, channel := service.GetRedirectURL(product, brand)
+
+		// Assert
+		assert.Equal(t, "https://shopee.co.id/shop/item", url)
+		assert.Equal(t, "shopee", channel)
+	})
+
+	t.Run("should follow the brand's channel order", func(t *testing.T) {
+		// Arrange
+		service := newRedirectTrackingService([]models.BrandRedirectChannel{
+			{BrandID: 7, Channel: "instagram", Position: 0},
+			{BrandID: 7, Channel: "whatsapp", Position: 1},
+		})
+
+		// Act
+		url, channel := service.GetRedirectURL(product, brand)
+
+		// Assert
+		assert.Equal(t, "https://instagram.com/shop", url)
+		assert.Equal(t, "instagram", channel)
+	})
+
+	t.Run("should skip ordered channels without a link", func(t *testing.T) {
+		// Arrange
+		service := newRedirectTrackingService([]models.BrandRedirectChannel{
+			{BrandID: 7, Channel: "tokopedia", Position: 0},
+			{BrandID: 7, Channel: "whatsapp", Position: 1},
+		})
+
+		// Act
+		url, channel := service.GetRedirectURL(product, brand)
+
+		// Assert
+		assert.Contains(t, url, "wa.me/628123456789")
+		assert.Equal(t, "whatsapp", channel)
+	})
+
+	t.Run("should split clicks between weighted channels", func(t *testing.T) {
+		// Arrange
+		service := newRedirectTrackingService([]models.BrandRedirectChannel{
+			{BrandID: 7, Channel: "whatsapp", Position: 0, Weight: 50},
+			{BrandID: 7, Channel: "shopee", Position: 1, Weight: 50},
+			{BrandID: 7, Channel: "instagram", Position: 2},
+		})
+
+		// Act
+		counts := make(map[string]int)
+		for i := 0; i < 400; i++ {
+			_, channel := service.GetRedirectURL(product, brand)
+			counts[channel]++
+		}
+
+		// Assert
+		assert.Greater(t, counts["whatsapp"], 100)
+		assert.Greater(t, counts["shopee"], 100)
+		assert.Zero(t, counts["instagram"])
+	})
+
+	t.Run("should fall back to the order when no weighted channel has a link", func(t *testing.T) {
+		// Arrange
+		service := newRedirectTrackingService([]models.BrandRedirectChannel{
+			{BrandID: 7, Channel: "tokopedia", Position: 0, Weight: 100},
+			{BrandID: 7, Channel: "instagram", Position: 1},
+		})
+
+		// Act
+		_, channel := service.GetRedirectURL(product, brand)
+
+		// Assert
+		assert.Equal(t, "instagram", channel)
+	})
+
+	t.Run("should fall back to the homepage without any link", func(t *testing.T) {
+		// Arrange
+		service := newRedirectTrackingService([]models.BrandRedirectChannel{})
+
+		// Act
+		url, channel := service.GetRedirectURL(&models.Product{ID: 1}, &models.Brand{ID: 7})
+
+		// Assert
+		assert.Equal(t, "https://flicknfit.com", url)
+		assert.Equal(t, "flicknfit", channel)
+	})
+}
+
+func TestRedirectChannelService_UpdateBrandChannels(t *testing.T) {
+	t.Run("should store channels in the given order", func(t *testing.T) {
+		// Arrange
+		channelRepo := new(mocks.MockRedirectChannelRepository)
+		memberRepo := new(mocks.MockBrandMemberRepository)
+		service := services.NewRedirectChannelService(channelRepo, memberRepo)
+		dto := &dtos.RedirectChannelsRequestDTO{Channels: []dtos.RedirectChannelDTO{
+			{Channel: "shopee", Weight: 70},
+			{Channel: "whatsapp", Weight: 30},
+		}}
+		stored := []models.BrandRedirectChannel{
+			{BrandID: 7, Channel: "shopee", Position: 0, Weight: 70},
+			{BrandID: 7, Channel: "whatsapp", Position: 1, Weight: 30},
+		}
+
+		memberRepo.On("GetMember", uint64(7), uint64(3)).Return(&models.BrandMember{BrandID: 7, UserID: 3}, nil)
+		channelRepo.On("ReplaceChannels", uint64(7), stored).Return(nil)
+		channelRepo.On("GetChannels", uint64(7)).Return(stored, nil)
+
+		// Act
+		response, err := service.UpdateBrandChannels(7, 3, dto)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.False(t, response.Default)
+		assert.Equal(t, dto.Channels, response.Channels)
+		channelRepo.AssertExpectations(t)
+	})
+
+	t.Run("should refuse users outside the brand", func(t *testing.T) {
+		// Arrange
+		channelRepo := new(mocks.MockRedirectChannelRepository)
+		memberRepo := new(mocks.MockBrandMemberRepository)
+		service := services.NewRedirectChannelService(channelRepo, memberRepo)
+
+		memberRepo.On("GetMember", uint64(7), uint64(3)).Return(nil, gorm.ErrRecordNotFound)
+
+		// Act
+		_, err := service.UpdateBrandChannels(7, 3, &dtos.RedirectChannelsRequestDTO{})
+
+		// Assert
+		assertAppErrorCode(t, err, http.StatusForbidden)
+		channelRepo.AssertNotCalled(t, "ReplaceChannels", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRedirectChannelService_GetChannelReport(t *testing.T) {
+	t.Run("should compute conversion rates per channel", func(t *testing.T) {
+		// Arrange
+		channelRepo := new(mocks.MockRedirectChannelRepository)
+		memberRepo := new(mocks.MockBrandMemberRepository)
+		service := services.NewRedirectChannelService(channelRepo, memberRepo)
+
+		memberRepo.On("GetMember", uint64(7), uint64(3)).Return(&models.BrandMember{BrandID: 7, UserID: 3}, nil)
+		channelRepo.On("GetChannelReport", uint64(7), mock.MatchedBy(func(since time.Time) bool {
+			return time.Since(since) > 29*24*time.Hour && time.Since(since) < 31*24*time.Hour
+		})).Return([]models.ChannelStat{
+			{Channel: "whatsapp", Clicks: 200, Conversions: 10, UnitsSold: 12},
+			{Channel: "unknown", Clicks: 0, Conversions: 3, UnitsSold: 3},
+		}, nil)
+
+		// Act
+		report, err := service.GetChannelReport(7, 3, 0)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 30, report.Days)
+		assert.InDelta(t, 0.05, report.Channels[0].ConversionRate, 0.0001)
+		assert.Zero(t, report.Channels[1].ConversionRate)
+	})
+}
+
+func TestTrackingService_BuildOutboundURL(t *testing.T) {
+	cfg := &config.Config{UTMSource: "flicknfit", UTMMedium: "referral", UTMCampaign: "product_click", ShopeeAffiliateID: "fnf-aff", ClickIDSecret: "secret"}
+	newService := func(params *models.BrandLinkParams) services.TrackingService {
+		channelRepo := new(mocks.MockRedirectChannelRepository)
+		if params != nil {
+			channelRepo.On("GetLinkParams", uint64(7)).Return(params, nil)
+		} else {
+			channelRepo.On("GetLinkParams", uint64(7)).Return(nil, gorm.ErrRecordNotFound)
+		}
+		return services.NewTrackingService(nil, new(mocks.MockProductRepository), new(mocks.MockBrandRepository), new(mocks.MockUserRepository), new(mocks.MockLinkCheckRepository), channelRepo, services.NewEventBus(services.EventBusOptions{}), cfg)
+	}
+	product := &models.Product{ID: 1}
+	brand := &models.Brand{ID: 7}
+	click := &models.ProductClick{ID: 99}
+
+	t.Run("should add UTM tags, affiliate ID and click ID after the existing query", func(t *testing.T) {
+		// Arrange
+		service := newService(nil)
+
+		// Act
+		url := service.BuildOutboundURL("https://shopee.co.id/item?sp_atk=abc&utm_source=brand", "shopee", product, brand, click)
+
+		// Assert
+		assert.True(t, strings.HasPrefix(url, "https://shopee.co.id/item?sp_atk=abc&utm_source=brand&"))
+		assert.Contains(t, url, "af_id=fnf-aff")
+		assert.Contains(t, url, "utm_medium=referral")
+		assert.Contains(t, url, "utm_content=1")
+		assert.Contains(t, url, "fnf_click_id="+utils.SignClickID(99, "secret"))
+		assert.NotContains(t, url, "utm_source=flicknfit")
+	})
+
+	t.Run("should prefer the brand's overrides", func(t *testing.T) {
+		// Arrange
+		service := newService(&models.BrandLinkParams{BrandID: 7, UTMCampaign: "ramadan", ShopeeAffiliateID: "brand-aff"})
+
+		// Act
+		url := service.BuildOutboundURL("https://shopee.co.id/item", "shopee", product, brand, click)
+
+		// Assert
+		assert.Contains(t, url, "utm_campaign=ramadan")
+		assert.Contains(t, url, "af_id=brand-aff")
+		assert.Contains(t, url, "utm_source=flicknfit")
+	})
+
+	t.Run("should append the click ID to the WhatsApp message", func(t *testing.T) {
+		// Arrange
+		service := newService(nil)
+		link := utils.WhatsAppLink("628123456789", "Halo! Apakah masih tersedia?")
+
+		// Act
+		url := service.BuildOutboundURL(link, "whatsapp", product, brand, click)
+
+		// Assert
+		assert.Equal(t, utils.WhatsAppLink("628123456789", "Halo! Apakah masih tersedia?\n\nRef: "+utils.SignClickID(99, "secret")), url)
+	})
+
+	t.Run("should leave the homepage fallback untouched", func(t *testing.T) {
+		// Arrange
+		service := newService(nil)
+
+		// Act
+		url := service.BuildOutboundURL("https://flicknfit.com", "flicknfit", product, brand, click)
+
+		// Assert
+		assert.Equal(t, "https://flicknfit.com", url)
+	})
+}
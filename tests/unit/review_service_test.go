@@ -2,8 +2,11 @@ package unit
 
 import (
 	"errors"
+	"flicknfit_backend/constants"
 	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
 	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
 	"flicknfit_backend/services"
 	"flicknfit_backend/tests/mocks"
 	"flicknfit_backend/tests/testhelpers"
@@ -28,7 +31,7 @@ func TestReviewService_CreateReview(t *testing.T) {
 			ReviewText: "Great product!",
 		}
 
-		mockProductRepo.On("GetProductByID", dto.ProductID).Return(&product, nil)
+		mockProductRepo.On("GetPublishedProductByID", dto.ProductID).Return(&product, nil)
 		mockReviewRepo.On("HasUserReviewedProduct", userID, dto.ProductID).Return(false, nil)
 		mockReviewRepo.On("CreateReview", mock.AnythingOfType("*models.Review")).Return(nil)
 
@@ -54,7 +57,7 @@ func TestReviewService_CreateReview(t *testing.T) {
 			ReviewText: "Great product!",
 		}
 
-		mockProductRepo.On("GetProductByID", dto.ProductID).Return(nil, errors.New("not found"))
+		mockProductRepo.On("GetPublishedProductByID", dto.ProductID).Return(nil, errors.New("not found"))
 
 		// Act
 		err := service.CreateReview(userID, dto)
@@ -64,6 +67,25 @@ func TestReviewService_CreateReview(t *testing.T) {
 		mockProductRepo.AssertExpectations(t)
 	})
 
+	t.Run("should return not found when product is a draft", func(t *testing.T) {
+		// Arrange
+		db := testhelpers.NewTestDB(t, &models.Product{}, &models.ProductItem{})
+		product := models.Product{BrandID: 1, Name: "Draft Jacket", Description: "Not published yet", Status: constants.ProductStatusDraft}
+		assert.NoError(t, db.Create(&product).Error)
+
+		mockReviewRepo := new(mocks.MockReviewRepository)
+		service := services.NewReviewService(mockReviewRepo, repositories.NewProductRepository(db))
+
+		// Act
+		err := service.CreateReview(1, &dtos.CreateReviewDTO{ProductID: product.ID, Rating: 5, ReviewText: "Great product!"})
+
+		// Assert
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, apperrors.ErrorTypeNotFound, appErr.Type)
+		mockReviewRepo.AssertExpectations(t)
+	})
+
 	t.Run("should return error when user already reviewed product", func(t *testing.T) {
 		// Arrange
 		mockReviewRepo := new(mocks.MockReviewRepository)
@@ -78,7 +100,7 @@ func TestReviewService_CreateReview(t *testing.T) {
 			ReviewText: "Great product!",
 		}
 
-		mockProductRepo.On("GetProductByID", dto.ProductID).Return(&product, nil)
+		mockProductRepo.On("GetPublishedProductByID", dto.ProductID).Return(&product, nil)
 		mockReviewRepo.On("HasUserReviewedProduct", userID, dto.ProductID).Return(true, nil)
 
 		// Act
@@ -104,7 +126,7 @@ func TestReviewService_GetProductReviews(t *testing.T) {
 		expectedReviews := []models.Review{testhelpers.CreateTestReview()}
 		expectedCount := 1
 
-		mockProductRepo.On("GetProductByID", productID).Return(&models.Product{ID: productID}, nil)
+		mockProductRepo.On("GetPublishedProductByID", productID).Return(&models.Product{ID: productID}, nil)
 		mockReviewRepo.On("GetProductReviews", productID, limit, 0).Return(expectedReviews, nil) // offset = (page-1)*limit = (1-1)*10 = 0
 		mockReviewRepo.On("GetProductReviewStats", productID).Return(map[string]interface{}{"average_rating": 4.5, "total_reviews": 1}, nil)
 
@@ -129,7 +151,7 @@ func TestReviewService_GetProductReviews(t *testing.T) {
 		limit := 10
 		expectedError := errors.New("database error")
 
-		mockProductRepo.On("GetProductByID", productID).Return(&models.Product{ID: productID}, nil)
+		mockProductRepo.On("GetPublishedProductByID", productID).Return(&models.Product{ID: productID}, nil)
 		mockReviewRepo.On("GetProductReviews", productID, limit, 0).Return([]models.Review{}, expectedError)
 
 		// Act
@@ -262,3 +284,59 @@ func TestReviewService_DeleteReview(t *testing.T) {
 		mockReviewRepo.AssertExpectations(t)
 	})
 }
+
+func TestReviewService_RecalculateRatings(t *testing.T) {
+	t.Run("should report product and brand drift", func(t *testing.T) {
+		// Arrange
+		mockReviewRepo := new(mocks.MockReviewRepository)
+		service := services.NewReviewService(mockReviewRepo, new(mocks.MockProductRepository))
+
+		mockReviewRepo.On("RecalculateAllRatings", true).Return([]models.RatingDrift{
+			{Entity: models.RatingDriftEntityProduct, ID: 1, StoredRating: 4.9, StoredReviewer: 99, ActualRating: 4.5, ActualReviewer: 2},
+			{Entity: models.RatingDriftEntityProduct, ID: 2, StoredReviewer: 1},
+			{Entity: models.RatingDriftEntityBrand, ID: 1, StoredRating: 1, ActualRating: 4.5, ActualReviewer: 2},
+		}, nil)
+
+		// Act
+		result, err := service.RecalculateRatings(true)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 2, result.ProductsDrifted)
+		assert.Equal(t, 1, result.BrandsDrifted)
+		assert.Len(t, result.Drifts, 3)
+		mockReviewRepo.AssertExpectations(t)
+	})
+
+	t.Run("should return an empty report when nothing drifted", func(t *testing.T) {
+		// Arrange
+		mockReviewRepo := new(mocks.MockReviewRepository)
+		service := services.NewReviewService(mockReviewRepo, new(mocks.MockProductRepository))
+
+		mockReviewRepo.On("RecalculateAllRatings", false).Return(nil, nil)
+
+		// Act
+		result, err := service.RecalculateRatings(false)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, result.Drifts)
+		assert.Zero(t, result.ProductsDrifted+result.BrandsDrifted)
+	})
+
+	t.Run("should wrap repository errors", func(t *testing.T) {
+		// Arrange
+		mockReviewRepo := new(mocks.MockReviewRepository)
+		service := services.NewReviewService(mockReviewRepo, new(mocks.MockProductRepository))
+
+		mockReviewRepo.On("RecalculateAllRatings", false).Return(nil, errors.New("database error"))
+
+		// Act
+		result, err := service.RecalculateRatings(false)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
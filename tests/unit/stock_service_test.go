@@ -0,0 +1,116 @@
+package unit
+
+import (
+	"flicknfit_backend/constants"
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// stockAdjustedTo makes the mocked AdjustStock fill in the movement like the real repository.
+func stockAdjustedTo(before, after int) func(args mock.Arguments) {
+	return func(args mock.Arguments) {
+		movement := args.Get(2).(*models.StockMovement)
+		movement.Delta = after - before
+		movement.StockBefore = before
+		movement.StockAfter = after
+	}
+}
+
+func TestStockService_AdjustStock(t *testing.T) {
+	newService := func() (services.StockService, *mocks.MockStockRepository, *mocks.MockBrandMemberRepository, *mocks.MockNotificationRepository) {
+		stockRepo := new(mocks.MockStockRepository)
+		memberRepo := new(mocks.MockBrandMemberRepository)
+		notificationRepo := new(mocks.MockNotificationRepository)
+		watchService, _, _ := newWatchService(new(mocks.MockCampaignRepository))
+		service := services.NewStockService(stockRepo, new(mocks.MockProductRepository), memberRepo, services.NewNotificationService(notificationRepo), watchService)
+		return service, stockRepo, memberRepo, notificationRepo
+	}
+	item := func(stock int) *models.ProductItem {
+		return &models.ProductItem{ID: 11, ProductID: 1, SKU: "LS-M", Stock: stock, Product: models.Product{ID: 1, BrandID: 7, Name: "Linen Shirt"}}
+	}
+
+	t.Run("should notify brand members when stock drops to the threshold", func(t *testing.T) {
+		// Arrange
+		service, stockRepo, memberRepo, notificationRepo := newService()
+		dto := &dtos.StockAdjustmentRequestDTO{Delta: -3, Reason: constants.StockReasonSale}
+
+		stockRepo.On("AdjustStock", uint64(11), -3, mock.AnythingOfType("*models.StockMovement")).
+			Run(stockAdjustedTo(8, 5)).Return(item(5), nil)
+		memberRepo.On("GetMemberUserIDs", uint64(7)).Return([]uint64{2, 3}, nil)
+		notificationRepo.On("CreateNotifications", mock.MatchedBy(func(n []models.Notification) bool {
+			return len(n) == 2 && n[0].Type == constants.NotificationTypeLowStock && n[1].UserID == 3
+		})).Return(nil)
+
+		// Act
+		_, movement, err := service.AdjustStock(11, 1, dto)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), *movement.ActorID)
+		assert.Equal(t, constants.StockReasonSale, movement.Reason)
+		notificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("should send out of stock alert when stock reaches zero", func(t *testing.T) {
+		// Arrange
+		service, stockRepo, memberRepo, notificationRepo := newService()
+		dto := &dtos.StockAdjustmentRequestDTO{Delta: -2, Reason: constants.StockReasonDamaged}
+
+		stockRepo.On("AdjustStock", uint64(11), -2, mock.AnythingOfType("*models.StockMovement")).
+			Run(stockAdjustedTo(2, 0)).Return(item(0), nil)
+		memberRepo.On("GetMemberUserIDs", uint64(7)).Return([]uint64{2}, nil)
+		notificationRepo.On("CreateNotifications", mock.MatchedBy(func(n []models.Notification) bool {
+			return len(n) == 1 && n[0].Type == constants.NotificationTypeOutOfStock
+		})).Return(nil)
+
+		// Act
+		_, _, err := service.AdjustStock(11, 1, dto)
+
+		// Assert
+		assert.NoError(t, err)
+		notificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not notify when stock stays above the threshold", func(t *testing.T) {
+		// Arrange
+		service, stockRepo, memberRepo, notificationRepo := newService()
+		dto := &dtos.StockAdjustmentRequestDTO{Delta: 10, Reason: constants.StockReasonRestock}
+
+		stockRepo.On("AdjustStock", uint64(11), 10, mock.AnythingOfType("*models.StockMovement")).
+			Run(stockAdjustedTo(2, 12)).Return(item(12), nil)
+
+		// Act
+		_, _, err := service.AdjustStock(11, 1, dto)
+
+		// Assert
+		assert.NoError(t, err)
+		memberRepo.AssertNotCalled(t, "GetMemberUserIDs", mock.Anything)
+		notificationRepo.AssertNotCalled(t, "CreateNotifications", mock.Anything)
+	})
+
+	t.Run("should return conflict when stock would go negative", func(t *testing.T) {
+		// Arrange
+		service, stockRepo, _, _ := newService()
+		dto := &dtos.StockAdjustmentRequestDTO{Delta: -5, Reason: constants.StockReasonCorrection}
+
+		stockRepo.On("AdjustStock", uint64(11), -5, mock.AnythingOfType("*models.StockMovement")).
+			Return(nil, repositories.ErrInsufficientStock)
+
+		// Act
+		_, _, err := service.AdjustStock(11, 1, dto)
+
+		// Assert
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, appErr.Code)
+	})
+}
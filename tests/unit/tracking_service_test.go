@@ -0,0 +1,235 @@
+package unit
+
+import (
+	"errors"
+	"flicknfit_backend/config"
+	"flicknfit_backend/models"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// trackClicks runs track against a tracking service backed by a started event bus, stops the bus and returns
+// the clicks it stored. User 3 allows tracking, user 4 opted out.
+func trackClicks(t *testing.T, clickRepo *mocks.MockProductClickRepository, cfg *config.Config, track func(service services.TrackingService)) []models.ProductClick {
+	productRepo := new(mocks.MockProductRepository)
+	productRepo.On("GetProductByID", uint64(5)).Return(&models.Product{ID: 5, BrandID: 7}, nil)
+	userRepo := new(mocks.MockUserRepository)
+	userRepo.On("GetUserByID", uint64(3)).Return(&models.User{ID: 3}, nil)
+	userRepo.On("GetUserByID", uint64(4)).Return(&models.User{ID: 4, DoNotTrack: true}, nil)
+
+	var stored []models.ProductClick
+	clickRepo.On("CreateBatch", mock.Anything).Run(func(args mock.Arguments) {
+		stored = append(stored, args.Get(0).([]models.ProductClick)...)
+	}).Return(nil)
+
+	bus := services.NewEventBus(services.EventBusOptions{QueueSize: 100, Workers: 1, BatchSize: 100, FlushInterval: time.Hour, PublishTimeout: time.Second})
+	service := services.NewTrackingService(clickRepo, productRepo, new(mocks.MockBrandRepository), userRepo, new(mocks.MockLinkCheckRepository), new(mocks.MockRedirectChannelRepository), bus, cfg)
+	bus.Start()
+	track(service)
+	bus.Stop()
+	return stored
+}
+
+func TestTrackingService_TrackClick(t *testing.T) {
+	const browser = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X)"
+	fullIP := &config.Config{ClickIPMode: "full"}
+
+	t.Run("should publish the click and store it unflagged when genuine", func(t *testing.T) {
+		// Arrange
+		clickRepo := new(mocks.MockProductClickRepository)
+		clickRepo.On("CountRecentDuplicates", mock.Anything, mock.Anything).Return(int64(0), nil)
+		clickRepo.On("CountRecentByIP", "10.0.0.1", mock.Anything).Return(int64(3), nil)
+
+		// Act
+		var click *models.ProductClick
+		var err error
+		stored := trackClicks(t, clickRepo, fullIP, func(service services.TrackingService) {
+			click, err = service.TrackClick(3, 5, "10.0.0.1", browser, "shopee", "")
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.NotNil(t, click.Ref)
+		assert.Zero(t, click.ID)
+		assert.Len(t, stored, 1)
+		assert.Equal(t, *click.Ref, *stored[0].Ref)
+		assert.False(t, stored[0].Suspicious)
+		assert.Equal(t, uint64(7), stored[0].BrandID)
+		assert.Equal(t, uint64(3), *stored[0].UserID)
+		assert.Equal(t, "10.0.0.1", stored[0].IPAddress)
+	})
+
+	t.Run("should store clicks of users who opted out of tracking without their account or IP address", func(t *testing.T) {
+		// Arrange
+		clickRepo := new(mocks.MockProductClickRepository)
+
+		// Act
+		stored := trackClicks(t, clickRepo, fullIP, func(service services.TrackingService) {
+			_, err := service.TrackClick(4, 5, "10.0.0.1", browser, "shopee", "")
+			assert.NoError(t, err)
+		})
+
+		// Assert
+		assert.Len(t, stored, 1)
+		assert.Nil(t, stored[0].UserID)
+		assert.Empty(t, stored[0].IPAddress)
+		assert.False(t, stored[0].Suspicious)
+		clickRepo.AssertNotCalled(t, "CountRecentDuplicates", mock.Anything, mock.Anything)
+		clickRepo.AssertNotCalled(t, "CountRecentByIP", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should flag bots without checking the click history", func(t *testing.T) {
+		// Arrange
+		clickRepo := new(mocks.MockProductClickRepository)
+
+		// Act
+		stored := trackClicks(t, clickRepo, fullIP, func(service services.TrackingService) {
+			service.TrackClick(0, 5, "10.0.0.1", "Googlebot/2.1 (+http://www.google.com/bot.html)", "shopee", "")
+			service.TrackClick(0, 5, "10.0.0.1", "", "shopee", "")
+		})
+
+		// Assert
+		assert.Len(t, stored, 2)
+		for _, click := range stored {
+			assert.True(t, click.Suspicious)
+			assert.Equal(t, "bot", click.SuspicionReason)
+		}
+		clickRepo.AssertNotCalled(t, "CountRecentDuplicates", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should flag a click repeated before the first one was stored", func(t *testing.T) {
+		// Arrange
+		clickRepo := new(mocks.MockProductClickRepository)
+		clickRepo.On("CountRecentDuplicates", mock.Anything, mock.Anything).Return(int64(0), nil)
+		clickRepo.On("CountRecentByIP", "10.0.0.1", mock.Anything).Return(int64(0), nil)
+
+		// Act
+		stored := trackClicks(t, clickRepo, fullIP, func(service services.TrackingService) {
+			service.TrackClick(3, 5, "10.0.0.1", browser, "shopee", "")
+			service.TrackClick(3, 5, "10.0.0.1", browser, "shopee", "")
+		})
+
+		// Assert
+		assert.Len(t, stored, 2)
+		assert.False(t, stored[0].Suspicious)
+		assert.Equal(t, "duplicate", stored[1].SuspicionReason)
+	})
+
+	t.Run("should flag a click repeating a stored one", func(t *testing.T) {
+		// Arrange
+		clickRepo := new(mocks.MockProductClickRepository)
+		clickRepo.On("CountRecentDuplicates", mock.Anything, mock.Anything).Return(int64(1), nil)
+
+		// Act
+		stored := trackClicks(t, clickRepo, fullIP, func(service services.TrackingService) {
+			service.TrackClick(3, 5, "10.0.0.1", browser, "shopee", "")
+		})
+
+		// Assert
+		assert.True(t, stored[0].Suspicious)
+		assert.Equal(t, "duplicate", stored[0].SuspicionReason)
+	})
+
+	t.Run("should flag a burst of clicks from one IP address", func(t *testing.T) {
+		// Arrange
+		clickRepo := new(mocks.MockProductClickRepository)
+		clickRepo.On("CountRecentDuplicates", mock.Anything, mock.Anything).Return(int64(0), nil)
+		clickRepo.On("CountRecentByIP", "10.0.0.1", mock.Anything).Return(int64(30), nil)
+
+		// Act
+		stored := trackClicks(t, clickRepo, fullIP, func(service services.TrackingService) {
+			service.TrackClick(0, 5, "10.0.0.1", browser, "shopee", "")
+		})
+
+		// Assert
+		assert.Equal(t, "rate", stored[0].SuspicionReason)
+	})
+
+	t.Run("should store the truncated IP address and check it for bursts", func(t *testing.T) {
+		// Arrange
+		clickRepo := new(mocks.MockProductClickRepository)
+		clickRepo.On("CountRecentDuplicates", mock.Anything, mock.Anything).Return(int64(0), nil)
+		clickRepo.On("CountRecentByIP", "203.0.113.0", mock.Anything).Return(int64(0), nil)
+
+		// Act
+		stored := trackClicks(t, clickRepo, &config.Config{ClickIPMode: "truncate"}, func(service services.TrackingService) {
+			service.TrackClick(0, 5, "203.0.113.77", browser, "shopee", "")
+		})
+
+		// Assert
+		assert.Equal(t, "203.0.113.0", stored[0].IPAddress)
+	})
+
+	t.Run("should count the click as genuine when the checks fail", func(t *testing.T) {
+		// Arrange
+		clickRepo := new(mocks.MockProductClickRepository)
+		clickRepo.On("CountRecentDuplicates", mock.Anything, mock.Anything).Return(int64(0), errors.New("db down"))
+		clickRepo.On("CountRecentByIP", "10.0.0.1", mock.Anything).Return(int64(0), errors.New("db down"))
+
+		// Act
+		stored := trackClicks(t, clickRepo, fullIP, func(service services.TrackingService) {
+			service.TrackClick(3, 5, "10.0.0.1", browser, "shopee", "")
+		})
+
+		// Assert
+		assert.False(t, stored[0].Suspicious)
+	})
+}
+
+func TestTrackingService_ApplyClickRetention(t *testing.T) {
+	now := time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC)
+	cutoff := now.AddDate(0, 0, -90)
+
+	newService := func(clickRepo *mocks.MockProductClickRepository, cfg *config.Config) services.TrackingService {
+		return services.NewTrackingService(clickRepo, new(mocks.MockProductRepository), new(mocks.MockBrandRepository), new(mocks.MockUserRepository), new(mocks.MockLinkCheckRepository), new(mocks.MockRedirectChannelRepository), services.NewEventBus(services.EventBusOptions{}), cfg)
+	}
+
+	t.Run("should anonymise old clicks in batches until none are left", func(t *testing.T) {
+		// Arrange
+		clickRepo := new(mocks.MockProductClickRepository)
+		service := newService(clickRepo, &config.Config{ClickRetentionDays: 90, ClickRetentionAction: "anonymize"})
+		clickRepo.On("AnonymizeClicksBefore", cutoff, 1000).Return(int64(1000), nil).Once()
+		clickRepo.On("AnonymizeClicksBefore", cutoff, 1000).Return(int64(12), nil).Once()
+
+		// Act
+		processed, err := service.ApplyClickRetention(now)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1012), processed)
+		clickRepo.AssertNotCalled(t, "DeleteClicksBefore", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should delete old clicks when configured to", func(t *testing.T) {
+		// Arrange
+		clickRepo := new(mocks.MockProductClickRepository)
+		service := newService(clickRepo, &config.Config{ClickRetentionDays: 90, ClickRetentionAction: "delete"})
+		clickRepo.On("DeleteClicksBefore", cutoff, 1000).Return(int64(3), nil)
+
+		// Act
+		processed, err := service.ApplyClickRetention(now)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), processed)
+	})
+
+	t.Run("should keep clicks without a retention period", func(t *testing.T) {
+		// Arrange
+		clickRepo := new(mocks.MockProductClickRepository)
+		service := newService(clickRepo, &config.Config{})
+
+		// Act
+		processed, err := service.ApplyClickRetention(now)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Zero(t, processed)
+		clickRepo.AssertNotCalled(t, "AnonymizeClicksBefore", mock.Anything, mock.Anything)
+	})
+}
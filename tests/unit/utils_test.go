@@ -1,6 +1,11 @@
 package unit
 
 import (
+	"encoding/binary"
+	"flicknfit_backend/utils"
+	"hash/crc32"
+	"net"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -25,3 +30,115 @@ func TestStringOperations(t *testing.T) {
 		assert.Contains(t, result, "World")
 	})
 }
+
+func TestClickID(t *testing.T) {
+	t.Run("should verify a signed click ID", func(t *testing.T) {
+		token := utils.SignClickID(42, "secret")
+
+		clickID, err := utils.VerifyClickID(token, "secret")
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(42), clickID)
+	})
+
+	t.Run("should reject altered or foreign click IDs", func(t *testing.T) {
+		token := utils.SignClickID(42, "secret")
+		_, signature, _ := strings.Cut(token, ".")
+
+		_, err := utils.VerifyClickID("43."+signature, "secret")
+		assert.Error(t, err)
+		_, err = utils.VerifyClickID(token, "other-secret")
+		assert.Error(t, err)
+		_, err = utils.VerifyClickID("42", "secret")
+		assert.Error(t, err)
+	})
+
+	t.Run("should sign and verify click references", func(t *testing.T) {
+		ref, err := utils.NewClickRef()
+		assert.NoError(t, err)
+		assert.Len(t, ref, 31)
+
+		verified, err := utils.VerifyClickRef(utils.SignClickRef(ref, "secret"), "secret")
+		assert.NoError(t, err)
+		assert.Equal(t, ref, verified)
+		_, err = utils.VerifyClickID(utils.SignClickRef(ref, "secret"), "secret")
+		assert.Error(t, err)
+	})
+}
+
+func TestDeviceClass(t *testing.T) {
+	t.Run("should classify common user agents", func(t *testing.T) {
+		cases := map[string]string{
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15":                   "mobile",
+			"Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 Chrome/120.0 Mobile Safari/537.36": "mobile",
+			"Mozilla/5.0 (Linux; Android 13; SM-X200) AppleWebKit/537.36 Chrome/120.0 Safari/537.36":        "tablet",
+			"Mozilla/5.0 (iPad; CPU OS 17_0 like Mac OS X)":                                                 "tablet",
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/120.0":                     "desktop",
+			"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)":                      "bot",
+			"curl/8.4.0":         "bot",
+			"Dart/3.3 (dart:io)": "mobile",
+			"":                   "unknown",
+		}
+		for userAgent, expected := range cases {
+			assert.Equal(t, expected, utils.DeviceClass(userAgent), userAgent)
+		}
+	})
+}
+
+func TestAnonymizeIP(t *testing.T) {
+	t.Run("should keep only the network when truncating", func(t *testing.T) {
+		assert.Equal(t, "203.0.113.0", utils.AnonymizeIP("203.0.113.77", "truncate", ""))
+		assert.Equal(t, "2001:db8:85a3::", utils.AnonymizeIP("2001:db8:85a3:8d3:1319:8a2e:370:7348", "truncate", ""))
+		assert.Equal(t, "", utils.AnonymizeIP("not-an-ip", "truncate", ""))
+	})
+
+	t.Run("should hash addresses consistently under a key", func(t *testing.T) {
+		hashed := utils.AnonymizeIP("203.0.113.77", "hash", "key")
+		assert.Len(t, hashed, 40)
+		assert.Equal(t, hashed, utils.AnonymizeIP("203.0.113.77", "hash", "key"))
+		assert.NotEqual(t, hashed, utils.AnonymizeIP("203.0.113.77", "hash", "other"))
+	})
+
+	t.Run("should store the address as received in full mode", func(t *testing.T) {
+		assert.Equal(t, "203.0.113.77", utils.AnonymizeIP("203.0.113.77", "full", ""))
+	})
+}
+
+func TestIsPublicIP(t *testing.T) {
+	t.Run("should reject loopback, private and link-local addresses", func(t *testing.T) {
+		cases := map[string]bool{
+			"203.0.113.77":    true,
+			"2606:4700::1111": true,
+			"127.0.0.1":       false,
+			"10.1.2.3":        false,
+			"192.168.0.10":    false,
+			"169.254.169.254": false,
+			"::1":             false,
+			"fe80::1":         false,
+			"fd00::1":         false,
+			"0.0.0.0":         false,
+		}
+		for ip, expected := range cases {
+			assert.Equal(t, expected, utils.IsPublicIP(net.ParseIP(ip)), ip)
+		}
+	})
+}
+
+func TestGenerateThumbnail(t *testing.T) {
+	t.Run("should scale an image down to the maximum width", func(t *testing.T) {
+		thumbnail, err := utils.GenerateThumbnail(testPNG(t, 800, 400), 400)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, thumbnail)
+	})
+
+	t.Run("should reject an image whose header claims too many pixels before decoding it", func(t *testing.T) {
+		// A 1x1 PNG whose header is rewritten to claim 30000x30000 pixels
+		data := testPNG(t, 1, 1)
+		binary.BigEndian.PutUint32(data[16:20], 30000)
+		binary.BigEndian.PutUint32(data[20:24], 30000)
+		binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+
+		_, err := utils.GenerateThumbnail(data, 400)
+		assert.ErrorContains(t, err, "too large")
+	})
+}
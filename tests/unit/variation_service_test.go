@@ -0,0 +1,152 @@
+package unit
+
+import (
+	"flicknfit_backend/dtos"
+	apperrors "flicknfit_backend/errors"
+	"flicknfit_backend/models"
+	"flicknfit_backend/repositories"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"flicknfit_backend/tests/testhelpers"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestVariationService_DeleteOption(t *testing.T) {
+	t.Run("should refuse to delete option used by product items", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(mocks.MockVariationRepository)
+		service := services.NewVariationService(mockRepo)
+
+		mockRepo.On("GetOptionByID", uint64(3)).Return(&models.ProductVariationOption{ID: 3, Value: "M"}, nil)
+		mockRepo.On("CountConfigurationsByOptionIDs", []uint64{3}).Return(int64(2), nil)
+
+		// Act
+		err := service.DeleteOption(3)
+
+		// Assert
+		assert.Error(t, err)
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, appErr.Code)
+		mockRepo.AssertNotCalled(t, "DeleteOption", mock.Anything)
+	})
+
+	t.Run("should delete unused option", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(mocks.MockVariationRepository)
+		service := services.NewVariationService(mockRepo)
+
+		mockRepo.On("GetOptionByID", uint64(3)).Return(&models.ProductVariationOption{ID: 3, Value: "M"}, nil)
+		mockRepo.On("CountConfigurationsByOptionIDs", []uint64{3}).Return(int64(0), nil)
+		mockRepo.On("DeleteOption", uint64(3)).Return(nil)
+
+		// Act
+		err := service.DeleteOption(3)
+
+		// Assert
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestVariationService_CreateOption(t *testing.T) {
+	t.Run("should require hex value for colour variation", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(mocks.MockVariationRepository)
+		service := services.NewVariationService(mockRepo)
+
+		mockRepo.On("GetVariationByID", uint64(1)).Return(&models.ProductVariation{ID: 1, Name: "Warna", IsColor: true}, nil)
+
+		// Act
+		option, err := service.CreateOption(1, &dtos.VariationOptionCreateRequestDTO{Value: "Navy"})
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, option)
+		mockRepo.AssertNotCalled(t, "CreateOption", mock.Anything)
+	})
+
+	t.Run("should append option to the end and normalise hex value", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(mocks.MockVariationRepository)
+		service := services.NewVariationService(mockRepo)
+
+		variation := &models.ProductVariation{
+			ID:      1,
+			Name:    "Warna",
+			IsColor: true,
+			Options: []models.ProductVariationOption{{ID: 1, Value: "Merah"}, {ID: 2, Value: "Biru"}},
+		}
+		mockRepo.On("GetVariationByID", uint64(1)).Return(variation, nil)
+		mockRepo.On("GetOptionByValue", uint64(1), "Navy").Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("CreateOption", mock.AnythingOfType("*models.ProductVariationOption")).Return(nil)
+
+		// Act
+		option, err := service.CreateOption(1, &dtos.VariationOptionCreateRequestDTO{Value: "Navy", HexValue: "#00f"})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "#0000FF", option.HexValue)
+		assert.Equal(t, 3, option.SortOrder)
+		assert.Equal(t, uint64(1), option.ProductAttributeID)
+	})
+	t.Run("should report a database error when the duplicate check fails", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(mocks.MockVariationRepository)
+		service := services.NewVariationService(mockRepo)
+
+		mockRepo.On("GetVariationByID", uint64(1)).Return(&models.ProductVariation{ID: 1, Name: "Ukuran"}, nil)
+		mockRepo.On("GetOptionByValue", uint64(1), "XL").Return(nil, assert.AnError)
+
+		// Act
+		option, err := service.CreateOption(1, &dtos.VariationOptionCreateRequestDTO{Value: "XL"})
+
+		// Assert
+		assert.Nil(t, option)
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, apperrors.ErrorTypeDatabase, appErr.Type)
+		mockRepo.AssertNotCalled(t, "CreateOption", mock.Anything)
+	})
+
+	t.Run("should report a conflict when a concurrent insert hits the unique index", func(t *testing.T) {
+		// Arrange
+		mockRepo := new(mocks.MockVariationRepository)
+		service := services.NewVariationService(mockRepo)
+
+		mockRepo.On("GetVariationByID", uint64(1)).Return(&models.ProductVariation{ID: 1, Name: "Ukuran"}, nil)
+		mockRepo.On("GetOptionByValue", uint64(1), "XL").Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("CreateOption", mock.AnythingOfType("*models.ProductVariationOption")).Return(repositories.ErrDuplicateVariationOption)
+
+		// Act
+		_, err := service.CreateOption(1, &dtos.VariationOptionCreateRequestDTO{Value: "XL"})
+
+		// Assert
+		appErr, ok := err.(*apperrors.AppError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusConflict, appErr.Code)
+	})
+}
+
+func TestVariationRepository_UniqueIndexes(t *testing.T) {
+	t.Run("should translate duplicate names and option values into sentinel errors", func(t *testing.T) {
+		// Arrange
+		db := testhelpers.NewTestDB(t, &models.ProductVariation{}, &models.ProductVariationOption{})
+		repo := repositories.NewVariationRepository(db)
+		variation := &models.ProductVariation{Name: "Ukuran", Options: []models.ProductVariationOption{{Value: "M"}}}
+		assert.NoError(t, repo.CreateVariation(variation))
+
+		// Act
+		variationErr := repo.CreateVariation(&models.ProductVariation{Name: "Ukuran"})
+		optionErr := repo.CreateOption(&models.ProductVariationOption{ProductAttributeID: variation.ID, Value: "M"})
+
+		// Assert
+		assert.ErrorIs(t, variationErr, repositories.ErrDuplicateVariation)
+		assert.ErrorIs(t, optionErr, repositories.ErrDuplicateVariationOption)
+	})
+}
@@ -0,0 +1,117 @@
+package unit
+
+import (
+	"flicknfit_backend/constants"
+	"flicknfit_backend/models"
+	"flicknfit_backend/services"
+	"flicknfit_backend/tests/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newWatchService builds a WatchService whose campaign lookups return no running campaigns.
+func newWatchService(campaignRepo *mocks.MockCampaignRepository) (services.WatchService, *mocks.MockWatchRepository, *mocks.MockNotificationRepository) {
+	watchRepo := new(mocks.MockWatchRepository)
+	notificationRepo := new(mocks.MockNotificationRepository)
+	campaignRepo.On("GetActiveCampaigns", mock.AnythingOfType("time.Time")).Return([]models.Campaign{}, nil).Maybe()
+	service := services.NewWatchService(watchRepo, campaignRepo, services.NewNotificationService(notificationRepo), new(mocks.MockMailer))
+	return service, watchRepo, notificationRepo
+}
+
+func TestWatchService_NotifyItemChanges(t *testing.T) {
+	product := func(stock int, discount float64) *models.Product {
+		return &models.Product{
+			ID:           1,
+			Name:         "Linen Shirt",
+			Status:       constants.ProductStatusActive,
+			Discount:     discount,
+			ProductItems: []models.ProductItem{{ID: 11, SKU: "LS-M", Price: 100000, Stock: stock}},
+		}
+	}
+	watcher := func(userID uint64, prefs models.WatchPreferences) models.ItemWatcher {
+		return models.ItemWatcher{UserID: userID, ProductItemID: 11, WatchPreferences: prefs}
+	}
+	targetPrice := func(price int) *int { return &price }
+
+	t.Run("should notify watchers once when an item is back in stock", func(t *testing.T) {
+		// Arrange
+		service, watchRepo, notificationRepo := newWatchService(new(mocks.MockCampaignRepository))
+		before := map[uint64]services.ItemSnapshot{11: {Stock: 0, EffectivePrice: 100000}}
+
+		watchRepo.On("GetItemWatchers", []uint64{11}).Return([]models.ItemWatcher{
+			watcher(2, models.WatchPreferences{NotifyBackInStock: true}),
+			watcher(2, models.WatchPreferences{NotifyBackInStock: true}),
+			watcher(3, models.WatchPreferences{NotifyBackInStock: false}),
+		}, nil)
+		notificationRepo.On("GetNotifiedUserIDs", []uint64{2, 2}, "back_in_stock:11", mock.AnythingOfType("time.Time")).Return([]uint64{}, nil)
+		notificationRepo.On("CreateNotifications", mock.MatchedBy(func(n []models.Notification) bool {
+			return len(n) == 1 && n[0].UserID == 2 && n[0].Type == constants.NotificationTypeBackInStock
+		})).Return(nil)
+
+		// Act
+		err := service.NotifyItemChanges(before, []*models.Product{product(5, 0)})
+
+		// Assert
+		assert.NoError(t, err)
+		notificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("should only alert price drop watchers whose target price is reached", func(t *testing.T) {
+		// Arrange
+		service, watchRepo, notificationRepo := newWatchService(new(mocks.MockCampaignRepository))
+		before := map[uint64]services.ItemSnapshot{11: {Stock: 4, EffectivePrice: 100000}}
+
+		watchRepo.On("GetItemWatchers", []uint64{11}).Return([]models.ItemWatcher{
+			watcher(2, models.WatchPreferences{NotifyPriceDrop: true, TargetPrice: targetPrice(90000)}),
+			watcher(3, models.WatchPreferences{NotifyPriceDrop: true, TargetPrice: targetPrice(70000)}),
+			watcher(4, models.WatchPreferences{NotifyPriceDrop: false}),
+			watcher(5, models.WatchPreferences{NotifyPriceDrop: true}),
+		}, nil)
+		notificationRepo.On("GetNotifiedUserIDs", []uint64{2, 5}, "price_drop:11", mock.AnythingOfType("time.Time")).Return([]uint64{}, nil)
+		notificationRepo.On("CreateNotifications", mock.MatchedBy(func(n []models.Notification) bool {
+			return len(n) == 2 && n[0].UserID == 2 && n[1].UserID == 5 && n[0].Type == constants.NotificationTypePriceDrop
+		})).Return(nil)
+
+		// Act
+		err := service.NotifyItemChanges(before, []*models.Product{product(4, 0.2)})
+
+		// Assert
+		assert.NoError(t, err)
+		notificationRepo.AssertExpectations(t)
+	})
+
+	t.Run("should skip users already notified within the cooldown", func(t *testing.T) {
+		// Arrange
+		service, watchRepo, notificationRepo := newWatchService(new(mocks.MockCampaignRepository))
+		before := map[uint64]services.ItemSnapshot{11: {Stock: 0, EffectivePrice: 100000}}
+
+		watchRepo.On("GetItemWatchers", []uint64{11}).Return([]models.ItemWatcher{
+			watcher(2, models.WatchPreferences{NotifyBackInStock: true}),
+		}, nil)
+		notificationRepo.On("GetNotifiedUserIDs", []uint64{2}, "back_in_stock:11", mock.AnythingOfType("time.Time")).Return([]uint64{2}, nil)
+
+		// Act
+		err := service.NotifyItemChanges(before, []*models.Product{product(3, 0)})
+
+		// Assert
+		assert.NoError(t, err)
+		notificationRepo.AssertNotCalled(t, "CreateNotifications", mock.Anything)
+	})
+
+	t.Run("should ignore unpublished products", func(t *testing.T) {
+		// Arrange
+		service, watchRepo, _ := newWatchService(new(mocks.MockCampaignRepository))
+		before := map[uint64]services.ItemSnapshot{11: {Stock: 0, EffectivePrice: 100000}}
+		draft := product(3, 0.5)
+		draft.Status = constants.ProductStatusDraft
+
+		// Act
+		err := service.NotifyItemChanges(before, []*models.Product{draft})
+
+		// Assert
+		assert.NoError(t, err)
+		watchRepo.AssertNotCalled(t, "GetItemWatchers", mock.Anything)
+	})
+}
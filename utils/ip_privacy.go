@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"flicknfit_backend/constants"
+	"net"
+)
+
+// AnonymizeIP returns the form of an IP address to store under the given privacy mode: the address itself,
+// its network with the host part zeroed, or a keyed hash. Addresses that cannot be parsed are not stored
+// in truncate mode.
+func AnonymizeIP(ip, mode, key string) string {
+	if ip == "" {
+		return ""
+	}
+	switch mode {
+	case constants.ClickIPModeTruncate:
+		parsed := net.ParseIP(ip)
+		if parsed == nil {
+			return ""
+		}
+		if v4 := parsed.To4(); v4 != nil {
+			return v4.Mask(net.CIDRMask(24, 32)).String()
+		}
+		return parsed.Mask(net.CIDRMask(48, 128)).String()
+	case constants.ClickIPModeHash:
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte(ip))
+		return hex.EncodeToString(mac.Sum(nil))[:constants.ClickIPHashLength]
+	default:
+		return ip
+	}
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ValidateWhatsAppNumber checks that a number is an Indonesian mobile number such as
+// 0812-3456-7890 or +62 812 3456 7890. Spaces, dashes and parentheses are ignored.
+func ValidateWhatsAppNumber(number string) error {
+	digits := strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(number)
+	digits = strings.TrimPrefix(digits, "+")
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("must contain only digits, spaces, dashes and a leading +")
+		}
+	}
+	if !strings.HasPrefix(digits, "08") && !strings.HasPrefix(digits, "628") {
+		return fmt.Errorf("must be an Indonesian mobile number starting with 08 or +628")
+	}
+	if len(digits) < 10 || len(digits) > 15 {
+		return fmt.Errorf("must have 10 to 15 digits")
+	}
+	return nil
+}
+
+// ValidateURLDomain checks that rawURL is an http(s) URL on one of the comma-separated domains
+// or their subdomains.
+func ValidateURLDomain(rawURL, domains string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("must be a valid http or https URL")
+	}
+	host := strings.ToLower(parsed.Hostname())
+	for _, domain := range strings.Split(domains, ",") {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be a link on %s", strings.ReplaceAll(domains, ",", ", "))
+}
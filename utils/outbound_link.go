@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// clickIDSignatureLength is the number of hex characters of the HMAC kept in a click ID
+const clickIDSignatureLength = 16
+
+// AppendQueryParams adds params to rawURL. Parameters already present in the URL are kept as they are,
+// so a brand's own UTM tags win. URLs that cannot be parsed are returned unchanged.
+func AppendQueryParams(rawURL string, params url.Values) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return rawURL
+	}
+	existing := parsed.Query()
+	extra := url.Values{}
+	for key, values := range params {
+		if existing.Has(key) || len(values) == 0 || values[0] == "" {
+			continue
+		}
+		extra.Set(key, values[0])
+	}
+	if len(extra) == 0 {
+		return rawURL
+	}
+	// The existing query string is kept byte for byte; the new parameters go after it
+	if parsed.RawQuery != "" {
+		parsed.RawQuery += "&"
+	}
+	parsed.RawQuery += extra.Encode()
+	return parsed.String()
+}
+
+// AppendWhatsAppText adds a line to the pre-filled message of a wa.me link, keeping the existing text.
+func AppendWhatsAppText(rawURL, line string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || line == "" {
+		return rawURL
+	}
+	query := parsed.Query()
+	text := query.Get("text")
+	if text != "" {
+		text += "\n\n"
+	}
+	query.Set("text", text+line)
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
+
+// SignClickID builds the click ID handed to brands: the click's database ID and an HMAC of it, so
+// reported click IDs cannot be guessed or altered.
+func SignClickID(clickID uint64, secret string) string {
+	id := strconv.FormatUint(clickID, 10)
+	return id + "." + clickIDSignature(id, secret)
+}
+
+// SignClickRef builds the click ID handed to brands from the reference of a click that is still being stored,
+// signed the same way as database IDs.
+func SignClickRef(ref, secret string) string {
+	return ref + "." + clickIDSignature(ref, secret)
+}
+
+// VerifyClickRef checks the signature of a click ID and returns what was signed: a click reference, or the
+// database ID of a click tracked before clicks were given references.
+func VerifyClickRef(token, secret string) (string, error) {
+	ref, signature, found := strings.Cut(token, ".")
+	if !found || ref == "" || !hmac.Equal([]byte(signature), []byte(clickIDSignature(ref, secret))) {
+		return "", fmt.Errorf("invalid click ID")
+	}
+	return ref, nil
+}
+
+// VerifyClickID checks the signature of a click ID and returns the click's database ID.
+func VerifyClickID(token, secret string) (uint64, error) {
+	id, err := VerifyClickRef(token, secret)
+	if err != nil {
+		return 0, err
+	}
+	clickID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid click ID")
+	}
+	return clickID, nil
+}
+
+// NewClickRef returns a random reference for a click, assigned before the click is stored. The "c" prefix
+// keeps it apart from numeric database IDs.
+func NewClickRef() (string, error) {
+	buf := make([]byte, 15)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "c" + hex.EncodeToString(buf), nil
+}
+
+// clickIDSignature returns the truncated hex HMAC-SHA256 of a click ID
+func clickIDSignature(id, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("click:" + id))
+	return hex.EncodeToString(mac.Sum(nil))[:clickIDSignatureLength]
+}
@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// GenerateSecret returns a random 256-bit secret encoded as hex.
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// SignPostback returns the hex HMAC-SHA256 of "<timestamp>.<body>" under the brand's secret.
+func SignPostback(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyPostbackSignature checks a postback signature, accepting an optional "sha256=" prefix.
+func VerifyPostbackSignature(secret, timestamp, signature string, body []byte) bool {
+	signature = strings.TrimPrefix(strings.TrimSpace(signature), "sha256=")
+	return hmac.Equal([]byte(strings.ToLower(signature)), []byte(SignPostback(secret, timestamp, body)))
+}
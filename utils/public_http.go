@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// IsPublicIP reports whether an address is reachable on the public internet, i.e. not loopback,
+// private, link-local, multicast or unspecified.
+func IsPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+// NewPublicHTTPClient returns an HTTP client for requesting user-supplied URLs. Hosts that are or resolve to
+// a non-public address are refused before the request is sent, and the dialer refuses such addresses too,
+// so redirects and DNS answers that change between lookup and connect cannot reach internal services.
+func NewPublicHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+				return fmt.Errorf("refusing to connect to non-public address %s", host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be dialed instead of the target, bypassing the address check
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: &publicTransport{next: transport}}
+}
+
+// publicTransport rejects requests to non-public hosts before handing them to the next transport
+type publicTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip checks every address the request host resolves to; it runs again for each redirect
+func (t *publicTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if !IsPublicIP(ip) {
+			return nil, fmt.Errorf("host %s is not a public address", host)
+		}
+		return t.next.RoundTrip(req)
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(req.Context(), host)
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		if !IsPublicIP(addr.IP) {
+			return nil, fmt.Errorf("host %s resolves to non-public address %s", host, addr.IP)
+		}
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"flicknfit_backend/constants"
+	"strings"
+)
+
+// botUserAgentMarkers identify crawlers, link previewers and scripted HTTP clients.
+var botUserAgentMarkers = []string{
+	"bot", "crawler", "spider", "slurp", "facebookexternalhit", "headless",
+	"curl/", "wget/", "python-requests", "go-http-client", "postman",
+}
+
+// tabletUserAgentMarkers identify tablets. Android tablets are recognised by the absence of "mobile".
+var tabletUserAgentMarkers = []string{"ipad", "tablet", "kindle", "silk/", "playbook"}
+
+// mobileUserAgentMarkers identify phones, including the FlickNFit app's own HTTP client.
+var mobileUserAgentMarkers = []string{"mobi", "iphone", "ipod", "android", "windows phone", "dart:io", "okhttp"}
+
+// desktopUserAgentMarkers identify desktop operating systems.
+var desktopUserAgentMarkers = []string{"windows nt", "macintosh", "x11", "linux", "cros"}
+
+// DeviceClass classifies a User-Agent header as mobile, tablet, desktop, bot or unknown.
+func DeviceClass(userAgent string) string {
+	ua := strings.ToLower(strings.TrimSpace(userAgent))
+	switch {
+	case ua == "":
+		return constants.DeviceClassUnknown
+	case containsAny(ua, botUserAgentMarkers):
+		return constants.DeviceClassBot
+	case containsAny(ua, tabletUserAgentMarkers),
+		strings.Contains(ua, "android") && !strings.Contains(ua, "mobile"):
+		return constants.DeviceClassTablet
+	case containsAny(ua, mobileUserAgentMarkers):
+		return constants.DeviceClassMobile
+	case containsAny(ua, desktopUserAgentMarkers):
+		return constants.DeviceClassDesktop
+	}
+	return constants.DeviceClassUnknown
+}
+
+// containsAny reports whether s contains one of the markers.
+func containsAny(s string, markers []string) bool {
+	for _, marker := range markers {
+		if strings.Contains(s, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	stderrors "errors"
+	apperrors "flicknfit_backend/errors"
 	"fmt"
 
 	"github.com/gofiber/fiber/v2"
@@ -51,3 +53,13 @@ func SendError(c *fiber.Ctx, statusCode int, message string) error {
 func SendSuccess(c *fiber.Ctx, message string, data interface{}) error {
 	return SendResponse(c, 200, message, data)
 }
+
+// SendAppError sends the status code and message carried by an *errors.AppError.
+// Any other error is reported with the given fallback status code.
+func SendAppError(c *fiber.Ctx, err error, fallbackStatus int) error {
+	var appErr *apperrors.AppError
+	if stderrors.As(err, &appErr) {
+		return SendResponse(c, appErr.Code, appErr.Message, nil)
+	}
+	return SendResponse(c, fallbackStatus, err.Error(), nil)
+}
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// WhatsAppLink creates a wa.me deep link for an Indonesian phone number, with an optional pre-filled message.
+func WhatsAppLink(phoneNumber, message string) string {
+	// Remove all non-numeric characters from phone number
+	cleanPhone := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phoneNumber)
+
+	// Ensure phone number starts with country code (62 for Indonesia)
+	if !strings.HasPrefix(cleanPhone, "62") {
+		// Remove leading 0 if exists
+		cleanPhone = strings.TrimPrefix(cleanPhone, "0")
+		cleanPhone = "62" + cleanPhone
+	}
+
+	if message == "" {
+		return "https://wa.me/" + cleanPhone
+	}
+	return fmt.Sprintf("https://wa.me/%s?text=%s", cleanPhone, url.QueryEscape(message))
+}
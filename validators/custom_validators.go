@@ -114,6 +114,7 @@ func validateProductStatus(fl validator.FieldLevel) bool {
 		constants.ProductStatusActive,
 		constants.ProductStatusInactive,
 		constants.ProductStatusDraft,
+		constants.ProductStatusScheduled,
 	}
 
 	for _, validStatus := range validStatuses {
@@ -145,7 +146,7 @@ func GetValidationErrorMessage(tag string, field string, param string) string {
 	case "role":
 		return field + " must be either 'user' or 'admin'"
 	case "product_status":
-		return field + " must be 'active', 'inactive', or 'draft'"
+		return field + " must be 'active', 'inactive', 'draft', or 'scheduled'"
 	case "numeric":
 		return field + " must be a number"
 	case "gte":